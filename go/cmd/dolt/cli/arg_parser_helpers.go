@@ -70,6 +70,7 @@ func CreateCommitArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(UpperCaseAllFlag, "A", "Adds all tables and databases (including new tables) in the working set to the staged set.")
 	ap.SupportsFlag(AmendFlag, "", "Amend previous commit")
 	ap.SupportsOptionalString(SignFlag, "S", "key-id", "Sign the commit using GPG. If no key-id is provided the key-id is taken from 'user.signingkey' the in the configuration")
+	ap.SupportsFlag(NoVerifyFlag, "", "Bypass the pre-commit and post-commit hooks of the repository. Requires the SUPER privilege.")
 	return ap
 }
 
@@ -101,6 +102,7 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(NoCommitFlag, "", "Perform the merge and stop just before creating a merge commit. Note this will not prevent a fast-forward merge; use the --no-ff arg together with the --no-commit arg to prevent both fast-forwards and merge commits.")
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsFlag(NoVerifyFlag, "", "Bypass the pre-merge, pre-commit and post-commit hooks of the repository. Requires the SUPER privilege.")
 
 	return ap
 }
@@ -124,6 +126,23 @@ func CreateRebaseArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateBisectArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("bisect")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"subcommand", "One of start, bad, good, skip, reset or run."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"rev", "The commits to mark, or for run, the query used to test each commit."})
+	return ap
+}
+
+func CreateNotesArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("notes")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"subcommand", "One of add, remove, push or fetch."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit to annotate, or for push and fetch, the remote. Defaults to HEAD and origin."})
+	ap.SupportsString(MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the note.")
+	ap.SupportsFlag(ForceFlag, "f", "Replace the existing note of the commit, if it has one.")
+	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	return ap
+}
+
 func CreatePushArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("push")
 	ap.SupportsString(UserFlag, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
@@ -152,10 +171,14 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file.")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use.")
+	ap.SupportsString(dbfactory.S3EndpointParam, "", "url", "Endpoint of the S3 compatible object store of an s3 remote.")
+	ap.SupportsFlag(dbfactory.S3PathStyleParam, "", "Use path-style addressing for the bucket of an s3 remote.")
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file.")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use.")
 	ap.SupportsString(UserFlag, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(SingleBranchFlag, "", "Clone only the history leading to the tip of a single branch, either specified by --branch or the remote's HEAD (default).")
+	ap.SupportsFlag(LazyFlag, "", "Fetch only the data of the checked out commit. The rest of history is fetched from the remote the first time it is read.")
+	ap.SupportsString(TablesFlag, "", "tables", "Fetch only the data of the given comma separated tables. The other tables are kept as read-only placeholders, and later fetches and pulls from the remote only fetch the given tables.")
 	return ap
 }
 
@@ -198,6 +221,16 @@ func CreateCherryPickArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateFormatPatchArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs("format-patch", 1)
+}
+
+func CreateAmArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("am", 1)
+	ap.SupportsInt(SkipParam, "", "count", "Skip the first {{.LessThan}}count{{.GreaterThan}} commits of the patch, such as those already applied before a conflict.")
+	return ap
+}
+
 func CreateFetchArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("fetch")
 	ap.SupportsString(UserFlag, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
@@ -273,10 +306,15 @@ func CreateBackupArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"profile", "AWS profile to use."})
 	ap.SupportsFlag(VerboseFlag, "v", "When printing the list of backups adds additional details.")
 	ap.SupportsFlag(ForceFlag, "f", "When restoring a backup, overwrite the contents of the existing database with the same name.")
+	ap.SupportsString(AtParam, "", "time", "When restoring a backup, restore the database to its state at {{.LessThan}}time{{.GreaterThan}}.")
+	ap.SupportsFlag(ContinuousFlag, "", "When syncing a backup, keep syncing new changes to the backup until interrupted.")
+	ap.SupportsInt(IntervalParam, "", "seconds", "With --continuous, the number of seconds to wait between syncs. Defaults to 10.")
 	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	ap.SupportsString(dbfactory.S3EndpointParam, "", "url", "Endpoint of the S3 compatible object store of an s3 backup")
+	ap.SupportsFlag(dbfactory.S3PathStyleParam, "", "Use path-style addressing for the bucket of an s3 backup")
 	return ap
 }
 
@@ -308,6 +346,16 @@ func CreateLogArgParser(isTableFunction bool) *argparser.ArgParser {
 	return ap
 }
 
+func CreateBlameArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("blame", 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"rev", "The revision to blame the table at. Defaults to HEAD."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table to blame."})
+	ap.SupportsString(SinceParam, "", "revision", "Only search history after {{.LessThan}}revision{{.GreaterThan}}. Rows that have not changed since are attributed to it.")
+	ap.SupportsString(UntilParam, "", "revision", "Blame the table as of {{.LessThan}}revision{{.GreaterThan}}. The same as giving the revision as an argument.")
+	ap.SupportsFlag(ColumnsFlag, "", "Attribute each cell to the commit that last changed it, instead of each row.")
+	return ap
+}
+
 func CreateGCArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("gc", 0)
 	ap.SupportsFlag(ShallowFlag, "s", "perform a fast, but incomplete garbage collection pass")
@@ -370,6 +418,7 @@ func CreateGlobalArgParser(name string) *argparser.ArgParser {
 
 var awsParams = []string{dbfactory.AWSRegionParam, dbfactory.AWSCredsTypeParam, dbfactory.AWSCredsFileParam, dbfactory.AWSCredsProfile}
 var ossParams = []string{dbfactory.OSSCredsFileParam, dbfactory.OSSCredsProfile}
+var s3Params = []string{dbfactory.S3EndpointParam, dbfactory.S3PathStyleParam}
 
 func ProcessBackupArgs(apr *argparser.ArgParseResults, scheme, backupUrl string) (map[string]string, error) {
 	params := map[string]string{}
@@ -380,6 +429,8 @@ func ProcessBackupArgs(apr *argparser.ArgParseResults, scheme, backupUrl string)
 		err = AddAWSParams(backupUrl, apr, params)
 	case dbfactory.OSSScheme:
 		err = AddOSSParams(backupUrl, apr, params)
+	case dbfactory.S3Scheme:
+		err = AddS3Params(backupUrl, apr, params)
 	default:
 		err = VerifyNoAwsParams(apr)
 	}
@@ -389,6 +440,10 @@ func ProcessBackupArgs(apr *argparser.ArgParseResults, scheme, backupUrl string)
 func AddAWSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) error {
 	isAWS := strings.HasPrefix(remoteUrl, "aws")
 
+	if err := verifyNoS3Params(apr); err != nil {
+		return err
+	}
+
 	if !isAWS {
 		for _, p := range awsParams {
 			if _, ok := apr.GetValue(p); ok {
@@ -426,7 +481,43 @@ func AddOSSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[s
 	return nil
 }
 
+// AddS3Params adds the AWS credential parameters and the S3 endpoint parameters in |apr| to |params|.
+func AddS3Params(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) error {
+	if !strings.HasPrefix(remoteUrl, "s3") {
+		if err := verifyNoS3Params(apr); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range awsParams {
+		if val, ok := apr.GetValue(p); ok {
+			params[p] = val
+		}
+	}
+	if val, ok := apr.GetValue(dbfactory.S3EndpointParam); ok {
+		params[dbfactory.S3EndpointParam] = val
+	}
+	if apr.Contains(dbfactory.S3PathStyleParam) {
+		params[dbfactory.S3PathStyleParam] = "true"
+	}
+
+	return nil
+}
+
+func verifyNoS3Params(apr *argparser.ArgParseResults) error {
+	for _, p := range s3Params {
+		if apr.Contains(p) {
+			return fmt.Errorf("%s param is only valid for s3 remotes in the format s3://s3-bucket/database", p)
+		}
+	}
+	return nil
+}
+
 func VerifyNoAwsParams(apr *argparser.ArgParseResults) error {
+	if err := verifyNoS3Params(apr); err != nil {
+		return err
+	}
+
 	if awsParams := apr.GetValues(awsParams...); len(awsParams) > 0 {
 		awsParamKeys := make([]string, 0, len(awsParams))
 		for k := range awsParams {
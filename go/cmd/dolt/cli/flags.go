@@ -21,14 +21,17 @@ const (
 	AllFlag              = "all"
 	AllowEmptyFlag       = "allow-empty"
 	AmendFlag            = "amend"
+	AtParam              = "at"
 	AuthorParam          = "author"
 	ArchiveLevelParam    = "archive-level"
 	BranchParam          = "branch"
 	CachedFlag           = "cached"
 	CheckoutCreateBranch = "b"
 	CreateResetBranch    = "B"
+	ColumnsFlag          = "columns"
 	CommitFlag           = "commit"
 	ContinueFlag         = "continue"
+	ContinuousFlag       = "continuous"
 	CopyFlag             = "copy"
 	DateParam            = "date"
 	DecorateFlag         = "decorate"
@@ -42,8 +45,10 @@ const (
 	GraphFlag            = "graph"
 	HardResetParam       = "hard"
 	HostFlag             = "host"
+	IntervalParam        = "interval"
 	IncludeUntrackedFlag = "include-untracked"
 	InteractiveFlag      = "interactive"
+	LazyFlag             = "lazy"
 	ListFlag             = "list"
 	MergesFlag           = "merges"
 	MessageArg           = "message"
@@ -54,12 +59,14 @@ const (
 	NoFFParam            = "no-ff"
 	NoPrettyFlag         = "no-pretty"
 	NoTLSFlag            = "no-tls"
+	NoVerifyFlag         = "no-verify"
 	NoJsonMergeFlag      = "dont-merge-json"
 	NotFlag              = "not"
 	NumberFlag           = "number"
 	OneLineFlag          = "oneline"
 	OursFlag             = "ours"
 	OutputOnlyFlag       = "output-only"
+	OutputParam          = "output"
 	ParentsFlag          = "parents"
 	PatchFlag            = "patch"
 	PasswordFlag         = "password"
@@ -67,13 +74,16 @@ const (
 	PruneFlag            = "prune"
 	QuietFlag            = "quiet"
 	RemoteParam          = "remote"
+	RepairParam          = "repair"
 	SetUpstreamFlag      = "set-upstream"
 	ShallowFlag          = "shallow"
 	ShowIgnoredFlag      = "ignored"
 	ShowSignatureFlag    = "show-signature"
 	SignFlag             = "gpg-sign"
 	SilentFlag           = "silent"
+	SinceParam           = "since"
 	SingleBranchFlag     = "single-branch"
+	SkipParam            = "skip"
 	SkipEmptyFlag        = "skip-empty"
 	SoftResetParam       = "soft"
 	SquashParam          = "squash"
@@ -83,6 +93,7 @@ const (
 	TablesFlag           = "tables"
 	TheirsFlag           = "theirs"
 	TrackFlag            = "track"
+	UntilParam           = "until"
 	UpperCaseAllFlag     = "ALL"
 	UserFlag             = "user"
 )
@@ -23,6 +23,6 @@ var Commands = cli.NewHiddenSubCommandHandler("admin", "Commands for directly wo
 	SetRefCmd{},
 	ShowRootCmd{},
 	ZstdCmd{},
-	StorageCmd{},
+	StorageCommands,
 	createchunk.Commands,
 })
@@ -24,18 +24,25 @@ import (
 	"github.com/dolthub/dolt/go/store/nbs"
 )
 
-type StorageCmd struct {
+// StorageCommands are the commands for inspecting the storage of the current database. With no subcommand, the storage
+// files are listed.
+var StorageCommands = cli.NewSubCommandHandlerWithUnspecified("storage", "Commands for inspecting the storage of the current database", false, StorageListCmd{}, []cli.Command{
+	StorageListCmd{},
+	StorageReportCmd{},
+})
+
+type StorageListCmd struct {
 }
 
-func (s StorageCmd) Name() string {
-	return "storage"
+func (s StorageListCmd) Name() string {
+	return "list"
 }
 
-func (s StorageCmd) Description() string {
+func (s StorageListCmd) Description() string {
 	return "print storage information for the current database"
 }
 
-func (s StorageCmd) Exec(ctx context.Context, _ string, _ []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+func (s StorageListCmd) Exec(ctx context.Context, _ string, _ []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
 	abs, err := dEnv.FS.Abs("")
 	if err != nil {
 		cli.Println(fmt.Sprintf("Couldn't get absolute path: %v", err))
@@ -50,20 +57,20 @@ func (s StorageCmd) Exec(ctx context.Context, _ string, _ []string, dEnv *env.Do
 	return 0
 }
 
-func (s StorageCmd) Docs() *cli.CommandDocumentation {
+func (s StorageListCmd) Docs() *cli.CommandDocumentation {
 	return &cli.CommandDocumentation{
-		CommandStr: "storage",
+		CommandStr: "storage list",
 		ShortDesc:  "print storage information for the current database",
 		LongDesc:   `Admin command to get some basic insights into the storage files in this database`,
 		Synopsis: []string{
-			"storage",
+			"",
 		},
 		ArgParser: s.ArgParser(),
 	}
 }
 
-func (s StorageCmd) ArgParser() *argparser.ArgParser {
+func (s StorageListCmd) ArgParser() *argparser.ArgParser {
 	return argparser.NewArgParserWithMaxArgs(s.Name(), 0)
 }
 
-var _ cli.Command = StorageCmd{}
+var _ cli.Command = StorageListCmd{}
@@ -0,0 +1,134 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dustin/go-humanize"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/tabular"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+var storageReportDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Report what the storage of the current database is used by",
+	LongDesc: `Walks the current database and attributes the size on disk of every chunk to the kind of data it holds:
+
+{{.EmphasisLeft}}rows{{.EmphasisRight}}: the row data and schema of each table at a branch head.
+
+{{.EmphasisLeft}}index{{.EmphasisRight}}: each secondary index of a table at a branch head.
+
+{{.EmphasisLeft}}blobs{{.EmphasisRight}}: the BLOB, TEXT and JSON values of each table at a branch head which are stored out of its rows.
+
+{{.EmphasisLeft}}workspace{{.EmphasisRight}}: changes in working sets which are not committed.
+
+{{.EmphasisLeft}}stash{{.EmphasisRight}}: changes only kept by stashes.
+
+{{.EmphasisLeft}}history{{.EmphasisRight}}: data only reachable from older commits, tags and remote tracking branches.
+
+{{.EmphasisLeft}}metadata{{.EmphasisRight}}: commits, refs and root values.
+
+{{.EmphasisLeft}}garbage{{.EmphasisRight}}: chunks which aren't reachable at all, which {{.EmphasisLeft}}dolt gc{{.EmphasisRight}} removes.
+
+Each chunk is counted once, for the first kind of data it is reached from in the order above. Reading every chunk of the database, the report can take a while for large databases. The same report is available in SQL from the {{.EmphasisLeft}}dolt_storage_usage{{.EmphasisRight}} system table.`,
+	Synopsis: []string{
+		"[-r {{.LessThan}}result-format{{.GreaterThan}}]",
+	},
+}
+
+var storageReportSchema = sql.Schema{
+	&sql.Column{Name: "Category", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "Table", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "Index", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "Chunks", Type: types.Uint64, Nullable: false},
+	&sql.Column{Name: "Size", Type: types.Text, Nullable: false},
+}
+
+type StorageReportCmd struct {
+}
+
+func (s StorageReportCmd) Name() string {
+	return "report"
+}
+
+func (s StorageReportCmd) Description() string {
+	return "report what the storage of the current database is used by"
+}
+
+func (s StorageReportCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(storageReportDocs, s.ArgParser())
+}
+
+func (s StorageReportCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(s.Name(), 0)
+	ap.SupportsString(commands.FormatFlag, "r", "result output format", "How to format the report. Valid values are tabular and json. Defaults to tabular.")
+	return ap
+}
+
+func (s StorageReportCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+	ap := s.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, storageReportDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+
+	format := apr.GetValueOrDefault(commands.FormatFlag, "tabular")
+	if format != "tabular" && format != "json" {
+		verr := errhand.BuildDError("invalid result format: %s. Valid values are tabular and json", format).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	report, err := dEnv.DoltDB(ctx).GetStorageUsage(ctx)
+	if err != nil {
+		verr := errhand.BuildDError("failed to report storage usage").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		cli.Println(string(data))
+		return 0
+	}
+
+	var totalChunks, totalBytes uint64
+	sqlCtx := sql.NewContext(ctx)
+	wr := tabular.NewFixedWidthTableWriter(storageReportSchema, iohelp.NopWrCloser(cli.OutStream), len(report)+1)
+	for _, u := range report {
+		totalChunks += u.Chunks
+		totalBytes += u.Bytes
+		err = wr.WriteSqlRow(sqlCtx, sql.Row{string(u.Category), u.Table, u.Index, u.Chunks, humanize.Bytes(u.Bytes)})
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+	if err = wr.Close(sqlCtx); err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	cli.Println(fmt.Sprintf("Total: %s chunks, %s", humanize.Comma(int64(totalChunks)), humanize.Bytes(totalBytes)))
+	return 0
+}
+
+var _ cli.Command = StorageReportCmd{}
@@ -0,0 +1,129 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var amDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Apply a patch written by dolt format-patch",
+	LongDesc: `Applies each commit of a patch written by {{.EmphasisLeft}}dolt format-patch{{.EmphasisRight}} as a new commit on the current branch, keeping its author, date and message. This requires your working set to be clean.
+
+The changes of each commit are merged into the current branch with a three-way merge, as with {{.EmphasisLeft}}dolt cherry-pick{{.EmphasisRight}}, so rows which were changed by both the patch and the current branch are reported as conflicts. The tables changed by the patch must have the same columns as they had in the database it was written from.
+
+If a commit does not apply cleanly, its changes are left in the working set and {{.EmphasisLeft}}dolt am{{.EmphasisRight}} stops. Resolve the conflicts, commit the changes, and apply the rest of the patch with {{.EmphasisLeft}}--skip{{.EmphasisRight}}, which skips the commits that were already applied.
+
+The patch can also be applied with the {{.EmphasisLeft}}dolt_am(){{.EmphasisRight}} stored procedure, which takes the contents of the patch as its argument.`,
+	Synopsis: []string{
+		"[--skip {{.LessThan}}count{{.GreaterThan}}] {{.LessThan}}patch_file{{.GreaterThan}}",
+	},
+}
+
+type AmCmd struct{}
+
+var _ cli.Command = AmCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd AmCmd) Name() string {
+	return "am"
+}
+
+// Description returns a description of the command
+func (cmd AmCmd) Description() string {
+	return amDocs.ShortDesc
+}
+
+// EventType returns the type of the event to log
+func (cmd AmCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+func (cmd AmCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(amDocs, ap)
+}
+
+func (cmd AmCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateAmArgParser()
+}
+
+// Exec executes the command
+func (cmd AmCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, amDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	patch, err := os.ReadFile(apr.Arg(0))
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read patch %s", apr.Arg(0)).AddCause(err).Build(), usage)
+	}
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	// conflicts are left in the working set for the user to resolve
+	if _, err = GetRowsForSql(queryist, sqlCtx, "set @@dolt_allow_commit_conflicts = 1"); err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if _, err = GetRowsForSql(queryist, sqlCtx, "set @@dolt_force_transaction_commit = 1"); err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	procArgs := []string{string(patch)}
+	if skip, ok := apr.GetInt(cli.SkipParam); ok {
+		procArgs = append([]string{"--" + cli.SkipParam, strconv.Itoa(skip)}, procArgs...)
+	}
+	query, err := interpolateStoredProcedureCall("DOLT_AM", procArgs)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if len(rows) != 1 {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: unexpected number of rows returned from dolt_am: %d", len(rows)).Build(), usage)
+	}
+
+	row := rows[0]
+	applied, err := getInt64ColAsInt64(row[1])
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	cli.Printf("Applied %d commits\n", applied)
+	if row[5] != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: %s", fmt.Sprint(row[5])).Build(), usage)
+	}
+	return 0
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var backfillDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Fetches all of the data a lazy clone is missing.",
+	LongDesc: `A clone made with {{.EmphasisLeft}}dolt clone --lazy{{.EmphasisRight}} only fetches the data of the checked out commit, and fetches the rest of history from its remote the first time it is read. {{.EmphasisLeft}}dolt backfill{{.EmphasisRight}} fetches everything the clone is still missing, and makes it a complete clone which no longer needs the remote to read its history.
+
+The remote a lazy clone fetches from is the remote it was cloned from, unless the {{.EmphasisLeft}}clone.lazyremote{{.EmphasisRight}} config value names another one.`,
+	Synopsis: []string{
+		"",
+	},
+}
+
+type BackfillCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BackfillCmd) Name() string {
+	return "backfill"
+}
+
+// Description returns a description of the command
+func (cmd BackfillCmd) Description() string {
+	return backfillDocs.ShortDesc
+}
+
+func (cmd BackfillCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(backfillDocs, ap)
+}
+
+func (cmd BackfillCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+}
+
+// Exec executes the command
+func (cmd BackfillCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, backfillDocs, ap))
+	cli.ParseArgsOrDie(ap, args, help)
+
+	ddb := dEnv.DoltDB(ctx)
+	if !ddb.IsLazyClone() {
+		cli.Println("This database is not a lazy clone; there is nothing to fetch.")
+		return 0
+	}
+
+	r, err := dEnv.LazyCloneRemote()
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	cli.Printf("Fetching missing data from %s\n", r.Name)
+
+	count, err := ddb.CompleteLazyClone(ctx)
+	if err != nil {
+		verr := errhand.BuildDError("error: failed to fetch missing data from %s", r.Name).AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.Println(fmt.Sprintf("Read %d chunks; this database is now a complete clone.", count))
+	return 0
+}
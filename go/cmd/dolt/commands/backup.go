@@ -17,7 +17,9 @@ package commands
 import (
 	"context"
 	"encoding/json"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/dolthub/dolt/go/store/types"
 
@@ -25,6 +27,8 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
@@ -37,7 +41,7 @@ var backupDocs = cli.CommandDocumentationContent{
 
 {{.EmphasisLeft}}add{{.EmphasisRight}}
 Adds a backup named {{.LessThan}}name{{.GreaterThan}} for the database at {{.LessThan}}url{{.GreaterThan}}.
-The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, gs, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}backups.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
+The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, s3, gs, az, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}backups.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
 The URL address must be unique to existing remotes and backups.
 
 AWS cloud backup urls should be of the form {{.EmphasisLeft}}aws://[dynamo-table:s3-bucket]/database{{.EmphasisRight}}. You may configure your aws cloud backup using the optional parameters {{.EmphasisLeft}}aws-region{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-type{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-file{{.EmphasisRight}}.
@@ -49,8 +53,12 @@ aws-creds-type specifies the means by which credentials should be retrieved in o
 	file: Uses the credentials file specified by the parameter aws-creds-file
 
 	
+S3 backup urls should be of the form {{.EmphasisLeft}}s3://s3-bucket/database{{.EmphasisRight}}. These keep all of their data in the bucket and do not need a DynamoDB table, so they can also be used with S3 compatible object stores. They use the same aws-region and aws-creds parameters as AWS cloud backups. The endpoint of an S3 compatible object store can be given with the parameter {{.EmphasisLeft}}s3-endpoint{{.EmphasisRight}} or the environment variable AWS_ENDPOINT_URL_S3, and {{.EmphasisLeft}}s3-path-style{{.EmphasisRight}} addresses the bucket in the path of its urls rather than in their host names.
+
 GCP backup urls should be of the form gs://gcs-bucket/database and will use the credentials setup using the gcloud command line available from Google.
 
+Azure backup urls should be of the form az://container/database. The storage account and its credentials are read from the environment variable AZURE_STORAGE_CONNECTION_STRING, or from AZURE_STORAGE_ACCOUNT along with one of AZURE_STORAGE_KEY, AZURE_STORAGE_SAS_TOKEN or AZURE_STORAGE_ACCESS_TOKEN, a Microsoft Entra ID access token for Azure Storage. AZURE_STORAGE_ENDPOINT can be set to use an endpoint other than https://{{.LessThan}}account{{.GreaterThan}}.blob.core.windows.net.
+
 The local filesystem can be used as a backup by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
 
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
@@ -59,9 +67,15 @@ Remove the backup named {{.LessThan}}name{{.GreaterThan}}. All configuration set
 {{.EmphasisLeft}}restore{{.EmphasisRight}}
 Restore a Dolt database from a given {{.LessThan}}url{{.GreaterThan}} into a specified directory {{.LessThan}}name{{.GreaterThan}}. This will fail if {{.LessThan}}name{{.GreaterThan}} is already a Dolt database unless '--force' is provided, in which case the existing database will be overwritten with the contents of the restored backup.
 
+With {{.EmphasisLeft}}--at{{.EmphasisRight}}, the database is restored to its state at {{.LessThan}}time{{.GreaterThan}}, such as {{.EmphasisLeft}}'2026-10-01 12:00:00'{{.EmphasisRight}}. Times without a time zone are in UTC. The state restored is the last one synced to the backup at or before that time, including branches, tags and uncommitted changes in working sets.
+
 {{.EmphasisLeft}}sync{{.EmphasisRight}}
 Snapshot the database and upload to the backup {{.LessThan}}name{{.GreaterThan}}. This includes branches, tags, working sets, and remote tracking refs.
 
+Every state of the database recorded in its chunk journal since the last sync is uploaded along with the latest one, and the backup keeps a log of them with the times they were written. This is what lets {{.EmphasisLeft}}restore --at{{.EmphasisRight}} restore the database to a point in time. States recorded before the last {{.EmphasisLeft}}dolt gc{{.EmphasisRight}} are no longer in the journal, so sync often, or continuously, for a detailed history.
+
+With {{.EmphasisLeft}}--continuous{{.EmphasisRight}}, the backup is synced again every {{.EmphasisLeft}}--interval{{.EmphasisRight}} seconds until the command is interrupted. The database is only opened while it is being synced, so it can be written to by a running {{.EmphasisLeft}}dolt sql-server{{.EmphasisRight}} or other dolt commands in the meantime.
+
 	
 {{.EmphasisLeft}}sync-url{{.EmphasisRight}}
 Snapshot the database and upload the backup to {{.LessThan}}url{{.GreaterThan}}. Like sync, this includes branches, tags, working sets, and remote tracking refs, but it does not require you to create a named backup`,
@@ -70,9 +84,9 @@ Snapshot the database and upload the backup to {{.LessThan}}url{{.GreaterThan}}.
 		"[-v | --verbose]",
 		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
-		"restore [--force] {{.LessThan}}url{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
-		"sync {{.LessThan}}name{{.GreaterThan}}",
-		"sync-url [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}}",
+		"restore [--force] [--at {{.LessThan}}time{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
+		"sync [--continuous [--interval {{.LessThan}}seconds{{.GreaterThan}}]] {{.LessThan}}name{{.GreaterThan}}",
+		"sync-url [--continuous [--interval {{.LessThan}}seconds{{.GreaterThan}}]] [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}}",
 	},
 }
 
@@ -243,7 +257,7 @@ func syncBackupUrl(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 	}
 
 	b := env.NewRemote("__temp__", backupUrl, params)
-	return backup(ctx, dEnv, b)
+	return backup(ctx, dEnv, b, apr)
 }
 
 func syncBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
@@ -263,11 +277,15 @@ func syncBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseR
 		return errhand.BuildDError("error: unknown backup: '%s' ", backupName).Build()
 	}
 
-	return backup(ctx, dEnv, b)
+	return backup(ctx, dEnv, b, apr)
 }
 
-func backup(ctx context.Context, dEnv *env.DoltEnv, b env.Remote) errhand.VerboseError {
-	destDb, err := b.GetRemoteDB(ctx, dEnv.DoltDB(ctx).ValueReadWriter().Format(), dEnv)
+// defaultBackupInterval is the number of seconds between syncs of a continuous backup.
+const defaultBackupInterval = 10
+
+func backup(ctx context.Context, dEnv *env.DoltEnv, b env.Remote, apr *argparser.ArgParseResults) errhand.VerboseError {
+	nbf := dEnv.DoltDB(ctx).ValueReadWriter().Format()
+	destDb, err := b.GetRemoteDB(ctx, nbf, dEnv)
 	if err != nil {
 		return errhand.BuildDError("error: unable to open destination.").AddCause(err).Build()
 	}
@@ -275,8 +293,61 @@ func backup(ctx context.Context, dEnv *env.DoltEnv, b env.Remote) errhand.Verbos
 	if err != nil {
 		return errhand.BuildDError("error: ").AddCause(err).Build()
 	}
-	err = actions.SyncRoots(ctx, dEnv.DoltDB(ctx), destDb, tmpDir, buildProgStarter(defaultLanguage), stopProgFuncs)
 
+	if !apr.Contains(cli.ContinuousFlag) {
+		err = actions.SyncRootsWithHistory(ctx, dEnv.DoltDB(ctx), destDb, tmpDir, buildProgStarter(defaultLanguage), stopProgFuncs)
+		return backupSyncErr(err, b)
+	}
+
+	interval := apr.GetIntOrDefault(cli.IntervalParam, defaultBackupInterval)
+	if interval <= 0 {
+		return errhand.BuildDError("error: --%s must be a positive number of seconds", cli.IntervalParam).Build()
+	}
+
+	// the database is opened for each sync, so that it can be written to by other processes between syncs, and so
+	// that the roots they write are seen.
+	if err = closeLocalDB(dEnv, dEnv.DoltDB(ctx)); err != nil {
+		return errhand.BuildDError("error: ").AddCause(err).Build()
+	}
+	for {
+		srcDb, err := doltdb.LoadDoltDB(ctx, nbf, doltdb.LocalDirDoltDB, dEnv.FS)
+		if err == nil {
+			err = actions.SyncRootsWithHistory(ctx, srcDb, destDb, tmpDir, actions.NoopRunProgFuncs, actions.NoopStopProgFuncs)
+			if cerr := closeLocalDB(dEnv, srcDb); cerr != nil && (err == nil || err == pull.ErrDBUpToDate) {
+				err = cerr
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		} else if err == nil {
+			cli.Printf("Synced backup at %s\n", time.Now().Format(time.DateTime))
+		} else if verr := backupSyncErr(err, b); verr != nil {
+			return verr
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Duration(interval) * time.Second):
+		}
+	}
+}
+
+// closeLocalDB closes |ddb|, the database of |dEnv|, and removes it from the cache of open databases so that it is
+// opened again the next time it is loaded.
+func closeLocalDB(dEnv *env.DoltEnv, ddb *doltdb.DoltDB) error {
+	err := ddb.Close()
+	if err != nil {
+		return err
+	}
+	path, ok, err := dbfactory.DataDirPath(dEnv.FS)
+	if err != nil || !ok {
+		return err
+	}
+	return dbfactory.DeleteFromSingletonCache(filepath.ToSlash(path))
+}
+
+func backupSyncErr(err error, b env.Remote) errhand.VerboseError {
 	switch err {
 	case nil:
 		return nil
@@ -310,6 +381,15 @@ func restoreBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 
 	force := apr.Contains(cli.ForceFlag)
 
+	var at *time.Time
+	if atStr, ok := apr.GetValue(cli.AtParam); ok {
+		t, err := dconfig.ParseDate(atStr)
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		at = &t
+	}
+
 	scheme, remoteUrl, err := env.GetAbsRemoteUrl(dEnv.FS, dEnv.Config, urlStr)
 	if err != nil {
 		return errhand.BuildDError("error: '%s' is not valid.", urlStr).Build()
@@ -353,8 +433,8 @@ func restoreBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 			return errhand.VerboseErrorFromError(err)
 		}
 
-		err = actions.SyncRoots(ctx, srcDb, existingDEnv.DoltDB(ctx), tmpDir, buildProgStarter(downloadLanguage), stopProgFuncs)
-		if err != nil {
+		err = actions.RestoreBackup(ctx, srcDb, existingDEnv.DoltDB(ctx), at, tmpDir, buildProgStarter(downloadLanguage), stopProgFuncs)
+		if err != nil && err != pull.ErrDBUpToDate {
 			return errhand.VerboseErrorFromError(err)
 		}
 	} else {
@@ -376,7 +456,7 @@ func restoreBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 		if err != nil {
 			return errhand.VerboseErrorFromError(err)
 		}
-		err = actions.SyncRoots(ctx, srcDb, clonedEnv.DoltDB(ctx), tmpDir, buildProgStarter(downloadLanguage), stopProgFuncs)
+		err = actions.RestoreBackup(ctx, srcDb, clonedEnv.DoltDB(ctx), at, tmpDir, buildProgStarter(downloadLanguage), stopProgFuncs)
 		if err != nil {
 			// If we're cloning into a directory that already exists do not erase it. Otherwise
 			// make best effort to delete the directory we created.
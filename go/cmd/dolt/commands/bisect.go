@@ -0,0 +1,118 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var bisectDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Use binary search to find the commit that introduced a change",
+	LongDesc: `Finds the first bad commit between a known bad commit and one or more known good commits, by repeatedly
+choosing a commit halfway between them to be tested and marked good or bad.
+
+A bisect session is started with {{.EmphasisLeft}}dolt bisect start{{.EmphasisRight}}, optionally given the bad commit
+followed by the good commits. Commits are then marked with {{.EmphasisLeft}}dolt bisect bad{{.EmphasisRight}} and
+{{.EmphasisLeft}}dolt bisect good{{.EmphasisRight}}, or with {{.EmphasisLeft}}dolt bisect skip{{.EmphasisRight}} if they
+can't be tested. Without a commit, these mark the commit currently being tested. The current branch is not changed; the
+commit to test can be queried using the revision database {{.LessThan}}database{{.GreaterThan}}/{{.LessThan}}commit{{.GreaterThan}}.
+
+{{.EmphasisLeft}}dolt bisect run{{.EmphasisRight}} automates the search by running a query against each commit to be
+tested. A commit is bad if the query returns any rows and good if it returns none.
+
+The session is ended with {{.EmphasisLeft}}dolt bisect reset{{.EmphasisRight}}.
+`,
+	Synopsis: []string{
+		`start [{{.LessThan}}bad{{.GreaterThan}} [{{.LessThan}}good{{.GreaterThan}}...]]`,
+		`(bad | good | skip) [{{.LessThan}}commit{{.GreaterThan}}...]`,
+		`run {{.LessThan}}query{{.GreaterThan}}`,
+		`reset`,
+	},
+}
+
+type BisectCmd struct{}
+
+var _ cli.Command = BisectCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BisectCmd) Name() string {
+	return "bisect"
+}
+
+// Description returns a description of the command
+func (cmd BisectCmd) Description() string {
+	return bisectDocs.ShortDesc
+}
+
+// EventType returns the type of the event to log
+func (cmd BisectCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_BISECT
+}
+
+func (cmd BisectCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(bisectDocs, ap)
+}
+
+func (cmd BisectCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateBisectArgParser()
+}
+
+// Exec executes the command
+func (cmd BisectCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, bisectDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	query, err := interpolateStoredProcedureCall("DOLT_BISECT", args)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	rows, err := GetRowsForSql(queryist, sqlCtx, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	status, err := getInt64ColAsInt64(rows[0][0])
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if status == 1 {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(errors.New("error: "+rows[0][1].(string))), usage)
+	}
+
+	cli.Println(rows[0][1].(string))
+	return 0
+}
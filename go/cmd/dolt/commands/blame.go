@@ -19,7 +19,8 @@ import (
 	"fmt"
 	"regexp"
 
-	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
@@ -31,15 +32,13 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 )
 
-const (
-	blameQueryTemplate = "SELECT * FROM dolt_blame_%s AS OF '%s'"
-)
-
 var blameDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Show what revision and author last modified each row of a table`,
-	LongDesc:  `Annotates each row in the given table with information from the revision which last modified the row. Optionally, start annotating from the given revision.`,
+	LongDesc: `Annotates each row in the given table with information from the revision which last modified the row. Optionally, start annotating from the given revision.
+
+Rows are followed through table renames and primary key changes, matching tables and columns by their tags. {{.EmphasisLeft}}--since{{.EmphasisRight}} limits the search to history after the given revision; rows that have not changed since then are attributed to that revision. With {{.EmphasisLeft}}--columns{{.EmphasisRight}}, each cell is annotated separately with the revision that last changed it.`,
 	Synopsis: []string{
-		`[{{.LessThan}}rev{{.GreaterThan}}] {{.LessThan}}tablename{{.GreaterThan}}`,
+		`[--since {{.LessThan}}rev{{.GreaterThan}}] [--until {{.LessThan}}rev{{.GreaterThan}}] [--columns] [{{.LessThan}}rev{{.GreaterThan}}] {{.LessThan}}tablename{{.GreaterThan}}`,
 	},
 }
 
@@ -61,8 +60,7 @@ func (cmd BlameCmd) Docs() *cli.CommandDocumentation {
 }
 
 func (cmd BlameCmd) ArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
-	return ap
+	return cli.CreateBlameArgParser()
 }
 
 func (cmd BlameCmd) RequiresRepo() bool {
@@ -79,18 +77,9 @@ func (cmd BlameCmd) EventType() eventsapi.ClientEventType {
 // Exec implements the `dolt blame` command. Blame annotates each row in the given table with information
 // from the revision which last modified the row, optionally starting from a given revision.
 //
-// Blame is computed as follows:
-//
-// First, a blame graph is initialized with one node for every row in the table at the given commit (defaulting
-// to HEAD of the currently checked-out branch).
-//
-// Starting from the given commit, walk backwards through the commit graph (currently by following each commit's
-// first parent, though this may change in the future).
-//
-// For each adjacent pair of commits `old` and `new`, check each remaining unblamed node to see if the row it represents
-// changed between the commits. If so, mark it with `new` as the blame origin and continue to the next node without blame.
-//
-// When all nodes have blame information, stop iterating through commits and print the blame graph.
+// Blame is computed by the dolt_blame table function, which walks the commit graph backwards from the given commit
+// (defaulting to HEAD of the currently checked-out branch), passing each row on to a parent commit in which it is
+// unchanged. A row is blamed on the first commit whose parents all changed it.
 // Exec executes the command
 func (cmd BlameCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
 	ap := cmd.ArgParser()
@@ -111,20 +100,21 @@ func (cmd BlameCmd) Exec(ctx context.Context, commandStr string, args []string,
 		defer closeFunc()
 	}
 
-	var schema sql.Schema
-	var ri sql.RowIter
-	if apr.NArg() == 1 {
-		schema, ri, _, err = queryist.Query(sqlCtx, fmt.Sprintf(blameQueryTemplate, apr.Arg(0), "HEAD"))
-	} else {
-		// validate input
-		ref := apr.Arg(0)
-		if !ref2.IsValidTagName(ref) && !doltdb.IsValidCommitHash(ref) && !isValidHeadRef(ref) {
+	// validate input
+	for _, rev := range blameRevisions(apr) {
+		if !ref2.IsValidTagName(rev) && !doltdb.IsValidCommitHash(rev) && !isValidHeadRef(rev) {
 			iohelp.WriteLine(cli.CliOut, "Invalid reference provided")
 			return 1
 		}
+	}
 
-		schema, ri, _, err = queryist.Query(sqlCtx, fmt.Sprintf(blameQueryTemplate, apr.Arg(1), apr.Arg(0)))
+	query, err := dbr.InterpolateForDialect(fmt.Sprintf("SELECT * FROM dolt_blame(%s)", buildPlaceholdersString(len(args))), stringSliceToInterfaceSlice(args), dialect.MySQL)
+	if err != nil {
+		iohelp.WriteLine(cli.CliOut, err.Error())
+		return 1
 	}
+
+	schema, ri, _, err := queryist.Query(sqlCtx, query)
 	if err != nil {
 		iohelp.WriteLine(cli.CliOut, err.Error())
 		return 1
@@ -139,6 +129,20 @@ func (cmd BlameCmd) Exec(ctx context.Context, commandStr string, args []string,
 	return 0
 }
 
+// blameRevisions returns the revisions given to blame, as an argument or with --since or --until.
+func blameRevisions(apr *argparser.ArgParseResults) []string {
+	var revs []string
+	if apr.NArg() == 2 {
+		revs = append(revs, apr.Arg(0))
+	}
+	for _, param := range []string{cli.SinceParam, cli.UntilParam} {
+		if rev, ok := apr.GetValue(param); ok {
+			revs = append(revs, rev)
+		}
+	}
+	return revs
+}
+
 func isValidHeadRef(s string) bool {
 	var refRegex = regexp.MustCompile(`(?i)^head[\~\^0-9]*$`)
 	return refRegex.MatchString(s)
@@ -0,0 +1,172 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlecmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const sinceFlag = "since"
+
+var createDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Writes branches and tags to a bundle file.",
+	LongDesc: `Writes the given branches and tags, and all of the data reachable from them, to the single file {{.LessThan}}file{{.GreaterThan}}. Bundles can be copied to machines without network access to the database, where they can be used as a read only remote: {{.EmphasisLeft}}dolt clone{{.EmphasisRight}} and {{.EmphasisLeft}}dolt remote add{{.EmphasisRight}} accept the path of a bundle file in place of a remote url, and the remote can then be fetched and pulled from like any other.
+
+With {{.EmphasisLeft}}--since{{.EmphasisRight}}, the bundle is incremental, and leaves out the data which is reachable from the given commit. An incremental bundle can only be fetched into a repository which already has that commit, and cannot be cloned.`,
+	Synopsis: []string{
+		"[--since {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}file{{.GreaterThan}} {{.LessThan}}ref{{.GreaterThan}}...",
+	},
+}
+
+type CreateCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CreateCmd) Name() string {
+	return "create"
+}
+
+// Description returns a description of the command
+func (cmd CreateCmd) Description() string {
+	return createDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd CreateCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd CreateCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(createDocs, ap)
+}
+
+func (cmd CreateCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The path of the bundle file to write."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A branch or tag to include in the bundle."})
+	ap.SupportsString(sinceFlag, "", "commit", "Leave out the data which is reachable from {{.LessThan}}commit{{.GreaterThan}}.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd CreateCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, createDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() < 2 {
+		verr := errhand.BuildDError("dolt bundle create requires a file and at least one ref").Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	ddb := dEnv.DoltDB(ctx)
+	refs := make([]ref.DoltRef, 0, apr.NArg()-1)
+	for _, name := range apr.Args[1:] {
+		r, err := resolveBundleRef(ctx, ddb, name)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		refs = append(refs, r)
+	}
+
+	var bases []hash.Hash
+	if since, ok := apr.GetValue(sinceFlag); ok {
+		h, err := resolveCommitHash(ctx, dEnv, since)
+		if err != nil {
+			verr := errhand.BuildDError("error: could not resolve %s", since).AddCause(err).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		bases = append(bases, h)
+	}
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	path, err := dEnv.FS.Abs(apr.Arg(0))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	n, err := ddb.WriteBundle(ctx, tmpDir, path, refs, bases)
+	if err != nil {
+		verr := errhand.BuildDError("error: failed to create bundle").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	names := make([]string, len(refs))
+	for i, r := range refs {
+		names[i] = r.String()
+	}
+	cli.Println(fmt.Sprintf("Wrote %d chunks for %s to %s", n, strings.Join(names, ", "), apr.Arg(0)))
+	return 0
+}
+
+// resolveBundleRef resolves |name| to a branch or a tag, or to a fully qualified ref.
+func resolveBundleRef(ctx context.Context, ddb *doltdb.DoltDB, name string) (ref.DoltRef, error) {
+	if ref.IsRef(name) {
+		r, err := ref.Parse(name)
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := ddb.HasRef(ctx, r); err != nil {
+			return nil, err
+		} else if ok {
+			return r, nil
+		}
+		return nil, fmt.Errorf("ref not found: %s", name)
+	}
+
+	for _, r := range []ref.DoltRef{ref.NewBranchRef(name), ref.NewTagRef(name)} {
+		if ok, err := ddb.HasRef(ctx, r); err != nil {
+			return nil, err
+		} else if ok {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not a branch or tag", name)
+}
+
+func resolveCommitHash(ctx context.Context, dEnv *env.DoltEnv, spec string) (hash.Hash, error) {
+	cs, err := doltdb.NewCommitSpec(spec)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	optCmt, err := dEnv.DoltDB(ctx).Resolve(ctx, cs, headRef)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return hash.Hash{}, doltdb.ErrGhostCommitEncountered
+	}
+	return cm.HashOf()
+}
@@ -232,6 +232,8 @@ func handleErrors(branchName string, err error) errhand.VerboseError {
 		return errhand.VerboseErrorFromError(err)
 	} else if actions.IsCheckoutWouldOverwrite(err) {
 		return errhand.VerboseErrorFromError(err)
+	} else if strings.Contains(err.Error(), "is already checked out in worktree") {
+		return errhand.BuildDError("fatal: %s", err.Error()).Build()
 	} else if err.Error() == actions.ErrWorkingSetsOnBothBranches.Error() {
 		str := fmt.Sprintf("error: There are uncommitted changes already on branch '%s'.", branchName) +
 			"This can happen when someone modifies that branch in a SQL session." +
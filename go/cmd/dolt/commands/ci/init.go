@@ -16,6 +16,7 @@ package ci
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -96,8 +97,11 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
+	// databases initialized before newer dolt ci tables were added are upgraded by creating the missing tables
 	hasTables, err := dolt_ci.HasDoltCITables(sqlCtx)
-	if err != nil {
+	if errors.Is(err, dolt_ci.ErrIncompleteDoltCITables) {
+		hasTables = false
+	} else if err != nil {
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
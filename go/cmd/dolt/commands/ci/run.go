@@ -0,0 +1,177 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var runDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Run a Dolt continuous integration workflow by name",
+	LongDesc: `Run a Dolt continuous integration workflow by name.
+
+Each step of each job in the workflow is run against the current branch, or the branch given with {{.EmphasisLeft}}--branch{{.EmphasisRight}}. Depending on its type, a step:
+
+  - runs a saved query and checks its {{.EmphasisLeft}}expected_columns{{.EmphasisRight}} and {{.EmphasisLeft}}expected_rows{{.EmphasisRight}}
+  - runs an inline {{.EmphasisLeft}}query{{.EmphasisRight}} and compares its result set with {{.EmphasisLeft}}expected_results{{.EmphasisRight}}
+  - checks that {{.EmphasisLeft}}assert_table{{.EmphasisRight}} exists and has the {{.EmphasisLeft}}expected_table_columns{{.EmphasisRight}}
+  - checks the number of rows in {{.EmphasisLeft}}row_count_table{{.EmphasisRight}} against {{.EmphasisLeft}}expected_row_count{{.EmphasisRight}}, or with {{.EmphasisLeft}}row_count_base{{.EmphasisRight}}, the change in that number since the merge base with {{.EmphasisLeft}}row_count_base{{.EmphasisRight}}
+  - checks the number of rows changed since the merge base with {{.EmphasisLeft}}diff_base{{.EmphasisRight}} against {{.EmphasisLeft}}expected_diff_rows{{.EmphasisRight}}
+  - checks that {{.EmphasisLeft}}dolt_verify_constraints{{.EmphasisRight}} finds no violations in the {{.EmphasisLeft}}verify_constraints{{.EmphasisRight}} tables
+
+The command exits with a non-zero exit code if any step fails.`,
+	Synopsis: []string{
+		"[--branch {{.LessThan}}branch{{.GreaterThan}}] {{.LessThan}}workflow name{{.GreaterThan}}",
+	},
+}
+
+type RunCmd struct{}
+
+// Name implements cli.Command.
+func (cmd RunCmd) Name() string {
+	return "run"
+}
+
+// Description implements cli.Command.
+func (cmd RunCmd) Description() string {
+	return runDocs.ShortDesc
+}
+
+// RequiresRepo implements cli.Command.
+func (cmd RunCmd) RequiresRepo() bool {
+	return true
+}
+
+// Docs implements cli.Command.
+func (cmd RunCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(runDocs, ap)
+}
+
+// Hidden should return true if this command should be hidden from the help text
+func (cmd RunCmd) Hidden() bool {
+	return false
+}
+
+// ArgParser implements cli.Command.
+func (cmd RunCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.SupportsString(cli.BranchParam, "b", "branch", "The branch to run the workflow against. Defaults to the current branch.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd RunCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, runDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if !cli.CheckEnvIsValid(dEnv) {
+		return 1
+	}
+
+	var verr errhand.VerboseError
+	verr = validateRunArgs(apr)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	workflowName := apr.Arg(0)
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	user, email, err := env.GetNameAndEmail(dEnv.Config)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	dbName := sqlCtx.GetCurrentDatabase()
+	if branch, ok := apr.GetValue(cli.BranchParam); ok {
+		_, err = commands.GetRowsForSql(queryist, sqlCtx, fmt.Sprintf("use %s", sql.QuoteIdentifier(dbName+"/"+branch)))
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
+	hasTables, err := dolt_ci.HasDoltCITables(sqlCtx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	if !hasTables {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(fmt.Errorf("dolt ci has not been initialized, please initialize with: dolt ci init")), usage)
+	}
+
+	wm := dolt_ci.NewWorkflowManager(user, email, queryist.Query)
+
+	db, err := newDatabase(sqlCtx, dbName, dEnv, false)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	result, err := wm.RunWorkflow(sqlCtx, db, workflowName)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	printWorkflowResult(result)
+	if !result.Passed() {
+		return 1
+	}
+	return 0
+}
+
+func printWorkflowResult(result *dolt_ci.WorkflowResult) {
+	cli.Println(color.CyanString(fmt.Sprintf("Running workflow: %s", result.Name)))
+	for _, job := range result.Jobs {
+		cli.Println(fmt.Sprintf("Running job: %s", job.Name))
+		for _, step := range job.Steps {
+			if step.Passed() {
+				cli.Println(color.GreenString(fmt.Sprintf("  PASS  %s", step.Name)))
+			} else {
+				cli.Println(color.RedString(fmt.Sprintf("  FAIL  %s: %s", step.Name, step.Err.Error())))
+			}
+		}
+	}
+
+	if result.Passed() {
+		cli.Println(color.GreenString(fmt.Sprintf("Dolt CI Workflow '%s' passed.", result.Name)))
+	} else {
+		cli.Println(color.RedString(fmt.Sprintf("Dolt CI Workflow '%s' failed.", result.Name)))
+	}
+}
+
+func validateRunArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 1 {
+		return errhand.BuildDError("expected 1 argument").SetPrintUsage().Build()
+	}
+	return nil
+}
@@ -43,12 +43,19 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+{{.LessThan}}remote-url{{.GreaterThan}} may also be the path of a bundle file written by {{.EmphasisLeft}}dolt bundle create{{.EmphasisRight}}. The bundle becomes a read only remote, which can be fetched and pulled from but not pushed to.
+
+{{.EmphasisLeft}}--tables{{.EmphasisRight}} makes a sparse clone, which only fetches the data of the given tables. The other tables are kept as placeholders, which can't be read or written, and fetches and pulls from the remote only fetch the given tables as well. Commits made in a sparse clone can be pushed to the remote it was cloned from.
 `,
 	Synopsis: []string{
 		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
 	},
 }
 
+// bundleExt is the extension of bundle files, which is left out of the directory a bundle is cloned into.
+const bundleExt = ".bundle"
+
 type CloneCmd struct{}
 
 // Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
@@ -103,6 +110,13 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	if verr != nil {
 		return verr
 	}
+	if apr.Contains(cli.LazyFlag) && apr.Contains(cli.DepthFlag) {
+		return errhand.BuildDError("error: --%s and --%s cannot be used together", cli.LazyFlag, cli.DepthFlag).SetPrintUsage().Build()
+	}
+	tables, verr := parseCloneTables(apr)
+	if verr != nil {
+		return verr
+	}
 
 	dEnv.UserPassConfig, verr = getRemoteUserAndPassConfig(apr)
 	if verr != nil {
@@ -134,6 +148,7 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	if verr != nil {
 		return verr
 	}
+	r.Tables = tables
 
 	// Create a new Dolt env for the clone
 	clonedEnv, err := actions.EnvForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version, env.GetCurrentUserHomeDir)
@@ -149,7 +164,7 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	// Nil out the old Dolt env so we don't accidentally operate on the wrong database
 	dEnv = nil
 
-	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, singleBranch, depth, clonedEnv)
+	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, singleBranch, depth, apr.Contains(cli.LazyFlag), clonedEnv)
 	if err != nil {
 		// If we're cloning into a directory that already exists do not erase it. Otherwise
 		// make best effort to delete the directory we created.
@@ -180,6 +195,35 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	return nil
 }
 
+// parseCloneTables returns the tables a sparse clone fetches, or nil if all tables are fetched.
+func parseCloneTables(apr *argparser.ArgParseResults) ([]string, errhand.VerboseError) {
+	tables, ok := apr.GetValueList(cli.TablesFlag)
+	if !ok {
+		return nil, nil
+	}
+	for _, flag := range []string{cli.LazyFlag, cli.DepthFlag} {
+		if apr.Contains(flag) {
+			return nil, errhand.BuildDError("error: --%s and --%s cannot be used together", cli.TablesFlag, flag).SetPrintUsage().Build()
+		}
+	}
+
+	var names []string
+	for _, t := range tables {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !doltdb.IsValidTableName(t) {
+			return nil, errhand.BuildDError("error: '%s' is not a valid table name", t).Build()
+		}
+		names = append(names, t)
+	}
+	if len(names) == 0 {
+		return nil, errhand.BuildDError("error: --%s requires at least one table", cli.TablesFlag).SetPrintUsage().Build()
+	}
+	return names, nil
+}
+
 func parseArgs(apr *argparser.ArgParseResults) (string, string, errhand.VerboseError) {
 	if apr.NArg() < 1 || apr.NArg() > 2 {
 		return "", "", errhand.BuildDError("").SetPrintUsage().Build()
@@ -202,6 +246,10 @@ func parseArgs(apr *argparser.ArgParseResults) (string, string, errhand.VerboseE
 		} else if dir == "/" {
 			return "", "", errhand.BuildDError("Could not infer repo name.  Please explicitly define a directory for this url").Build()
 		}
+		// a bundle is cloned into a directory named after the file, without its extension
+		if trimmed := strings.TrimSuffix(dir, bundleExt); trimmed != "" {
+			dir = trimmed
+		}
 	}
 
 	return dir, urlStr, nil
@@ -179,6 +179,7 @@ func performCommit(ctx context.Context, commandStr string, args []string, cliCtx
 	if resultRow == nil {
 		return 0, true
 	}
+	printHookWarnings(sqlCtx)
 
 	commit, err := getCommitInfo(queryist, sqlCtx, "HEAD")
 	if cli.ExecuteWithStdioRestored != nil {
@@ -193,6 +194,17 @@ func performCommit(ctx context.Context, commandStr string, args []string, cliCtx
 	return 0, false
 }
 
+// printHookWarnings prints the warnings of repository hooks which failed after the last query completed, such as a
+// failing post-commit hook.
+func printHookWarnings(sqlCtx *sql.Context) {
+	prefix := string(env.PostCommitHook) + " hook failed"
+	for _, warn := range sqlCtx.Session.Warnings() {
+		if strings.HasPrefix(warn.Message, prefix) {
+			cli.PrintErrln(color.YellowString("warning: %s", warn.Message))
+		}
+	}
+}
+
 // constructParametrizedDoltCommitQuery generates the sql query necessary to call the DOLT_COMMIT() stored procedure with placeholders
 // for arg input. Also returns a list of the inputs in the order in which they appear in the query.
 func constructParametrizedDoltCommitQuery(msg string, apr *argparser.ArgParseResults, cliCtx cli.CliContext) (string, []interface{}, error) {
@@ -273,6 +285,10 @@ func constructParametrizedDoltCommitQuery(msg string, apr *argparser.ArgParseRes
 		writeToBuffer("--skip-empty")
 	}
 
+	if apr.Contains(cli.NoVerifyFlag) {
+		writeToBuffer("--no-verify")
+	}
+
 	cfgSign := cliCtx.Config().GetStringOrDefault("sqlserver.global.gpgsign", "")
 	if apr.Contains(cli.SignFlag) || strings.ToLower(cfgSign) == "true" {
 		writeToBuffer("--gpg-sign")
@@ -36,6 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/gcctx"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
@@ -83,6 +84,7 @@ type SqlEngineConfig struct {
 	SystemVariables            SystemVariables
 	ClusterController          *cluster.Controller
 	AutoGCController           *dsqle.AutoGCController
+	CIController               *dolt_ci.CIController
 	BinlogReplicaController    binlogreplication.BinlogReplicaController
 	EventSchedulerStatus       eventscheduler.SchedulerStatus
 }
@@ -179,6 +181,7 @@ func NewSqlEngine(
 		IsReadOnly:     config.IsReadOnly,
 		IsServerLocked: config.IsServerLocked,
 	}).WithBackgroundThreads(bThreads)
+	pro.SetStatementRunner(engine)
 
 	if err := configureBinlogPrimaryController(engine); err != nil {
 		return nil, err
@@ -231,6 +234,18 @@ func NewSqlEngine(
 		dprocedures.UseSessionAwareSafepointController = true
 	}
 
+	if config.CIController != nil {
+		err = config.CIController.RunBackgroundThread(bThreads, sqlEngine.NewDefaultContext, engine.Query)
+		if err != nil {
+			return nil, err
+		}
+		err = config.CIController.ApplyCommitHooks(ctx, mrEnv, dbs...)
+		if err != nil {
+			return nil, err
+		}
+		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, config.CIController.InitDatabaseHook())
+	}
+
 	var statsPro sql.StatsProvider
 	_, enabled, _ := sql.SystemVariables.GetGlobal(dsess.DoltStatsEnabled)
 	if enabled.(int8) == 1 {
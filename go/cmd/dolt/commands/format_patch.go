@@ -0,0 +1,111 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var formatPatchDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Write the changes of a range of commits to a portable patch",
+	LongDesc: `Writes the schema and row changes of the commits reachable from {{.LessThan}}to{{.GreaterThan}} but not from {{.LessThan}}from{{.GreaterThan}} to a patch, along with their authors, dates and messages. If only {{.LessThan}}from{{.GreaterThan}} is given, {{.LessThan}}to{{.GreaterThan}} is HEAD. The patch is written to standard output unless {{.EmphasisLeft}}-o{{.EmphasisRight}} is given.
+
+A patch is a JSON document which does not reference the database it was written from, so it can be applied with {{.EmphasisLeft}}dolt am{{.EmphasisRight}} to any database with the same tables, including ones which do not share any history with this one. Merge commits are not written to the patch; the changes they merge are written with the commits that made them. Tables without a primary key, and changes to primary keys, cannot be written to a patch.
+
+The patch is also returned by the {{.EmphasisLeft}}dolt_format_patch(){{.EmphasisRight}} stored procedure.`,
+	Synopsis: []string{
+		"[-o {{.LessThan}}file{{.GreaterThan}}] {{.LessThan}}from{{.GreaterThan}}..{{.LessThan}}to{{.GreaterThan}}",
+		"[-o {{.LessThan}}file{{.GreaterThan}}] {{.LessThan}}from{{.GreaterThan}}",
+	},
+}
+
+type FormatPatchCmd struct{}
+
+var _ cli.Command = FormatPatchCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd FormatPatchCmd) Name() string {
+	return "format-patch"
+}
+
+// Description returns a description of the command
+func (cmd FormatPatchCmd) Description() string {
+	return formatPatchDocs.ShortDesc
+}
+
+// EventType returns the type of the event to log
+func (cmd FormatPatchCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+func (cmd FormatPatchCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(formatPatchDocs, ap)
+}
+
+func (cmd FormatPatchCmd) ArgParser() *argparser.ArgParser {
+	ap := cli.CreateFormatPatchArgParser()
+	ap.SupportsString(cli.OutputParam, "o", "file", "Write the patch to {{.LessThan}}file{{.GreaterThan}} instead of standard output.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd FormatPatchCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, formatPatchDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	query, err := interpolateStoredProcedureCall("DOLT_FORMAT_PATCH", apr.Args)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if len(rows) != 1 {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: unexpected number of rows returned from dolt_format_patch: %d", len(rows)).Build(), usage)
+	}
+	patch := fmt.Sprint(rows[0][0])
+
+	if path, ok := apr.GetValue(cli.OutputParam); ok {
+		if err = os.WriteFile(path, []byte(patch), 0644); err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to write patch to %s", path).AddCause(err).Build(), usage)
+		}
+		return 0
+	}
+	cli.Print(patch)
+	return 0
+}
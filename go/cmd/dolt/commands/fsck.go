@@ -16,6 +16,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/fatih/color"
 
@@ -35,9 +36,18 @@ func (cmd FsckCmd) Description() string {
 
 var fsckDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Verifies the contents of the database are not corrupted.",
-	LongDesc:  "Verifies the contents of the database are not corrupted.",
+	LongDesc: `Verifies the contents of the database are not corrupted. Every chunk is read and checked against its address, the chunks referenced by each chunk must be present, and the chunk journal must not end with a torn or corrupted record.
+
+With {{.EmphasisLeft}}--repair{{.EmphasisRight}}, the problems found are repaired using the chunks of the named remote or backup:
+
+  - The chunk journal is truncated to the end of its last valid record. The bytes removed are kept in a file next to the journal, with the {{.EmphasisLeft}}.torn{{.EmphasisRight}} suffix.
+  - A good copy of each corrupt chunk is fetched by its address. Table files holding corrupt chunks are rewritten into a new table file, and the old table files are left on disk until they are removed by {{.EmphasisLeft}}dolt gc{{.EmphasisRight}}. A table file is only rewritten if all of its corrupt chunks can be repaired.
+  - Missing chunks are fetched, along with the missing chunks they reference.
+
+The database is checked again after it is repaired. With {{.EmphasisLeft}}--dry-run{{.EmphasisRight}}, the repairs which would be made are listed and nothing is changed.`,
 	Synopsis: []string{
 		"[--quiet]",
+		"[--quiet] --repair {{.LessThan}}remote-or-backup{{.GreaterThan}} [--dry-run]",
 	},
 }
 
@@ -48,6 +58,8 @@ func (cmd FsckCmd) Docs() *cli.CommandDocumentation {
 func (cmd FsckCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
 	ap.SupportsFlag(cli.QuietFlag, "", "Don't show progress. Just print final report.")
+	ap.SupportsString(cli.RepairParam, "", "remote-or-backup", "Repair the problems found, using the chunks of the named remote or backup.")
+	ap.SupportsFlag(cli.DryRunFlag, "", "With --repair, list the repairs which would be made without changing the database.")
 
 	return ap
 }
@@ -64,13 +76,72 @@ func (cmd FsckCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	}
 
 	quiet := apr.Contains(cli.QuietFlag)
+	dryRun := apr.Contains(cli.DryRunFlag)
+	repairFrom, repair := apr.GetValue(cli.RepairParam)
+	if dryRun && !repair {
+		cli.PrintErrln(fmt.Sprintf("error: --%s can only be used with --%s", cli.DryRunFlag, cli.RepairParam))
+		return 1
+	}
 
+	var srcDB *doltdb.DoltDB
+	if repair {
+		var err error
+		srcDB, err = getFSCKRepairSource(ctx, dEnv, repairFrom)
+		if err != nil {
+			cli.PrintErrln(err.Error())
+			return 1
+		}
+	}
+
+	report, ok := runFSCK(ctx, dEnv, quiet)
+	if !ok {
+		return 1
+	}
+	if !repair || len(report.Problems) == 0 {
+		return printFSCKReport(report)
+	}
+
+	cli.Println("Before repair:")
+	printFSCKReport(report)
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		cli.PrintErrln(err.Error())
+		return 1
+	}
+	repaired, err := dEnv.DoltDB(ctx).RepairFSCK(ctx, report, srcDB, tmpDir, dryRun)
+	if err != nil {
+		cli.PrintErrln(fmt.Sprintf("error: repair failed: %s", err.Error()))
+		return 1
+	}
+	printFSCKRepairReport(repaired, repairFrom, dryRun)
+	if dryRun {
+		return 1
+	}
+
+	cli.Println("After repair:")
+	report, ok = runFSCK(ctx, dEnv, true)
+	if !ok {
+		return 1
+	}
+	return printFSCKReport(report)
+}
+
+// runFSCK checks the database, printing progress unless |quiet| is true. Returns false if the check couldn't be run.
+func runFSCK(ctx context.Context, dEnv *env.DoltEnv, quiet bool) (*doltdb.FSCKReport, bool) {
 	progress := make(chan string, 32)
-	go fsckHandleProgress(ctx, progress, quiet)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fsckHandleProgress(ctx, progress, quiet)
+	}()
 
 	var report *doltdb.FSCKReport
-	terminate = func() bool {
-		defer close(progress)
+	terminate := func() bool {
+		defer func() {
+			close(progress)
+			<-done
+		}()
 		var err error
 		report, err = dEnv.DoltDB(ctx).FSCK(ctx, progress)
 		if err != nil {
@@ -86,11 +157,60 @@ func (cmd FsckCmd) Exec(ctx context.Context, commandStr string, args []string, d
 			return false
 		}
 	}()
-	if terminate {
-		return 1
+	return report, !terminate
+}
+
+// getFSCKRepairSource returns the database of the remote or backup named |name|.
+func getFSCKRepairSource(ctx context.Context, dEnv *env.DoltEnv, name string) (*doltdb.DoltDB, error) {
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+	r, ok := remotes.Get(name)
+	if !ok {
+		backups, err := dEnv.GetBackups()
+		if err != nil {
+			return nil, err
+		}
+		r, ok = backups.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("error: unknown remote or backup: '%s'", name)
+		}
 	}
+	srcDB, err := r.GetRemoteDB(ctx, dEnv.DoltDB(ctx).Format(), dEnv)
+	if err != nil {
+		return nil, fmt.Errorf("error: unable to open '%s': %w", name, err)
+	}
+	return srcDB, nil
+}
 
-	return printFSCKReport(report)
+func printFSCKRepairReport(repaired *doltdb.FSCKRepairReport, from string, dryRun bool) {
+	verb := func(done, todo string) string {
+		if dryRun {
+			return todo
+		}
+		return done
+	}
+
+	cli.Println("Repair:")
+	if repaired.TruncatedJournalBytes > 0 {
+		cli.Printf("  %s %d bytes from the end of the chunk journal\n", verb("Truncated", "Would truncate"), repaired.TruncatedJournalBytes)
+		if repaired.TornJournalFile != "" {
+			cli.Printf("  The truncated bytes were saved to %s\n", repaired.TornJournalFile)
+		}
+	}
+	for _, h := range repaired.Repaired.ToSlice() {
+		cli.Printf("  %s corrupt chunk %s from %s\n", verb("Repaired", "Would repair"), h.String(), from)
+	}
+	for _, h := range repaired.Fetched.ToSlice() {
+		cli.Printf("  %s missing chunk %s from %s\n", verb("Fetched", "Would fetch"), h.String(), from)
+	}
+	for _, h := range repaired.ReplacedFiles.ToSlice() {
+		cli.Printf("  %s table file %s\n", verb("Replaced", "Would replace"), h.String())
+	}
+	for _, h := range repaired.Unavailable.ToSlice() {
+		cli.Println(color.YellowString("  No good copy of chunk %s is available from %s", h.String(), from))
+	}
 }
 
 func printFSCKReport(report *doltdb.FSCKReport) int {
@@ -103,6 +223,7 @@ func printFSCKReport(report *doltdb.FSCKReport) int {
 			cli.Println(color.RedString("------ Corruption Found ------"))
 			cli.PrintErrln(e.Error())
 		}
+		cli.Printf("Corrupt Chunks: %d, Missing Chunks: %d, Torn Journal Bytes: %d\n", len(report.CorruptChunks), len(report.MissingChunks), report.TornJournalBytes)
 
 		return 1
 	}
@@ -154,7 +154,13 @@ func (cmd GarbageCollectionCmd) constructDoltGCQuery(apr *argparser.ArgParseResu
 }
 
 func MaybeMigrateEnv(ctx context.Context, dEnv *env.DoltEnv) (*env.DoltEnv, error) {
-	migrated, err := nbs.MaybeMigrateFileManifest(ctx, dbfactory.DoltDataDir)
+	dataDir, ok, err := dbfactory.DataDirPath(dEnv.FS)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return dEnv, nil
+	}
+	migrated, err := nbs.MaybeMigrateFileManifest(ctx, dataDir)
 	if err != nil {
 		return nil, err
 	}
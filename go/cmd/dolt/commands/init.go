@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -36,6 +37,7 @@ const (
 	usernameParamName   = "name"
 	initBranchParamName = "initial-branch"
 	funHashFlag         = "fun"
+	encryptFlag         = "encrypt"
 )
 
 var initDocs = cli.CommandDocumentationContent{
@@ -43,10 +45,14 @@ var initDocs = cli.CommandDocumentationContent{
 	LongDesc: `This command creates an empty Dolt data repository in the current directory.
 
 Running dolt init in an already initialized directory will fail.
+
+If {{.EmphasisLeft}}--encrypt{{.EmphasisRight}} is given, the chunk data of the repository is encrypted at rest. The key is read from {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY{{.EmphasisRight}}, from the file named by {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY_FILE{{.EmphasisRight}}, or from the key management command in {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY_COMMAND{{.EmphasisRight}}. Keys are 32 bytes, encoded as hex or base64. The same key must be available whenever the repository is used. Use {{.EmphasisLeft}}dolt rotate-key{{.EmphasisRight}} to change it.
+
+Encrypted repositories can be pushed to and pulled from remotes, which receive the decrypted data. They cannot be served by the remotesapi of a sql-server, so clones and fetches of an encrypted database through {{.EmphasisLeft}}--remotesapi-port{{.EmphasisRight}} fail, including those of read replicas and of cluster members which fetch from it. A sql-server can still push an encrypted database to remotes and to cluster standbys.
 `,
 
 	Synopsis: []string{
-		"",
+		"[--encrypt]",
 	},
 }
 
@@ -79,6 +85,7 @@ func (cmd InitCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsString(emailParamName, "", "email", fmt.Sprintf("The email address used. If not provided will be taken from {{.EmphasisLeft}}%s{{.EmphasisRight}} in the global config.", config.UserEmailKey))
 	ap.SupportsString(cli.DateParam, "", "date", "Specify the date used in the initial commit. If not specified the current system time is used.")
 	ap.SupportsString(initBranchParamName, "b", "branch", fmt.Sprintf("The branch name used to initialize this database. If not provided will be taken from {{.EmphasisLeft}}%s{{.EmphasisRight}} in the global config. If unset, the default initialized branch will be named '%s'.", config.InitBranchName, env.DefaultInitBranch))
+	ap.SupportsFlag(encryptFlag, "", "Encrypt the chunk data of this repository at rest with the key configured in the environment.")
 	ap.SupportsFlag(funHashFlag, "", "") // This flag is an easter egg. We can't currently prevent it from being listed in the help, but the description is deliberately left blank.
 	return ap
 }
@@ -154,7 +161,20 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		commitMetaGenerator = datas.MakeFunCommitMetaGenerator(name, email, t)
 	}
 
-	err := dEnv.InitRepoWithCommitMetaGenerator(context.Background(), types.Format_Default, initBranch, commitMetaGenerator)
+	var err error
+	if apr.Contains(encryptFlag) {
+		var keys *nbs.EncryptionKeys
+		keys, err = nbs.LoadEncryptionKeys()
+		if err == nil {
+			_, err = keys.Primary(ctx)
+		}
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: cannot encrypt repository").AddCause(err).Build(), usage)
+		}
+		err = dEnv.InitEncryptedRepoWithCommitMetaGenerator(context.Background(), types.Format_Default, initBranch, commitMetaGenerator, keys)
+	} else {
+		err = dEnv.InitRepoWithCommitMetaGenerator(context.Background(), types.Format_Default, initBranch, commitMetaGenerator)
+	}
 	if err != nil {
 		cli.PrintErrln(color.RedString("Failed to initialize directory as a data repo. %s", err.Error()))
 		return 1
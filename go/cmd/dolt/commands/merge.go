@@ -154,6 +154,7 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return 1
 	}
 	mergeResultRow := rows[0]
+	printHookWarnings(sqlCtx)
 
 	upToDate, err := everythingUpToDate(mergeResultRow)
 	if err != nil {
@@ -284,6 +285,9 @@ func constructInterpolatedDoltMergeQuery(apr *argparser.ArgParseResults, cliCtx
 	if apr.Contains(cli.NoEditFlag) {
 		writeToBuffer("--no-edit", false)
 	}
+	if apr.Contains(cli.NoVerifyFlag) {
+		writeToBuffer("--no-verify", false)
+	}
 
 	writeToBuffer("--author", false)
 	var author string
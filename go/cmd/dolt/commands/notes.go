@@ -0,0 +1,162 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var notesDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Add or inspect notes attached to commits",
+	LongDesc: `Adds, shows or removes notes attached to commits, without changing the commits themselves. With no arguments, or with {{.EmphasisLeft}}list{{.EmphasisRight}}, lists the notes of the repository along with the commits they are attached to.
+
+Notes can also be queried with the {{.EmphasisLeft}}dolt_notes{{.EmphasisRight}} system table, which can be joined with {{.EmphasisLeft}}dolt_log{{.EmphasisRight}} on {{.EmphasisLeft}}commit_hash{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}add{{.EmphasisRight}}
+Attaches the note given with {{.EmphasisLeft}}-m{{.EmphasisRight}} to {{.LessThan}}commit{{.GreaterThan}}, which defaults to HEAD. A commit can have only one note; use {{.EmphasisLeft}}-f{{.EmphasisRight}} to replace an existing note.
+
+{{.EmphasisLeft}}show{{.EmphasisRight}}
+Shows the note attached to {{.LessThan}}commit{{.GreaterThan}}, which defaults to HEAD.
+
+{{.EmphasisLeft}}remove{{.EmphasisRight}}
+Removes the note attached to {{.LessThan}}commit{{.GreaterThan}}, which defaults to HEAD.
+
+{{.EmphasisLeft}}push{{.EmphasisRight}}, {{.EmphasisLeft}}fetch{{.EmphasisRight}}
+Copies notes to or from {{.LessThan}}remote{{.GreaterThan}}, which defaults to origin. Notes are not pushed or fetched along with branches. Only notes on commits which already exist on the receiving side are copied, and they replace any note that side has for the same commit.`,
+	Synopsis: []string{
+		"[list]",
+		"add -m {{.LessThan}}msg{{.GreaterThan}} [-f] [{{.LessThan}}commit{{.GreaterThan}}]",
+		"show [{{.LessThan}}commit{{.GreaterThan}}]",
+		"remove [{{.LessThan}}commit{{.GreaterThan}}]",
+		"(push | fetch) [{{.LessThan}}remote{{.GreaterThan}}]",
+	},
+}
+
+const (
+	listNotesId = "list"
+	showNotesId = "show"
+)
+
+type NotesCmd struct{}
+
+var _ cli.Command = NotesCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd NotesCmd) Name() string {
+	return "notes"
+}
+
+// Description returns a description of the command
+func (cmd NotesCmd) Description() string {
+	return notesDocs.ShortDesc
+}
+
+// EventType returns the type of the event to log
+func (cmd NotesCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+func (cmd NotesCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(notesDocs, ap)
+}
+
+func (cmd NotesCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateNotesArgParser()
+}
+
+// Exec executes the command
+func (cmd NotesCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, notesDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	var verr errhand.VerboseError
+	switch {
+	case apr.NArg() == 0, apr.NArg() == 1 && apr.Arg(0) == listNotesId:
+		verr = listNotes(queryist, sqlCtx)
+	case apr.Arg(0) == showNotesId:
+		if apr.NArg() > 2 {
+			verr = errhand.BuildDError("").SetPrintUsage().Build()
+			break
+		}
+		spec := "HEAD"
+		if apr.NArg() == 2 {
+			spec = apr.Arg(1)
+		}
+		verr = showNote(queryist, sqlCtx, spec)
+	default:
+		verr = callDoltNotes(queryist, sqlCtx, args)
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func listNotes(queryist cli.Queryist, sqlCtx *sql.Context) errhand.VerboseError {
+	rows, err := GetRowsForSql(queryist, sqlCtx, "SELECT commit_hash, note FROM dolt_notes ORDER BY commit_hash")
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	for _, row := range rows {
+		cli.Printf("%s %s\n", row[0], row[1])
+	}
+	return nil
+}
+
+func showNote(queryist cli.Queryist, sqlCtx *sql.Context, spec string) errhand.VerboseError {
+	rows, err := InterpolateAndRunQuery(queryist, sqlCtx, "SELECT n.note FROM dolt_notes n WHERE n.commit_hash = dolt_hashof(?)", spec)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	if len(rows) == 0 {
+		return errhand.BuildDError("error: no note found for commit '%s'", spec).Build()
+	}
+	cli.Println(rows[0][0])
+	return nil
+}
+
+func callDoltNotes(queryist cli.Queryist, sqlCtx *sql.Context, args []string) errhand.VerboseError {
+	query, err := interpolateStoredProcedureCall("DOLT_NOTES", args)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, query)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	if len(rows) > 0 && rows[0][1] != nil {
+		if msg := fmt.Sprint(rows[0][1]); msg != "" {
+			cli.Println(msg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var rotateKeyDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Re-encrypts an encrypted repository with a new key.",
+	LongDesc: `Re-encrypts the chunk data of a repository created with {{.EmphasisLeft}}dolt init --encrypt{{.EmphasisRight}} with a new key.
+
+The new key is the first key configured in {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY{{.EmphasisRight}} or {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY_FILE{{.EmphasisRight}}, or the key returned by {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY_COMMAND{{.EmphasisRight}} when it is run without arguments. The key the repository is currently encrypted with must also be available, for example as the second key in {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY{{.EmphasisRight}}.
+
+A full garbage collection is run, which writes all reachable data with the new key, and any remaining table files and chunk journal records are then re-encrypted in place. Once the command completes, the old key is no longer needed.
+
+This command must not be run while a sql-server is serving the repository.`,
+	Synopsis: []string{
+		"",
+	},
+}
+
+type RotateKeyCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RotateKeyCmd) Name() string {
+	return "rotate-key"
+}
+
+// Description returns a description of the command
+func (cmd RotateKeyCmd) Description() string {
+	return rotateKeyDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd RotateKeyCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd RotateKeyCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(rotateKeyDocs, ap)
+}
+
+func (cmd RotateKeyCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+}
+
+// Exec executes the command
+func (cmd RotateKeyCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, rotateKeyDocs, ap))
+	cli.ParseArgsOrDie(ap, args, help)
+
+	if dEnv.HasDoltSqlServerInfo() {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: a sql-server is running from this directory, stop it before rotating the encryption key").Build(), usage)
+	}
+
+	keys, err := nbs.LoadEncryptionKeys()
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	newKey, err := keys.Primary(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	ddb := dEnv.DoltDB(ctx)
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+	oldID, ok := nbs.EncryptionKeyIDOf(cs)
+	if !ok {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: this repository is not encrypted").Build(), usage)
+	}
+
+	if oldID != newKey.ID() {
+		if err = nbs.RotateEncryptionKey(cs, newKey); err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to rotate encryption key").AddCause(err).Build(), usage)
+		}
+		err = ddb.GC(ctx, types.GCModeFull, chunks.NoArchive, nil)
+		if err != nil && !errors.Is(err, chunks.ErrNothingToCollect) {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to rewrite data with the new key").AddCause(err).Build(), usage)
+		}
+	}
+
+	// re-encrypting is also how an interrupted rotation is completed, so it runs even if the key is unchanged
+	if _, err = nbs.ReencryptTableFiles(ctx, cs); err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to re-encrypt table files").AddCause(err).Build(), usage)
+	}
+
+	if oldID == newKey.ID() {
+		cli.Println(color.CyanString("Repository is encrypted with key %s.", newKey.ID()))
+	} else {
+		cli.Println(color.CyanString("Rotated encryption key from %s to %s.", oldID, newKey.ID()))
+	}
+	return 0
+}
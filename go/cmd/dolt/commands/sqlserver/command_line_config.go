@@ -342,6 +342,18 @@ func (cfg *commandLineServerConfig) RemotesapiReadOnly() *bool {
 	return cfg.remotesapiReadOnly
 }
 
+func (cfg *commandLineServerConfig) HTTPAPIConfig() servercfg.HTTPAPIConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) SlowQueryLogConfig() servercfg.SlowQueryLogConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) CIConfig() servercfg.CIConfig {
+	return nil
+}
+
 func (cfg *commandLineServerConfig) ClusterConfig() servercfg.ClusterConfig {
 	return nil
 }
@@ -0,0 +1,608 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+)
+
+const (
+	// httpAPIQueryPath is the path that the HTTP API accepts queries on.
+	httpAPIQueryPath = "/query"
+	// httpAPIMaxRequestBytes limits the size of the request bodies that the HTTP API accepts.
+	httpAPIMaxRequestBytes = 16 * 1024 * 1024
+
+	httpAPIFormatJSON   = "json"
+	httpAPIFormatNDJSON = "ndjson"
+	ndjsonContentType   = "application/x-ndjson"
+
+	jwtAuthPluginName = "authentication_dolt_jwt"
+)
+
+var errHTTPAPIAccessDenied = errors.New("access denied")
+var errHTTPAPIInsecureTransport = errors.New("connections using insecure transport are prohibited while require_secure_transport is enabled")
+var errHTTPAPITooManyConnections = errors.New("too many connections")
+
+// httpAPIRequest is the body of a request to the HTTP API.
+type httpAPIRequest struct {
+	// Query is the single SQL statement to run. It may contain ? placeholders, which are bound to |Params|.
+	Query string `json:"query"`
+	// Params are the values bound to the placeholders of |Query|, in order.
+	Params []interface{} `json:"params"`
+	// Database is the database the statement is run in. If it is empty, the statement is run without a current
+	// database.
+	Database string `json:"database"`
+	// Revision is the branch, tag or commit of |Database| which the statement is run against. If it is empty, the
+	// default branch of the database is used.
+	Revision string `json:"revision"`
+	// Format is either "json" or "ndjson". If it is empty, the format is chosen from the Accept header of the request.
+	Format string `json:"format"`
+}
+
+type httpAPIColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type httpAPIOkResult struct {
+	RowsAffected uint64 `json:"rows_affected"`
+	LastInsertID uint64 `json:"last_insert_id"`
+}
+
+type httpAPIError struct {
+	Error string `json:"error"`
+}
+
+// httpAPIHandler serves the HTTP API of sql-server. Each request runs a single statement in a new session, as the user
+// the request authenticates as. Like a MySQL client, a request authenticates with the user name and password of an
+// account, using HTTP basic auth. Accounts which use JWT authentication pass their token as the password.
+//
+// Sessions are built the same way as those of MySQL connections, and each request is registered as a connection in
+// the process list of the server for as long as it runs. So requests show up in SHOW PROCESSLIST, can be ended with
+// KILL, and count towards max_connections.
+//
+// Since every request has its own session, a transaction cannot span requests. When autocommit is disabled for the
+// session, the changes a statement makes are rolled back once the request completes.
+type httpAPIHandler struct {
+	sqlEngine      *engine.SqlEngine
+	rawDb          *mysql_db.MySQLDb
+	jwtPlugin      mysql_db.PlaintextAuthPlugin
+	allowedOrigins []string
+	sessionBuilder server.SessionBuilder
+	// killConnection closes a connection of the MySQL listener, for KILL CONNECTION statements run over the HTTP API.
+	killConnection         func(connID uint32) error
+	addr                   string
+	requireSecureTransport bool
+	maxConnections         uint64
+
+	// connMu serializes checking the number of connections against |maxConnections| with adding a connection.
+	connMu sync.Mutex
+	// lastConnID is the connection id last given to a request. The MySQL listener counts its connection ids up from 1,
+	// so requests count theirs down from the top of the range to keep the ids in the process list unique.
+	lastConnID atomic.Uint32
+	lastPid    atomic.Uint64
+}
+
+func newHTTPAPIHandler(se *engine.SqlEngine, config servercfg.ServerConfig, killConnection func(connID uint32) error) *httpAPIHandler {
+	httpAPIConfig := config.HTTPAPIConfig()
+	h := &httpAPIHandler{
+		sqlEngine:              se,
+		rawDb:                  se.GetUnderlyingEngine().Analyzer.Catalog.MySQLDb,
+		jwtPlugin:              engine.NewAuthenticateDoltJWTPlugin(config.JwksConfig()),
+		allowedOrigins:         httpAPIConfig.AllowedOrigins(),
+		sessionBuilder:         newSessionBuilder(se, config),
+		killConnection:         killConnection,
+		addr:                   net.JoinHostPort(httpAPIConfig.Host(), strconv.Itoa(httpAPIConfig.Port())),
+		requireSecureTransport: config.RequireSecureTransport(),
+		maxConnections:         config.MaxConnections(),
+	}
+	h.lastConnID.Store(math.MaxUint32)
+	return h
+}
+
+func (h *httpAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != httpAPIQueryPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	if h.requireSecureTransport && r.TLS == nil {
+		writeHTTPAPIError(w, http.StatusForbidden, errHTTPAPIInsecureTransport)
+		return
+	}
+
+	h.setCORSHeaders(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	} else if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST, OPTIONS")
+		writeHTTPAPIError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+
+	client, err := h.authenticate(r)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="dolt"`)
+		writeHTTPAPIError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	var req httpAPIRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, httpAPIMaxRequestBytes))
+	dec.UseNumber()
+	if err = dec.Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeHTTPAPIError(w, http.StatusRequestEntityTooLarge, err)
+		} else {
+			writeHTTPAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		}
+		return
+	}
+
+	format, err := httpAPIResultFormat(&req, r)
+	if err != nil {
+		writeHTTPAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		writeHTTPAPIError(w, http.StatusBadRequest, errors.New("query is required"))
+		return
+	}
+	if req.Revision != "" && req.Database == "" {
+		writeHTTPAPIError(w, http.StatusBadRequest, errors.New("revision requires a database"))
+		return
+	}
+	bindings, err := httpAPIBindings(req.Params)
+	if err != nil {
+		writeHTTPAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	connID, err := h.addConnection(r.RemoteAddr)
+	if err != nil {
+		writeHTTPAPIError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	pl := h.sqlEngine.GetUnderlyingEngine().ProcessList
+	defer pl.RemoveConnection(connID)
+
+	sqlCtx, err := h.newContext(r.Context(), connID, client, req.Query)
+	if err != nil {
+		writeHTTPAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+
+	if req.Database != "" {
+		if err = h.useDatabase(sqlCtx, req.Database, req.Revision); err != nil {
+			writeHTTPAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	pl.ConnectionReady(sqlCtx.Session)
+
+	sqlCtx, err = pl.BeginQuery(sqlCtx, req.Query)
+	if err != nil {
+		writeHTTPAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer pl.EndQuery(sqlCtx)
+
+	sch, iter, err := h.query(sqlCtx, req.Query, bindings)
+	if err != nil {
+		writeHTTPAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeHTTPAPIResults(sqlCtx, w, format, sch, iter)
+}
+
+// setCORSHeaders allows the origin of |r| to read the response to it, if it is one of the allowed origins.
+func (h *httpAPIHandler) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+	for _, allowed := range h.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept")
+			w.Header().Add("Vary", "Origin")
+			return
+		}
+	}
+}
+
+// authenticate checks the basic auth credentials of |r| against the accounts of the server, and returns the client
+// they authenticate as.
+func (h *httpAPIHandler) authenticate(r *http.Request) (sql.Client, error) {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return sql.Client{}, errors.New("authentication required")
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return sql.Client{}, err
+	}
+	client := sql.Client{User: user, Address: host, Capabilities: 0}
+
+	if h.rawDb.Enabled() {
+		rd := h.rawDb.Reader()
+		userEntry := h.rawDb.GetUser(rd, user, host, false)
+		rd.Close()
+		if userEntry == nil || userEntry.Locked {
+			return sql.Client{}, errHTTPAPIAccessDenied
+		}
+		if userEntry.Plugin == jwtAuthPluginName {
+			authenticated, err := h.jwtPlugin.Authenticate(h.rawDb, user, userEntry, password)
+			if err != nil || !authenticated {
+				return sql.Client{}, errHTTPAPIAccessDenied
+			}
+			return client, nil
+		}
+	}
+
+	err = commands.ValidatePasswordFromAddr(h.rawDb, user, password, &net.TCPAddr{IP: net.ParseIP(host)})
+	if err != nil {
+		return sql.Client{}, errHTTPAPIAccessDenied
+	}
+	return client, nil
+}
+
+// addConnection registers a new connection from |remoteAddr| in the process list of the server, and returns its id.
+// Returns errHTTPAPITooManyConnections if the server already has max_connections connections. The connection must be
+// removed from the process list once the request completes.
+func (h *httpAPIHandler) addConnection(remoteAddr string) (uint32, error) {
+	pl := h.sqlEngine.GetUnderlyingEngine().ProcessList
+
+	h.connMu.Lock()
+	defer h.connMu.Unlock()
+	if h.maxConnections > 0 && uint64(len(pl.Processes())) >= h.maxConnections {
+		return 0, errHTTPAPITooManyConnections
+	}
+	connID := h.lastConnID.Add(^uint32(0))
+	pl.AddConnection(connID, remoteAddr)
+	return connID, nil
+}
+
+// newContext returns a context for running |query| with a new session for |client|, which is built by the session
+// builder of the server for the connection |connID|.
+func (h *httpAPIHandler) newContext(ctx context.Context, connID uint32, client sql.Client, query string) (*sql.Context, error) {
+	conn := &mysql.Conn{
+		ConnectionID: connID,
+		User:         client.User,
+		UserData:     sql.MysqlConnectionUser{User: client.User, Host: client.Address},
+	}
+	sess, err := h.sessionBuilder(ctx, conn, h.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	eng := h.sqlEngine.GetUnderlyingEngine()
+	return h.sqlEngine.ContextFactory(
+		ctx,
+		sql.WithSession(sess),
+		sql.WithPid(h.lastPid.Add(1)),
+		sql.WithQuery(query),
+		sql.WithMemoryManager(eng.MemoryManager),
+		sql.WithProcessList(eng.ProcessList),
+		sql.WithServices(sql.Services{KillConnection: h.killConnection}),
+	), nil
+}
+
+// useDatabase makes |revision| of the database named |dbName| the current database of the session of |ctx|. Like a
+// COM_INIT_DB command of a MySQL connection, it is run as an operation of the connection, rather than as a query.
+func (h *httpAPIHandler) useDatabase(ctx *sql.Context, dbName, revision string) error {
+	if revision != "" {
+		dbName = dbName + dsess.DbRevisionDelimiter + revision
+	}
+	pl := h.sqlEngine.GetUnderlyingEngine().ProcessList
+	ctx, err := pl.BeginOperation(ctx)
+	if err != nil {
+		return err
+	}
+	defer pl.EndOperation(ctx)
+
+	_, iter, _, err := h.sqlEngine.Query(ctx, "USE "+sqlfmt.QuoteIdentifier(dbName))
+	if err != nil {
+		return err
+	}
+	_, err = sql.RowIterToRows(ctx, iter)
+	return err
+}
+
+// query runs the single statement |query| with the |bindings| given for its placeholders.
+func (h *httpAPIHandler) query(ctx *sql.Context, query string, bindings map[string]sqlparser.Expr) (sql.Schema, sql.RowIter, error) {
+	eng := h.sqlEngine.GetUnderlyingEngine()
+	query = sql.RemoveSpaceAndDelimiter(query, ';')
+	parsed, remainder, err := eng.Parser.ParseOneWithOptions(ctx, query, sql.LoadSqlMode(ctx).ParserOptions())
+	if err != nil {
+		return nil, nil, err
+	}
+	if remainder < len(query) && strings.TrimSpace(query[remainder:]) != "" {
+		return nil, nil, errors.New("only a single statement can be run per request")
+	}
+
+	// The plan is bound directly, rather than through the prepared statement cache of the engine, which is keyed by
+	// connection id and is shared with the MySQL listener.
+	node, err := eng.BoundQueryPlan(ctx, query, parsed, bindings)
+	if err != nil {
+		return nil, nil, err
+	}
+	sch, iter, _, err := eng.PrepQueryPlanForExecution(ctx, query, node, nil)
+	return sch, iter, err
+}
+
+// httpAPIResultFormat returns the format the results of |req| are written in.
+func httpAPIResultFormat(req *httpAPIRequest, r *http.Request) (string, error) {
+	switch strings.ToLower(req.Format) {
+	case httpAPIFormatJSON:
+		return httpAPIFormatJSON, nil
+	case httpAPIFormatNDJSON:
+		return httpAPIFormatNDJSON, nil
+	case "":
+		if strings.Contains(r.Header.Get("Accept"), ndjsonContentType) {
+			return httpAPIFormatNDJSON, nil
+		}
+		return httpAPIFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, expected %q or %q", req.Format, httpAPIFormatJSON, httpAPIFormatNDJSON)
+	}
+}
+
+// httpAPIBindings returns the bindings for the ? placeholders of a query, from the JSON values of |params|. JSON
+// objects and arrays are bound as strings of JSON.
+func httpAPIBindings(params []interface{}) (map[string]sqlparser.Expr, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	bindings := make(map[string]sqlparser.Expr, len(params))
+	for i, param := range params {
+		var expr sqlparser.Expr
+		switch v := param.(type) {
+		case nil:
+			expr = &sqlparser.NullVal{}
+		case bool:
+			expr = sqlparser.BoolVal(v)
+		case json.Number:
+			if strings.ContainsAny(v.String(), ".eE") {
+				expr = sqlparser.NewFloatVal([]byte(v.String()))
+			} else {
+				expr = sqlparser.NewIntVal([]byte(v.String()))
+			}
+		case string:
+			expr = sqlparser.NewStrVal([]byte(v))
+		case map[string]interface{}, []interface{}:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			expr = sqlparser.NewStrVal(b)
+		default:
+			return nil, fmt.Errorf("unsupported value for parameter %d: %v", i+1, v)
+		}
+		bindings[fmt.Sprintf("v%d", i+1)] = expr
+	}
+	return bindings, nil
+}
+
+// writeHTTPAPIResults streams the rows of |iter| to |w| in |format|. The iterator is always closed, which is also when
+// an autocommit transaction is committed, so errors are written after the rows if they occur once the response has
+// been started.
+//
+// The json format writes a single object with the columns of the result, its rows and an error if there was one. The
+// ndjson format writes one object per line: the columns first, then an array for each row, then an error if there was
+// one. Results of statements which do not return rows are written as an object with the number of rows affected.
+func writeHTTPAPIResults(ctx *sql.Context, w http.ResponseWriter, format string, sch sql.Schema, iter sql.RowIter) {
+	if types.IsOkResultSchema(sch) {
+		rows, err := sql.RowIterToRows(ctx, iter)
+		if err != nil {
+			writeHTTPAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+		var res httpAPIOkResult
+		for _, row := range rows {
+			okResult := types.GetOkResult(row)
+			res.RowsAffected += okResult.RowsAffected
+			res.LastInsertID = okResult.InsertID
+		}
+		writeHTTPAPIJSON(w, format, http.StatusOK, res)
+		return
+	}
+
+	columns := make([]httpAPIColumn, len(sch))
+	for i, col := range sch {
+		columns[i] = httpAPIColumn{Name: col.Name, Type: col.Type.String()}
+	}
+
+	var err error
+	var rw httpAPIRowWriter
+	if format == httpAPIFormatNDJSON {
+		w.Header().Set("Content-Type", ndjsonContentType)
+		rw = &ndjsonRowWriter{w: w}
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		rw = &jsonRowWriter{w: w}
+	}
+	w.WriteHeader(http.StatusOK)
+
+	err = rw.begin(columns)
+	for err == nil {
+		var row sql.Row
+		row, err = iter.Next(ctx)
+		if err != nil {
+			break
+		}
+		var vals []interface{}
+		vals, err = httpAPIRowValues(ctx, sch, row)
+		if err == nil {
+			err = rw.row(vals)
+		}
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	if closeErr := iter.Close(ctx); err == nil {
+		err = closeErr
+	}
+	_ = rw.end(err)
+}
+
+// httpAPIRowValues converts |row| into the values written for it, using the same text representation as the MySQL
+// protocol. Numbers are written as JSON numbers, JSON documents as JSON, binary values as base64 strings, and
+// everything else as strings.
+func httpAPIRowValues(ctx *sql.Context, sch sql.Schema, row sql.Row) ([]interface{}, error) {
+	sqlVals, err := server.RowToSQL(ctx, sch, row, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]interface{}, len(sqlVals))
+	for i, v := range sqlVals {
+		switch {
+		case v.IsNull():
+			vals[i] = nil
+		case v.IsIntegral(), v.IsFloat(), v.Type() == sqltypes.Decimal:
+			vals[i] = json.Number(v.ToString())
+		case v.Type() == sqltypes.TypeJSON:
+			vals[i] = json.RawMessage(v.Raw())
+		case v.IsText():
+			vals[i] = v.ToString()
+		case v.IsBinary():
+			vals[i] = base64.StdEncoding.EncodeToString(v.Raw())
+		default:
+			vals[i] = v.ToString()
+		}
+	}
+	return vals, nil
+}
+
+// httpAPIRowWriter writes the columns and rows of a result set in one of the formats of the HTTP API.
+type httpAPIRowWriter interface {
+	begin(columns []httpAPIColumn) error
+	row(vals []interface{}) error
+	end(err error) error
+}
+
+type jsonRowWriter struct {
+	w       io.Writer
+	numRows int
+}
+
+func (jw *jsonRowWriter) begin(columns []httpAPIColumn) error {
+	b, err := json.Marshal(columns)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(jw.w, `{"columns":%s,"rows":[`, b)
+	return err
+}
+
+func (jw *jsonRowWriter) row(vals []interface{}) error {
+	b, err := json.Marshal(vals)
+	if err != nil {
+		return err
+	}
+	if jw.numRows > 0 {
+		_, err = jw.w.Write([]byte{','})
+		if err != nil {
+			return err
+		}
+	}
+	jw.numRows++
+	_, err = jw.w.Write(b)
+	return err
+}
+
+func (jw *jsonRowWriter) end(err error) error {
+	if err == nil {
+		_, err = io.WriteString(jw.w, "]}\n")
+		return err
+	}
+	b, err := json.Marshal(err.Error())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(jw.w, `],"error":%s}`+"\n", b)
+	return err
+}
+
+type ndjsonRowWriter struct {
+	w io.Writer
+}
+
+func (nw *ndjsonRowWriter) begin(columns []httpAPIColumn) error {
+	return nw.writeLine(struct {
+		Columns []httpAPIColumn `json:"columns"`
+	}{columns})
+}
+
+func (nw *ndjsonRowWriter) row(vals []interface{}) error {
+	return nw.writeLine(vals)
+}
+
+func (nw *ndjsonRowWriter) end(err error) error {
+	if err == nil {
+		return nil
+	}
+	return nw.writeLine(httpAPIError{Error: err.Error()})
+}
+
+func (nw *ndjsonRowWriter) writeLine(v interface{}) error {
+	return json.NewEncoder(nw.w).Encode(v)
+}
+
+func writeHTTPAPIJSON(w http.ResponseWriter, format string, status int, v interface{}) {
+	if format == httpAPIFormatNDJSON {
+		w.Header().Set("Content-Type", ndjsonContentType)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeHTTPAPIError(w http.ResponseWriter, status int, err error) {
+	writeHTTPAPIJSON(w, httpAPIFormatJSON, status, httpAPIError{Error: err.Error()})
+}
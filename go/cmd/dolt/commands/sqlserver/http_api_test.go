@@ -0,0 +1,304 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/utils/svcs"
+)
+
+func TestHTTPAPI(t *testing.T) {
+	ctx := context.Background()
+	env, err := sqle.CreateEnvWithSeedData()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, env.DoltDB(ctx).Close())
+	}()
+
+	// The users this test creates are persisted to a privileges file on disk, rather than in the test environment.
+	privilegeFile := filepath.Join(t.TempDir(), "privileges.db")
+	serverConfig, err := servercfg.NewYamlConfig([]byte(`
+log_level: fatal
+privilege_file: ` + privilegeFile + `
+
+listener:
+  host: localhost
+  port: 15320
+
+http_api:
+  host: localhost
+  port: 15321
+  allowed_origins:
+  - https://example.com
+`))
+	require.NoError(t, err)
+
+	sc := svcs.NewController()
+	defer sc.Stop()
+	go func() {
+		_, _ = Serve(context.Background(), &Config{
+			Version:      "0.0.0",
+			ServerConfig: serverConfig,
+			Controller:   sc,
+			DoltEnv:      env,
+		})
+	}()
+	require.NoError(t, sc.WaitForStart())
+
+	const url = "http://localhost:15321/query"
+	post := func(t *testing.T, user, password string, req httpAPIRequest) (int, string) {
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		require.NoError(t, err)
+		if user != "" {
+			httpReq.SetBasicAuth(user, password)
+		}
+		resp, err := http.DefaultClient.Do(httpReq)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, strings.TrimSpace(string(respBody))
+	}
+
+	t.Run("authentication", func(t *testing.T) {
+		status, body := post(t, "", "", httpAPIRequest{Query: "select 1"})
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, `{"error":"authentication required"}`, body)
+		status, _ = post(t, "root", "wrong", httpAPIRequest{Query: "select 1"})
+		assert.Equal(t, http.StatusUnauthorized, status)
+		status, _ = post(t, "nobody", "", httpAPIRequest{Query: "select 1"})
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("select", func(t *testing.T) {
+		status, body := post(t, "root", "", httpAPIRequest{
+			Query:    "select name, age, is_married from people where age > ? order by age",
+			Params:   []interface{}{24},
+			Database: "dolt",
+		})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"columns":[{"name":"name","type":"varchar(40)"},{"name":"age","type":"int unsigned"},{"name":"is_married","type":"int"}],`+
+			`"rows":[["John Johnson",25,0],["Bill Billerson",32,1]]}`, body)
+
+		status, body = post(t, "root", "", httpAPIRequest{
+			Query:    "select name, title from people where name = ? and ? is null",
+			Params:   []interface{}{"Rob Robertson", nil},
+			Database: "dolt",
+			Format:   "ndjson",
+		})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"columns":[{"name":"name","type":"varchar(40)"},{"name":"title","type":"varchar(40)"}]}`+"\n"+
+			`["Rob Robertson",""]`, body)
+
+		status, body = post(t, "root", "", httpAPIRequest{Query: "select * from people"})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, `{"error":"no database selected"}`, body)
+
+		status, body = post(t, "root", "", httpAPIRequest{Query: "select 1; select 2"})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, `{"error":"only a single statement can be run per request"}`, body)
+	})
+
+	t.Run("writes and revisions", func(t *testing.T) {
+		for _, query := range []string{
+			"call dolt_commit('-Am', 'seed data')",
+			"call dolt_branch('feature')",
+		} {
+			status, body := post(t, "root", "", httpAPIRequest{Query: query, Database: "dolt"})
+			require.Equal(t, http.StatusOK, status, body)
+		}
+
+		status, body := post(t, "root", "", httpAPIRequest{
+			Query:    "insert into people (id, name, age) values (?, ?, ?)",
+			Params:   []interface{}{"00000000-0000-0000-0000-000000000003", "Jill Jillson", 40},
+			Database: "dolt",
+			Revision: "feature",
+		})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"rows_affected":1,"last_insert_id":0}`, body)
+
+		status, body = post(t, "root", "", httpAPIRequest{Query: "select count(*) from people", Database: "dolt", Revision: "feature"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"columns":[{"name":"count(*)","type":"bigint"}],"rows":[[4]]}`, body)
+		status, body = post(t, "root", "", httpAPIRequest{Query: "select count(*) from people", Database: "dolt"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"columns":[{"name":"count(*)","type":"bigint"}],"rows":[[3]]}`, body)
+	})
+
+	t.Run("grants", func(t *testing.T) {
+		for _, query := range []string{
+			"create user reader@'%' identified by 'pass'",
+			"grant select on dolt.* to reader@'%'",
+		} {
+			status, body := post(t, "root", "", httpAPIRequest{Query: query})
+			require.Equal(t, http.StatusOK, status, body)
+		}
+
+		status, _ := post(t, "reader", "wrong", httpAPIRequest{Query: "select 1"})
+		assert.Equal(t, http.StatusUnauthorized, status)
+		status, body := post(t, "reader", "pass", httpAPIRequest{Query: "select count(*) from people", Database: "dolt"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"columns":[{"name":"count(*)","type":"bigint"}],"rows":[[3]]}`, body)
+		status, body = post(t, "reader", "pass", httpAPIRequest{Query: "delete from people", Database: "dolt"})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, body, "command denied to user 'reader'@'%'")
+	})
+
+	t.Run("processlist and kill", func(t *testing.T) {
+		const sleepQuery = "select sleep(30)"
+		done := make(chan string)
+		go func() {
+			_, body := post(t, "reader", "pass", httpAPIRequest{Query: sleepQuery})
+			done <- body
+		}()
+
+		var connID int64
+		require.Eventually(t, func() bool {
+			status, body := post(t, "root", "", httpAPIRequest{
+				Query:  "select id, user from information_schema.processlist where info = ?",
+				Params: []interface{}{sleepQuery},
+			})
+			require.Equal(t, http.StatusOK, status, body)
+			var res struct {
+				Rows [][]interface{} `json:"rows"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(body), &res))
+			if len(res.Rows) != 1 {
+				return false
+			}
+			assert.Equal(t, "reader", res.Rows[0][1])
+			connID = int64(res.Rows[0][0].(float64))
+			return true
+		}, 10*time.Second, 50*time.Millisecond)
+
+		status, body := post(t, "root", "", httpAPIRequest{Query: fmt.Sprintf("kill query %d", connID)})
+		require.Equal(t, http.StatusOK, status, body)
+		select {
+		case body := <-done:
+			assert.Contains(t, body, "canceled")
+		case <-time.After(10 * time.Second):
+			t.Fatal("killed query did not end")
+		}
+	})
+
+	t.Run("cors", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodOptions, url, nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", "https://example.com")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "https://example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+
+		req.Header.Set("Origin", "https://other.example.com")
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestHTTPAPIMaxConnections(t *testing.T) {
+	ctx := context.Background()
+	env, err := sqle.CreateEnvWithSeedData()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, env.DoltDB(ctx).Close())
+	}()
+
+	serverConfig, err := servercfg.NewYamlConfig([]byte(`
+log_level: fatal
+
+listener:
+  host: localhost
+  port: 15323
+  max_connections: 1
+
+http_api:
+  host: localhost
+  port: 15324
+`))
+	require.NoError(t, err)
+
+	sc := svcs.NewController()
+	defer sc.Stop()
+	go func() {
+		_, _ = Serve(context.Background(), &Config{
+			Version:      "0.0.0",
+			ServerConfig: serverConfig,
+			Controller:   sc,
+			DoltEnv:      env,
+		})
+	}()
+	require.NoError(t, sc.WaitForStart())
+
+	post := func(query string) (int, string) {
+		body, err := json.Marshal(httpAPIRequest{Query: query})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:15324/query", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.SetBasicAuth("root", "")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, strings.TrimSpace(string(respBody))
+	}
+
+	done := make(chan int)
+	go func() {
+		status, _ := post("select sleep(2)")
+		done <- status
+	}()
+	require.Eventually(t, func() bool {
+		status, _ := post("select 1")
+		return status == http.StatusServiceUnavailable
+	}, 2*time.Second, 10*time.Millisecond)
+	status, body := post("select 1")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, `{"error":"too many connections"}`, body)
+
+	assert.Equal(t, http.StatusOK, <-done)
+	status, body = post("select 1")
+	assert.Equal(t, http.StatusOK, status, body)
+}
+
+func TestHTTPAPIRequireSecureTransport(t *testing.T) {
+	h := &httpAPIHandler{requireSecureTransport: true}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, httpAPIQueryPath, strings.NewReader(`{"query":"select 1"}`)))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "insecure transport")
+}
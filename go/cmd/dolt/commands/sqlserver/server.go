@@ -48,6 +48,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
@@ -283,6 +284,16 @@ func ConfigureServices(
 	}
 	controller.Register(InitAutoGCController)
 
+	InitCIController := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			if ciConfig := cfg.ServerConfig.CIConfig(); ciConfig != nil {
+				config.CIController = dolt_ci.NewCIController(ciConfig.User(), lgr)
+			}
+			return nil
+		},
+	}
+	controller.Register(InitCIController)
+
 	// mySQLServer is going to be populated down below once further services
 	// are initialized. However, we want to block Controller shutdown on all
 	// connections being fully drained from the Server. Stopping the
@@ -656,7 +667,7 @@ func ConfigureServices(
 			}
 			var err error
 			args.FS = sqlEngine.FileSystem()
-			args.DBCache, err = sqle.RemoteSrvDBCache(sqle.GetInterceptorSqlContext, sqle.DoNotCreateUnknownDatabases)
+			args.DBCache, err = sqle.RemoteSrvDBCache(sqle.GetInterceptorSqlContext, sqle.DoNotCreateUnknownDatabases, sqle.CheckBranchPermissions, sqle.UserBranchProtection)
 			if err != nil {
 				lgr.Errorf("error creating SQL engine context for remotesapi server: %v", err)
 				return err
@@ -695,6 +706,62 @@ func ConfigureServices(
 	}
 	controller.Register(RunRemoteSrv)
 
+	type HTTPAPIService struct {
+		state svcs.ServiceState
+		lis   net.Listener
+		srv   *http.Server
+	}
+	var httpAPISrv HTTPAPIService
+	RunHTTPAPIServer := &svcs.AnonService{
+		InitF: func(context.Context) (err error) {
+			httpAPIConfig := cfg.ServerConfig.HTTPAPIConfig()
+			if httpAPIConfig == nil {
+				return nil
+			}
+			// Queries are sent over the HTTP API with the credentials of an account, so it must be served over TLS
+			// whenever MySQL connections must be.
+			if cfg.ServerConfig.RequireSecureTransport() && serverConf.TLSConfig == nil {
+				return errors.New("the http api requires tls_key and tls_cert to be provided when require_secure_transport is enabled")
+			}
+			httpAPISrv.state.Swap(svcs.ServiceState_Init)
+
+			addr := net.JoinHostPort(httpAPIConfig.Host(), strconv.Itoa(httpAPIConfig.Port()))
+			httpAPISrv.lis, err = net.Listen("tcp", addr)
+			if err != nil {
+				lgr.Errorf("error starting http api listener on %s: %v", addr, err)
+				return err
+			}
+			if serverConf.TLSConfig != nil {
+				httpAPISrv.lis = tls.NewListener(httpAPISrv.lis, serverConf.TLSConfig)
+			}
+
+			// The MySQL server is created after the HTTP API, but before either starts serving.
+			killConnection := func(connID uint32) error {
+				return mySQLServer.SessionManager().KillConnection(connID)
+			}
+			httpAPISrv.srv = &http.Server{
+				Addr:    addr,
+				Handler: newHTTPAPIHandler(sqlEngine, cfg.ServerConfig, killConnection),
+			}
+			return nil
+		},
+		RunF: func(context.Context) {
+			if httpAPISrv.state.CompareAndSwap(svcs.ServiceState_Init, svcs.ServiceState_Run) {
+				_ = httpAPISrv.srv.Serve(httpAPISrv.lis)
+			}
+		},
+		StopF: func() error {
+			state := httpAPISrv.state.Swap(svcs.ServiceState_Stopped)
+			if state == svcs.ServiceState_Run {
+				httpAPISrv.srv.Close()
+			} else if state == svcs.ServiceState_Init {
+				httpAPISrv.lis.Close()
+			}
+			return nil
+		},
+	}
+	controller.Register(RunHTTPAPIServer)
+
 	var clusterRemoteSrv RemoteSrvService
 	RunClusterRemoteSrv := &svcs.AnonService{
 		InitF: func(context.Context) error {
@@ -750,6 +817,33 @@ func ConfigureServices(
 	}
 	controller.Register(RunClusterRemoteSrv)
 
+	// The slow query log times queries by wrapping the engine's process list, which
+	// the SQL server takes its own reference to, so it must be set up first.
+	var slowQueries *slowQueryLog
+	InitSlowQueryLog := &svcs.AnonService{
+		InitF: func(context.Context) (err error) {
+			slowQueryLogConfig := cfg.ServerConfig.SlowQueryLogConfig()
+			if slowQueryLogConfig == nil {
+				return nil
+			}
+			slowQueries, err = newSlowQueryLog(slowQueryLogConfig, lgr)
+			if err != nil {
+				return err
+			}
+			eng := sqlEngine.GetUnderlyingEngine()
+			eng.ProcessList = &slowQueryProcessList{ProcessList: eng.ProcessList, log: slowQueries}
+			eng.Analyzer.ExecBuilder = &slowQueryExecBuilder{NodeExecBuilder: eng.Analyzer.ExecBuilder, log: slowQueries}
+			return nil
+		},
+		StopF: func() error {
+			if slowQueries != nil {
+				return slowQueries.Close()
+			}
+			return nil
+		},
+	}
+	controller.Register(InitSlowQueryLog)
+
 	// We still have some startup to do from this point, and we do not run
 	// the SQL server until we are fully booted. We also want to stop the
 	// SQL server as the first thing we stop. However, if startup fails
@@ -1090,6 +1184,9 @@ func newSessionBuilder(se *engine.SqlEngine, config servercfg.ServerConfig) serv
 		if err != nil {
 			return nil, err
 		}
+		if config.SlowQueryLogConfig() != nil {
+			dsess.EnableRowsExaminedCounter()
+		}
 
 		varsForUser := userToSessionVars[conn.User]
 		if len(varsForUser) > 0 {
@@ -23,6 +23,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gocraft/dbr/v2"
 	"github.com/stretchr/testify/assert"
@@ -362,6 +363,8 @@ func TestServerSetDefaultBranch(t *testing.T) {
 	require.NoError(t, err)
 
 	const dbName = "dolt"
+	// The default branch is a global, so don't leave it pointing at a missing branch for later tests.
+	defer sql.SystemVariables.AssignValues(map[string]interface{}{dsess.DefaultBranchKey(dbName): ""})
 
 	defaultBranch := env.DefaultInitBranch
 
@@ -618,6 +621,22 @@ listener:
   # port: 8000
   # read_only: false
 
+# http_api:
+  # host: localhost
+  # port: 8080
+  # allowed_origins:
+  # - https://example.com
+
+# slow_query_log:
+  # long_query_time_millis: 10000
+  # file: slow_query.log
+  # format: text
+  # max_size_mb: 100
+  # max_backups: 5
+
+# ci:
+  # user: ci
+
 # privilege_file: ` + privilegeFilePath +
 		`
 
@@ -659,7 +678,11 @@ branch_control_file: dir1/dir2/abc.db
     # - https://standby_replica_two.svc.cluster.local
     # server_name_dns:
     # - standby_replica_one.svc.cluster.local
-    # - standby_replica_two.svc.cluster.local`
+    # - standby_replica_two.svc.cluster.local
+  # automatic_failover:
+    # enable: false
+    # heartbeat_interval_millis: 1000
+    # failover_timeout_millis: 5000`
 
 	ap := SqlServerCmd{}.ArgParser()
 
@@ -0,0 +1,382 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+// slowQueryLog records the queries run by sql-server which take longer than a threshold. Each one is written to a
+// rotating log file, if one is configured, and to the dolt_slow_queries system table of the database it ran against.
+//
+// Queries are timed by a sql.ProcessList wrapper, from when the handler begins them until their results have been
+// spooled and their transaction committed, and their plans are captured by a sql.NodeExecBuilder wrapper. Rows
+// examined are the rows read from tables by the query, as counted by the session. Index lookups done inside of joins
+// are not counted.
+type slowQueryLog struct {
+	longQueryTime time.Duration
+	format        servercfg.LogFormat
+	// out is nil if slow queries are only recorded in the dolt_slow_queries system table.
+	out *rotatingFile
+	lgr logrus.FieldLogger
+
+	mu sync.Mutex
+	// running holds the queries which have begun but not ended, keyed by the query's process id.
+	running map[uint64]*runningQuery
+}
+
+type runningQuery struct {
+	query     string
+	user      string
+	host      string
+	database  string
+	startedAt time.Time
+	// rowsExamined is the session's rows examined counter, and rowsExaminedAtStart its value when the query began.
+	rowsExamined        *atomic.Int64
+	rowsExaminedAtStart int64
+	// node is the analyzed plan of the query. It is nil until the query is built for execution.
+	node sql.Node
+}
+
+func newSlowQueryLog(config servercfg.SlowQueryLogConfig, lgr logrus.FieldLogger) (*slowQueryLog, error) {
+	l := &slowQueryLog{
+		longQueryTime: time.Duration(config.LongQueryTimeMillis()) * time.Millisecond,
+		format:        config.Format(),
+		lgr:           lgr,
+		running:       make(map[uint64]*runningQuery),
+	}
+	if config.File() != "" {
+		out, err := openRotatingFile(config.File(), int64(config.MaxSizeMB())*1024*1024, config.MaxBackups())
+		if err != nil {
+			return nil, err
+		}
+		l.out = out
+	}
+	return l, nil
+}
+
+func (l *slowQueryLog) Close() error {
+	if l.out == nil {
+		return nil
+	}
+	return l.out.Close()
+}
+
+func (l *slowQueryLog) begin(ctx *sql.Context, query string) {
+	q := &runningQuery{
+		query:     query,
+		user:      ctx.Session.Client().User,
+		host:      ctx.Session.Client().Address,
+		database:  ctx.GetCurrentDatabase(),
+		startedAt: time.Now(),
+	}
+	if sess, ok := ctx.Session.(*dsess.DoltSession); ok && sess.RowsExaminedCounter() != nil {
+		q.rowsExamined = sess.RowsExaminedCounter()
+		q.rowsExaminedAtStart = q.rowsExamined.Load()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.running[ctx.Pid()] = q
+}
+
+// planned records |node| as the plan of the running query of |ctx|, if it is the first node built for it. Nodes built
+// later for the same query, like the statements of a stored procedure, are ignored.
+func (l *slowQueryLog) planned(ctx *sql.Context, node sql.Node) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if q, ok := l.running[ctx.Pid()]; ok && q.node == nil {
+		q.node = node
+	}
+}
+
+func (l *slowQueryLog) end(ctx *sql.Context) {
+	l.mu.Lock()
+	q, ok := l.running[ctx.Pid()]
+	delete(l.running, ctx.Pid())
+	l.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	duration := time.Since(q.startedAt)
+	if duration < l.longQueryTime {
+		return
+	}
+
+	slowQuery := dtables.SlowQuery{
+		Query:         q.query,
+		User:          q.user,
+		Host:          q.host,
+		StartedAt:     q.startedAt,
+		Duration:      duration,
+		CommitOutcome: commitOutcome(ctx, q.node),
+	}
+	slowQuery.Database, slowQuery.Branch = dsess.SplitRevisionDbName(q.database)
+	if slowQuery.Branch == "" && slowQuery.Database != "" {
+		if sess, ok := ctx.Session.(*dsess.DoltSession); ok {
+			slowQuery.Branch, _, _ = sess.CurrentHead(ctx, slowQuery.Database)
+		}
+	}
+	if q.rowsExamined != nil {
+		slowQuery.RowsExamined = q.rowsExamined.Load() - q.rowsExaminedAtStart
+	}
+	if q.node != nil {
+		slowQuery.Plan = sql.Describe(q.node, sql.DescribeOptions{})
+		// The query's row iterator sets FOUND_ROWS() to the number of rows it returned before it ends the query.
+		if plan.GetQueryType(q.node) == plan.QueryTypeSelect {
+			slowQuery.RowsReturned = ctx.GetLastQueryInfoInt(sql.FoundRows)
+		}
+	}
+
+	if slowQuery.Database != "" {
+		dtables.RecordSlowQuery(slowQuery)
+	}
+	if l.out != nil {
+		if err := l.write(slowQuery); err != nil {
+			l.lgr.Warnf("error writing to slow query log: %v", err)
+		}
+	}
+}
+
+// commitOutcome returns the commit outcome of the query of |ctx| that has just ended, given the plan it was built
+// from. A query with no plan failed before it could run.
+func commitOutcome(ctx *sql.Context, node sql.Node) string {
+	if node == nil {
+		return dtables.SlowQueryFailed
+	}
+	// A transaction committed by the query is cleared from the session.
+	if ctx.GetTransaction() == nil {
+		return dtables.SlowQueryCommitted
+	}
+	autocommit, err := plan.IsSessionAutocommit(ctx)
+	if err == nil && autocommit && !ctx.GetIgnoreAutoCommit() {
+		return dtables.SlowQueryFailed
+	}
+	return dtables.SlowQueryUncommitted
+}
+
+type slowQueryJSONEntry struct {
+	Time          string  `json:"time"`
+	User          string  `json:"user"`
+	Host          string  `json:"host"`
+	Database      string  `json:"database,omitempty"`
+	Branch        string  `json:"branch,omitempty"`
+	QueryTime     float64 `json:"query_time"`
+	RowsExamined  int64   `json:"rows_examined"`
+	RowsReturned  int64   `json:"rows_returned"`
+	CommitOutcome string  `json:"commit_outcome"`
+	Plan          string  `json:"plan,omitempty"`
+	Query         string  `json:"query"`
+}
+
+func (l *slowQueryLog) write(q dtables.SlowQuery) error {
+	var entry []byte
+	if l.format == servercfg.LogFormat_JSON {
+		var err error
+		entry, err = json.Marshal(slowQueryJSONEntry{
+			Time:          q.StartedAt.UTC().Format(time.RFC3339Nano),
+			User:          q.User,
+			Host:          q.Host,
+			Database:      q.Database,
+			Branch:        q.Branch,
+			QueryTime:     q.Duration.Seconds(),
+			RowsExamined:  q.RowsExamined,
+			RowsReturned:  q.RowsReturned,
+			CommitOutcome: q.CommitOutcome,
+			Plan:          q.Plan,
+			Query:         q.Query,
+		})
+		if err != nil {
+			return err
+		}
+		entry = append(entry, '\n')
+	} else {
+		entry = []byte(formatSlowQuery(q))
+	}
+	_, err := l.out.Write(entry)
+	return err
+}
+
+// formatSlowQuery formats |q| for a text slow query log, in the layout of MySQL's slow query log with Dolt's
+// additional fields. The plan is written as comment lines above the query.
+func formatSlowQuery(q dtables.SlowQuery) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Time: %s\n", q.StartedAt.UTC().Format(time.RFC3339Nano))
+	fmt.Fprintf(&sb, "# User@Host: %s @ %s\n", q.User, q.Host)
+	fmt.Fprintf(&sb, "# Database: %s  Branch: %s\n", q.Database, q.Branch)
+	fmt.Fprintf(&sb, "# Query_time: %f  Rows_examined: %d  Rows_returned: %d  Commit_outcome: %s\n",
+		q.Duration.Seconds(), q.RowsExamined, q.RowsReturned, q.CommitOutcome)
+	if q.Plan != "" {
+		sb.WriteString("# Plan:\n")
+		for _, line := range strings.Split(strings.TrimRight(q.Plan, "\n"), "\n") {
+			sb.WriteString("#   ")
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString(strings.TrimRight(strings.TrimSpace(q.Query), ";"))
+	sb.WriteString(";\n")
+	return sb.String()
+}
+
+// slowQueryProcessList wraps the engine's process list to time the queries run by sql-server for a slowQueryLog.
+type slowQueryProcessList struct {
+	sql.ProcessList
+	log *slowQueryLog
+}
+
+var _ sql.ProcessList = (*slowQueryProcessList)(nil)
+
+func (pl *slowQueryProcessList) BeginQuery(ctx *sql.Context, query string) (*sql.Context, error) {
+	ctx, err := pl.ProcessList.BeginQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if !ctx.IsInterpreted() {
+		pl.log.begin(ctx, query)
+	}
+	return ctx, nil
+}
+
+// EndQuery is called both when a query's row iterator is closed and when the handler is done with the query, so
+// only the first call for a query is recorded.
+func (pl *slowQueryProcessList) EndQuery(ctx *sql.Context) {
+	if !ctx.IsInterpreted() {
+		pl.log.end(ctx)
+	}
+	pl.ProcessList.EndQuery(ctx)
+}
+
+// slowQueryExecBuilder wraps the engine's exec builder to capture the plans of the queries being timed by a
+// slowQueryLog.
+type slowQueryExecBuilder struct {
+	sql.NodeExecBuilder
+	log *slowQueryLog
+}
+
+var _ sql.NodeExecBuilder = (*slowQueryExecBuilder)(nil)
+
+func (b *slowQueryExecBuilder) Build(ctx *sql.Context, n sql.Node, r sql.Row) (sql.RowIter, error) {
+	iter, err := b.NodeExecBuilder.Build(ctx, n, r)
+	if err != nil {
+		return nil, err
+	}
+	b.log.planned(ctx, n)
+	return iter, nil
+}
+
+// rotatingFile is an append-only log file which is renamed to |path|.1 once it grows past a maximum size. Earlier
+// rotations are shifted to |path|.2 and so on, and the oldest is deleted once there are more than |maxBackups|.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate moves the current file aside and opens a new one. The file is reopened even if moving it fails, so that
+// later entries are still written.
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	err := r.shiftBackups()
+	if openErr := r.open(); openErr != nil {
+		return openErr
+	}
+	return err
+}
+
+func (r *rotatingFile) shiftBackups() error {
+	if r.maxBackups == 0 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.Remove(r.backupPath(r.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(r.backupPath(i), r.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(r.path, r.backupPath(1))
+}
+
+func (r *rotatingFile) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
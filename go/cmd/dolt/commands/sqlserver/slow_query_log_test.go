@@ -0,0 +1,190 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocraft/dbr/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/utils/svcs"
+)
+
+func TestSlowQueryLog(t *testing.T) {
+	ctx := context.Background()
+	env, err := sqle.CreateEnvWithSeedData()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, env.DoltDB(ctx).Close())
+	}()
+
+	logFile := filepath.Join(t.TempDir(), "slow_query.log")
+	serverConfig, err := servercfg.NewYamlConfig([]byte(`
+log_level: fatal
+
+listener:
+  host: localhost
+  port: 15322
+
+slow_query_log:
+  long_query_time_millis: 0
+  file: ` + logFile + `
+  format: json
+`))
+	require.NoError(t, err)
+
+	sc := svcs.NewController()
+	defer sc.Stop()
+	go func() {
+		_, _ = Serve(context.Background(), &Config{
+			Version:      "0.0.0",
+			ServerConfig: serverConfig,
+			Controller:   sc,
+			DoltEnv:      env,
+		})
+	}()
+	require.NoError(t, sc.WaitForStart())
+
+	conn, err := dbr.Open("mysql", servercfg.ConnectionString(serverConfig, "dolt"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	const selectQuery = "select name from people where age > 24"
+	rows, err := conn.Query(selectQuery)
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	require.NoError(t, rows.Close())
+
+	const failedQuery = "select * from not_a_table"
+	_, err = conn.Exec(failedQuery)
+	require.Error(t, err)
+
+	const insertQuery = "insert into people (id, name, age) values ('00000000-0000-0000-0000-000000000003', 'Jill Jillson', 40)"
+	tx, err := conn.Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec(insertQuery)
+	require.NoError(t, err)
+
+	type slowQueryRow struct {
+		Branch        string
+		RowsExamined  int64
+		RowsReturned  int64
+		CommitOutcome string
+		Plan          *string
+	}
+	getSlowQuery := func(t *testing.T, query string) slowQueryRow {
+		var r slowQueryRow
+		err := conn.QueryRow("select branch, rows_examined, rows_returned, commit_outcome, query_plan from dolt_slow_queries where query = ?", query).
+			Scan(&r.Branch, &r.RowsExamined, &r.RowsReturned, &r.CommitOutcome, &r.Plan)
+		require.NoError(t, err)
+		return r
+	}
+
+	r := getSlowQuery(t, selectQuery)
+	assert.Equal(t, "main", r.Branch)
+	assert.Equal(t, int64(3), r.RowsExamined)
+	assert.Equal(t, int64(2), r.RowsReturned)
+	assert.Equal(t, dtables.SlowQueryCommitted, r.CommitOutcome)
+	require.NotNil(t, r.Plan)
+	assert.Contains(t, *r.Plan, "people")
+
+	r = getSlowQuery(t, failedQuery)
+	assert.Equal(t, dtables.SlowQueryFailed, r.CommitOutcome)
+	assert.Nil(t, r.Plan)
+
+	r = getSlowQuery(t, insertQuery)
+	assert.Equal(t, dtables.SlowQueryUncommitted, r.CommitOutcome)
+	assert.Equal(t, int64(0), r.RowsReturned)
+	require.NoError(t, tx.Commit())
+
+	contents, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+		var entry slowQueryJSONEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry.Query == selectQuery {
+			found = true
+			assert.Equal(t, "root", entry.User)
+			assert.Equal(t, "dolt", entry.Database)
+			assert.Equal(t, "main", entry.Branch)
+			assert.Equal(t, int64(3), entry.RowsExamined)
+			assert.Equal(t, int64(2), entry.RowsReturned)
+			assert.Equal(t, dtables.SlowQueryCommitted, entry.CommitOutcome)
+			assert.NotEmpty(t, entry.Plan)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slow_query.log")
+	f, err := openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	for _, entry := range []string{"entry 1\n", "entry 2\n", "entry 3\n", "entry 4\n"} {
+		_, err = f.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	for file, expected := range map[string]string{
+		path:        "entry 4\n",
+		path + ".1": "entry 3\n",
+		path + ".2": "entry 2\n",
+	} {
+		contents, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(contents))
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFormatSlowQuery(t *testing.T) {
+	q := dtables.SlowQuery{
+		Query:         "select * from t;",
+		User:          "root",
+		Host:          "127.0.0.1:50000",
+		Database:      "mydb",
+		Branch:        "feature",
+		CommitOutcome: dtables.SlowQueryCommitted,
+		RowsExamined:  10,
+		RowsReturned:  1,
+		Plan:          "Project\n └─ Table\n",
+	}
+	q.StartedAt, _ = time.Parse(time.RFC3339, "2025-01-02T03:04:05Z")
+	q.Duration = 1500 * time.Millisecond
+	assert.Equal(t, `# Time: 2025-01-02T03:04:05Z
+# User@Host: root @ 127.0.0.1:50000
+# Database: mydb  Branch: feature
+# Query_time: 1.500000  Rows_examined: 10  Rows_returned: 1  Commit_outcome: committed
+# Plan:
+#   Project
+#    └─ Table
+select * from t;
+`, formatSlowQuery(q))
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/streammux"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+var transferServerDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Serves a database to a single client over stdin and stdout.",
+	LongDesc: `Serves the dolt database in {{.LessThan}}path{{.GreaterThan}} to a single client, which speaks the remotesapi protocol over streams multiplexed on the command's stdin and stdout. The command exits when the client closes its stdin.
+
+This command is run on the host of {{.EmphasisLeft}}ssh://{{.EmphasisRight}} remotes by clients which push to, pull from or clone them, and is not meant to be run directly.`,
+	Synopsis: []string{
+		"[--read-only] {{.LessThan}}path{{.GreaterThan}}",
+	},
+}
+
+const transferServerReadOnlyFlag = "read-only"
+
+type TransferServerCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd TransferServerCmd) Name() string {
+	return "transfer-server"
+}
+
+// Description returns a description of the command
+func (cmd TransferServerCmd) Description() string {
+	return transferServerDocs.ShortDesc
+}
+
+// Hidden should return true if this command should be hidden from the help text
+func (cmd TransferServerCmd) Hidden() bool {
+	return true
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd TransferServerCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd TransferServerCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(transferServerDocs, ap)
+}
+
+func (cmd TransferServerCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"path", "The directory of the dolt database to serve."})
+	ap.SupportsFlag(transferServerReadOnlyFlag, "", "Reject pushes to the database.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd TransferServerCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, transferServerDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	// stdout carries the protocol, so anything else this process prints must go to stderr. os.Stdout is redirected
+	// while commands run, so the real stdout is taken from the restored stdio.
+	stdout := os.Stdout
+	if cli.ExecuteWithStdioRestored != nil {
+		cli.ExecuteWithStdioRestored(func() {
+			stdout = os.Stdout
+		})
+	}
+	cli.CliOut = cli.CliErr
+
+	fs, err := dEnv.FS.WithWorkingDir(apr.Arg(0))
+	if err != nil {
+		cli.PrintErrln(err)
+		return 1
+	}
+	srvEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, doltdb.LocalDirDoltDB, dEnv.Version)
+	if srvEnv.DBLoadError != nil {
+		cli.PrintErrln(fmt.Sprintf("failed to load dolt database at %s: %v", apr.Arg(0), srvEnv.DBLoadError))
+		return 1
+	} else if !srvEnv.Valid() {
+		cli.PrintErrln(fmt.Sprintf("%s is not a dolt database", apr.Arg(0)))
+		return 1
+	}
+
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(srvEnv.DoltDB(ctx)))
+	store, ok := cs.(remotesrv.RemoteSrvStore)
+	if !ok {
+		cli.PrintErrln(fmt.Sprintf("the database at %s cannot be served", apr.Arg(0)))
+		return 1
+	}
+
+	lgr := logrus.New()
+	lgr.SetOutput(cli.CliErr)
+	lgr.SetLevel(logrus.ErrorLevel)
+	srv, err := remotesrv.NewServer(remotesrv.ServerArgs{
+		Logger:             logrus.NewEntry(lgr),
+		HttpHost:           cmd.Name(),
+		FS:                 fs,
+		DBCache:            singletonDBCache{store},
+		ReadOnly:           apr.Contains(transferServerReadOnlyFlag),
+		ConcurrencyControl: remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_ASSERT_WORKING_SET,
+	})
+	if err != nil {
+		cli.PrintErrln(err)
+		return 1
+	}
+
+	// the client multiplexes its gRPC and HTTP connections over stdin and stdout
+	session := streammux.Server(stdioConn{Reader: os.Stdin, WriteCloser: stdout})
+	srv.ServeHTTP2Conns(ctx, session)
+	session.Close()
+	return 0
+}
+
+type stdioConn struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// singletonDBCache serves the same store for every repo path, since a transfer-server serves a single database.
+type singletonDBCache struct {
+	store remotesrv.RemoteSrvStore
+}
+
+func (c singletonDBCache) Get(ctx context.Context, path, nbfVerStr string) (remotesrv.RemoteSrvStore, error) {
+	return c.store, nil
+}
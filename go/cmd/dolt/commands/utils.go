@@ -283,7 +283,7 @@ func newLateBindingEngine(
 
 			authResponse := buildAuthResponse(salt, config.ServerPass)
 
-			err := passwordValidate(rawDb, salt, dbUser, authResponse)
+			err := passwordValidate(rawDb, salt, dbUser, authResponse, localhostAddr())
 			if err != nil {
 				se.Close()
 				return nil, nil, nil, err
@@ -460,12 +460,9 @@ func getTimestampColAsUint64(col interface{}) (uint64, error) {
 	}
 }
 
-// passwordValidate validates the password for the given user. This is a helper function around ValidateHash. Returns
-// nil if the user is authenticated, an error otherwise.
-func passwordValidate(rawDb *mysql_db.MySQLDb, salt []byte, user string, authResponse []byte) error {
-	// The port is meaningless here. It's going to be stripped in the ValidateHash function
-	addr, _ := net.ResolveTCPAddr("tcp", "localhost:3306")
-
+// passwordValidate validates the password for the given user, connecting from |addr|. This is a helper function around
+// ValidateHash. Returns nil if the user is authenticated, an error otherwise.
+func passwordValidate(rawDb *mysql_db.MySQLDb, salt []byte, user string, authResponse []byte, addr net.Addr) error {
 	authenticated, err := rawDb.ValidateHash(salt, user, authResponse, addr)
 	if err != nil {
 		return err
@@ -518,7 +515,25 @@ func ValidatePasswordWithAuthResponse(rawDb *mysql_db.MySQLDb, user, password st
 	}
 
 	authResponse := buildAuthResponse(salt, password)
-	return passwordValidate(rawDb, salt, user, authResponse)
+	return passwordValidate(rawDb, salt, user, authResponse, localhostAddr())
+}
+
+// ValidatePasswordFromAddr is like ValidatePasswordWithAuthResponse, but validates the password of the account which
+// matches a client connecting from |addr|, rather than from localhost.
+func ValidatePasswordFromAddr(rawDb *mysql_db.MySQLDb, user, password string, addr net.Addr) error {
+	salt, err := mysql.NewSalt()
+	if err != nil {
+		return err
+	}
+
+	authResponse := buildAuthResponse(salt, password)
+	return passwordValidate(rawDb, salt, user, authResponse, addr)
+}
+
+func localhostAddr() net.Addr {
+	// The port is meaningless here. It's going to be stripped in the ValidateHash function
+	addr, _ := net.ResolveTCPAddr("tcp", "localhost:3306")
+	return addr
 }
 
 // GetDoltStatus retrieves the status of the current working set of changes in the working set, and returns two
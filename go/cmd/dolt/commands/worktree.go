@@ -0,0 +1,142 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var worktreeDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Manage multiple working trees",
+	LongDesc: `Manage multiple working trees attached to the same repository. With no arguments, or with {{.EmphasisLeft}}list{{.EmphasisRight}}, shows the worktrees of the repository and the branch checked out in each.
+
+A worktree is a directory with its own HEAD and working set which shares its storage with the other worktrees of the repository, so several branches can be worked on at the same time without cloning the database. A branch can be checked out in only one worktree at a time.
+
+{{.EmphasisLeft}}add{{.EmphasisRight}}
+Creates a worktree at {{.LessThan}}path{{.GreaterThan}} with the existing branch {{.LessThan}}branch{{.GreaterThan}} checked out. The directory is created if it does not exist. The new worktree starts with a copy of the remotes of the main worktree.
+
+{{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
+Removes the worktree at {{.LessThan}}path{{.GreaterThan}}. Only its .dolt directory is deleted, and the directory itself if it is then empty. Uncommitted changes in the worktree remain in the working set of its branch, and can be seen by checking the branch out elsewhere.
+
+The storage of a repository is locked by the process using it, so, like running two {{.EmphasisLeft}}dolt{{.EmphasisRight}} commands in one directory at the same time, changes cannot be written from two worktrees at the same time. Run a sql-server to serve concurrent writers.`,
+
+	Synopsis: []string{
+		"[list]",
+		"add {{.LessThan}}path{{.GreaterThan}} {{.LessThan}}branch{{.GreaterThan}}",
+		"remove {{.LessThan}}path{{.GreaterThan}}",
+	},
+}
+
+const (
+	addWorktreeId         = "add"
+	listWorktreeId        = "list"
+	removeWorktreeId      = "remove"
+	removeWorktreeShortId = "rm"
+)
+
+type WorktreeCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd WorktreeCmd) Name() string {
+	return "worktree"
+}
+
+// Description returns a description of the command
+func (cmd WorktreeCmd) Description() string {
+	return worktreeDocs.ShortDesc
+}
+
+func (cmd WorktreeCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(worktreeDocs, ap)
+}
+
+func (cmd WorktreeCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 3)
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd WorktreeCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd WorktreeCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, worktreeDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	var verr errhand.VerboseError
+	switch {
+	case apr.NArg() == 0, apr.NArg() == 1 && apr.Arg(0) == listWorktreeId:
+		verr = printWorktrees(dEnv)
+	case apr.Arg(0) == addWorktreeId:
+		if apr.NArg() != 3 {
+			verr = errhand.BuildDError("").SetPrintUsage().Build()
+			break
+		}
+		verr = addWorktree(ctx, dEnv, apr.Arg(1), apr.Arg(2))
+	case apr.Arg(0) == removeWorktreeId, apr.Arg(0) == removeWorktreeShortId:
+		if apr.NArg() != 2 {
+			verr = errhand.BuildDError("").SetPrintUsage().Build()
+			break
+		}
+		verr = removeWorktree(dEnv, apr.Arg(1))
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func printWorktrees(dEnv *env.DoltEnv) errhand.VerboseError {
+	worktrees, err := dEnv.Worktrees()
+	if err != nil {
+		return errhand.BuildDError("error: failed to read worktrees").AddCause(err).Build()
+	}
+	for _, wt := range worktrees {
+		if wt.Head == nil {
+			cli.Printf("%s  (missing)\n", wt.Path)
+		} else {
+			cli.Printf("%s  [%s]\n", wt.Path, wt.Head.GetPath())
+		}
+	}
+	return nil
+}
+
+func addWorktree(ctx context.Context, dEnv *env.DoltEnv, path, branch string) errhand.VerboseError {
+	err := dEnv.AddWorktree(ctx, path, branch)
+	if err != nil {
+		return errhand.BuildDError("fatal: could not add worktree at '%s'", path).AddCause(err).Build()
+	}
+	cli.Println(fmt.Sprintf("Added worktree at '%s' with branch '%s' checked out", path, branch))
+	return nil
+}
+
+func removeWorktree(dEnv *env.DoltEnv, path string) errhand.VerboseError {
+	err := dEnv.RemoveWorktree(path)
+	if err != nil {
+		return errhand.BuildDError("fatal: could not remove worktree at '%s'", path).AddCause(err).Build()
+	}
+	return nil
+}
@@ -42,6 +42,7 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/bundlecmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/ci"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/credcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/cvcmds"
@@ -93,6 +94,10 @@ var commandsWithoutCliCtx = []cli.Command{
 	commands.ProfileCmd{},
 	commands.ArchiveCmd{},
 	commands.FsckCmd{},
+	commands.RotateKeyCmd{},
+	commands.TransferServerCmd{},
+	bundlecmds.Commands,
+	commands.BackfillCmd{},
 	commands.ConfigCmd{},
 }
 
@@ -664,11 +669,17 @@ If you're interested in running this command against a remote host, hit us up on
 	if noValidRepository && isValidRepositoryRequired {
 		return func(ctx context.Context) (cli.Queryist, *sql.Context, func(), error) {
 			err := errors.New("The current directory is not a valid dolt repository.")
+			if rootEnv.HasDoltDataDir() {
+				// load the database to report why it is not valid
+				rootEnv.DoltDB(ctx)
+			}
 			if errors.Is(rootEnv.DBLoadError, nbs.ErrUnsupportedTableFileFormat) {
 				// This is fairly targeted and specific to allow for better error messaging. We should consider
 				// breaking this out into its own function if we add more conditions.
 
 				err = errors.New("The data in this database is in an unsupported format. Please upgrade to the latest version of Dolt.")
+			} else if errors.Is(rootEnv.DBLoadError, nbs.ErrNoEncryptionKey) || errors.Is(rootEnv.DBLoadError, nbs.ErrDecryptionFailed) {
+				err = rootEnv.DBLoadError
 			}
 
 			return nil, nil, nil, err
@@ -18,6 +18,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/admin"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/bundlecmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/ci"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/cnfcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/credcmds"
@@ -51,6 +52,8 @@ var doltSubCommands = []cli.Command{
 	commands.MergeCmd{},
 	cnfcmds.Commands,
 	commands.CherryPickCmd{},
+	commands.FormatPatchCmd{},
+	commands.AmCmd{},
 	commands.RevertCmd{},
 	commands.CloneCmd{},
 	commands.FetchCmd{},
@@ -72,6 +75,7 @@ var doltSubCommands = []cli.Command{
 	indexcmds.Commands,
 	commands.ReadTablesCmd{},
 	commands.GarbageCollectionCmd{},
+	commands.RotateKeyCmd{},
 	commands.FsckCmd{},
 	commands.FilterBranchCmd{},
 	commands.MergeBaseCmd{},
@@ -88,7 +92,13 @@ var doltSubCommands = []cli.Command{
 	commands.QueryDiff{},
 	commands.ReflogCmd{},
 	commands.RebaseCmd{},
+	commands.BisectCmd{},
+	commands.WorktreeCmd{},
+	commands.NotesCmd{},
 	commands.ArchiveCmd{},
+	commands.TransferServerCmd{},
+	bundlecmds.Commands,
+	commands.BackfillCmd{},
 	ci.Commands,
 	commands.DebugCmd{},
 }
@@ -46,6 +46,13 @@ type ReplicationServiceClient interface {
 	UpdateUsersAndGrants(ctx context.Context, in *UpdateUsersAndGrantsRequest, opts ...grpc.CallOption) (*UpdateUsersAndGrantsResponse, error)
 	UpdateBranchControl(ctx context.Context, in *UpdateBranchControlRequest, opts ...grpc.CallOption) (*UpdateBranchControlResponse, error)
 	DropDatabase(ctx context.Context, in *DropDatabaseRequest, opts ...grpc.CallOption) (*DropDatabaseResponse, error)
+	// When automatic failover is enabled, a primary calls this method on each
+	// of its standbys periodically. A standby which stops receiving heartbeats
+	// from its primary will try to elect a new primary.
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	// Called by a standby on the other servers in the cluster when it is
+	// electing a new primary. Answered regardless of the role of the server.
+	GetFailoverStatus(ctx context.Context, in *GetFailoverStatusRequest, opts ...grpc.CallOption) (*GetFailoverStatusResponse, error)
 }
 
 type replicationServiceClient struct {
@@ -83,6 +90,24 @@ func (c *replicationServiceClient) DropDatabase(ctx context.Context, in *DropDat
 	return out, nil
 }
 
+func (c *replicationServiceClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, "/dolt.services.replicationapi.v1alpha1.ReplicationService/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicationServiceClient) GetFailoverStatus(ctx context.Context, in *GetFailoverStatusRequest, opts ...grpc.CallOption) (*GetFailoverStatusResponse, error) {
+	out := new(GetFailoverStatusResponse)
+	err := c.cc.Invoke(ctx, "/dolt.services.replicationapi.v1alpha1.ReplicationService/GetFailoverStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReplicationServiceServer is the server API for ReplicationService service.
 // All implementations must embed UnimplementedReplicationServiceServer
 // for forward compatibility
@@ -96,6 +121,13 @@ type ReplicationServiceServer interface {
 	UpdateUsersAndGrants(context.Context, *UpdateUsersAndGrantsRequest) (*UpdateUsersAndGrantsResponse, error)
 	UpdateBranchControl(context.Context, *UpdateBranchControlRequest) (*UpdateBranchControlResponse, error)
 	DropDatabase(context.Context, *DropDatabaseRequest) (*DropDatabaseResponse, error)
+	// When automatic failover is enabled, a primary calls this method on each
+	// of its standbys periodically. A standby which stops receiving heartbeats
+	// from its primary will try to elect a new primary.
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	// Called by a standby on the other servers in the cluster when it is
+	// electing a new primary. Answered regardless of the role of the server.
+	GetFailoverStatus(context.Context, *GetFailoverStatusRequest) (*GetFailoverStatusResponse, error)
 	mustEmbedUnimplementedReplicationServiceServer()
 }
 
@@ -112,6 +144,12 @@ func (UnimplementedReplicationServiceServer) UpdateBranchControl(context.Context
 func (UnimplementedReplicationServiceServer) DropDatabase(context.Context, *DropDatabaseRequest) (*DropDatabaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DropDatabase not implemented")
 }
+func (UnimplementedReplicationServiceServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedReplicationServiceServer) GetFailoverStatus(context.Context, *GetFailoverStatusRequest) (*GetFailoverStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFailoverStatus not implemented")
+}
 func (UnimplementedReplicationServiceServer) mustEmbedUnimplementedReplicationServiceServer() {}
 
 // UnsafeReplicationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -179,6 +217,42 @@ func _ReplicationService_DropDatabase_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReplicationService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicationServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dolt.services.replicationapi.v1alpha1.ReplicationService/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicationServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReplicationService_GetFailoverStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFailoverStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicationServiceServer).GetFailoverStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dolt.services.replicationapi.v1alpha1.ReplicationService/GetFailoverStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicationServiceServer).GetFailoverStatus(ctx, req.(*GetFailoverStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReplicationService_ServiceDesc is the grpc.ServiceDesc for ReplicationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -198,6 +272,14 @@ var ReplicationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DropDatabase",
 			Handler:    _ReplicationService_DropDatabase_Handler,
 		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _ReplicationService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "GetFailoverStatus",
+			Handler:    _ReplicationService_GetFailoverStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dolt/services/replicationapi/v1alpha1/replication.proto",
@@ -0,0 +1,143 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bisect
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrBadIsAncestorOfGood is returned when the bad commit is reachable from one of the good commits, so there is no
+// commit which could have changed the result from good to bad.
+var ErrBadIsAncestorOfGood = errors.New("the bad commit is an ancestor of a good commit; the good and bad commits may have been swapped")
+
+// Result is the outcome of a bisection step. Exactly one of FirstBad, Next or Candidates is set.
+type Result struct {
+	// FirstBad is the first bad commit, once it has been found.
+	FirstBad *doltdb.Commit
+	// Next is the commit which should be tested next.
+	Next *doltdb.Commit
+	// Remaining is the number of commits which may still need to be tested after Next.
+	Remaining int
+	// Steps is roughly the number of steps needed to find the first bad commit after Next has been tested.
+	Steps int
+	// Candidates is set when only skipped commits are left to test, and contains every commit which could be the
+	// first bad commit.
+	Candidates []*doltdb.Commit
+}
+
+// Bisect finds the commit to test next in order to find the first bad commit, given a |bad| commit, the |good|
+// commits and the commits to |skip|. The candidates are the commits reachable from |bad| but not from any of
+// |good|. The commit chosen is the one which most evenly splits the candidates into those reachable from it and
+// those that are not, so that either result of testing it rules out as many candidates as possible.
+func Bisect(ctx context.Context, ddb *doltdb.DoltDB, bad hash.Hash, good []hash.Hash, skip []hash.Hash) (*Result, error) {
+	itr, err := commitwalk.GetDotDotRevisionsIterator[context.Context](ctx, ddb, []hash.Hash{bad}, ddb, good, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []hash.Hash
+	commits := make(map[hash.Hash]*doltdb.Commit)
+	parents := make(map[hash.Hash][]hash.Hash)
+	for {
+		h, optCmt, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		ph, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, h)
+		commits[h] = cm
+		parents[h] = ph
+	}
+
+	if _, ok := commits[bad]; !ok {
+		return nil, ErrBadIsAncestorOfGood
+	}
+	if len(candidates) == 1 {
+		return &Result{FirstBad: commits[bad]}, nil
+	}
+
+	skipped := make(map[hash.Hash]bool, len(skip))
+	for _, h := range skip {
+		skipped[h] = true
+	}
+
+	total := len(candidates)
+	bestWeight := -1
+	var best hash.Hash
+	var bestAncestors int
+	for _, h := range candidates {
+		if h == bad || skipped[h] {
+			continue
+		}
+		ancestors := countAncestors(h, parents)
+		weight := min(ancestors, total-ancestors)
+		if weight > bestWeight {
+			bestWeight = weight
+			best = h
+			bestAncestors = ancestors
+		}
+	}
+
+	if bestWeight < 0 {
+		res := &Result{}
+		for _, h := range candidates {
+			res.Candidates = append(res.Candidates, commits[h])
+		}
+		return res, nil
+	}
+
+	// If the commit is bad, its own ancestors remain. Otherwise, the rest of the candidates remain. Either way, the
+	// bad commit itself does not need to be tested.
+	remaining := max(bestAncestors, total-bestAncestors) - 1
+	return &Result{
+		Next:      commits[best],
+		Remaining: remaining,
+		Steps:     bits.Len(uint(remaining)),
+	}, nil
+}
+
+// countAncestors returns the number of candidates reachable from |h|, including |h| itself. Only candidates have an
+// entry in |parents|, so the walk stops at the boundary of the candidate set.
+func countAncestors(h hash.Hash, parents map[hash.Hash][]hash.Hash) int {
+	seen := map[hash.Hash]bool{h: true}
+	pending := []hash.Hash{h}
+	for len(pending) > 0 {
+		cur := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for _, p := range parents[cur] {
+			if _, ok := parents[p]; ok && !seen[p] {
+				seen[p] = true
+				pending = append(pending, p)
+			}
+		}
+	}
+	return len(seen)
+}
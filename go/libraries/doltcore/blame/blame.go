@@ -0,0 +1,699 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package blame attributes the rows, or the individual cells, of a table to the commits that last changed them.
+//
+// Blame walks the commit graph backwards from a starting commit, newest commits first. Every commit holds the set of
+// rows that still need an origin. For each of these rows the commit's parents are checked: if a parent has the same
+// row, the row is passed on to that parent, otherwise the commit is the row's origin. Rows and columns are matched
+// between commits by column tag rather than by name, so blame follows renamed tables and columns, and rows are found
+// again after the primary key of a table changed, as long as the columns of the old key still exist.
+//
+// To bound the memory blame needs, the rows of the table are blamed in batches of up to batchSize rows, in primary key
+// order, each with a walk of its own. A walk ends as soon as all of the rows of its batch have an origin.
+package blame
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ErrKeylessTable is returned when blaming a table without a primary key, whose rows cannot be told apart.
+var ErrKeylessTable = errors.New("cannot blame a table without a primary key")
+
+// diffThreshold is the number of rows a commit must still attribute before changes to a table with an unchanged
+// schema are found by diffing it with its parent, instead of looking up each row in the parent.
+const diffThreshold = 1024
+
+// batchSize is the number of rows of the blamed table attributed by each walk of the commit graph.
+var batchSize = 64 * 1024
+
+// Options control how a table is blamed.
+type Options struct {
+	// Since is an optional boundary commit. History before it is not searched, and rows that did not change after it
+	// are attributed to it.
+	Since *doltdb.Commit
+	// Cells attributes each non primary key cell to the commit that last changed it, instead of attributing whole rows.
+	Cells bool
+}
+
+// Origin is the commit a row or cell is attributed to.
+type Origin struct {
+	Hash hash.Hash
+	Meta *datas.CommitMeta
+}
+
+// Row is the blame for one row of the blamed table.
+type Row struct {
+	// Key is the primary key of the row in the blamed commit.
+	Key val.Tuple
+	// Origin is the commit that last changed the row. It is nil when blaming cells.
+	Origin *Origin
+	// Cells are the commits that last changed each of the Iter's Columns. They are only set when blaming cells.
+	Cells []*Origin
+}
+
+// Iter is the blame for a table. It returns one Row per row of the table, in primary key order.
+type Iter struct {
+	Name    doltdb.TableName
+	Schema  schema.Schema
+	KeyDesc val.TupleDesc
+	NS      tree.NodeStore
+	// Columns are the columns blamed per cell. They are the stored, non primary key columns of the table.
+	Columns []schema.Column
+
+	ddb     *doltdb.DoltDB
+	cm      *doltdb.Commit
+	opts    Options
+	tbl     *tableAt
+	rows    prolly.MapIter
+	origins map[hash.Hash]*Origin
+	batch   []Row
+}
+
+// Table returns the blame for the rows of the table |name| as of commit |cm|. Rows are blamed as they are read from
+// the returned Iter.
+func Table(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, name doltdb.TableName, opts Options) (*Iter, error) {
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tbl, ok, err := loadTable(ctx, root, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
+	if schema.IsKeyless(tbl.sch) {
+		return nil, ErrKeylessTable
+	}
+
+	rows, err := tbl.rows.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	it := &Iter{
+		Name:    tbl.name,
+		Schema:  tbl.sch,
+		KeyDesc: tbl.kd,
+		NS:      tbl.rows.NodeStore(),
+		ddb:     ddb,
+		cm:      cm,
+		opts:    opts,
+		tbl:     tbl,
+		rows:    rows,
+		origins: make(map[hash.Hash]*Origin),
+	}
+	if opts.Cells {
+		it.Columns = storedColumns(tbl.sch.GetNonPKCols())
+	}
+	return it, nil
+}
+
+// Next returns the blame for the next row of the table, or io.EOF once all rows have been returned.
+func (it *Iter) Next(ctx context.Context) (Row, error) {
+	if len(it.batch) == 0 {
+		if err := it.nextBatch(ctx); err != nil {
+			return Row{}, err
+		}
+	}
+	row := it.batch[0]
+	it.batch = it.batch[1:]
+	return row, nil
+}
+
+// nextBatch blames the next batch of rows of the table with a walk of the commit graph. Returns io.EOF if all rows
+// have been blamed.
+func (it *Iter) nextBatch(ctx context.Context) error {
+	b := &blamer{
+		ddb:     it.ddb,
+		opts:    it.opts,
+		nodes:   make(map[hash.Hash]*node),
+		origins: it.origins,
+		columns: it.Columns,
+	}
+	start, err := b.addCommit(ctx, it.cm)
+	if err != nil {
+		return err
+	}
+	start.tbl = it.tbl
+	start.pending = make(map[string]*pendingRow)
+
+	for len(b.rows) < batchSize {
+		k, _, err := it.rows.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		e := entry{row: len(b.rows)}
+		row := Row{Key: k}
+		if it.opts.Cells {
+			row.Cells = make([]*Origin, len(it.Columns))
+			e.tags = make([]uint64, len(it.Columns))
+			for i, col := range it.Columns {
+				e.tags[i] = col.Tag
+			}
+		}
+		b.rows = append(b.rows, row)
+		start.pending[string(k)] = &pendingRow{key: k, entries: []entry{e}}
+	}
+	if len(b.rows) == 0 {
+		return io.EOF
+	}
+	b.numPending++
+
+	if it.opts.Since != nil {
+		since, err := b.addCommit(ctx, it.opts.Since)
+		if err != nil {
+			return err
+		}
+		since.boundary = true
+	}
+
+	for b.numPending > 0 && b.queue.Len() > 0 {
+		n := heap.Pop(&b.queue).(*node)
+		if len(n.pending) > 0 {
+			b.numPending--
+		}
+		if err := b.process(ctx, n); err != nil {
+			return err
+		}
+	}
+
+	it.batch = b.rows
+	return nil
+}
+
+// tableAt is a table as of one commit.
+type tableAt struct {
+	name   doltdb.TableName
+	sch    schema.Schema
+	rows   prolly.Map
+	kd, vd val.TupleDesc
+}
+
+func loadTable(ctx context.Context, root doltdb.RootValue, name doltdb.TableName) (*tableAt, bool, error) {
+	tbl, correctedName, ok, err := doltdb.GetTableInsensitive(ctx, root, name)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	name.Name = correctedName
+	return newTableAt(ctx, name, tbl)
+}
+
+func newTableAt(ctx context.Context, name doltdb.TableName, tbl *doltdb.Table) (*tableAt, bool, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	rows, err := durable.ProllyMapFromIndex(idx)
+	if err != nil {
+		return nil, false, err
+	}
+	kd, vd := rows.Descriptors()
+	return &tableAt{name: name, sch: sch, rows: rows, kd: kd, vd: vd}, true, nil
+}
+
+// findTable finds the table |t| in an older |root|. The table is matched by the tags of its primary key columns, so
+// that it is found even if it had another name in |root|. Rows of a table that had no primary key in |root| can't be
+// matched, so such a table is not returned.
+func findTable(ctx context.Context, root doltdb.RootValue, t *tableAt) (*tableAt, bool, error) {
+	tbl, ok, err := root.GetTable(ctx, t.name)
+	if err != nil {
+		return nil, false, err
+	}
+	name := t.name
+	if ok {
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		ok = sharesKeyTag(t.sch, sch)
+	}
+
+	for _, tag := range t.sch.GetPKCols().Tags {
+		if ok {
+			break
+		}
+		tbl, name, ok, err = doltdb.GetTableByColTag(ctx, root, tag)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	prev, _, err := newTableAt(ctx, name, tbl)
+	if err != nil || schema.IsKeyless(prev.sch) {
+		return nil, false, err
+	}
+	return prev, true, nil
+}
+
+func sharesKeyTag(sch, prev schema.Schema) bool {
+	for _, tag := range sch.GetPKCols().Tags {
+		if _, ok := prev.GetAllCols().GetByTag(tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// field returns the encoding and the stored bytes of the column with |tag| in the row |k|, |v|. NULL values and
+// columns the table does not have return nil bytes.
+func (t *tableAt) field(tag uint64, k, v val.Tuple) (val.Encoding, []byte) {
+	if i, ok := t.sch.GetPKCols().TagToIdx[tag]; ok {
+		return t.kd.Types[i].Enc, t.kd.GetField(i, k)
+	}
+	if i, ok := t.sch.GetNonPKCols().StoredIndexByTag(tag); ok {
+		return t.vd.Types[i].Enc, t.vd.GetField(i, v)
+	}
+	return 0, nil
+}
+
+// sameKey returns whether rows have the same primary key encoding in |t| and |prev|.
+func (t *tableAt) sameKey(prev *tableAt) bool {
+	tags, prevTags := t.sch.GetPKCols().Tags, prev.sch.GetPKCols().Tags
+	if len(tags) != len(prevTags) {
+		return false
+	}
+	for i := range tags {
+		if tags[i] != prevTags[i] || t.kd.Types[i].Enc != prev.kd.Types[i].Enc {
+			return false
+		}
+	}
+	return true
+}
+
+// keyIn returns the primary key in |prev| of the row |k|, |v| of |t|, built from the values of the columns of the
+// primary key of |prev|. It returns false if |t| doesn't have all of these columns.
+func (t *tableAt) keyIn(prev *tableAt, k, v val.Tuple) (val.Tuple, bool) {
+	if t.sameKey(prev) {
+		return k, true
+	}
+	tb := val.NewTupleBuilder(prev.kd, prev.rows.NodeStore())
+	for i, tag := range prev.sch.GetPKCols().Tags {
+		enc, b := t.field(tag, k, v)
+		if b == nil || enc != prev.kd.Types[i].Enc {
+			return nil, false
+		}
+		tb.PutRaw(i, b)
+	}
+	key, err := tb.BuildPermissive(prev.rows.Pool())
+	if err != nil {
+		return nil, false
+	}
+	return key, true
+}
+
+// sameCell returns whether the column with |tag| has the same value in the row |k|, |v| of |t| and the row |pk|, |pv|
+// of |prev|. A column that one of the tables doesn't have is treated as NULL.
+func (t *tableAt) sameCell(prev *tableAt, tag uint64, k, v, pk, pv val.Tuple) bool {
+	enc, b := t.field(tag, k, v)
+	prevEnc, prevB := prev.field(tag, pk, pv)
+	if b == nil || prevB == nil {
+		return b == nil && prevB == nil
+	}
+	return enc == prevEnc && bytes.Equal(b, prevB)
+}
+
+func storedColumns(cols *schema.ColCollection) []schema.Column {
+	var stored []schema.Column
+	for i := 0; i < cols.StoredSize(); i++ {
+		stored = append(stored, cols.GetByStoredIndex(i))
+	}
+	return stored
+}
+
+// entry is a row of the result, or some of its cells, that still need an origin.
+type entry struct {
+	row  int
+	tags []uint64
+}
+
+// pendingRow is a row of a table as of some commit, with the result entries that are still attributed to it.
+type pendingRow struct {
+	key     val.Tuple
+	entries []entry
+}
+
+// node is a commit in the walk.
+type node struct {
+	commit *doltdb.Commit
+	hash   hash.Hash
+	height uint64
+	// boundary nodes are reachable from Options.Since. They don't pass their rows on to their parents.
+	boundary bool
+	tbl      *tableAt
+	pending  map[string]*pendingRow
+}
+
+// queue orders nodes so that all children of a commit are processed before the commit itself.
+type queue []*node
+
+func (q queue) Len() int {
+	return len(q)
+}
+
+func (q queue) Less(i, j int) bool {
+	if q[i].height != q[j].height {
+		return q[i].height > q[j].height
+	}
+	return q[i].hash.String() < q[j].hash.String()
+}
+
+func (q queue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *queue) Push(x interface{}) {
+	*q = append(*q, x.(*node))
+}
+
+func (q *queue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// blamer walks the commit graph to blame a batch of rows.
+type blamer struct {
+	ddb     *doltdb.DoltDB
+	opts    Options
+	queue   queue
+	nodes   map[hash.Hash]*node
+	origins map[hash.Hash]*Origin
+	columns []schema.Column
+	// rows are the rows of the batch, which entries refer to by index.
+	rows []Row
+	// numPending is the number of queued nodes with rows to attribute.
+	numPending int
+}
+
+func (b *blamer) addCommit(ctx context.Context, cm *doltdb.Commit) (*node, error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if n, ok := b.nodes[h]; ok {
+		return n, nil
+	}
+	height, err := cm.Height()
+	if err != nil {
+		return nil, err
+	}
+	n := &node{commit: cm, hash: h, height: height}
+	b.nodes[h] = n
+	heap.Push(&b.queue, n)
+	return n, nil
+}
+
+// parents returns the parents of |n|, skipping ghost commits of shallow clones.
+func (b *blamer) parents(ctx context.Context, n *node) ([]*node, error) {
+	var parents []*node
+	for i := 0; i < n.commit.NumParents(); i++ {
+		opt, err := n.commit.GetParent(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		cm, ok := opt.ToCommit()
+		if !ok {
+			continue
+		}
+		p, err := b.addCommit(ctx, cm)
+		if err != nil {
+			return nil, err
+		}
+		parents = append(parents, p)
+	}
+	return parents, nil
+}
+
+func (b *blamer) process(ctx context.Context, n *node) error {
+	if !n.boundary && len(n.pending) == 0 {
+		return nil
+	}
+	parents, err := b.parents(ctx, n)
+	if err != nil {
+		return err
+	}
+
+	if n.boundary {
+		for _, p := range parents {
+			p.boundary = true
+		}
+		return b.blameAll(ctx, n)
+	}
+
+	// tables holds the table in each parent, or nil if the parent doesn't have it
+	tables := make([]*tableAt, len(parents))
+	for i, p := range parents {
+		pr, err := p.commit.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		t, ok, err := findTable(ctx, pr, n.tbl)
+		if err != nil {
+			return err
+		}
+		if ok {
+			tables[i] = t
+		}
+	}
+
+	// a table identical to a parent's came from that parent as a whole
+	for i, t := range tables {
+		if t != nil && schema.SchemasAreEqual(n.tbl.sch, t.sch) && n.tbl.rows.HashOf() == t.rows.HashOf() {
+			b.passAll(parents[i], t, n.pending)
+			n.pending = nil
+			return nil
+		}
+	}
+
+	// changed holds, for parents whose table has the same schema, the keys of the pending rows that changed
+	changed := make([]map[string]struct{}, len(parents))
+	if len(n.pending) >= diffThreshold {
+		// only the range of keys of the pending rows is diffed
+		var first, last val.Tuple
+		for _, pr := range n.pending {
+			if first == nil || n.tbl.kd.Compare(ctx, pr.key, first) < 0 {
+				first = pr.key
+			}
+			if last == nil || n.tbl.kd.Compare(ctx, pr.key, last) > 0 {
+				last = pr.key
+			}
+		}
+		for i, t := range tables {
+			if t == nil || !schema.SchemasAreEqual(n.tbl.sch, t.sch) {
+				continue
+			}
+			changed[i] = make(map[string]struct{})
+			err = prolly.DiffMapsKeyRange(ctx, t.rows, n.tbl.rows, first, nil, func(_ context.Context, d tree.Diff) error {
+				if n.tbl.kd.Compare(ctx, val.Tuple(d.Key), last) > 0 {
+					return io.EOF
+				}
+				if _, ok := n.pending[string(d.Key)]; ok {
+					changed[i][string(d.Key)] = struct{}{}
+				}
+				return nil
+			})
+			if err != nil && err != io.EOF {
+				return err
+			}
+		}
+	}
+
+	for ks, pr := range n.pending {
+		var v val.Tuple
+		err = n.tbl.rows.Get(ctx, pr.key, func(_, value val.Tuple) error {
+			v = value
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		entries := pr.entries
+		for i, t := range tables {
+			if t == nil || len(entries) == 0 {
+				continue
+			}
+			if changed[i] != nil {
+				if _, ok := changed[i][ks]; !ok {
+					b.pass(parents[i], t, pr.key, entries...)
+					entries = nil
+				}
+				continue
+			}
+			entries, err = b.passUnchanged(ctx, parents[i], t, n.tbl, pr.key, v, entries)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(entries) > 0 {
+			if err = b.blame(ctx, n, entries); err != nil {
+				return err
+			}
+		}
+	}
+	n.pending = nil
+	return nil
+}
+
+// passUnchanged passes the parts of |entries| that are the same in |t| to the parent |p|, and returns the rest.
+func (b *blamer) passUnchanged(ctx context.Context, p *node, t, child *tableAt, k, v val.Tuple, entries []entry) ([]entry, error) {
+	pk, ok := child.keyIn(t, k, v)
+	if !ok {
+		return entries, nil
+	}
+	var pv val.Tuple
+	found := false
+	err := t.rows.Get(ctx, pk, func(key, value val.Tuple) error {
+		if key != nil {
+			found, pv = true, value
+		}
+		return nil
+	})
+	if err != nil || !found {
+		return entries, err
+	}
+
+	if !b.opts.Cells {
+		if child.sameKey(t) && schema.SchemasAreEqual(child.sch, t.sch) {
+			if bytes.Equal(v, pv) {
+				b.pass(p, t, pk, entries...)
+				return nil, nil
+			}
+			return entries, nil
+		}
+		for _, tag := range child.sch.GetAllCols().Tags {
+			if !child.sameCell(t, tag, k, v, pk, pv) {
+				return entries, nil
+			}
+		}
+		for _, tag := range t.sch.GetAllCols().Tags {
+			if !child.sameCell(t, tag, k, v, pk, pv) {
+				return entries, nil
+			}
+		}
+		b.pass(p, t, pk, entries...)
+		return nil, nil
+	}
+
+	var rest []entry
+	for _, e := range entries {
+		var same, diff []uint64
+		for _, tag := range e.tags {
+			if child.sameCell(t, tag, k, v, pk, pv) {
+				same = append(same, tag)
+			} else {
+				diff = append(diff, tag)
+			}
+		}
+		if len(same) > 0 {
+			b.pass(p, t, pk, entry{row: e.row, tags: same})
+		}
+		if len(diff) > 0 {
+			rest = append(rest, entry{row: e.row, tags: diff})
+		}
+	}
+	return rest, nil
+}
+
+// pass attributes |entries| to the row |key| of the table |t| in the parent |p|.
+func (b *blamer) pass(p *node, t *tableAt, key val.Tuple, entries ...entry) {
+	if p.pending == nil {
+		p.pending = make(map[string]*pendingRow)
+		p.tbl = t
+		b.numPending++
+	}
+	if pr, ok := p.pending[string(key)]; ok {
+		pr.entries = append(pr.entries, entries...)
+		return
+	}
+	p.pending[string(key)] = &pendingRow{key: key, entries: entries}
+}
+
+// passAll attributes all of |pending| to the parent |p|, whose table |t| is identical.
+func (b *blamer) passAll(p *node, t *tableAt, pending map[string]*pendingRow) {
+	if p.pending == nil {
+		p.pending = pending
+		p.tbl = t
+		b.numPending++
+		return
+	}
+	for _, pr := range pending {
+		b.pass(p, t, pr.key, pr.entries...)
+	}
+}
+
+func (b *blamer) blameAll(ctx context.Context, n *node) error {
+	for _, pr := range n.pending {
+		if err := b.blame(ctx, n, pr.entries); err != nil {
+			return err
+		}
+	}
+	n.pending = nil
+	return nil
+}
+
+// blame makes |n| the origin of |entries|.
+func (b *blamer) blame(ctx context.Context, n *node, entries []entry) error {
+	o, ok := b.origins[n.hash]
+	if !ok {
+		meta, err := n.commit.GetCommitMeta(ctx)
+		if err != nil {
+			return err
+		}
+		o = &Origin{Hash: n.hash, Meta: meta}
+		b.origins[n.hash] = o
+	}
+
+	for _, e := range entries {
+		row := &b.rows[e.row]
+		if !b.opts.Cells {
+			row.Origin = o
+			continue
+		}
+		for _, tag := range e.tags {
+			for i, col := range b.columns {
+				if col.Tag == tag {
+					row.Cells[i] = o
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ const (
 	Permissions_Admin Permissions = 1 << iota // Permissions_Admin grants unrestricted control over a branch, including modification of table entries
 	Permissions_Write                         // Permissions_Write allows for all modifying operations on a branch, but does not allow modification of table entries
 	Permissions_Read                          // Permissions_Read allows for reading from a branch, which is equivalent to having no permissions
+	Permissions_Deny                          // Permissions_Deny hides a branch, so that it may be neither read nor modified
 
 	Permissions_None Permissions = 0 // Permissions_None represents a lack of permissions, which defaults to allowing reading
 )
@@ -83,6 +84,10 @@ func (tbl *Access) Match(database string, branch string, user string, host strin
 			perms |= result.Permissions
 		}
 	}
+	// A deny overrides any read and write permissions that were matched with the same length, but not admin
+	if perms&Permissions_Deny == Permissions_Deny && perms&Permissions_Admin != Permissions_Admin {
+		perms = Permissions_Deny
+	}
 	return len(results) > 0, perms
 }
 
@@ -261,14 +266,38 @@ OuterLoop:
 }
 
 // Consolidate reduces the permission set down to the most representative permission. For example, having both admin and
-// write permissions are equivalent to only having the admin permission. Additionally, having no permissions is
-// equivalent to only having the read permission.
+// write permissions are equivalent to only having the admin permission, and having both write and deny permissions are
+// equivalent to only having the deny permission. Additionally, having no permissions is equivalent to only having the
+// read permission.
 func (perm Permissions) Consolidate() Permissions {
 	if perm&Permissions_Admin == Permissions_Admin {
 		return Permissions_Admin
+	} else if perm&Permissions_Deny == Permissions_Deny {
+		return Permissions_Deny
 	} else if perm&Permissions_Write == Permissions_Write {
 		return Permissions_Write
 	} else {
 		return Permissions_Read
 	}
 }
+
+// Allows returns whether the permission set allows for all of the given flags. Admin allows everything, and reading is
+// allowed by every permission set that can read.
+func (perm Permissions) Allows(flags Permissions) bool {
+	if perm&Permissions_Admin == Permissions_Admin {
+		return true
+	}
+	if flags&Permissions_Read == Permissions_Read {
+		if !perm.CanRead() {
+			return false
+		}
+		flags &^= Permissions_Read
+	}
+	return perm&flags == flags
+}
+
+// CanRead returns whether the permission set allows for reading from a branch. Every permission set allows reading,
+// unless it has been denied without also granting admin.
+func (perm Permissions) CanRead() bool {
+	return perm&Permissions_Deny != Permissions_Deny || perm&Permissions_Admin == Permissions_Admin
+}
@@ -34,6 +34,9 @@ var (
 	ErrIncorrectPermissions  = errors.NewKind("`%s`@`%s` does not have the correct permissions on branch `%s`")
 	ErrCannotCreateBranch    = errors.NewKind("`%s`@`%s` cannot create a branch named `%s`")
 	ErrCannotDeleteBranch    = errors.NewKind("`%s`@`%s` cannot delete the branch `%s`")
+	ErrCannotReadBranch      = errors.NewKind("`%s`@`%s` cannot read the branch `%s`")
+	ErrCannotReadCommit      = errors.NewKind("`%s`@`%s` cannot read the commit `%s`")
+	ErrCannotProtectBranch   = errors.NewKind("`%s`@`%s` cannot change the protection rule of the branch `%s`")
 	ErrExpressionsTooLong    = errors.NewKind("expressions are too long [%q, %q, %q, %q]")
 	ErrInsertingAccessRow    = errors.NewKind("`%s`@`%s` cannot add the row [%q, %q, %q, %q, %q]")
 	ErrInsertingNamespaceRow = errors.NewKind("`%s`@`%s` cannot add the row [%q, %q, %q, %q]")
@@ -252,12 +255,38 @@ func CheckAccess(ctx context.Context, flags Permissions) error {
 	// Get the permissions for the branch, user, and host combination
 	_, perms := controller.Access.Match(database, branch, user, host)
 	// If either the flags match or the user is an admin for this branch, then we allow access
-	if (perms&flags == flags) || (perms&Permissions_Admin == Permissions_Admin) {
+	if perms.Allows(flags) {
 		return nil
 	}
 	return ErrIncorrectPermissions.New(user, host, branch)
 }
 
+// CanReadBranch returns whether the given context can read the branch with the given name in the given database. As
+// with CheckAccess, contexts without an associated user (such as most CLI commands) may read every branch.
+func CanReadBranch(ctx context.Context, database string, branchName string) error {
+	branchAwareSession := GetBranchAwareSession(ctx)
+	// A nil session means we're not in the SQL context, so we allow the read
+	if branchAwareSession == nil {
+		return nil
+	}
+	controller := branchAwareSession.GetController()
+	// Reads were not checked against branch permissions before the deny permission existed, so a session without a
+	// controller, which can have no deny entries, may read every branch
+	if controller == nil {
+		return nil
+	}
+	controller.Access.RWMutex.RLock()
+	defer controller.Access.RWMutex.RUnlock()
+
+	user := branchAwareSession.GetUser()
+	host := branchAwareSession.GetHost()
+	_, perms := controller.Access.Match(getDatabaseNameOnly(database), branchName, user, host)
+	if perms.CanRead() {
+		return nil
+	}
+	return ErrCannotReadBranch.New(user, host, branchName)
+}
+
 // CanCreateBranch returns whether the given context can create a branch with the given name. In general, SQL statements
 // will almost always return a *sql.Context, so any checks from the SQL path will be able to validate a branch's name.
 // However, not all CLI commands use *sql.Context, and therefore will not have any user associated with the context. In
@@ -315,6 +344,37 @@ func CanDeleteBranch(ctx context.Context, branchName string) error {
 	return ErrCannotDeleteBranch.New(user, host, branchName)
 }
 
+// CanProtectBranch returns whether the given context can change the protection rule of the branch with the given name
+// in the given database. This requires admin permissions on the branch, or the database privileges which also allow
+// modifying the branch control tables. As with CheckAccess, contexts without an associated user (such as most CLI
+// commands) may change any rule.
+func CanProtectBranch(ctx context.Context, database string, branchName string) error {
+	branchAwareSession := GetBranchAwareSession(ctx)
+	// A nil session means we're not in the SQL context, so we allow the change
+	if branchAwareSession == nil {
+		return nil
+	}
+	database = getDatabaseNameOnly(database)
+	if HasDatabasePrivileges(branchAwareSession, database) {
+		return nil
+	}
+	controller := branchAwareSession.GetController()
+	// Any context that has a non-nil session should always have a non-nil controller, so this is an error
+	if controller == nil {
+		return ErrMissingController.New()
+	}
+	controller.Access.RWMutex.RLock()
+	defer controller.Access.RWMutex.RUnlock()
+
+	user := branchAwareSession.GetUser()
+	host := branchAwareSession.GetHost()
+	_, perms := controller.Access.Match(database, branchName, user, host)
+	if perms&Permissions_Admin == Permissions_Admin {
+		return nil
+	}
+	return ErrCannotProtectBranch.New(user, host, branchName)
+}
+
 // AddAdminForContext adds an entry in the access table for the user represented by the given context. If the
 // context is missing some functionality that is needed to perform the addition, such as a user or the Controller, then
 // this simply returns.
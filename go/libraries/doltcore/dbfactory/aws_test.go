@@ -640,3 +640,31 @@ aws_secret_access_key = new_secret_access_key
 		}
 	})
 }
+
+func TestS3ClientFromParams(t *testing.T) {
+	t.Setenv("HOME", "/does_not_exist")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_ENDPOINT_URL", "")
+	t.Setenv("AWS_ENDPOINT_URL_S3", "")
+
+	client, err := s3ClientFromParams(context.Background(), map[string]interface{}{
+		S3EndpointParam:  "http://localhost:9000",
+		S3PathStyleParam: "true",
+	})
+	require.NoError(t, err)
+	opts := client.Options()
+	assert.Equal(t, "http://localhost:9000", aws.ToString(opts.BaseEndpoint))
+	assert.True(t, opts.UsePathStyle)
+	assert.Equal(t, defaultS3Region, opts.Region)
+
+	client, err = s3ClientFromParams(context.Background(), map[string]interface{}{AWSRegionParam: "eu-west-1"})
+	require.NoError(t, err)
+	opts = client.Options()
+	assert.Nil(t, opts.BaseEndpoint)
+	assert.False(t, opts.UsePathStyle)
+	assert.Equal(t, "eu-west-1", opts.Region)
+
+	_, err = s3ClientFromParams(context.Background(), map[string]interface{}{S3PathStyleParam: "sometimes"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// BundleFactory is a DBFactory implementation for reading databases from bundle files, which are written by
+// `dolt bundle create`. Bundles are read only.
+type BundleFactory struct {
+}
+
+func (fact BundleFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	return fmt.Errorf("bundle remotes are read only")
+}
+
+// CreateDB opens the bundle file at the path of |urlObj| as a read only database
+func (fact BundleFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	path, err := url.PathUnescape(urlObj.Path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	path = filepath.FromSlash(urlObj.Host + path)
+
+	cs, err := nbs.NewBundleStore(ctx, nbf.VersionString(), path, nbs.NewUnlimitedMemQuotaProvider())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vrw, ns)
+	return db, vrw, ns, nil
+}
@@ -53,6 +53,18 @@ const (
 
 	OSSScheme = "oss"
 
+	// AzureScheme
+	AzureScheme = "az"
+
+	// S3Scheme
+	S3Scheme = "s3"
+
+	// SSHScheme
+	SSHScheme = "ssh"
+
+	// BundleScheme
+	BundleScheme = "bundle"
+
 	defaultScheme       = HTTPSScheme
 	defaultMemTableSize = 256 * 1024 * 1024
 )
@@ -73,6 +85,10 @@ var DBFactories = map[string]DBFactory{
 	OSSScheme:     OSSFactory{},
 	GSScheme:      GSFactory{},
 	OCIScheme:     OCIFactory{},
+	AzureScheme:   AzureFactory{},
+	S3Scheme:      S3Factory{},
+	SSHScheme:     SSHFactory{},
+	BundleScheme:  BundleFactory{},
 	FileScheme:    FileFactory{},
 	MemScheme:     MemFactory{},
 	LocalBSScheme: LocalBSFactory{},
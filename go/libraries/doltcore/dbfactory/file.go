@@ -21,6 +21,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -61,6 +62,46 @@ const (
 var DoltDataDir = filepath.Join(DoltDir, DataDir)
 var DoltStatsDir = filepath.Join(DoltDir, StatsDir)
 
+// dataDirPointerPrefix starts the contents of a data dir pointer file. A .dolt directory which shares the data
+// directory of another one, like a linked worktree of a repository, has a pointer file in place of its data directory,
+// holding the absolute path of the data directory it uses.
+const dataDirPointerPrefix = "datadir: "
+
+// DataDirPath returns the absolute path of the data directory of the .dolt directory of |fs|, following a data dir
+// pointer file if there is one, and whether it exists.
+func DataDirPath(fs filesys.ReadableFS) (string, bool, error) {
+	exists, isDir := fs.Exists(DoltDataDir)
+	if !exists {
+		return "", false, nil
+	}
+	if isDir {
+		path, err := fs.Abs(DoltDataDir)
+		return path, err == nil, err
+	}
+
+	data, err := fs.ReadFile(DoltDataDir)
+	if err != nil {
+		return "", false, err
+	}
+	path, ok := strings.CutPrefix(strings.TrimSpace(string(data)), dataDirPointerPrefix)
+	if !ok || !filepath.IsAbs(path) {
+		return "", false, errors.New("file exists where the dolt data directory should be")
+	}
+	if exists, isDir := fs.Exists(path); !exists || !isDir {
+		return "", false, fmt.Errorf("dolt data directory '%s' does not exist", path)
+	}
+	return path, true, nil
+}
+
+// WriteDataDirPointer makes the .dolt directory of |fs| use the data directory at the absolute path |dataDir|, by
+// writing a data dir pointer file in place of its own data directory.
+func WriteDataDirPointer(fs filesys.ReadWriteFS, dataDir string) error {
+	if !filepath.IsAbs(dataDir) {
+		return fmt.Errorf("data directory '%s' is not an absolute path", dataDir)
+	}
+	return fs.WriteFile(DoltDataDir, []byte(dataDirPointerPrefix+dataDir+"\n"), os.ModePerm)
+}
+
 // FileFactory is a DBFactory implementation for creating local filesys backed databases
 type FileFactory struct {
 }
@@ -170,6 +211,12 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 		}
 	}
 
+	// the oldgen store of an encrypted database is encrypted too
+	err = nbs.InheritEncryption(path, oldgenPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	oldGenSt, err := nbs.NewLocalStore(ctx, newGenSt.Version(), oldgenPath, defaultMemTableSize, q)
 	if err != nil {
 		return nil, nil, nil, err
@@ -183,6 +230,11 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 	st := nbs.NewGenerationalCS(oldGenSt, newGenSt, ghostGen)
 	// metrics?
 
+	err = st.LoadLazyGen()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	if params != nil {
 		if nameV, ok := params[DatabaseNameParam]; ok && nameV != nil {
 			if name, ok := nameV.(string); ok && name != "" {
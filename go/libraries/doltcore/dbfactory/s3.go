@@ -0,0 +1,114 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// S3EndpointParam is a creation parameter that can be used to set the endpoint of an S3 compatible object store
+	S3EndpointParam = "s3-endpoint"
+
+	// S3PathStyleParam is a creation parameter that can be used to address buckets in the path of urls, rather
+	// than in their host names
+	S3PathStyleParam = "s3-path-style"
+
+	// defaultS3Region is the region used to sign requests to S3 compatible object stores when none is configured
+	defaultS3Region = "us-east-1"
+)
+
+// S3Factory is a DBFactory implementation for creating databases backed by S3 or an S3 compatible object store.
+// Unlike AWSFactory, the manifest is kept in the bucket along with the table files, so no DynamoDB table is needed.
+type S3Factory struct {
+}
+
+func (fact S3Factory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	// nothing to prepare
+	return nil
+}
+
+// CreateDB creates an S3 backed database
+func (fact S3Factory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	// s3://[bucket]/[path]
+	dbName, err := validatePath(urlObj.Path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	client, err := s3ClientFromParams(ctx, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	bs := blobstore.NewS3Blobstore(client, urlObj.Host, dbName)
+	q := nbs.NewUnlimitedMemQuotaProvider()
+	s3Store, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(s3Store)
+	ns := tree.NewNodeStore(s3Store)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+// s3ClientFromParams creates an S3 client from the AWS credential parameters and the S3 endpoint parameters in
+// |params|. Without an endpoint parameter, the endpoint comes from AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL, or is
+// the endpoint of AWS S3 for the configured region.
+func s3ClientFromParams(ctx context.Context, params map[string]interface{}) (*s3.Client, error) {
+	cfg, err := awsConfigFromParams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := ""
+	if val, ok := params[S3EndpointParam]; ok {
+		endpoint = val.(string)
+	}
+	pathStyle := false
+	if val, ok := params[S3PathStyleParam]; ok {
+		pathStyle, err = strconv.ParseBool(val.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s", S3PathStyleParam, val)
+		}
+	}
+
+	// S3 compatible object stores generally ignore the region, but requests still need one to be signed
+	if cfg.Region == "" {
+		cfg.Region = defaultS3Region
+	}
+
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = pathStyle
+	}), nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/libraries/events"
+	"github.com/dolthub/dolt/go/libraries/utils/streammux"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// defaultSSHCommand is the command used to connect to the host of an ssh remote when DOLT_SSH_COMMAND is not set
+	defaultSSHCommand = "ssh"
+
+	// defaultSSHRemoteDolt is the dolt command run on the host of an ssh remote when DOLT_SSH_REMOTE_DOLT is not set
+	defaultSSHRemoteDolt = "dolt"
+
+	// sshExitTimeout is how long a closed connection waits for its ssh process to exit before killing it
+	sshExitTimeout = 5 * time.Second
+)
+
+// SSHFactory is a DBFactory implementation for creating databases on hosts which are reachable with ssh. Each
+// database runs `dolt transfer-server` on the host, and the remotesapi protocol is spoken over streams multiplexed on
+// the stdin and stdout of the ssh process.
+//
+// The ssh command can be replaced with DOLT_SSH_COMMAND, which is run with the same arguments as ssh: optional -p and
+// port, a -- separator, the [user@]host of the remote, and the command to run on it.
+type SSHFactory struct {
+}
+
+func (fact SSHFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	return fmt.Errorf("ssh scheme cannot support this operation")
+}
+
+// CreateDB creates a database backed by a dolt repository on the host of an ssh remote
+func (fact SSHFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	// ssh://[user@]host[:port]/path
+	dialer, err := newSSHDialer(urlObj)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	session, err := dialer.start()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	conn, err := grpc.Dial(urlObj.Host,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return session.Open()
+		}),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(128*1024*1024)),
+		grpc.WithChainUnaryInterceptor(remotestorage.EventsUnaryClientInterceptor(events.GlobalCollector())),
+		grpc.WithChainUnaryInterceptor(remotestorage.RetryingUnaryClientInterceptor),
+	)
+	if err != nil {
+		session.Close()
+		return nil, nil, nil, err
+	}
+
+	csClient := remotesapi.NewChunkStoreServiceClient(conn)
+	cs, err := remotestorage.NewDoltChunkStoreFromPath(ctx, nbf, urlObj.Path, urlObj.Host, false, csClient)
+	if err != nil {
+		conn.Close()
+		session.Close()
+		return nil, nil, nil, fmt.Errorf("could not access dolt url '%s': %w", urlObj.String(), err)
+	}
+
+	// table files are downloaded and uploaded over HTTP/2 connections to the same transfer-server
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return session.Open()
+		},
+	}
+	cs = cs.WithHTTPFetcher(&http.Client{Transport: transport})
+	cs.SetFinalizer(func() error {
+		transport.CloseIdleConnections()
+		return errors.Join(conn.Close(), session.Close())
+	})
+
+	if _, ok := params[NoCachingParameter]; ok {
+		cs = cs.WithNoopChunkCache()
+	}
+
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+// sshDialer starts transfer-servers for the database of an ssh remote.
+type sshDialer struct {
+	args []string
+}
+
+func newSSHDialer(urlObj *url.URL) (sshDialer, error) {
+	path := urlObj.Path
+	if strings.Trim(path, "/") == "" {
+		return sshDialer{}, fmt.Errorf("ssh remote url '%s' must include the path of a dolt database", urlObj.String())
+	}
+	// ssh://host/~/path is relative to the home directory of the remote user
+	if path == "/~" {
+		path = "."
+	} else if strings.HasPrefix(path, "/~/") {
+		path = path[len("/~/"):]
+	}
+
+	sshCmd := os.Getenv(dconfig.EnvSSHCommand)
+	if sshCmd == "" {
+		sshCmd = defaultSSHCommand
+	}
+	remoteDolt := os.Getenv(dconfig.EnvSSHRemoteDolt)
+	if remoteDolt == "" {
+		remoteDolt = defaultSSHRemoteDolt
+	}
+
+	// A host or user which starts with a dash would be taken as an option by ssh, such as -oProxyCommand, which runs a
+	// local command. The -- separator covers ssh, but not every DOLT_SSH_COMMAND, so these are rejected as well.
+	host := urlObj.Hostname()
+	if host == "" || strings.HasPrefix(host, "-") {
+		return sshDialer{}, fmt.Errorf("ssh remote url '%s' has an invalid host '%s'", urlObj.String(), host)
+	}
+	if urlObj.User != nil && urlObj.User.Username() != "" {
+		user := urlObj.User.Username()
+		if strings.HasPrefix(user, "-") {
+			return sshDialer{}, fmt.Errorf("ssh remote url '%s' has an invalid user '%s'", urlObj.String(), user)
+		}
+		host = user + "@" + host
+	}
+
+	args := strings.Fields(sshCmd)
+	if port := urlObj.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	args = append(args, "--", host, remoteDolt+" transfer-server "+shellQuote(path))
+
+	return sshDialer{args: args}, nil
+}
+
+// start runs a transfer-server and returns a session which multiplexes connections to it over the stdin and stdout
+// of the ssh process. Closing the session closes the stdin of the transfer-server, which then exits.
+func (d sshDialer) start() (*streammux.Session, error) {
+	cmd := exec.Command(d.args[0], d.args[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stderr = sshStderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", d.args[0], err)
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	return streammux.Client(&sshConn{
+		Reader: stdout,
+		stdin:  stdin,
+		cmd:    cmd,
+		exited: exited,
+	}), nil
+}
+
+// sshStderr is the stderr of the dolt process, which the dolt cli redirects while commands run. Prompts and errors
+// from ssh are written to it.
+var sshStderr io.Writer = os.Stderr
+
+// sshConn is the stdin and stdout of an ssh process.
+type sshConn struct {
+	io.Reader
+	stdin  io.WriteCloser
+	cmd    *exec.Cmd
+	exited chan struct{}
+}
+
+func (c *sshConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+func (c *sshConn) Close() error {
+	err := c.stdin.Close()
+	select {
+	case <-c.exited:
+	case <-time.After(sshExitTimeout):
+		_ = c.cmd.Process.Kill()
+		<-c.exited
+	}
+	return err
+}
+
+// shellQuote quotes |s| as a single argument for the shell which runs commands on the remote host.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
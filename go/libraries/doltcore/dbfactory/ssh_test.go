@@ -0,0 +1,76 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+func TestSSHDialerArgs(t *testing.T) {
+	t.Setenv(dconfig.EnvSSHCommand, "")
+	t.Setenv(dconfig.EnvSSHRemoteDolt, "")
+
+	tests := []struct {
+		url      string
+		expected []string
+	}{
+		{"ssh://host/path/to/db", []string{"ssh", "--", "host", "dolt transfer-server '/path/to/db'"}},
+		{"ssh://user@host:2222/db", []string{"ssh", "-p", "2222", "--", "user@host", "dolt transfer-server '/db'"}},
+		{"ssh://host/~/db", []string{"ssh", "--", "host", "dolt transfer-server 'db'"}},
+		{"ssh://host/it's", []string{"ssh", "--", "host", `dolt transfer-server '/it'\''s'`}},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			urlObj, err := url.Parse(test.url)
+			require.NoError(t, err)
+			dialer, err := newSSHDialer(urlObj)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, dialer.args)
+		})
+	}
+
+	t.Run("DOLT_SSH_COMMAND", func(t *testing.T) {
+		t.Setenv(dconfig.EnvSSHCommand, "ssh -i key")
+		t.Setenv(dconfig.EnvSSHRemoteDolt, "/opt/dolt")
+		urlObj, err := url.Parse("ssh://host/db")
+		require.NoError(t, err)
+		dialer, err := newSSHDialer(urlObj)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ssh", "-i", "key", "--", "host", "/opt/dolt transfer-server '/db'"}, dialer.args)
+	})
+}
+
+func TestSSHDialerRejectsOptions(t *testing.T) {
+	for _, u := range []string{
+		"ssh://-oProxyCommand=touch%20pwned@host/db",
+		"ssh://-oProxyCommand=x@host/db",
+		"ssh://-oProxyCommand=x/db",
+		"ssh://host",
+		"ssh:///db",
+	} {
+		t.Run(u, func(t *testing.T) {
+			urlObj, err := url.Parse(u)
+			require.NoError(t, err)
+			_, err = newSSHDialer(urlObj)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -35,6 +35,17 @@ const (
 	EnvOssEndpoint                   = "OSS_ENDPOINT"
 	EnvOssAccessKeyID                = "OSS_ACCESS_KEY_ID"
 	EnvOssAccessKeySecret            = "OSS_ACCESS_KEY_SECRET"
+	EnvAzureStorageConnectionString  = "AZURE_STORAGE_CONNECTION_STRING"
+	EnvAzureStorageAccount           = "AZURE_STORAGE_ACCOUNT"
+	EnvAzureStorageKey               = "AZURE_STORAGE_KEY"
+	EnvAzureStorageSASToken          = "AZURE_STORAGE_SAS_TOKEN"
+	EnvAzureStorageAccessToken       = "AZURE_STORAGE_ACCESS_TOKEN"
+	EnvAzureStorageEndpoint          = "AZURE_STORAGE_ENDPOINT"
+	EnvEncryptionKey                 = "DOLT_ENCRYPTION_KEY"
+	EnvEncryptionKeyFile             = "DOLT_ENCRYPTION_KEY_FILE"
+	EnvEncryptionKeyCommand          = "DOLT_ENCRYPTION_KEY_COMMAND"
+	EnvSSHCommand                    = "DOLT_SSH_COMMAND"
+	EnvSSHRemoteDolt                 = "DOLT_SSH_REMOTE_DOLT"
 	EnvVerboseAssertTableFilesClosed = "DOLT_VERBOSE_ASSERT_TABLE_FILES_CLOSED"
 	EnvDisableGcProcedure            = "DOLT_DISABLE_GC_PROCEDURE"
 	EnvEditTableBufferRows           = "DOLT_EDIT_TABLE_BUFFER_ROWS"
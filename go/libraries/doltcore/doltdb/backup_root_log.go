@@ -0,0 +1,142 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// backupRootLogKey is the key of the tuple in which a backup keeps its root log.
+const backupRootLogKey = "backup_root_log"
+
+// rootLogEntrySz is the size of an encoded RootLogEntry: a uint64 of Unix epoch seconds followed by the root hash.
+const rootLogEntrySz = 8 + hash.ByteLen
+
+// RootLogEntry is a root of a database, along with the time it was written. Roots hold every ref of a database,
+// including its working sets, so a database can be restored to the exact state it was in at that time.
+type RootLogEntry struct {
+	Root hash.Hash
+	Time time.Time
+}
+
+// JournalRootLog returns the roots recorded in the chunk journal of |ddb|, oldest first. Roots written without a
+// timestamp, and roots whose chunks are no longer in the database, are left out. Returns nil if the database does not
+// have a chunk journal.
+func (ddb *DoltDB) JournalRootLog(ctx context.Context) ([]RootLogEntry, error) {
+	journal := ddb.ChunkJournal()
+	if journal == nil {
+		return nil, nil
+	}
+
+	var entries []RootLogEntry
+	err := journal.IterateJournalRoots(ctx, func(root hash.Hash, timestamp time.Time) error {
+		if !timestamp.IsZero() && !root.IsEmpty() {
+			entries = append(entries, RootLogEntry{Root: root, Time: timestamp})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	roots := hash.NewHashSet()
+	for _, e := range entries {
+		roots.Insert(e.Root)
+	}
+	absent, err := datas.ChunkStoreFromDatabase(ddb.db).HasMany(ctx, roots)
+	if err != nil {
+		return nil, err
+	}
+	if absent.Size() == 0 {
+		return entries, nil
+	}
+	present := entries[:0]
+	for _, e := range entries {
+		if !absent.Has(e.Root) {
+			present = append(present, e)
+		}
+	}
+	return present, nil
+}
+
+// BackupRootLog returns the root log of the backup |ddb|, oldest first, and whether the backup has one. The root log
+// holds the roots of the backed up database at the times they were synced to the backup.
+func (ddb *DoltDB) BackupRootLog(ctx context.Context) ([]RootLogEntry, bool, error) {
+	val, ok, err := ddb.GetTuple(ctx, backupRootLogKey)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	if len(val)%rootLogEntrySz != 0 {
+		return nil, false, fmt.Errorf("invalid backup root log of %d bytes", len(val))
+	}
+
+	entries := make([]RootLogEntry, 0, len(val)/rootLogEntrySz)
+	for ; len(val) > 0; val = val[rootLogEntrySz:] {
+		secs := binary.BigEndian.Uint64(val)
+		entries = append(entries, RootLogEntry{
+			Root: hash.New(val[8:rootLogEntrySz]),
+			Time: time.Unix(int64(secs), 0),
+		})
+	}
+	return entries, true, nil
+}
+
+// CommitBackupRoot sets the root of the backup |ddb| to a root holding the datasets of |root|, along with |log| as
+// the backup's root log. The chunks of |root| must already be in the backup. Like CommitRoot, the root is only set if
+// the current root of the backup is |last|, and it returns whether it was set.
+func (ddb *DoltDB) CommitBackupRoot(ctx context.Context, root hash.Hash, log []RootLogEntry, last hash.Hash) (bool, error) {
+	logID := ref.NewTupleRef(backupRootLogKey).String()
+
+	datasets := make(map[string]hash.Hash)
+	dsMap, err := ddb.db.DatasetsByRootHash(ctx, root)
+	if err != nil {
+		return false, err
+	}
+	err = dsMap.IterAll(ctx, func(id string, addr hash.Hash) error {
+		// a database restored from a backup may have kept the backup's root log
+		if id != logID {
+			datasets[id] = addr
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	buf := make([]byte, 0, len(log)*rootLogEntrySz)
+	for _, e := range log {
+		buf = binary.BigEndian.AppendUint64(buf, uint64(e.Time.Unix()))
+		buf = append(buf, e.Root[:]...)
+	}
+	r, err := ddb.vrw.WriteValue(ctx, types.SerialMessage(datas.Tuple_flatbuffer(buf)))
+	if err != nil {
+		return false, err
+	}
+	datasets[logID] = r.TargetHash()
+
+	newRoot, err := datas.WriteStoreRoot(ctx, ddb.vrw, ddb.ns, datasets)
+	if err != nil {
+		return false, err
+	}
+	return ddb.CommitRoot(ctx, newRoot, last)
+}
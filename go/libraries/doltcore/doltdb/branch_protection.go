@@ -0,0 +1,342 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// branchProtectionKey is the key of the tuple in which a database keeps its branch protection rules.
+const branchProtectionKey = "branch_protection"
+
+var ErrBranchProtected = errors.New("branch is protected")
+
+// BranchProtectionRule restricts the ways in which the head of a branch may be updated. Rules are not versioned: they
+// are kept alongside the branches of a database, so they apply to every revision of it, and they are copied along
+// with the database by cluster replication and backups, but not by push and pull.
+type BranchProtectionRule struct {
+	Branch string `json:"branch"`
+	// RequireMerge only allows the branch head to be updated to a merge commit, so that changes arrive by merge
+	// rather than by commits made directly on the branch.
+	RequireMerge bool `json:"require_merge,omitempty"`
+	// BlockForceUpdates only allows the branch head to be updated to a descendant of the current head.
+	BlockForceUpdates bool `json:"block_force_updates,omitempty"`
+	// BlockDeletion does not allow the branch to be deleted.
+	BlockDeletion bool `json:"block_deletion,omitempty"`
+	// RequiredWorkflows are the names of Dolt CI workflows which must have passed on the commit merged into the
+	// branch. For an update which is not a merge commit, that is the new head itself. dolt_merge runs the workflows
+	// which have not passed on the merged commit yet.
+	RequiredWorkflows []string `json:"required_workflows,omitempty"`
+}
+
+// WorkflowPassedFunc returns whether the workflow named |workflow| has passed on the commit |commit|.
+type WorkflowPassedFunc func(ctx context.Context, commit hash.Hash, workflow string) (bool, error)
+
+// GetBranchProtectionRules returns the branch protection rules of the database, ordered by branch.
+func (ddb *DoltDB) GetBranchProtectionRules(ctx context.Context) ([]BranchProtectionRule, error) {
+	val, ok, err := ddb.GetTuple(ctx, branchProtectionKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return decodeBranchProtectionRules(val)
+}
+
+// SetBranchProtectionRules replaces the branch protection rules of the database with |rules|.
+func (ddb *DoltDB) SetBranchProtectionRules(ctx context.Context, rules []BranchProtectionRule) error {
+	if len(rules) == 0 {
+		err := ddb.DeleteTuple(ctx, branchProtectionKey)
+		if err == ErrTupleNotFound {
+			return nil
+		}
+		return err
+	}
+	rules = append([]BranchProtectionRule(nil), rules...)
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Branch < rules[j].Branch
+	})
+	val, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, branchProtectionKey, val)
+}
+
+// GetBranchProtectionRule returns the protection rule for the branch named |branch|, or nil if it has none. Branch
+// names are matched case-insensitively.
+func (ddb *DoltDB) GetBranchProtectionRule(ctx context.Context, branch string) (*BranchProtectionRule, error) {
+	rules, err := ddb.GetBranchProtectionRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findBranchProtectionRule(rules, branch), nil
+}
+
+func findBranchProtectionRule(rules []BranchProtectionRule, branch string) *BranchProtectionRule {
+	for i := range rules {
+		if strings.EqualFold(rules[i].Branch, branch) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+func decodeBranchProtectionRules(val []byte) ([]BranchProtectionRule, error) {
+	var rules []BranchProtectionRule
+	if err := json.Unmarshal(val, &rules); err != nil {
+		return nil, fmt.Errorf("invalid branch protection rules: %w", err)
+	}
+	return rules, nil
+}
+
+// CheckCommit returns an error if a commit with |numParents| parents may not be made on the branch of the rule.
+// |amend| is true if the commit replaces the current head of the branch rather than adding to it.
+func (r *BranchProtectionRule) CheckCommit(numParents int, amend bool) error {
+	if r == nil {
+		return nil
+	}
+	if amend && r.BlockForceUpdates {
+		return fmt.Errorf("%w: cannot amend the head of '%s'", ErrBranchProtected, r.Branch)
+	}
+	if r.RequireMerge && numParents < 2 {
+		return fmt.Errorf("%w: '%s' only accepts merges", ErrBranchProtected, r.Branch)
+	}
+	return nil
+}
+
+// CheckMergedCommit returns an error if the commit |merged| may not be merged into the branch of the rule, because a
+// required workflow has not passed on it. Workflows are not checked if |passed| is nil.
+func (r *BranchProtectionRule) CheckMergedCommit(ctx context.Context, merged hash.Hash, passed WorkflowPassedFunc) error {
+	if r == nil || passed == nil {
+		return nil
+	}
+	for _, workflow := range r.RequiredWorkflows {
+		ok, err := passed(ctx, merged, workflow)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: workflow '%s' has not passed on %s, which is required to update '%s'", ErrBranchProtected, workflow, merged.String(), r.Branch)
+		}
+	}
+	return nil
+}
+
+// CheckUpdate returns an error if the branch of the rule may not be updated from |oldHead| to |newHead|. A nil
+// |oldHead| creates the branch, which is always allowed, and a nil |newHead| deletes it. Workflows are not checked if
+// |passed| is nil.
+func (r *BranchProtectionRule) CheckUpdate(ctx context.Context, oldHead, newHead *Commit, passed WorkflowPassedFunc) error {
+	if r == nil || oldHead == nil {
+		return nil
+	}
+	if newHead == nil {
+		if r.BlockDeletion {
+			return fmt.Errorf("%w: cannot delete '%s'", ErrBranchProtected, r.Branch)
+		}
+		return nil
+	}
+
+	oldAddr, err := oldHead.HashOf()
+	if err != nil {
+		return err
+	}
+	newAddr, err := newHead.HashOf()
+	if err != nil {
+		return err
+	}
+	if oldAddr == newAddr {
+		return nil
+	}
+
+	if r.BlockForceUpdates {
+		ff, err := oldHead.CanFastForwardTo(ctx, newHead)
+		if err != nil && err != ErrIsAhead {
+			return err
+		}
+		if !ff {
+			return fmt.Errorf("%w: cannot make a non-fast-forward update of '%s'", ErrBranchProtected, r.Branch)
+		}
+	}
+	if err := r.CheckCommit(newHead.NumParents(), false); err != nil {
+		return err
+	}
+
+	merged := newAddr
+	if newHead.NumParents() > 1 {
+		parents, err := newHead.ParentHashes(ctx)
+		if err != nil {
+			return err
+		}
+		merged = parents[1]
+	}
+	return r.CheckMergedCommit(ctx, merged, passed)
+}
+
+// CheckRootUpdate returns an error if updating the root of the database from |last| to |current| updates any
+// branch in a way its protection rule does not allow. It is used for updates which write the root of the database
+// directly, such as pushes to a remotesapi endpoint.
+//
+// If |allowRuleChanges| is false, the update may not change the branch protection rules themselves, nor the CI run
+// log, whose runs count toward the workflows a rule requires to pass. Otherwise, only
+// the restrictions which are in effect both before and after the update are enforced, so that an update which, for
+// example, removes a rule and then deletes the branch it protected is accepted.
+func (ddb *DoltDB) CheckRootUpdate(ctx context.Context, last, current hash.Hash, allowRuleChanges bool, passed WorkflowPassedFunc) error {
+	if last == current || last.IsEmpty() {
+		return nil
+	}
+	lastDatasets, err := ddb.datasetAddrsAtRoot(ctx, last)
+	if err != nil {
+		return err
+	}
+	currentDatasets, err := ddb.datasetAddrsAtRoot(ctx, current)
+	if err != nil {
+		return err
+	}
+
+	ciRunsID := ref.NewTupleRef(ciRunsKey).String()
+	if lastDatasets[ciRunsID] != currentDatasets[ciRunsID] && !allowRuleChanges {
+		return fmt.Errorf("%w: the CI run log can only be written by the server running the workflows", ErrBranchProtected)
+	}
+
+	rulesID := ref.NewTupleRef(branchProtectionKey).String()
+	lastRules, err := ddb.branchProtectionRulesAtRoot(ctx, last)
+	if err != nil {
+		return err
+	}
+	rules := lastRules
+	if lastDatasets[rulesID] != currentDatasets[rulesID] {
+		if !allowRuleChanges {
+			return fmt.Errorf("%w: branch protection rules can only be changed through the %s system table", ErrBranchProtected, BranchProtectionTableName)
+		}
+		currentRules, err := ddb.branchProtectionRulesAtRoot(ctx, current)
+		if err != nil {
+			return err
+		}
+		rules = intersectBranchProtectionRules(lastRules, currentRules)
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		id := ref.NewBranchRef(rule.Branch).String()
+		oldAddr, newAddr := lastDatasets[id], currentDatasets[id]
+		if oldAddr == newAddr || oldAddr.IsEmpty() {
+			continue
+		}
+		oldHead, err := ddb.commitAtAddr(ctx, oldAddr)
+		if err != nil {
+			return err
+		}
+		var newHead *Commit
+		if !newAddr.IsEmpty() {
+			newHead, err = ddb.commitAtAddr(ctx, newAddr)
+			if err != nil {
+				return err
+			}
+		}
+		if err = rule.CheckUpdate(ctx, oldHead, newHead, passed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// datasetAddrsAtRoot returns the addresses of the datasets at the root |root|, keyed by their ids. Since branch
+// protection rules match branch names case-insensitively, branch ids are lower cased.
+func (ddb *DoltDB) datasetAddrsAtRoot(ctx context.Context, root hash.Hash) (map[string]hash.Hash, error) {
+	dsMap, err := ddb.db.DatasetsByRootHash(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make(map[string]hash.Hash)
+	err = dsMap.IterAll(ctx, func(id string, addr hash.Hash) error {
+		if ref.IsRef(id) {
+			if rf, err := ref.Parse(id); err == nil && rf.GetType() == ref.BranchRefType {
+				id = strings.ToLower(id)
+			}
+		}
+		addrs[id] = addr
+		return nil
+	})
+	return addrs, err
+}
+
+func (ddb *DoltDB) branchProtectionRulesAtRoot(ctx context.Context, root hash.Hash) ([]BranchProtectionRule, error) {
+	ds, err := ddb.db.GetDatasetByRootHash(ctx, ref.NewTupleRef(branchProtectionKey).String(), root)
+	if err != nil {
+		return nil, err
+	}
+	if !ds.HasHead() {
+		return nil, nil
+	}
+	tup, err := datas.LoadTuple(ctx, ddb.Format(), ddb.NodeStore(), ddb.ValueReadWriter(), ds)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := decodeBranchProtectionRules(tup.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		rules[i].Branch = strings.ToLower(rules[i].Branch)
+	}
+	return rules, nil
+}
+
+func (ddb *DoltDB) commitAtAddr(ctx context.Context, addr hash.Hash) (*Commit, error) {
+	optCmt, err := ddb.ReadCommit(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+// intersectBranchProtectionRules returns the restrictions which are in both |a| and |b|.
+func intersectBranchProtectionRules(a, b []BranchProtectionRule) []BranchProtectionRule {
+	var rules []BranchProtectionRule
+	for _, ra := range a {
+		rb := findBranchProtectionRule(b, ra.Branch)
+		if rb == nil {
+			continue
+		}
+		rule := BranchProtectionRule{
+			Branch:            ra.Branch,
+			RequireMerge:      ra.RequireMerge && rb.RequireMerge,
+			BlockForceUpdates: ra.BlockForceUpdates && rb.BlockForceUpdates,
+			BlockDeletion:     ra.BlockDeletion && rb.BlockDeletion,
+		}
+		for _, workflow := range ra.RequiredWorkflows {
+			for _, other := range rb.RequiredWorkflows {
+				if workflow == other {
+					rule.RequiredWorkflows = append(rule.RequiredWorkflows, workflow)
+					break
+				}
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
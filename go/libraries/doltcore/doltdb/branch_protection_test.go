@@ -0,0 +1,190 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBranchProtectionRules(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	rules, err := ddb.GetBranchProtectionRules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	require.NoError(t, ddb.SetBranchProtectionRules(ctx, []BranchProtectionRule{
+		{Branch: "release", BlockDeletion: true},
+		{Branch: "Main", RequireMerge: true, RequiredWorkflows: []string{"tests"}},
+	}))
+	rules, err = ddb.GetBranchProtectionRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "Main", rules[0].Branch)
+	assert.Equal(t, "release", rules[1].Branch)
+
+	rule, err := ddb.GetBranchProtectionRule(ctx, "main")
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+	assert.Equal(t, []string{"tests"}, rule.RequiredWorkflows)
+	rule, err = ddb.GetBranchProtectionRule(ctx, "feature")
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+
+	require.NoError(t, ddb.SetBranchProtectionRules(ctx, nil))
+	rules, err = ddb.GetBranchProtectionRules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+	require.NoError(t, ddb.SetBranchProtectionRules(ctx, nil))
+}
+
+func TestBranchProtectionRuleCheckCommit(t *testing.T) {
+	rule := &BranchProtectionRule{Branch: "main", RequireMerge: true, BlockForceUpdates: true}
+	assert.ErrorIs(t, rule.CheckCommit(1, false), ErrBranchProtected)
+	assert.NoError(t, rule.CheckCommit(2, false))
+	assert.ErrorIs(t, rule.CheckCommit(2, true), ErrBranchProtected)
+
+	rule = &BranchProtectionRule{Branch: "main", BlockDeletion: true}
+	assert.NoError(t, rule.CheckCommit(1, false))
+	assert.NoError(t, rule.CheckCommit(1, true))
+
+	var none *BranchProtectionRule
+	assert.NoError(t, none.CheckCommit(1, true))
+}
+
+func TestCheckRootUpdate(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	mainRef := ref.NewBranchRef("main")
+
+	commit := func() *Commit {
+		cs, _ := NewCommitSpec("main")
+		optCmt, err := ddb.Resolve(ctx, cs, nil)
+		require.NoError(t, err)
+		head, ok := optCmt.ToCommit()
+		require.True(t, ok)
+		root, err := head.GetRootValue(ctx)
+		require.NoError(t, err)
+		_, valHash, err := ddb.WriteRootValue(ctx, root)
+		require.NoError(t, err)
+		meta, err := datas.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "a commit")
+		require.NoError(t, err)
+		cm, err := ddb.Commit(ctx, valHash, mainRef, meta)
+		require.NoError(t, err)
+		return cm
+	}
+	nomsRoot := func() hash.Hash {
+		h, err := ddb.NomsRoot(ctx)
+		require.NoError(t, err)
+		return h
+	}
+
+	c1 := commit()
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("other"), c1, nil))
+	require.NoError(t, ddb.SetBranchProtectionRules(ctx, []BranchProtectionRule{
+		{Branch: "main", BlockForceUpdates: true, BlockDeletion: true, RequiredWorkflows: []string{"tests"}},
+	}))
+	protected := nomsRoot()
+
+	passed := func(context.Context, hash.Hash, string) (bool, error) { return true, nil }
+	failed := func(context.Context, hash.Hash, string) (bool, error) { return false, nil }
+
+	// a fast-forward update requires the workflow to have passed on the new head
+	c2 := commit()
+	fastForward := nomsRoot()
+	assert.NoError(t, ddb.CheckRootUpdate(ctx, protected, fastForward, false, passed))
+	assert.NoError(t, ddb.CheckRootUpdate(ctx, protected, fastForward, false, nil))
+	c2Hash, err := c2.HashOf()
+	require.NoError(t, err)
+	err = ddb.CheckRootUpdate(ctx, protected, fastForward, false, failed)
+	assert.ErrorIs(t, err, ErrBranchProtected)
+	assert.Contains(t, err.Error(), c2Hash.String())
+
+	// moving the head back is not a fast-forward update
+	require.NoError(t, ddb.SetHeadToCommit(ctx, mainRef, c1))
+	assert.ErrorIs(t, ddb.CheckRootUpdate(ctx, fastForward, nomsRoot(), false, passed), ErrBranchProtected)
+
+	require.NoError(t, ddb.DeleteBranch(ctx, mainRef, nil))
+	deleted := nomsRoot()
+	assert.ErrorIs(t, ddb.CheckRootUpdate(ctx, protected, deleted, false, passed), ErrBranchProtected)
+
+	// an update which also removes the rule is only accepted if rule changes are allowed
+	require.NoError(t, ddb.SetBranchProtectionRules(ctx, nil))
+	unprotected := nomsRoot()
+	assert.ErrorIs(t, ddb.CheckRootUpdate(ctx, protected, unprotected, false, passed), ErrBranchProtected)
+	assert.NoError(t, ddb.CheckRootUpdate(ctx, protected, unprotected, true, nil))
+	assert.NoError(t, ddb.CheckRootUpdate(ctx, deleted, deleted, false, failed))
+}
+
+func TestCIRuns(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	before, err := ddb.NomsRoot(ctx)
+	require.NoError(t, err)
+
+	commit := hash.Parse("0123456789abcdefghijklmnopqrstuv")
+	passed, err := ddb.CIWorkflowPassed(ctx, commit, "tests")
+	require.NoError(t, err)
+	assert.False(t, passed)
+
+	id, err := ddb.RecordCIRun(ctx, CIRun{Workflow: "tests", CommitHash: commit.String(), Steps: []CIStepRun{{Status: CIRunStatusFailed}}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), id)
+	passed, err = ddb.CIWorkflowPassed(ctx, commit, "Tests")
+	require.NoError(t, err)
+	assert.False(t, passed)
+
+	// the most recent run counts
+	id, err = ddb.RecordCIRun(ctx, CIRun{Workflow: "tests", CommitHash: commit.String(), Steps: []CIStepRun{{Status: CIRunStatusPassed}}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), id)
+	passed, err = ddb.CIWorkflowPassed(ctx, commit, "Tests")
+	require.NoError(t, err)
+	assert.True(t, passed)
+
+	for i := 0; i < MaxCIRuns; i++ {
+		_, err = ddb.RecordCIRun(ctx, CIRun{Workflow: "other", CommitHash: commit.String(), Error: "no such workflow"})
+		require.NoError(t, err)
+	}
+	runs, err := ddb.GetCIRuns(ctx)
+	require.NoError(t, err)
+	require.Len(t, runs, MaxCIRuns)
+	assert.Equal(t, uint64(3), runs[0].ID)
+	assert.Equal(t, uint64(MaxCIRuns+2), runs[len(runs)-1].ID)
+	assert.False(t, runs[0].Passed())
+
+	// the run log can not be written by a push
+	after, err := ddb.NomsRoot(ctx)
+	require.NoError(t, err)
+	assert.ErrorIs(t, ddb.CheckRootUpdate(ctx, before, after, false, nil), ErrBranchProtected)
+	assert.NoError(t, ddb.CheckRootUpdate(ctx, before, after, true, nil))
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrMissingBundlePrerequisite is returned when reading an incremental bundle into a database which does not have the
+// commits the bundle was created from.
+var ErrMissingBundlePrerequisite = errors.New("missing bundle prerequisite")
+
+// WriteBundle writes |refs|, and the chunks reachable from them, to a bundle file at |path|. Chunks which are
+// reachable from the commits in |bases| are left out, and the bundle can only be read by databases which have those
+// commits. It returns the number of chunks in the bundle.
+func (ddb *DoltDB) WriteBundle(ctx context.Context, tempDir, path string, refs []ref.DoltRef, bases []hash.Hash) (int, error) {
+	datasets := make(map[string]hash.Hash, len(refs))
+	heads := make([]hash.Hash, 0, len(refs))
+	for _, r := range refs {
+		ds, err := ddb.db.GetDataset(ctx, r.String())
+		if err != nil {
+			return 0, err
+		}
+		addr, ok := ds.MaybeHeadAddr()
+		if !ok {
+			return 0, fmt.Errorf("ref not found: %s", r.String())
+		}
+		datasets[r.String()] = addr
+		heads = append(heads, addr)
+	}
+
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	visited := hash.NewHashSet()
+	err := walkChunks(ctx, cs, ddb.Format(), bases, visited, func(chunks.Chunk) error { return nil })
+	if err != nil {
+		return 0, err
+	}
+
+	bw, err := nbs.NewBundleWriter(tempDir)
+	if err != nil {
+		return 0, err
+	}
+	addChunk := func(c chunks.Chunk) error {
+		return bw.AddChunk(c)
+	}
+
+	// the root of the bundle holds only the bundled refs, so it is written to a separate store
+	rootCS := (&chunks.MemoryStorage{}).NewViewWithFormat(ddb.Format().VersionString())
+	root, err := datas.WriteStoreRoot(ctx, types.NewValueStore(rootCS), tree.NewNodeStore(rootCS), datasets)
+	if err == nil {
+		err = walkChunks(ctx, rootCS, ddb.Format(), []hash.Hash{root}, visited, addChunk)
+	}
+	if err == nil {
+		err = walkChunks(ctx, cs, ddb.Format(), heads, visited, addChunk)
+	}
+	if err == nil {
+		err = bw.Finish(path, ddb.Format().VersionString(), root, bases)
+	}
+	if err != nil {
+		return 0, errors.Join(err, bw.Cancel())
+	}
+	return bw.ChunkCount(), nil
+}
+
+// walkChunks calls |cb| with each chunk in |cs| which is reachable from |roots| and is not in |visited|, and adds it
+// to |visited|. Chunks which are not in |cs| are skipped, unless they are one of |roots|.
+func walkChunks(ctx context.Context, cs chunks.ChunkStore, nbf *types.NomsBinFormat, roots []hash.Hash, visited hash.HashSet, cb func(chunks.Chunk) error) error {
+	walkAddrs := types.WalkAddrsForNBF(nbf, nil)
+	requested := hash.NewHashSet()
+	next := hash.NewHashSet()
+	for _, h := range roots {
+		if !visited.Has(h) {
+			next.Insert(h)
+		}
+	}
+	for isRoots := true; next.Size() > 0; isRoots = false {
+		batch := next
+		next = hash.NewHashSet()
+		requested.InsertAll(batch)
+
+		var mu sync.Mutex
+		var cbErr error
+		found := 0
+		err := cs.GetMany(ctx, batch, func(ctx context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			if cbErr != nil {
+				return
+			}
+			found++
+			visited.Insert(c.Hash())
+			if cbErr = cb(*c); cbErr != nil {
+				return
+			}
+			cbErr = walkAddrs(*c, func(h hash.Hash, _ bool) error {
+				if !visited.Has(h) && !requested.Has(h) {
+					next.Insert(h)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		} else if cbErr != nil {
+			return cbErr
+		} else if isRoots && found != batch.Size() {
+			return fmt.Errorf("%w: missing chunks", ErrHashNotFound)
+		}
+	}
+	return nil
+}
+
+// checkBundlePrerequisites returns an error if |srcCS| is an incremental bundle, and |destCS| does not have the
+// commits it was created from.
+func checkBundlePrerequisites(ctx context.Context, srcCS, destCS chunks.ChunkStore) error {
+	prereqs, ok := nbs.BundlePrerequisites(srcCS)
+	if !ok || len(prereqs) == 0 {
+		return nil
+	}
+	absent, err := destCS.HasMany(ctx, hash.NewHashSet(prereqs...))
+	if err != nil {
+		return err
+	}
+	for _, h := range prereqs {
+		if absent.Has(h) {
+			return fmt.Errorf("%w: the bundle requires commit %s, which is not in this database", ErrMissingBundlePrerequisite, h.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ciRunsKey is the key of the tuple in which a database keeps its CI run log.
+const ciRunsKey = "ci_runs"
+
+// MaxCIRuns is the number of workflow runs kept in the CI run log of a database. Older runs are discarded.
+const MaxCIRuns = 256
+
+const (
+	// CIRunStatusPassed is the status of a workflow step that ran and passed.
+	CIRunStatusPassed = "passed"
+	// CIRunStatusFailed is the status of a workflow step that ran and failed.
+	CIRunStatusFailed = "failed"
+	// CIRunStatusError is the status of a workflow run that could not be run at all.
+	CIRunStatusError = "error"
+)
+
+// ciRunsMu serializes updates to CI run logs, which replace the whole log.
+var ciRunsMu sync.Mutex
+
+// CIRun is the result of a single run of a Dolt CI workflow against a commit. Like branch protection rules, runs are
+// not versioned: they are kept alongside the branches of a database, so they are copied along with the database by
+// cluster replication and backups, but not by push and pull.
+type CIRun struct {
+	ID       uint64 `json:"id"`
+	Workflow string `json:"workflow"`
+	// Branch is the branch whose update triggered the run. It is empty for runs which were not triggered by a branch
+	// update, such as those of the workflows required to merge into a protected branch.
+	Branch     string `json:"branch,omitempty"`
+	CommitHash string `json:"commit"`
+	// Error is set if the workflow could not be run. In that case Steps may be empty.
+	Error      string      `json:"error,omitempty"`
+	Steps      []CIStepRun `json:"steps,omitempty"`
+	StartedAt  time.Time   `json:"started_at"`
+	FinishedAt time.Time   `json:"finished_at"`
+}
+
+// CIStepRun is the result of a single step of a workflow run.
+type CIStepRun struct {
+	Job        string    `json:"job"`
+	Step       string    `json:"step"`
+	Status     string    `json:"status"`
+	Message    string    `json:"message,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// Passed returns whether the workflow could be run and all of its steps passed.
+func (r CIRun) Passed() bool {
+	if r.Error != "" {
+		return false
+	}
+	for _, step := range r.Steps {
+		if step.Status != CIRunStatusPassed {
+			return false
+		}
+	}
+	return true
+}
+
+// GetCIRuns returns the CI run log of the database, oldest first.
+func (ddb *DoltDB) GetCIRuns(ctx context.Context) ([]CIRun, error) {
+	val, ok, err := ddb.GetTuple(ctx, ciRunsKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var runs []CIRun
+	if err := json.Unmarshal(val, &runs); err != nil {
+		return nil, fmt.Errorf("invalid ci run log: %w", err)
+	}
+	return runs, nil
+}
+
+// RecordCIRun adds |run| to the CI run log of the database and returns the id it was assigned. Only the most recent
+// MaxCIRuns runs are kept.
+func (ddb *DoltDB) RecordCIRun(ctx context.Context, run CIRun) (uint64, error) {
+	ciRunsMu.Lock()
+	defer ciRunsMu.Unlock()
+
+	runs, err := ddb.GetCIRuns(ctx)
+	if err != nil {
+		return 0, err
+	}
+	run.ID = 1
+	if len(runs) > 0 {
+		run.ID = runs[len(runs)-1].ID + 1
+	}
+	runs = append(runs, run)
+	if len(runs) > MaxCIRuns {
+		runs = runs[len(runs)-MaxCIRuns:]
+	}
+
+	val, err := json.Marshal(runs)
+	if err != nil {
+		return 0, err
+	}
+	if err = ddb.SetTuple(ctx, ciRunsKey, val); err != nil {
+		return 0, err
+	}
+	return run.ID, nil
+}
+
+// CIWorkflowPassed implements WorkflowPassedFunc. It returns whether the most recent run of the workflow named
+// |workflow| against |commit| in the CI run log of the database passed. Returns false if the log has no such run.
+func (ddb *DoltDB) CIWorkflowPassed(ctx context.Context, commit hash.Hash, workflow string) (bool, error) {
+	runs, err := ddb.GetCIRuns(ctx)
+	if err != nil {
+		return false, err
+	}
+	for i := len(runs) - 1; i >= 0; i-- {
+		if strings.EqualFold(runs[i].Workflow, workflow) && runs[i].CommitHash == commit.String() {
+			return runs[i].Passed(), nil
+		}
+	}
+	return false, nil
+}
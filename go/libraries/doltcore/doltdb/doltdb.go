@@ -124,29 +124,32 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 		params = make(map[string]any)
 	}
 
+	// Pull the database name out of the URL string. For filesystem-based databases (e.g. in-memory or disk-based
+	// filesystem implementations), we can determine the database name by looking at the filesystem path. This
+	// won't work for other storage schemes though.
+	var name string
 	if urlStr == LocalDirDoltDB {
-		exists, isDir := fs.Exists(dbfactory.DoltDataDir)
-		if !exists {
-			return nil, ErrMissingDoltDataDir
-		} else if !isDir {
-			return nil, errors.New("file exists where the dolt data directory should be")
+		localPath, err := fs.Abs(dbfactory.DoltDataDir)
+		if err != nil {
+			return nil, err
 		}
+		name = findParentDirectory(earl.FileUrlFromPath(filepath.ToSlash(localPath), os.PathSeparator), ".dolt")
 
-		absPath, err := fs.Abs(dbfactory.DoltDataDir)
+		// The data directory may be shared with another .dolt directory, in which case it is named by a pointer file.
+		absPath, ok, err := dbfactory.DataDirPath(fs)
 		if err != nil {
 			return nil, err
+		} else if !ok {
+			return nil, ErrMissingDoltDataDir
 		}
 
 		urlStr = earl.FileUrlFromPath(filepath.ToSlash(absPath), os.PathSeparator)
 
 		params[dbfactory.ChunkJournalParam] = struct{}{}
+	} else {
+		name = findParentDirectory(urlStr, ".dolt")
 	}
 
-	// Pull the database name out of the URL string. For filesystem-based databases (e.g. in-memory or disk-based
-	// filesystem implementations), we can determine the database name by looking at the filesystem path. This
-	// won't work for other storage schemes though.
-	name := findParentDirectory(urlStr, ".dolt")
-
 	params[dbfactory.DatabaseNameParam] = name
 
 	db, vrw, ns, err := dbfactory.CreateDB(ctx, nbf, urlStr, params)
@@ -1780,6 +1783,9 @@ func (ddb *DoltDB) GC(ctx context.Context, mode types.GCMode, cmp chunks.GCArchi
 	if !ok {
 		return fmt.Errorf("this database does not support garbage collection")
 	}
+	if ddb.IsLazyClone() {
+		return ErrLazyCloneGC
+	}
 
 	err := ddb.pruneUnreferencedDatasets(ctx)
 	if err != nil {
@@ -1886,7 +1892,11 @@ func pullHash(
 	destCS := datas.ChunkStoreFromDatabase(destDB)
 	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
 
-	if datas.CanUsePuller(srcDB) && datas.CanUsePuller(destDB) {
+	if err := checkBundlePrerequisites(ctx, srcCS, destCS); err != nil {
+		return err
+	}
+
+	if datas.CanUsePuller(srcDB, destDB) {
 		puller, err := pull.NewPuller(ctx, tempDir, defaultTargetFileSize, srcCS, destCS, waf, targetHashes, statsCh)
 		if err == pull.ErrDBUpToDate {
 			return nil
@@ -1907,8 +1917,12 @@ func (ddb *DoltDB) getAddrs(c chunks.Chunk) chunks.GetAddrsCb {
 }
 
 func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- pull.TableFileEvent) error {
-	return pull.Clone(ctx, datas.ChunkStoreFromDatabase(ddb.db),
-		datas.ChunkStoreFromDatabase(destDB.db),
+	srcCS, destCS := datas.ChunkStoreFromDatabase(ddb.db), datas.ChunkStoreFromDatabase(destDB.db)
+	if err := checkBundlePrerequisites(ctx, srcCS, destCS); err != nil {
+		return err
+	}
+	return pull.Clone(ctx, srcCS,
+		destCS,
 		ddb.getAddrs,
 		eventCh)
 }
@@ -2298,6 +2312,23 @@ func (ddb *DoltDB) PurgeCaches() {
 type FSCKReport struct {
 	ChunkCount uint32
 	Problems   []error
+	// CorruptChunks holds the chunks which can't be read, or whose content doesn't match their address.
+	CorruptChunks []CorruptChunk
+	// MissingChunks holds the addresses of chunks which are referenced by other chunks, but are not in the database.
+	MissingChunks hash.HashSet
+	// JournalOffset is the end of the last valid record of the chunk journal, and TornJournalBytes is the number of
+	// bytes after it which are not zero. Those bytes are left by an interrupted write or a corrupted record.
+	JournalOffset    int64
+	TornJournalBytes int64
+}
+
+// CorruptChunk is a chunk found by FSCK which can't be read, or whose content doesn't match its address.
+type CorruptChunk struct {
+	Addr hash.Hash
+	// File is the name of the table file which holds the chunk.
+	File hash.Hash
+	// OldGen is true if the table file is in the old generation of the database.
+	OldGen bool
 }
 
 // FSCK performs a full file system check on the database. This is currently exposed with the CLI as `dolt fsck`
@@ -2324,7 +2355,14 @@ func (ddb *DoltDB) FSCK(ctx context.Context, progress chan string) (*FSCKReport,
 	chunkCount += chunkCount2
 	proccessedCnt := int64(0)
 
+	walkAddrs, err := types.WalkAddrsForChunkStore(cs)
+	if err != nil {
+		return nil, err
+	}
+
 	var errs []error
+	var corrupt []CorruptChunk
+	refs := hash.NewHashSet()
 
 	decodeMsg := func(chk chunks.Chunk) string {
 		hrs := ""
@@ -2344,43 +2382,14 @@ func (ddb *DoltDB) FSCK(ctx context.Context, progress chan string) (*FSCKReport,
 		defer errsLock.Unlock()
 		errs = append(errs, err)
 	}
+	appendCorrupt := func(c CorruptChunk) {
+		errsLock.Lock()
+		defer errsLock.Unlock()
+		corrupt = append(corrupt, c)
+	}
 
-	// Callback for validating chunks. This code could be called concurrently, though that is not currently the case.
-	validationCallback := func(chunk chunks.Chunk) {
-		chunkOk := true
+	reportProgress := func(h hash.Hash, chunkOk bool) {
 		pCnt := atomic.AddInt64(&proccessedCnt, 1)
-		h := chunk.Hash()
-		raw := chunk.Data()
-		calcChkSum := hash.Of(raw)
-
-		if h != calcChkSum {
-			fuzzyMatch := false
-			// Special case for the journal chunk source. We may have an address which has 4 null bytes at the end.
-			if h[hash.ByteLen-1] == 0 && h[hash.ByteLen-2] == 0 && h[hash.ByteLen-3] == 0 && h[hash.ByteLen-4] == 0 {
-				// Now we'll just verify that the first 16 bytes match.
-				ln := hash.ByteLen - 4
-				fuzzyMatch = bytes.Compare(h[:ln], calcChkSum[:ln]) == 0
-			}
-			if !fuzzyMatch {
-				hrs := decodeMsg(chunk)
-				appendErr(errors.New(fmt.Sprintf("Chunk: %s content hash mismatch: %s\n%s", h.String(), calcChkSum.String(), hrs)))
-				chunkOk = false
-			}
-		}
-
-		if chunkOk {
-			// Round trip validation. Ensure that the top level store returns the same data.
-			c, err := cs.Get(ctx, h)
-			if err != nil {
-				appendErr(errors.New(fmt.Sprintf("Chunk: %s load failed with error: %s", h.String(), err.Error())))
-				chunkOk = false
-			} else if bytes.Compare(raw, c.Data()) != 0 {
-				hrs := decodeMsg(chunk)
-				appendErr(errors.New(fmt.Sprintf("Chunk: %s read with incorrect ID: %s\n%s", h.String(), c.Hash().String(), hrs)))
-				chunkOk = false
-			}
-		}
-
 		percentage := (float64(pCnt) * 100) / float64(chunkCount)
 		result := fmt.Sprintf("(%4.1f%% done)", percentage)
 
@@ -2392,16 +2401,102 @@ func (ddb *DoltDB) FSCK(ctx context.Context, progress chan string) (*FSCKReport,
 		progress <- progStr
 	}
 
-	err = gs.OldGen().IterateAllChunks(ctx, validationCallback)
+	// Callback for validating chunks. This code could be called concurrently, though that is not currently the case.
+	validationCallback := func(oldGen bool) func(chunks.Chunk, hash.Hash) {
+		return func(chunk chunks.Chunk, file hash.Hash) {
+			chunkOk := true
+			h := chunk.Hash()
+			raw := chunk.Data()
+			calcChkSum := hash.Of(raw)
+
+			if h != calcChkSum {
+				fuzzyMatch := false
+				// Special case for the journal chunk source. We may have an address which has 4 null bytes at the end.
+				if h[hash.ByteLen-1] == 0 && h[hash.ByteLen-2] == 0 && h[hash.ByteLen-3] == 0 && h[hash.ByteLen-4] == 0 {
+					// Now we'll just verify that the first 16 bytes match.
+					ln := hash.ByteLen - 4
+					fuzzyMatch = bytes.Compare(h[:ln], calcChkSum[:ln]) == 0
+				}
+				if !fuzzyMatch {
+					hrs := decodeMsg(chunk)
+					appendErr(errors.New(fmt.Sprintf("Chunk: %s content hash mismatch: %s\n%s", h.String(), calcChkSum.String(), hrs)))
+					appendCorrupt(CorruptChunk{Addr: h, File: file, OldGen: oldGen})
+					chunkOk = false
+				}
+			}
+
+			if chunkOk {
+				// Round trip validation. Ensure that the top level store returns the same data.
+				c, err := cs.Get(ctx, h)
+				if err != nil {
+					appendErr(errors.New(fmt.Sprintf("Chunk: %s load failed with error: %s", h.String(), err.Error())))
+					chunkOk = false
+				} else if bytes.Compare(raw, c.Data()) != 0 {
+					hrs := decodeMsg(chunk)
+					appendErr(errors.New(fmt.Sprintf("Chunk: %s read with incorrect ID: %s\n%s", h.String(), c.Hash().String(), hrs)))
+					chunkOk = false
+				}
+			}
+
+			if chunkOk {
+				// Collect the addresses this chunk references, to find the ones which are missing.
+				err := walkAddrs(chunk, func(addr hash.Hash, _ bool) error {
+					errsLock.Lock()
+					defer errsLock.Unlock()
+					refs.Insert(addr)
+					return nil
+				})
+				if err != nil {
+					appendErr(errors.New(fmt.Sprintf("Chunk: %s references can't be read: %s", h.String(), err.Error())))
+				}
+			}
+
+			reportProgress(h, chunkOk)
+		}
+	}
+
+	badChunkCallback := func(oldGen bool) func(hash.Hash, hash.Hash, error) {
+		return func(h hash.Hash, file hash.Hash, err error) {
+			appendErr(errors.New(fmt.Sprintf("Chunk: %s load failed with error: %s", h.String(), err.Error())))
+			appendCorrupt(CorruptChunk{Addr: h, File: file, OldGen: oldGen})
+			reportProgress(h, false)
+		}
+	}
+
+	oldGen, newGen := gs.OldGen().(*nbs.NomsBlockStore), gs.NewGen().(*nbs.NomsBlockStore)
+	err = oldGen.ScanAllChunks(ctx, validationCallback(true), badChunkCallback(true))
+	if err != nil {
+		return nil, err
+	}
+	err = newGen.ScanAllChunks(ctx, validationCallback(false), badChunkCallback(false))
 	if err != nil {
 		return nil, err
 	}
-	err = gs.NewGen().IterateAllChunks(ctx, validationCallback)
+
+	missing, err := gs.HasMany(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	for h := range missing {
+		errs = append(errs, errors.New(fmt.Sprintf("Chunk: %s is referenced, but is not in the database", h.String())))
+	}
+
+	journalOff, tornBytes, err := newGen.TornJournalTail(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if tornBytes > 0 {
+		errs = append(errs, errors.New(fmt.Sprintf("Chunk journal: %d bytes after the last valid record at offset %d can't be read", tornBytes, journalOff)))
+	}
 
-	FSCKReport := FSCKReport{Problems: errs, ChunkCount: chunkCount}
+	FSCKReport := FSCKReport{
+		Problems:         errs,
+		ChunkCount:       chunkCount,
+		CorruptChunks:    corrupt,
+		MissingChunks:    missing,
+		JournalOffset:    journalOff,
+		TornJournalBytes: tornBytes,
+	}
 
 	return &FSCKReport, nil
 }
@@ -368,3 +368,51 @@ func TestLDNoms(t *testing.T) {
 		}
 	}
 }
+
+func TestWorkingSetBisectState(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	cs, err := NewCommitSpec("main")
+	require.NoError(t, err)
+	optCmt, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	commit, ok := optCmt.ToCommit()
+	require.True(t, ok)
+	h, err := commit.HashOf()
+	require.NoError(t, err)
+	root, err := commit.GetRootValue(ctx)
+	require.NoError(t, err)
+
+	wsRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef("main"))
+	require.NoError(t, err)
+	ws := EmptyWorkingSet(wsRef).WithWorkingRoot(root).WithStagedRoot(root)
+	require.NoError(t, ddb.UpdateWorkingSet(ctx, wsRef, ws, hash.Hash{}, TodoWorkingSetMeta(), nil))
+	ws, err = ddb.ResolveWorkingSet(ctx, wsRef)
+	require.NoError(t, err)
+	assert.Nil(t, ws.BisectState())
+	noBisectHash, err := ws.HashOf()
+	require.NoError(t, err)
+
+	// the addresses of a bisect state are walked like those of merge and rebase states, so they must be commits
+	state := &BisectState{
+		Bad:     h,
+		Good:    []hash.Hash{h, h},
+		Skip:    []hash.Hash{h},
+		Current: h,
+	}
+	require.NoError(t, ddb.UpdateWorkingSet(ctx, wsRef, ws.WithBisectState(state), noBisectHash, TodoWorkingSetMeta(), nil))
+	ws, err = ddb.ResolveWorkingSet(ctx, wsRef)
+	require.NoError(t, err)
+	assert.Equal(t, state, ws.BisectState())
+	bisectHash, err := ws.HashOf()
+	require.NoError(t, err)
+
+	require.NoError(t, ddb.UpdateWorkingSet(ctx, wsRef, ws.WithBisectState(nil), bisectHash, TodoWorkingSetMeta(), nil))
+	ws, err = ddb.ResolveWorkingSet(ctx, wsRef)
+	require.NoError(t, err)
+	assert.Nil(t, ws.BisectState())
+}
@@ -50,6 +50,8 @@ const (
 
 var (
 	ErrUnknownAutoIncrementValue = fmt.Errorf("auto increment set for non-numeric column type")
+	// ErrSparseTable is returned when reading a table whose data was left out of a sparse clone.
+	ErrSparseTable = errors.New("table is not part of this sparse clone")
 )
 
 var (
@@ -184,6 +186,9 @@ func TableFromAddr(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeS
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := val.(types.GhostValue); ok {
+		return nil, ErrSparseTable
+	}
 
 	if !vrw.Format().UsesFlatbuffers() {
 		st, ok := val.(types.Struct)
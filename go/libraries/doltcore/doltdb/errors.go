@@ -34,6 +34,8 @@ var ErrHashNotFound = errors.New("could not find a value for this hash")
 var ErrBranchNotFound = errors.New("branch not found")
 var ErrTagNotFound = errors.New("tag not found")
 var ErrTupleNotFound = errors.New("tuple not found")
+var ErrNoteNotFound = errors.New("no note found for commit")
+var ErrNoteExists = errors.New("a note already exists for commit")
 var ErrWorkingSetNotFound = errors.New("working set not found")
 var ErrWorkspaceNotFound = errors.New("workspace not found")
 var ErrTableNotFound = errors.New("table not found")
@@ -0,0 +1,199 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// FSCKRepairReport describes the repairs made by RepairFSCK, or the repairs which would be made by a dry run.
+type FSCKRepairReport struct {
+	// Repaired holds the corrupt chunks which were replaced by a good copy from the source database.
+	Repaired hash.HashSet
+	// Fetched holds the missing chunks which were fetched from the source database. The chunks they reference which
+	// were missing too are fetched along with them.
+	Fetched hash.HashSet
+	// Unavailable holds the corrupt and missing chunks which the source database doesn't have, or has no good copy of.
+	Unavailable hash.HashSet
+	// ReplacedFiles holds the table files which were replaced by a new table file holding the repaired chunks.
+	ReplacedFiles hash.HashSet
+	// TruncatedJournalBytes is the number of bytes removed from the end of the chunk journal, and TornJournalFile is
+	// the file they were written to.
+	TruncatedJournalBytes int64
+	TornJournalFile       string
+}
+
+// RepairFSCK repairs the problems found by FSCK in |report|, using the chunks of |srcDB|. It:
+//   - truncates the chunk journal to the end of its last valid record, keeping the removed bytes in a file next to it,
+//   - fetches a good copy of each corrupt chunk by its address, and rewrites each table file holding corrupt chunks
+//     into a new table file. Corrupt chunks in the chunk journal are repaired by appending the good copy to it,
+//   - fetches each missing chunk, and the missing chunks it references.
+//
+// A table file is only rewritten if every corrupt chunk in it has a good copy. If |dryRun| is true, nothing is changed,
+// and the report describes the repairs which would be made.
+func (ddb *DoltDB) RepairFSCK(ctx context.Context, report *FSCKReport, srcDB *DoltDB, tempDir string, dryRun bool) (*FSCKRepairReport, error) {
+	gs, ok := datas.ChunkStoreFromDatabase(ddb.db).(*nbs.GenerationalNBS)
+	if !ok {
+		return nil, errors.New("FSCK requires a local database")
+	}
+	oldGen, newGen := gs.OldGen().(*nbs.NomsBlockStore), gs.NewGen().(*nbs.NomsBlockStore)
+	srcCS := datas.ChunkStoreFromDatabase(srcDB.db)
+
+	repair := &FSCKRepairReport{
+		Repaired:      hash.NewHashSet(),
+		Fetched:       hash.NewHashSet(),
+		Unavailable:   hash.NewHashSet(),
+		ReplacedFiles: hash.NewHashSet(),
+	}
+
+	wanted := hash.NewHashSet()
+	for _, c := range report.CorruptChunks {
+		wanted.Insert(c.Addr)
+	}
+	wanted.InsertAll(report.MissingChunks)
+	absent, err := srcCS.HasMany(ctx, wanted)
+	if err != nil {
+		return nil, err
+	}
+	repair.Unavailable.InsertAll(absent)
+
+	if report.TornJournalBytes > 0 {
+		repair.TruncatedJournalBytes = report.TornJournalBytes
+		if !dryRun {
+			repair.TornJournalFile, err = newGen.TruncateTornJournalTail(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Fetch good copies of the corrupt chunks.
+	goodCopies := make(map[hash.Hash]chunks.Chunk)
+	var mu sync.Mutex
+	toGet := hash.NewHashSet()
+	for _, c := range report.CorruptChunks {
+		if !repair.Unavailable.Has(c.Addr) {
+			toGet.Insert(c.Addr)
+		}
+	}
+	if len(toGet) > 0 {
+		err = srcCS.GetMany(ctx, toGet, func(_ context.Context, chk *chunks.Chunk) {
+			// the source could be corrupt too
+			if hash.Of(chk.Data()) == chk.Hash() {
+				mu.Lock()
+				defer mu.Unlock()
+				goodCopies[chk.Hash()] = *chk
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for h := range toGet {
+		if _, ok := goodCopies[h]; !ok {
+			repair.Unavailable.Insert(h)
+		}
+	}
+
+	type genFile struct {
+		file   hash.Hash
+		oldGen bool
+	}
+	repairable := make(map[genFile]map[hash.Hash]chunks.Chunk)
+	unrepairable := make(map[genFile]bool)
+	for _, c := range report.CorruptChunks {
+		gf := genFile{c.File, c.OldGen}
+		if repair.Unavailable.Has(c.Addr) {
+			unrepairable[gf] = true
+			continue
+		}
+		if repairable[gf] == nil {
+			repairable[gf] = make(map[hash.Hash]chunks.Chunk)
+		}
+		repairable[gf][c.Addr] = goodCopies[c.Addr]
+	}
+
+	for _, oldGenFiles := range []bool{true, false} {
+		store := newGen
+		if oldGenFiles {
+			store = oldGen
+		}
+		files := hash.NewHashSet()
+		chks := make(map[hash.Hash]chunks.Chunk)
+		journalChks := make(map[hash.Hash]chunks.Chunk)
+		for gf, fileChks := range repairable {
+			// Chunks are appended to the journal one by one, but a table file is only replaced if all of its corrupt
+			// chunks can be repaired.
+			if gf.oldGen != oldGenFiles || (unrepairable[gf] && !nbs.IsJournalFile(gf.file)) {
+				continue
+			}
+			for h, chk := range fileChks {
+				repair.Repaired.Insert(h)
+				if nbs.IsJournalFile(gf.file) {
+					journalChks[h] = chk
+				} else {
+					chks[h] = chk
+				}
+			}
+			if !nbs.IsJournalFile(gf.file) {
+				files.Insert(gf.file)
+				repair.ReplacedFiles.Insert(gf.file)
+			}
+		}
+		if dryRun {
+			continue
+		}
+		if len(files) > 0 {
+			if _, err = store.RepairTableFiles(ctx, files, chks); err != nil {
+				return nil, err
+			}
+		}
+		if len(journalChks) > 0 {
+			if err = store.RepairJournalChunks(ctx, journalChks); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Fetch the missing chunks, along with the missing chunks they reference.
+	var targets []hash.Hash
+	for h := range report.MissingChunks {
+		if !repair.Unavailable.Has(h) {
+			repair.Fetched.Insert(h)
+			targets = append(targets, h)
+		}
+	}
+	if len(targets) > 0 && !dryRun {
+		err = ddb.PullChunks(ctx, tempDir, srcDB, targets, nil, nil)
+		if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
+			return nil, err
+		}
+	}
+
+	if !dryRun {
+		// cached values may have been read from the corrupt chunks
+		ddb.PurgeCaches()
+	}
+
+	return repair, nil
+}
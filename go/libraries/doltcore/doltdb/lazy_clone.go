@@ -0,0 +1,103 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// ErrLazyCloneGC is returned when garbage collecting a lazy clone, which would need to fetch all of history to do so.
+var ErrLazyCloneGC = errors.New("cannot garbage collect a lazy clone; run dolt backfill to make it a complete clone first")
+
+// ErrNotLazyClone is returned when completing a database which is not a lazy clone.
+var ErrNotLazyClone = errors.New("this database is not a lazy clone")
+
+// lazyCloneStore is implemented by chunk stores which can be lazy clones.
+type lazyCloneStore interface {
+	PersistLazyHashes(ctx context.Context, refs hash.HashSet) error
+	IsLazy() bool
+	SetLazySource(open nbs.LazySource)
+	CompleteLazyClone(ctx context.Context, fetch func(ctx context.Context, lazyRefs hash.HashSet) error) error
+}
+
+func (ddb *DoltDB) lazyCloneStore() (lazyCloneStore, bool) {
+	lcs, ok := datas.ChunkStoreFromDatabase(ddb.db).(lazyCloneStore)
+	return lcs, ok
+}
+
+// IsLazyClone returns true if this database is a lazy clone, which fetches the history it is missing from a remote
+// as it is read.
+func (ddb *DoltDB) IsLazyClone() bool {
+	lcs, ok := ddb.lazyCloneStore()
+	return ok && lcs.IsLazy()
+}
+
+// PersistLazyCommits makes this database a lazy clone, which is missing |lazyCommits| and the data they reference.
+// This is how the clone tells the storage layer which commits it skipped. Fetches and pulls stop at these commits,
+// and the chunks they reference are fetched from the source set by SetLazyCloneSource when they are read.
+func (ddb *DoltDB) PersistLazyCommits(ctx context.Context, lazyCommits hash.HashSet) error {
+	lcs, ok := ddb.lazyCloneStore()
+	if !ok {
+		return errors.New("this database does not support lazy clones")
+	}
+	return lcs.PersistLazyHashes(ctx, lazyCommits)
+}
+
+// SetLazyCloneSource sets the function which opens the remote database that a lazy clone fetches missing data from.
+// The remote is opened the first time data is missing. It does nothing if this database is not a lazy clone.
+func (ddb *DoltDB) SetLazyCloneSource(open func(ctx context.Context) (*DoltDB, error)) {
+	lcs, ok := ddb.lazyCloneStore()
+	if !ok || !lcs.IsLazy() {
+		return
+	}
+	lcs.SetLazySource(func(ctx context.Context) (chunks.ChunkStore, error) {
+		srcDB, err := open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return datas.ChunkStoreFromDatabase(srcDB.db), nil
+	})
+}
+
+// CompleteLazyClone fetches all of the data a lazy clone is missing from its remote, and makes it a complete clone.
+// It returns the number of chunks which were read.
+func (ddb *DoltDB) CompleteLazyClone(ctx context.Context) (int, error) {
+	lcs, ok := ddb.lazyCloneStore()
+	if !ok || !lcs.IsLazy() {
+		return 0, ErrNotLazyClone
+	}
+
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	count := 0
+	err := lcs.CompleteLazyClone(ctx, func(ctx context.Context, lazyRefs hash.HashSet) error {
+		root, err := cs.Root(ctx)
+		if err != nil {
+			return err
+		}
+		// Chunks fetched on demand were cached without the chunks they reference, so every reachable chunk is read,
+		// rather than stopping at the chunks which are present.
+		return walkChunks(ctx, cs, ddb.Format(), []hash.Hash{root}, hash.NewHashSet(), func(chunks.Chunk) error {
+			count++
+			return nil
+		})
+	})
+	return count, err
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// Note is a message attached to a commit after the fact. Notes are stored like tags: the ref refs/notes/<commit hash>
+// points to a tag object whose target is the annotated commit and whose description is the note. Unlike a commit
+// message, a note can be added, changed or removed without rewriting any history.
+type Note struct {
+	// Commit is the hash of the annotated commit.
+	Commit hash.Hash
+	// Meta holds the author of the note, the time it was written, and the note itself as the description.
+	Meta *datas.TagMeta
+}
+
+var notesRefFilter = map[ref.RefType]struct{}{ref.NotesRefType: {}}
+
+// GetNote returns the note attached to the commit with the given hash, or ErrNoteNotFound if it has none.
+func (ddb *DoltDB) GetNote(ctx context.Context, commit hash.Hash) (*Note, error) {
+	note, err := ddb.resolveNote(ctx, ref.NewNoteRef(commit.String()))
+	if err == ErrNoteNotFound {
+		return nil, fmt.Errorf("%w %s", ErrNoteNotFound, commit.String())
+	}
+	return note, err
+}
+
+func (ddb *DoltDB) resolveNote(ctx context.Context, noteRef ref.NoteRef) (*Note, error) {
+	ds, err := ddb.db.GetDataset(ctx, noteRef.String())
+	if err != nil {
+		return nil, err
+	}
+	if !ds.HasHead() {
+		return nil, ErrNoteNotFound
+	}
+	if !ds.IsTag() {
+		return nil, fmt.Errorf("note ref %s does not point to a tag", noteRef.String())
+	}
+
+	meta, commitAddr, err := ds.HeadTag()
+	if err != nil {
+		return nil, err
+	}
+	return &Note{Commit: commitAddr, Meta: meta}, nil
+}
+
+// GetNotes returns every note in the database.
+func (ddb *DoltDB) GetNotes(ctx context.Context) ([]*Note, error) {
+	var notes []*Note
+	err := ddb.VisitRefsOfType(ctx, notesRefFilter, func(r ref.DoltRef, _ hash.Hash) error {
+		nr, ok := r.(ref.NoteRef)
+		if !ok {
+			return nil
+		}
+		note, err := ddb.resolveNote(ctx, nr)
+		if err != nil {
+			return err
+		}
+		notes = append(notes, note)
+		return nil
+	})
+	return notes, err
+}
+
+// GetNoteRefsWithHashes returns the ref of every note in the database along with the address of its tag object.
+func (ddb *DoltDB) GetNoteRefsWithHashes(ctx context.Context) ([]RefWithHash, error) {
+	var refs []RefWithHash
+	err := ddb.VisitRefsOfType(ctx, notesRefFilter, func(r ref.DoltRef, addr hash.Hash) error {
+		refs = append(refs, RefWithHash{r, addr})
+		return nil
+	})
+	return refs, err
+}
+
+// SetNote attaches a note to the commit given. If the commit already has a note, ErrNoteExists is returned unless
+// |force| is true, in which case the existing note is replaced.
+func (ddb *DoltDB) SetNote(ctx context.Context, c *Commit, meta *datas.TagMeta, force bool) error {
+	commitAddr, err := c.HashOf()
+	if err != nil {
+		return err
+	}
+
+	noteRef := ref.NewNoteRef(commitAddr.String())
+	ds, err := ddb.db.GetDataset(ctx, noteRef.String())
+	if err != nil {
+		return err
+	}
+
+	if ds.HasHead() {
+		if !force {
+			return fmt.Errorf("%w %s", ErrNoteExists, commitAddr.String())
+		}
+		// Tag objects cannot be altered once written, so the old note is removed before the new one is written.
+		ds, err = ddb.db.Delete(ctx, ds, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = ddb.db.Tag(ctx, ds, commitAddr, datas.TagOptions{Meta: meta})
+	return err
+}
+
+// DeleteNote removes the note attached to the commit with the given hash, or returns ErrNoteNotFound if it has none.
+func (ddb *DoltDB) DeleteNote(ctx context.Context, commit hash.Hash) error {
+	err := ddb.deleteRef(ctx, ref.NewNoteRef(commit.String()), nil, "")
+	if err == ErrBranchNotFound {
+		return fmt.Errorf("%w %s", ErrNoteNotFound, commit.String())
+	}
+	return err
+}
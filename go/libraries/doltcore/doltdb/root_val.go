@@ -90,7 +90,8 @@ type RootValue interface {
 	HasTable(ctx context.Context, tName TableName) (bool, error)
 	// IterRootObjects calls the callback function on each RootObject in this RootValue.
 	IterRootObjects(ctx context.Context, cb func(name TableName, rootObj RootObject) (stop bool, err error)) error
-	// IterTables calls the callback function cb on each table in this RootValue.
+	// IterTables calls the callback function cb on each table in this RootValue. Tables which were left out of a
+	// sparse clone are skipped.
 	IterTables(ctx context.Context, cb func(name TableName, table *Table, sch schema.Schema) (stop bool, err error)) error
 	// NodeStore returns this root's NodeStore.
 	NodeStore() tree.NodeStore
@@ -510,7 +511,13 @@ func (root *rootValue) SetTableHash(ctx context.Context, tName TableName, h hash
 		return nil, err
 	}
 
-	ref, err := types.NewRef(val, root.vrw.Format())
+	var ref types.Ref
+	if ghost, ok := val.(types.GhostValue); ok {
+		// the placeholder of a table left out of a sparse clone
+		ref, err = types.NewGhostRef(ghost, root.vrw.Format())
+	} else {
+		ref, err = types.NewRef(val, root.vrw.Format())
+	}
 
 	if err != nil {
 		return nil, err
@@ -561,7 +568,11 @@ func (root *rootValue) GetTable(ctx context.Context, tName TableName) (*Table, b
 		return nil, false, err
 	}
 
-	return GetTable(ctx, root, addr)
+	tbl, ok, err := GetTable(ctx, root, addr)
+	if errors.Is(err, durable.ErrSparseTable) {
+		return nil, false, fmt.Errorf("%w: %s", err, tName.Name)
+	}
+	return tbl, ok, err
 }
 
 func GetTable(ctx context.Context, root RootValue, addr hash.Hash) (*Table, bool, error) {
@@ -651,7 +662,10 @@ func TablesWithDataConflicts(ctx context.Context, root RootValue) ([]TableName,
 	conflicted := make([]TableName, 0, len(names))
 	for _, name := range names {
 		tbl, ok, err := root.GetTable(ctx, name)
-		if err != nil {
+		if errors.Is(err, ErrSparseTable) {
+			// the placeholder of a table left out of a sparse clone is unchanged from its remote
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 		if !ok {
@@ -694,7 +708,10 @@ func TablesWithConstraintViolations(ctx context.Context, root RootValue) ([]Tabl
 	violating := make([]TableName, 0, len(names))
 	for _, name := range names {
 		tbl, ok, err := root.GetTable(ctx, name)
-		if err != nil {
+		if errors.Is(err, ErrSparseTable) {
+			// the placeholder of a table left out of a sparse clone is unchanged from its remote
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 		if !ok {
@@ -751,7 +768,8 @@ func (root *rootValue) IterRootObjects(ctx context.Context, cb func(name TableNa
 	return nil
 }
 
-// IterTables calls the callback function cb on each table in this RootValue.
+// IterTables calls the callback function cb on each table in this RootValue. Tables which were left out of a sparse
+// clone are skipped.
 func (root *rootValue) IterTables(ctx context.Context, cb func(name TableName, table *Table, sch schema.Schema) (stop bool, err error)) error {
 	schemaNames, err := schemaNames(ctx, root)
 	if err != nil {
@@ -766,7 +784,9 @@ func (root *rootValue) IterTables(ctx context.Context, cb func(name TableName, t
 
 		err = tm.Iter(ctx, func(name string, addr hash.Hash) (bool, error) {
 			nt, err := durable.TableFromAddr(ctx, root.VRW(), root.ns, addr)
-			if err != nil {
+			if errors.Is(err, durable.ErrSparseTable) {
+				return false, nil
+			} else if err != nil {
 				return true, err
 			}
 			tbl := &Table{table: nt}
@@ -943,7 +963,7 @@ func (root *rootValue) putTable(ctx context.Context, tName TableName, ref types.
 	var preserveTablesHash bool
 	if root.tablesHash != 0 {
 		var err error
-		_, preserveTablesHash, err = root.GetTable(ctx, tName)
+		_, preserveTablesHash, err = root.GetTableHash(ctx, tName)
 		if err != nil {
 			return nil, err
 		}
@@ -1169,10 +1189,15 @@ func ValidateForeignKeysOnSchemas(ctx context.Context, root RootValue) (RootValu
 		return nil, err
 	}
 	allTablesSet := make(map[TableName]schema.Schema)
+	// the placeholders of tables left out of a sparse clone are unchanged from their remote, and were validated there
+	sparseTables := make(map[TableName]struct{})
 	var rootObjNamesMap map[TableName]struct{}
 	for _, tableName := range allTablesSlice {
 		tbl, ok, err := root.GetTable(ctx, tableName)
-		if err != nil {
+		if errors.Is(err, ErrSparseTable) {
+			sparseTables[tableName] = struct{}{}
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 		if !ok {
@@ -1201,6 +1226,11 @@ func ValidateForeignKeysOnSchemas(ctx context.Context, root RootValue) (RootValu
 	// some of these checks are sanity checks and should never happen
 	allForeignKeys := fkCollection.AllKeys()
 	for _, foreignKey := range allForeignKeys {
+		_, childSparse := sparseTables[foreignKey.TableName]
+		_, parentSparse := sparseTables[foreignKey.ReferencedTableName]
+		if childSparse || parentSparse {
+			continue
+		}
 		tblSch, existsInRoot := allTablesSet[foreignKey.TableName]
 		if existsInRoot {
 			if err := foreignKey.ValidateTableSchema(tblSch); err != nil {
@@ -0,0 +1,161 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrSparseTable is returned when reading a table whose data was left out of a sparse clone.
+var ErrSparseTable = durable.ErrSparseTable
+
+// PersistSparseTables finds the tables which are not in |tables| in the commits reachable from |commits| in |srcDB|,
+// and persists their addresses as placeholders in this database. Commits which this database already has are not
+// visited. Tables with the dolt_ prefix are always kept, so that views, triggers and the like keep working.
+//
+// The placeholders are present as far as pulls, pushes and commits are concerned, so only the kept tables are fetched,
+// and commits which reference the placeholders can be pushed to a remote which has their data. Reading a placeholder
+// returns ErrSparseTable. Returns the addresses of the placeholders, which should be skipped when pulling.
+func (ddb *DoltDB) PersistSparseTables(ctx context.Context, srcDB *DoltDB, commits []hash.Hash, tables []string) (hash.HashSet, error) {
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return nil, errors.New("sparse clones are not supported by this database's storage format")
+	}
+
+	kept := make(map[string]struct{}, len(tables))
+	for _, t := range tables {
+		kept[strings.ToLower(t)] = struct{}{}
+	}
+
+	keptAddrs := hash.NewHashSet()
+	sparseAddrs := hash.NewHashSet()
+	visited := hash.NewHashSet()
+	toVisit := append([]hash.Hash(nil), commits...)
+	for len(toVisit) > 0 {
+		h := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		if visited.Has(h) {
+			continue
+		}
+		visited.Insert(h)
+
+		has, err := ddb.Has(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if has {
+			continue
+		}
+
+		optCmt, err := srcDB.ReadCommit(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, ErrGhostCommitEncountered
+		}
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		toVisit = append(toVisit, parents...)
+
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names, err := root.GetTableNames(ctx, DefaultSchemaName)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			addr, ok, err := root.GetTableHash(ctx, TableName{Name: name})
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if _, ok := kept[strings.ToLower(name)]; ok || HasDoltPrefix(name) {
+				keptAddrs.Insert(addr)
+			} else {
+				sparseAddrs.Insert(addr)
+			}
+		}
+	}
+
+	// A table which is kept can have the same data as one which isn't.
+	for addr := range keptAddrs {
+		sparseAddrs.Remove(addr)
+	}
+	if sparseAddrs.Size() == 0 {
+		return sparseAddrs, nil
+	}
+
+	// Placeholders are persisted the same way as the ghost commits of a shallow clone.
+	err := ddb.db.Database.PersistGhostCommitIDs(ctx, sparseAddrs)
+	if err != nil {
+		return nil, err
+	}
+	return sparseAddrs, nil
+}
+
+// SparseTableNames returns the names of the tables in |root| which are placeholders for tables left out of a sparse
+// clone. It returns nil when this database has no placeholders.
+func (ddb *DoltDB) SparseTableNames(ctx context.Context, root RootValue, schemaName string) (map[string]struct{}, error) {
+	gcs, ok := datas.ChunkStoreFromDatabase(ddb.db).(chunks.GenerationalCS)
+	if !ok {
+		return nil, nil
+	}
+	ghosts, ok := gcs.GhostGen().(*nbs.GhostBlockStore)
+	if !ok || ghosts.IsEmpty() {
+		return nil, nil
+	}
+
+	names, err := root.GetTableNames(ctx, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	var sparse map[string]struct{}
+	for _, name := range names {
+		addr, ok, err := root.GetTableHash(ctx, TableName{Name: name, Schema: schemaName})
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		isGhost, err := ghosts.Has(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		if isGhost {
+			if sparse == nil {
+				sparse = make(map[string]struct{})
+			}
+			sparse[name] = struct{}{}
+		}
+	}
+	return sparse, nil
+}
@@ -0,0 +1,415 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// StorageUsageCategory is the kind of data held by the chunks counted in a StorageUsage.
+type StorageUsageCategory string
+
+const (
+	// StorageUsageRows is the row data of a table at a branch head, along with its schema.
+	StorageUsageRows StorageUsageCategory = "rows"
+	// StorageUsageIndex is the data of a secondary index of a table at a branch head.
+	StorageUsageIndex StorageUsageCategory = "index"
+	// StorageUsageBlobs is the BLOB, TEXT and JSON values of a table at a branch head which are stored out of its rows.
+	StorageUsageBlobs StorageUsageCategory = "blobs"
+	// StorageUsageWorkspace is data only reachable from working sets, i.e. changes which are not committed.
+	StorageUsageWorkspace StorageUsageCategory = "workspace"
+	// StorageUsageStash is data only reachable from stashes.
+	StorageUsageStash StorageUsageCategory = "stash"
+	// StorageUsageHistory is data only reachable from commits which are not branch heads, and from tags and remote
+	// tracking branches.
+	StorageUsageHistory StorageUsageCategory = "history"
+	// StorageUsageMetadata is commits, refs, and the root values of branch heads.
+	StorageUsageMetadata StorageUsageCategory = "metadata"
+	// StorageUsageGarbage is data which is not reachable at all, and which is removed by garbage collection.
+	StorageUsageGarbage StorageUsageCategory = "garbage"
+)
+
+// storageUsageCategories is the order in which StorageUsage entries are reported.
+var storageUsageCategories = []StorageUsageCategory{
+	StorageUsageRows,
+	StorageUsageIndex,
+	StorageUsageBlobs,
+	StorageUsageWorkspace,
+	StorageUsageStash,
+	StorageUsageHistory,
+	StorageUsageMetadata,
+	StorageUsageGarbage,
+}
+
+// metadataFileIDs are the messages which are counted as StorageUsageMetadata, wherever they are reached from.
+var metadataFileIDs = map[string]struct{}{
+	serial.StoreRootFileID:     {},
+	serial.CommitFileID:        {},
+	serial.CommitClosureFileID: {},
+	serial.TagFileID:           {},
+	serial.WorkingSetFileID:    {},
+	serial.StashListFileID:     {},
+	serial.TupleFileID:         {},
+}
+
+// storageUsageBatchSz is the number of chunks whose sizes are looked up at a time when counting garbage.
+const storageUsageBatchSz = 64 * 1024
+
+// StorageUsage is the number of chunks, and the bytes they take up on disk, used by one kind of data in a database.
+type StorageUsage struct {
+	Category StorageUsageCategory `json:"category"`
+	// Table is the table the data belongs to, for the rows, index and blobs categories.
+	Table string `json:"table,omitempty"`
+	// Index is the secondary index the data belongs to, for the index category.
+	Index  string `json:"index,omitempty"`
+	Chunks uint64 `json:"chunks"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+type storageUsageKey struct {
+	category StorageUsageCategory
+	table    string
+	index    string
+}
+
+// GetStorageUsage attributes every chunk in the database to the kind of data it holds. The chunk graph is walked from
+// the tables of each branch head, then from working sets and stashes, and lastly from every other ref. Each chunk is
+// counted once, for the first kind of data it is reached from, so data shared by a branch head and older commits is
+// counted as the branch head's data. Chunks which aren't reachable at all are counted as garbage. Entries are ordered
+// by category, then by table and index.
+func (ddb *DoltDB) GetStorageUsage(ctx context.Context) ([]StorageUsage, error) {
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return nil, errors.New("storage usage is only supported for databases in the __DOLT__ format")
+	}
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	gs, ok := cs.(*nbs.GenerationalNBS)
+	if !ok {
+		return nil, errors.New("storage usage requires a local database")
+	}
+	walkAddrs, err := types.WalkAddrsForChunkStore(cs)
+	if err != nil {
+		return nil, err
+	}
+	w := &storageUsageWalker{
+		cs:        gs,
+		walkAddrs: walkAddrs,
+		claimed:   hash.NewHashSet(),
+		usage:     make(map[storageUsageKey]*StorageUsage),
+	}
+
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		cm, err := ddb.ResolveCommitRef(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err = w.walkRoot(ctx, root); err != nil {
+			return nil, err
+		}
+	}
+
+	var workingSets, stashes []hash.Hash
+	dss, err := ddb.db.Datasets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = dss.IterAll(ctx, func(id string, addr hash.Hash) error {
+		if ref.IsWorkingSet(id) {
+			workingSets = append(workingSets, addr)
+		} else if strings.HasPrefix(id, ref.PrefixForType(ref.StashRefType)) {
+			stashes = append(stashes, addr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range workingSets {
+		if err = w.walk(ctx, addr, storageUsageKey{category: StorageUsageWorkspace}); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range stashes {
+		if err = w.walk(ctx, addr, storageUsageKey{category: StorageUsageStash}); err != nil {
+			return nil, err
+		}
+	}
+
+	nomsRoot, err := ddb.NomsRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = w.walk(ctx, nomsRoot, storageUsageKey{category: StorageUsageHistory}); err != nil {
+		return nil, err
+	}
+
+	if err = w.countGarbage(ctx); err != nil {
+		return nil, err
+	}
+	return w.results(), nil
+}
+
+// storageUsageWalker walks the chunk graph of a database, claiming each chunk for the first kind of data it is
+// reached from.
+type storageUsageWalker struct {
+	cs        *nbs.GenerationalNBS
+	walkAddrs func(chunks.Chunk, func(h hash.Hash, isleaf bool) error) error
+	claimed   hash.HashSet
+	usage     map[storageUsageKey]*StorageUsage
+}
+
+// walkRoot claims the chunks of each table in |root| for its indexes, its rows and its out of line values, and then
+// the rest of |root| as metadata.
+func (w *storageUsageWalker) walkRoot(ctx context.Context, root RootValue) error {
+	err := root.IterTables(ctx, func(name TableName, tbl *Table, sch schema.Schema) (bool, error) {
+		tblName := name.String()
+		indexes, err := tbl.GetIndexSet(ctx)
+		if err != nil {
+			return true, err
+		}
+		for _, def := range sch.Indexes().AllIndexes() {
+			idx, err := indexes.GetIndex(ctx, sch, nil, def.Name())
+			if err != nil {
+				return true, err
+			}
+			addr, err := idx.HashOf()
+			if err != nil {
+				return true, err
+			}
+			err = w.walk(ctx, addr, storageUsageKey{category: StorageUsageIndex, table: tblName, index: def.Name()})
+			if err != nil {
+				return true, err
+			}
+		}
+
+		// the primary index is embedded in the table, so its chunks are reached from the table itself
+		addr, err := tbl.HashOf()
+		if err != nil {
+			return true, err
+		}
+		return false, w.walk(ctx, addr, storageUsageKey{category: StorageUsageRows, table: tblName})
+	})
+	if err != nil {
+		return err
+	}
+
+	addr, err := root.HashOf()
+	if err != nil {
+		return err
+	}
+	return w.walk(ctx, addr, storageUsageKey{category: StorageUsageMetadata})
+}
+
+// walk claims every unclaimed chunk reachable from |start| for |key|, level by level. Out of line values, and the
+// chunks they reach, are claimed for the blobs of |key|'s table, and the messages in metadataFileIDs as metadata.
+// Walks which aren't of history stop at commits, so that the commit graph is left to the history walk.
+func (w *storageUsageWalker) walk(ctx context.Context, start hash.Hash, key storageUsageKey) error {
+	next := map[hash.Hash]storageUsageParent{start: {}}
+	for len(next) > 0 {
+		cur := hash.NewHashSet()
+		for h := range next {
+			cur.Insert(h)
+		}
+		parents := next
+		next = make(map[hash.Hash]storageUsageParent)
+		visited := make(map[hash.Hash]storageUsageKey)
+
+		var mu sync.Mutex
+		var walkErr error
+		err := w.cs.GetMany(ctx, cur, func(ctx context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			if walkErr != nil || w.claimed.Has(c.Hash()) {
+				return
+			}
+			fileID := serial.GetFileID(c.Data())
+			if fileID == serial.CommitFileID && key.category != StorageUsageHistory {
+				return
+			}
+
+			w.claimed.Insert(c.Hash())
+			parent := parents[c.Hash()]
+			inValue := parent.inValue || isOutOfLineValue(parent.fileID, fileID)
+			k := key
+			if _, ok := metadataFileIDs[fileID]; ok {
+				k = storageUsageKey{category: StorageUsageMetadata}
+			} else if inValue && key.table != "" {
+				k = storageUsageKey{category: StorageUsageBlobs, table: key.table}
+			}
+			visited[c.Hash()] = k
+
+			walkErr = w.walkAddrs(*c, func(addr hash.Hash, _ bool) error {
+				if !w.claimed.Has(addr) {
+					next[addr] = storageUsageParent{fileID: fileID, inValue: inValue}
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+		if walkErr != nil {
+			return walkErr
+		}
+
+		addrs := hash.NewHashSet()
+		for h := range visited {
+			addrs.Insert(h)
+		}
+		sizes, err := w.chunkSizes(ctx, addrs)
+		if err != nil {
+			return err
+		}
+		for h, k := range visited {
+			w.add(k, sizes[h])
+		}
+	}
+	return nil
+}
+
+// storageUsageParent is the chunk an address was reached from during a walk.
+type storageUsageParent struct {
+	fileID string
+	// inValue is true if the parent is part of an out of line value.
+	inValue bool
+}
+
+// isOutOfLineValue returns true if a chunk with |fileID| which is referenced by a chunk with |parentFileID| holds an
+// out of line value. Leaf nodes of prolly trees, and tables, whose primary index root is embedded, reference BLOB,
+// TEXT and JSON values which are stored out of line. Those are stored as blob trees, or for JSON, as address maps over
+// blobs.
+func isOutOfLineValue(parentFileID, fileID string) bool {
+	switch parentFileID {
+	case serial.TableFileID, serial.ProllyTreeNodeFileID, serial.VectorIndexNodeFileID:
+		return fileID == serial.BlobFileID || fileID == serial.AddressMapFileID
+	default:
+		return false
+	}
+}
+
+// countGarbage counts every chunk in the store which wasn't claimed by a walk as garbage.
+func (w *storageUsageWalker) countGarbage(ctx context.Context) error {
+	garbage := hash.NewHashSet()
+	err := w.cs.IterateAllChunks(ctx, func(c chunks.Chunk) {
+		if !w.claimed.Has(c.Hash()) {
+			garbage.Insert(c.Hash())
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	key := storageUsageKey{category: StorageUsageGarbage}
+	batch := hash.NewHashSet()
+	flush := func() error {
+		sizes, err := w.chunkSizes(ctx, batch)
+		if err != nil {
+			return err
+		}
+		for h := range batch {
+			w.add(key, sizes[h])
+		}
+		batch = hash.NewHashSet()
+		return nil
+	}
+	for h := range garbage {
+		batch.Insert(h)
+		if batch.Size() >= storageUsageBatchSz {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// chunkSizes returns the number of bytes each chunk in |addrs| takes up in the table files of the store.
+func (w *storageUsageWalker) chunkSizes(ctx context.Context, addrs hash.HashSet) (map[hash.Hash]uint32, error) {
+	sizes := make(map[hash.Hash]uint32, addrs.Size())
+	if addrs.Size() == 0 {
+		return sizes, nil
+	}
+	// GetChunkLocations removes the chunks it finds from the set it is given
+	locs, err := w.cs.GetChunkLocations(ctx, addrs.Copy())
+	if err != nil {
+		return nil, err
+	}
+	for _, ranges := range locs {
+		for h, r := range ranges {
+			sizes[h] = r.Length
+		}
+	}
+	return sizes, nil
+}
+
+func (w *storageUsageWalker) add(key storageUsageKey, sz uint32) {
+	u, ok := w.usage[key]
+	if !ok {
+		u = &StorageUsage{Category: key.category, Table: key.table, Index: key.index}
+		w.usage[key] = u
+	}
+	u.Chunks++
+	u.Bytes += uint64(sz)
+}
+
+// results returns the usage of each kind of data, with an entry for every category which isn't specific to a table
+// even if it has no chunks.
+func (w *storageUsageWalker) results() []StorageUsage {
+	order := make(map[StorageUsageCategory]int, len(storageUsageCategories))
+	for i, c := range storageUsageCategories {
+		order[c] = i
+		if c != StorageUsageRows && c != StorageUsageIndex && c != StorageUsageBlobs {
+			key := storageUsageKey{category: c}
+			if _, ok := w.usage[key]; !ok {
+				w.usage[key] = &StorageUsage{Category: c}
+			}
+		}
+	}
+
+	res := make([]StorageUsage, 0, len(w.usage))
+	for _, u := range w.usage {
+		res = append(res, *u)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Category != res[j].Category {
+			return order[res[i].Category] < order[res[j].Category]
+		}
+		if res[i].Table != res[j].Table {
+			return res[i].Table < res[j].Table
+		}
+		return res[i].Index < res[j].Index
+	})
+	return res
+}
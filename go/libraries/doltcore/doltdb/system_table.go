@@ -159,6 +159,11 @@ var getWriteableSystemTables = func() []string {
 		WorkflowStepsTableName,
 		WorkflowSavedQueryStepsTableName,
 		WorkflowSavedQueryStepExpectedRowColumnResultsTableName,
+		WorkflowSqlAssertionStepsTableName,
+		WorkflowSchemaAssertionStepsTableName,
+		WorkflowRowCountStepsTableName,
+		WorkflowDiffSizeStepsTableName,
+		WorkflowVerifyConstraintsStepsTableName,
 	}
 }
 
@@ -178,6 +183,11 @@ func GeneratedSystemTableNames() []string {
 		GetHelpTableName(),
 		GetBackupsTableName(),
 		GetStashesTableName(),
+		GetCIRunsTableName(),
+		GetNotesTableName(),
+		GetStorageUsageTableName(),
+		GetBranchProtectionTableName(),
+		GetSlowQueriesTableName(),
 	}
 }
 
@@ -379,6 +389,21 @@ var GetTagsTableName = func() string {
 	return TagsTableName
 }
 
+// GetNotesTableName returns the notes table name
+var GetNotesTableName = func() string {
+	return NotesTableName
+}
+
+// GetStorageUsageTableName returns the storage usage table name
+var GetStorageUsageTableName = func() string {
+	return StorageUsageTableName
+}
+
+// GetBranchProtectionTableName returns the branch protection table name
+var GetBranchProtectionTableName = func() string {
+	return BranchProtectionTableName
+}
+
 // GetHelpTableName returns the help table name
 var GetHelpTableName = func() string {
 	return HelpTableName
@@ -388,6 +413,16 @@ var GetBackupsTableName = func() string {
 	return BackupsTableName
 }
 
+// GetCIRunsTableName returns the ci runs table name
+var GetCIRunsTableName = func() string {
+	return CIRunsTableName
+}
+
+// GetSlowQueriesTableName returns the slow queries table name
+var GetSlowQueriesTableName = func() string {
+	return SlowQueriesTableName
+}
+
 var GetStashesTableName = func() string {
 	return StashesTableName
 }
@@ -435,6 +470,15 @@ const (
 	// TagsTableName is the tags table name
 	TagsTableName = "dolt_tags"
 
+	// NotesTableName is the notes table name
+	NotesTableName = "dolt_notes"
+
+	// StorageUsageTableName is the storage usage table name
+	StorageUsageTableName = "dolt_storage_usage"
+
+	// BranchProtectionTableName is the branch protection rules table name
+	BranchProtectionTableName = "dolt_branch_protection"
+
 	// IgnoreTableName is the ignore table name
 	IgnoreTableName = "dolt_ignore"
 
@@ -604,6 +648,90 @@ const (
 
 	// WorkflowSavedQueryStepExpectedRowColumnResultsUpdatedAtColName is the name of the updated at column on the workflow saved query step expected row column results table
 	WorkflowSavedQueryStepExpectedRowColumnResultsUpdatedAtColName = "updated_at"
+
+	// WorkflowSqlAssertionStepsTableName is the name of the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsTableName = "dolt_ci_workflow_sql_assertion_steps"
+
+	// WorkflowSqlAssertionStepsIdPkColName is the name of the id column on the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsIdPkColName = "id"
+
+	// WorkflowSqlAssertionStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowSqlAssertionStepsQueryColName is the name of the query column on the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsQueryColName = "query"
+
+	// WorkflowSqlAssertionStepsExpectedResultsColName is the name of the expected results column on the workflow sql assertion steps table
+	WorkflowSqlAssertionStepsExpectedResultsColName = "expected_results"
+
+	// WorkflowSchemaAssertionStepsTableName is the name of the workflow schema assertion steps table
+	WorkflowSchemaAssertionStepsTableName = "dolt_ci_workflow_schema_assertion_steps"
+
+	// WorkflowSchemaAssertionStepsIdPkColName is the name of the id column on the workflow schema assertion steps table
+	WorkflowSchemaAssertionStepsIdPkColName = "id"
+
+	// WorkflowSchemaAssertionStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow schema assertion steps table
+	WorkflowSchemaAssertionStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowSchemaAssertionStepsTableNameColName is the name of the table name column on the workflow schema assertion steps table
+	WorkflowSchemaAssertionStepsTableNameColName = "table_name"
+
+	// WorkflowSchemaAssertionStepsExpectedColumnsColName is the name of the expected columns column on the workflow schema assertion steps table
+	WorkflowSchemaAssertionStepsExpectedColumnsColName = "expected_columns"
+
+	// WorkflowRowCountStepsTableName is the name of the workflow row count steps table
+	WorkflowRowCountStepsTableName = "dolt_ci_workflow_row_count_steps"
+
+	// WorkflowRowCountStepsIdPkColName is the name of the id column on the workflow row count steps table
+	WorkflowRowCountStepsIdPkColName = "id"
+
+	// WorkflowRowCountStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow row count steps table
+	WorkflowRowCountStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowRowCountStepsBaseRefColName is the name of the base ref column on the workflow row count steps table. If it is set, the row count is compared against the count at the merge base of this ref and HEAD.
+	WorkflowRowCountStepsBaseRefColName = "base_ref"
+
+	// WorkflowRowCountStepsTableNameColName is the name of the table name column on the workflow row count steps table
+	WorkflowRowCountStepsTableNameColName = "table_name"
+
+	// WorkflowRowCountStepsComparisonTypeColName is the name of the comparison type column on the workflow row count steps table
+	WorkflowRowCountStepsComparisonTypeColName = "comparison_type"
+
+	// WorkflowRowCountStepsRowCountColName is the name of the row count column on the workflow row count steps table
+	WorkflowRowCountStepsRowCountColName = "row_count"
+
+	// WorkflowDiffSizeStepsTableName is the name of the workflow diff size steps table
+	WorkflowDiffSizeStepsTableName = "dolt_ci_workflow_diff_size_steps"
+
+	// WorkflowDiffSizeStepsIdPkColName is the name of the id column on the workflow diff size steps table
+	WorkflowDiffSizeStepsIdPkColName = "id"
+
+	// WorkflowDiffSizeStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow diff size steps table
+	WorkflowDiffSizeStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowDiffSizeStepsBaseRefColName is the name of the base ref column on the workflow diff size steps table. The diff is computed from the merge base of this ref and HEAD.
+	WorkflowDiffSizeStepsBaseRefColName = "base_ref"
+
+	// WorkflowDiffSizeStepsTableNameColName is the name of the table name column on the workflow diff size steps table
+	WorkflowDiffSizeStepsTableNameColName = "table_name"
+
+	// WorkflowDiffSizeStepsComparisonTypeColName is the name of the comparison type column on the workflow diff size steps table
+	WorkflowDiffSizeStepsComparisonTypeColName = "comparison_type"
+
+	// WorkflowDiffSizeStepsRowCountColName is the name of the changed row count column on the workflow diff size steps table
+	WorkflowDiffSizeStepsRowCountColName = "row_count"
+
+	// WorkflowVerifyConstraintsStepsTableName is the name of the workflow verify constraints steps table
+	WorkflowVerifyConstraintsStepsTableName = "dolt_ci_workflow_verify_constraints_steps"
+
+	// WorkflowVerifyConstraintsStepsIdPkColName is the name of the id column on the workflow verify constraints steps table
+	WorkflowVerifyConstraintsStepsIdPkColName = "id"
+
+	// WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName is the name of the workflow step id foreign key column on the workflow verify constraints steps table
+	WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName = "workflow_step_id_fk"
+
+	// WorkflowVerifyConstraintsStepsTablesColName is the name of the tables column on the workflow verify constraints steps table
+	WorkflowVerifyConstraintsStepsTablesColName = "tables"
 )
 
 const (
@@ -625,4 +753,8 @@ const (
 const (
 	HelpTableName    = "dolt_help"
 	BackupsTableName = "dolt_backups"
+	// CIRunsTableName is the name of the table containing the results of workflows run by sql-server on branch updates
+	CIRunsTableName = "dolt_ci_runs"
+	// SlowQueriesTableName is the name of the table containing the queries recorded by sql-server's slow query log
+	SlowQueriesTableName = "dolt_slow_queries"
 )
@@ -120,6 +120,17 @@ func (rs RebaseState) WithRebasingStarted(rebasingStarted bool) *RebaseState {
 	return &rs
 }
 
+// BisectState tracks the state of a bisect session, which is started by dolt_bisect('start') and ended by
+// dolt_bisect('reset'). It records the commits which have been marked bad, good and skipped, and the commit to test
+// next. Empty hashes are commits that haven't been given or determined yet.
+type BisectState struct {
+	Bad  hash.Hash
+	Good []hash.Hash
+	Skip []hash.Hash
+	// Current is the commit that should be tested next.
+	Current hash.Hash
+}
+
 type MergeState struct {
 	// the source commit
 	commit *Commit
@@ -265,6 +276,7 @@ type WorkingSet struct {
 	stagedRoot  RootValue
 	mergeState  *MergeState
 	rebaseState *RebaseState
+	bisectState *BisectState
 }
 
 var _ Rootish = &WorkingSet{}
@@ -296,6 +308,13 @@ func (ws WorkingSet) WithRebaseState(rebaseState *RebaseState) *WorkingSet {
 	return &ws
 }
 
+// WithBisectState returns a copy of this working set with the bisect session state given. A nil |bisectState| ends
+// the bisect session.
+func (ws WorkingSet) WithBisectState(bisectState *BisectState) *WorkingSet {
+	ws.bisectState = bisectState
+	return &ws
+}
+
 func (ws WorkingSet) WithUnmergableTables(tables []TableName) *WorkingSet {
 	ws.mergeState.unmergableTables = tables
 	return &ws
@@ -395,6 +414,11 @@ func (ws *WorkingSet) RebaseState() *RebaseState {
 	return ws.rebaseState
 }
 
+// BisectState returns the state of the bisect session in progress, or nil if there is none.
+func (ws *WorkingSet) BisectState() *BisectState {
+	return ws.bisectState
+}
+
 func (ws *WorkingSet) MergeActive() bool {
 	return ws.mergeState != nil
 }
@@ -551,6 +575,16 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 		}
 	}
 
+	var bisectState *BisectState
+	if dsws.BisectState != nil {
+		bisectState = &BisectState{
+			Bad:     dsws.BisectState.BadCommitAddr(),
+			Good:    dsws.BisectState.GoodCommitAddrs(),
+			Skip:    dsws.BisectState.SkipCommitAddrs(),
+			Current: dsws.BisectState.CurrentCommitAddr(),
+		}
+	}
+
 	addr, _ := ds.MaybeHeadAddr()
 
 	return &WorkingSet{
@@ -561,6 +595,7 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 		stagedRoot:  stagedRoot,
 		mergeState:  mergeState,
 		rebaseState: rebaseState,
+		bisectState: bisectState,
 	}, nil
 }
 
@@ -648,11 +683,17 @@ func (ws *WorkingSet) writeValues(ctx context.Context, db *DoltDB, meta *datas.W
 			ws.rebaseState.lastAttemptedStep, ws.rebaseState.rebasingStarted)
 	}
 
+	var bisectState *datas.BisectState
+	if ws.bisectState != nil {
+		bisectState = datas.NewBisectState(ws.bisectState.Bad, ws.bisectState.Good, ws.bisectState.Skip, ws.bisectState.Current)
+	}
+
 	return &datas.WorkingSetSpec{
 		Meta:        meta,
 		WorkingRoot: workingRoot,
 		StagedRoot:  stagedRoot,
 		MergeState:  mergeState,
 		RebaseState: rebaseState,
+		BisectState: bisectState,
 	}, nil
 }
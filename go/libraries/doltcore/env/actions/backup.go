@@ -0,0 +1,187 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrNoBackupHistory is returned when restoring a backup to a point in time, and the backup has no root log.
+var ErrNoBackupHistory = errors.New("backup has no point-in-time history")
+
+// ErrNoRootAtTime is returned when restoring a backup to a point in time before the first root in its root log.
+var ErrNoRootAtTime = errors.New("backup has no root recorded at or before the given time")
+
+// SyncRootsWithHistory copies the chunks of |srcDb| to the backup |destDb| and sets the root of the backup to the root
+// of |srcDb|, like SyncRoots. Along with the current root, the roots recorded in the chunk journal of |srcDb| since the
+// last sync are copied, and they are added to the root log of the backup. The backup can then be restored to any of
+// those roots with RestoreBackup. Returns pull.ErrDBUpToDate if the backup already has the current root.
+func SyncRootsWithHistory(ctx context.Context, srcDb, destDb *doltdb.DoltDB, tempTableDir string, progStarter ProgStarter, progStopper ProgStopper) error {
+	srcRoot, err := srcDb.NomsRoot(ctx)
+	if err != nil {
+		return err
+	}
+	destRoot, err := destDb.NomsRoot(ctx)
+	if err != nil {
+		return err
+	}
+	rootLog, _, err := destDb.BackupRootLog(ctx)
+	if err != nil {
+		return err
+	}
+	journal, err := srcDb.JournalRootLog(ctx)
+	if err != nil {
+		return err
+	}
+
+	added := newRootLogEntries(rootLog, journal, srcRoot, time.Now())
+	if len(added) == 0 {
+		return pull.ErrDBUpToDate
+	}
+
+	targets := make([]hash.Hash, 0, len(added))
+	seen := hash.NewHashSet()
+	for _, e := range added {
+		if !seen.Has(e.Root) {
+			seen.Insert(e.Root)
+			targets = append(targets, e.Root)
+		}
+	}
+
+	newCtx, cancelFunc := context.WithCancel(ctx)
+	wg, statsCh := progStarter(newCtx)
+	defer func() {
+		progStopper(cancelFunc, wg, statsCh)
+		if err == nil {
+			cli.Println()
+		}
+	}()
+
+	err = destDb.PullChunks(ctx, tempTableDir, srcDb, targets, statsCh, nil)
+	if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
+		return err
+	}
+
+	rootLog = append(rootLog, added...)
+	err = commitRootWithRetries(ctx, destDb, destRoot, func(last hash.Hash) (bool, error) {
+		return destDb.CommitBackupRoot(ctx, srcRoot, rootLog, last)
+	})
+	return err
+}
+
+// newRootLogEntries returns the entries of |journal| which were written after the last entry of |rootLog|, ending
+// with |srcRoot|. If the journal doesn't end with |srcRoot|, it is added with the time |now|. Returns nothing if the
+// last entry of |rootLog| is |srcRoot|.
+func newRootLogEntries(rootLog, journal []doltdb.RootLogEntry, srcRoot hash.Hash, now time.Time) []doltdb.RootLogEntry {
+	var last doltdb.RootLogEntry
+	if len(rootLog) > 0 {
+		last = rootLog[len(rootLog)-1]
+		if last.Root == srcRoot {
+			return nil
+		}
+	}
+
+	start := 0
+	if len(rootLog) > 0 {
+		found := false
+		for i := len(journal) - 1; i >= 0; i-- {
+			if journal[i].Root == last.Root {
+				start, found = i+1, true
+				break
+			}
+		}
+		if !found {
+			// the journal was replaced since the last sync, by garbage collection for example
+			for start < len(journal) && journal[start].Time.Before(last.Time) {
+				start++
+			}
+		}
+	}
+
+	added := append([]doltdb.RootLogEntry(nil), journal[start:]...)
+	if len(added) == 0 || added[len(added)-1].Root != srcRoot {
+		added = append(added, doltdb.RootLogEntry{Root: srcRoot, Time: now})
+	}
+	// keep the root log in time order, even if the clock went backwards
+	for i := range added {
+		if added[i].Time.Before(last.Time) {
+			added[i].Time = last.Time
+		}
+		last = added[i]
+	}
+	return added
+}
+
+// RestoreBackup copies the contents of the backup |srcDb| to |destDb|. If |at| is nil, the latest state of the backup
+// is restored. Otherwise, the database is restored to the last root in the backup's root log which was written at or
+// before |at|, which requires a backup synced with SyncRootsWithHistory.
+func RestoreBackup(ctx context.Context, srcDb, destDb *doltdb.DoltDB, at *time.Time, tempTableDir string, progStarter ProgStarter, progStopper ProgStopper) error {
+	rootLog, ok, err := srcDb.BackupRootLog(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok || len(rootLog) == 0 {
+		if at != nil {
+			return ErrNoBackupHistory
+		}
+		return SyncRoots(ctx, srcDb, destDb, tempTableDir, progStarter, progStopper)
+	}
+
+	entry := rootLog[len(rootLog)-1]
+	if at != nil {
+		i := len(rootLog) - 1
+		for i >= 0 && rootLog[i].Time.After(*at) {
+			i--
+		}
+		if i < 0 {
+			return fmt.Errorf("%w; the first root was recorded at %s", ErrNoRootAtTime, rootLog[0].Time.UTC().Format(time.DateTime))
+		}
+		entry = rootLog[i]
+	}
+
+	destRoot, err := destDb.NomsRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if entry.Root == destRoot {
+		return pull.ErrDBUpToDate
+	}
+
+	newCtx, cancelFunc := context.WithCancel(ctx)
+	wg, statsCh := progStarter(newCtx)
+	defer func() {
+		progStopper(cancelFunc, wg, statsCh)
+		if err == nil {
+			cli.Println()
+		}
+	}()
+
+	err = destDb.PullChunks(ctx, tempTableDir, srcDb, []hash.Hash{entry.Root}, statsCh, nil)
+	if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
+		return err
+	}
+	err = commitRootWithRetries(ctx, destDb, destRoot, func(last hash.Hash) (bool, error) {
+		return destDb.CommitRoot(ctx, entry.Root, last)
+	})
+	return err
+}
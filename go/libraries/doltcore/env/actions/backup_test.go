@@ -0,0 +1,103 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestNewRootLogEntries(t *testing.T) {
+	r := func(s string) hash.Hash {
+		return hash.Of([]byte(s))
+	}
+	e := func(s string, secs int64) doltdb.RootLogEntry {
+		return doltdb.RootLogEntry{Root: r(s), Time: time.Unix(secs, 0)}
+	}
+	now := time.Unix(100, 0)
+
+	tests := []struct {
+		name     string
+		rootLog  []doltdb.RootLogEntry
+		journal  []doltdb.RootLogEntry
+		srcRoot  hash.Hash
+		expected []doltdb.RootLogEntry
+	}{
+		{
+			name:     "first sync",
+			journal:  []doltdb.RootLogEntry{e("a", 1), e("b", 2)},
+			srcRoot:  r("b"),
+			expected: []doltdb.RootLogEntry{e("a", 1), e("b", 2)},
+		},
+		{
+			name:     "no journal",
+			srcRoot:  r("a"),
+			expected: []doltdb.RootLogEntry{e("a", 100)},
+		},
+		{
+			name:    "up to date",
+			rootLog: []doltdb.RootLogEntry{e("a", 1), e("b", 2)},
+			journal: []doltdb.RootLogEntry{e("a", 1), e("b", 2)},
+			srcRoot: r("b"),
+		},
+		{
+			name:     "roots since last sync",
+			rootLog:  []doltdb.RootLogEntry{e("a", 1)},
+			journal:  []doltdb.RootLogEntry{e("a", 1), e("b", 2), e("c", 3)},
+			srcRoot:  r("c"),
+			expected: []doltdb.RootLogEntry{e("b", 2), e("c", 3)},
+		},
+		{
+			name:     "last synced root written again",
+			rootLog:  []doltdb.RootLogEntry{e("a", 1), e("b", 2)},
+			journal:  []doltdb.RootLogEntry{e("a", 1), e("b", 2), e("a", 3), e("b", 4), e("c", 5)},
+			srcRoot:  r("c"),
+			expected: []doltdb.RootLogEntry{e("c", 5)},
+		},
+		{
+			name:     "journal replaced since last sync",
+			rootLog:  []doltdb.RootLogEntry{e("a", 1), e("b", 4)},
+			journal:  []doltdb.RootLogEntry{e("x", 3), e("c", 5)},
+			srcRoot:  r("c"),
+			expected: []doltdb.RootLogEntry{e("c", 5)},
+		},
+		{
+			name:     "current root not in journal",
+			rootLog:  []doltdb.RootLogEntry{e("a", 1)},
+			journal:  []doltdb.RootLogEntry{e("a", 1), e("b", 2)},
+			srcRoot:  r("c"),
+			expected: []doltdb.RootLogEntry{e("b", 2), e("c", 100)},
+		},
+		{
+			name:     "clock went backwards",
+			rootLog:  []doltdb.RootLogEntry{e("a", 10)},
+			journal:  []doltdb.RootLogEntry{e("a", 10), e("b", 5)},
+			srcRoot:  r("b"),
+			expected: []doltdb.RootLogEntry{e("b", 10)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := newRootLogEntries(test.rootLog, test.journal, test.srcRoot, now)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -165,9 +166,11 @@ func sortedKeys(m map[string]iohelp.ReadStats) []string {
 // CloneRemote - common entry point for both dolt_clone() and `dolt clone`
 // The database must be initialized with a remote before calling this function.
 //
-// The `branch` parameter is the branch to clone. If it is empty, the default branch is used.
-func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch bool, depth int, dEnv *env.DoltEnv) error {
-	// We support two forms of cloning: full and shallow. These two approaches have little in common, with the exception
+// The `branch` parameter is the branch to clone. If it is empty, the default branch is used. If `lazy` is true, only
+// the data of the checked out commit is fetched, and the rest is fetched from the remote as it is read. If the remote
+// has a list of tables, only the data of those tables is fetched.
+func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch bool, depth int, lazy bool, dEnv *env.DoltEnv) error {
+	// We support four forms of cloning: full, shallow, lazy and sparse. These approaches have little in common, with the exception
 	// of the first and last steps. Determining the branch to check out and setting the working set to the checked out commit.
 
 	srcRefHashes, branch, err := getSrcRefs(ctx, branch, srcDB, dEnv)
@@ -184,10 +187,20 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 		remoteName = "origin"
 	}
 
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return err
+	}
+	remote, _ := remotes.Get(remoteName)
+
 	var checkedOutCommit *doltdb.Commit
 
 	// Step 1) Pull the remote information we care about to a local disk.
-	if depth <= 0 {
+	if lazy {
+		checkedOutCommit, err = lazyClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
+	} else if len(remote.Tables) > 0 {
+		checkedOutCommit, err = sparseClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch, remote.Tables)
+	} else if depth <= 0 {
 		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
 	} else {
 		checkedOutCommit, err = shallowCloneDataPull(ctx, dEnv.DbData(ctx), srcDB, remoteName, branch, depth)
@@ -322,6 +335,196 @@ func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, src
 	return cm, nil
 }
 
+// cloneRefs returns the branch and tag refs in |srcRefHashes| which a clone of |branch| keeps, and the commit at the
+// head of |branch|.
+func cloneRefs(srcRefHashes []doltdb.RefWithHash, branch string, singleBranch bool) ([]doltdb.RefWithHash, hash.Hash, error) {
+	var head hash.Hash
+	var refs []doltdb.RefWithHash
+	for _, refHash := range srcRefHashes {
+		switch refHash.Ref.GetType() {
+		case ref.BranchRefType:
+			if singleBranch && refHash.Ref.GetPath() != branch {
+				continue
+			}
+			if refHash.Ref.GetPath() == branch {
+				head = refHash.Hash
+			}
+		case ref.TagRefType:
+		default:
+			continue
+		}
+		refs = append(refs, refHash)
+	}
+	if head.IsEmpty() {
+		return nil, hash.Hash{}, fmt.Errorf("%w: %s", ErrFailedToCreateLocalBranch, branch)
+	}
+	return refs, head, nil
+}
+
+// setClonedRefs creates a remote ref for each branch in |refs|, the local branch |branch| and each tag, and returns the
+// commit at the head of |branch|.
+func setClonedRefs(ctx context.Context, ddb *doltdb.DoltDB, refs []doltdb.RefWithHash, head hash.Hash, branch, remoteName string) (*doltdb.Commit, error) {
+	for _, refHash := range refs {
+		r := refHash.Ref
+		if r.GetType() == ref.BranchRefType {
+			remoteRef := ref.NewRemoteRef(remoteName, r.GetPath())
+			err := ddb.SetHead(ctx, remoteRef, refHash.Hash)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateRemoteRef, remoteRef.String(), err.Error())
+			}
+		}
+		if r.GetType() == ref.TagRefType || r.GetPath() == branch {
+			err := ddb.SetHead(ctx, r, refHash.Hash)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateLocalBranch, r.String(), err.Error())
+			}
+		}
+	}
+
+	optCmt, err := ddb.ReadCommit(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+// lazyClone pulls only the data of the commit at the head of |branch|. Every other commit on the remote's branches
+// and tags is persisted as a lazy commit, and the data of those commits is fetched from |srcDB| when it is read.
+func lazyClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool) (*doltdb.Commit, error) {
+	refs, head, err := cloneRefs(srcRefHashes, branch, singleBranch)
+	if err != nil {
+		return nil, err
+	}
+	lazyCommits := hash.NewHashSet()
+	for _, refHash := range refs {
+		lazyCommits.Insert(refHash.Hash)
+	}
+
+	// The ancestors of each branch are lazy too, so that later fetches stop at them.
+	for _, refHash := range refs {
+		if refHash.Ref.GetType() != ref.BranchRefType {
+			continue
+		}
+		cs, err := doltdb.NewCommitSpec(refHash.Hash.String())
+		if err != nil {
+			return nil, err
+		}
+		closure, err := srcDB.BootstrapShallowResolve(ctx, cs)
+		if err != nil {
+			return nil, err
+		}
+		ancestors, err := closure.AsHashSet(ctx)
+		if err != nil {
+			return nil, err
+		}
+		lazyCommits.InsertAll(ancestors)
+	}
+	lazyCommits.Remove(head)
+
+	ddb := dEnv.DoltDB(ctx)
+	if remoteName != "origin" {
+		if err := setLazyCloneRemote(dEnv, remoteName); err != nil {
+			return nil, err
+		}
+	}
+	err = ddb.PersistLazyCommits(ctx, lazyCommits)
+	if err != nil {
+		return nil, err
+	}
+	ddb.SetLazyCloneSource(func(ctx context.Context) (*doltdb.DoltDB, error) {
+		return srcDB, nil
+	})
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	err = ddb.PullChunks(ctx, tmpDir, srcDB, []hash.Hash{head}, nil, lazyCommits)
+	if err != nil && err != pull.ErrDBUpToDate {
+		return nil, err
+	}
+
+	return setClonedRefs(ctx, ddb, refs, head, branch, remoteName)
+}
+
+// sparseClone pulls the commits on the remote's branches and tags, leaving out the data of every table which is not in
+// |tables|. The tables which are left out are kept as placeholders.
+func sparseClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool, tables []string) (*doltdb.Commit, error) {
+	refs, head, err := cloneRefs(srcRefHashes, branch, singleBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	optCmt, err := srcDB.ReadCommit(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+	headCommit, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitRuntimeFailure
+	}
+	headRoot, err := headCommit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tables {
+		_, ok, err := headRoot.ResolveTableName(ctx, doltdb.TableName{Name: t})
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", doltdb.ErrTableNotFound, t)
+		}
+	}
+
+	var commits, targets []hash.Hash
+	for _, refHash := range refs {
+		targets = append(targets, refHash.Hash)
+		if refHash.Ref.GetType() != ref.TagRefType {
+			commits = append(commits, refHash.Hash)
+			continue
+		}
+		tag, err := srcDB.ResolveTag(ctx, refHash.Ref.(ref.TagRef))
+		if err != nil {
+			return nil, err
+		}
+		h, err := tag.Commit.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, h)
+	}
+
+	ddb := dEnv.DoltDB(ctx)
+	placeholders, err := ddb.PersistSparseTables(ctx, srcDB, commits, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	err = ddb.PullChunks(ctx, tmpDir, srcDB, targets, nil, placeholders)
+	if err != nil && err != pull.ErrDBUpToDate {
+		return nil, err
+	}
+
+	return setClonedRefs(ctx, ddb, refs, head, branch, remoteName)
+}
+
+// setLazyCloneRemote records |remoteName| as the remote which a lazy clone fetches missing data from.
+func setLazyCloneRemote(dEnv *env.DoltEnv, remoteName string) error {
+	if localConf, ok := dEnv.Config.GetConfig(env.LocalConfig); ok {
+		return localConf.SetStrings(map[string]string{config.LazyCloneRemoteKey: remoteName})
+	}
+	return dEnv.Config.CreateLocalConfig(".", map[string]string{config.LazyCloneRemoteKey: remoteName})
+}
+
 // shallowCloneDataPull is a shallow clone specific helper function to pull only the data required to show the given branch
 // at the depth given.
 func shallowCloneDataPull[C doltdb.Context](ctx C, destData env.DbData[C], srcDB *doltdb.DoltDB, remoteName, branch string, depth int) (*doltdb.Commit, error) {
@@ -0,0 +1,351 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// A CIController runs Dolt CI workflows from a running SQL server. It
+// is only created when the server config has a |ci| section, and
+// works as follows:
+//
+// Post Commit Hooks are installed on every database in the
+// DoltDatabaseProvider for the SQL Engine. When a branch head
+// advances, the hook forwards the branch and its new head to a
+// background thread. The background thread runs every workflow whose
+// push trigger matches the branch against the new head commit, using
+// the workflows stored at that commit, and records the results in the
+// CI run log of the database, which can be queried from the
+// dolt_ci_runs system table. When the new head is a merge commit, the
+// branch was the target of a merge, which runs the workflows whose
+// pull_request trigger matches the branch as well.
+//
+// Workflow queries are run as the account configured in the |ci|
+// section, with its privileges, not as the user who updated the
+// branch.
+//
+// Commit hooks run after the branch head has been updated, so a
+// failing workflow does not reject the update.
+type CIController struct {
+	workCh chan ciWork
+	lgr    *logrus.Logger
+	// user is the account, at localhost, which workflow queries are run as.
+	user string
+
+	ctxF   func(context.Context) (*sql.Context, error)
+	queryF queryFunc
+}
+
+const ciWorkBufferSize = 1024
+
+// Passed by a commit hook to the CI thread, requesting the thread to
+// run the workflows of database |dbName| against |head| of |branch|.
+type ciWork struct {
+	dbName string
+	ddb    *doltdb.DoltDB
+	branch string
+	head   hash.Hash
+}
+
+func NewCIController(user string, lgr *logrus.Logger) *CIController {
+	return &CIController{
+		workCh: make(chan ciWork, ciWorkBufferSize),
+		lgr:    lgr,
+		user:   user,
+	}
+}
+
+// During engine initialization, this should be called to ensure the
+// background worker thread responsible for running workflows is
+// running. |queryF| is used to run the queries of workflow steps.
+func (c *CIController) RunBackgroundThread(threads *sql.BackgroundThreads, ctxF func(context.Context) (*sql.Context, error), queryF func(*sql.Context, string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)) error {
+	c.ctxF = ctxF
+	c.queryF = queryF
+	return threads.Add("dolt_ci_thread", c.ciBgThread)
+}
+
+func (c *CIController) ciBgThread(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case work := <-c.workCh:
+			c.doWork(ctx, work)
+		}
+	}
+}
+
+func (c *CIController) doWork(ctx context.Context, work ciWork) {
+	sqlCtx, err := c.ctxF(ctx)
+	if err != nil {
+		c.lgr.Warnf("dolt_ci: Could not create session to run workflows for %s: %v", work.dbName, err)
+		return
+	}
+	sqlCtx.Session.SetClient(sql.Client{User: c.user, Address: "localhost", Capabilities: 0})
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+
+	// Pin the session to the new head so that a later update to the branch does not change what is being tested.
+	sqlCtx.SetCurrentDatabase(work.dbName + dsess.DbRevisionDelimiter + work.head.String())
+
+	hasTables, err := HasDoltCITables(sqlCtx)
+	if err != nil {
+		c.lgr.Warnf("dolt_ci: Could not check for workflows in %s on branch %s: %v", work.dbName, work.branch, err)
+		return
+	}
+	if !hasTables {
+		return
+	}
+
+	merge, err := isMergeCommit(sqlCtx, work.ddb, work.head)
+	if err != nil {
+		c.lgr.Warnf("dolt_ci: Could not read head of %s branch %s: %v", work.dbName, work.branch, err)
+		return
+	}
+
+	wm := NewWorkflowManager("", "", c.queryF)
+	workflows, err := wm.listWorkflows(sqlCtx)
+	if err != nil {
+		c.lgr.Warnf("dolt_ci: Could not list workflows in %s on branch %s: %v", work.dbName, work.branch, err)
+		return
+	}
+
+	for _, workflow := range workflows {
+		config, err := wm.getWorkflowConfig(sqlCtx, string(*workflow.Name))
+		if err != nil {
+			c.lgr.Warnf("dolt_ci: Could not load workflow %s in %s: %v", *workflow.Name, work.dbName, err)
+			continue
+		}
+		if !pushTriggerMatchesBranch(config, work.branch) && !(merge && pullRequestTriggerMatchesBranch(config, work.branch)) {
+			continue
+		}
+		c.runWorkflow(sqlCtx, wm, config, work)
+	}
+}
+
+func (c *CIController) runWorkflow(ctx *sql.Context, wm *doltWorkflowManager, config *WorkflowConfig, work ciWork) {
+	run, err := newCIRun(ctx, wm, config, work.head)
+	run.Branch = work.branch
+	id, recordErr := work.ddb.RecordCIRun(ctx, run)
+	if recordErr != nil {
+		c.lgr.Warnf("dolt_ci: Could not record run of workflow %s on %s branch %s: %v", run.Workflow, work.dbName, work.branch, recordErr)
+		return
+	}
+	switch {
+	case err != nil:
+		c.lgr.Warnf("dolt_ci: Run %d of workflow %s on %s branch %s could not be run: %v", id, run.Workflow, work.dbName, work.branch, err)
+	case run.Passed():
+		c.lgr.Infof("dolt_ci: Run %d of workflow %s on %s branch %s passed", id, run.Workflow, work.dbName, work.branch)
+	default:
+		c.lgr.Warnf("dolt_ci: Run %d of workflow %s on %s branch %s failed", id, run.Workflow, work.dbName, work.branch)
+	}
+}
+
+// newCIRun runs the workflow |config| against the current database of |ctx|, which is |commit|, and returns its run.
+// The returned error is that of a workflow which could not be run at all, which is also recorded in the run.
+func newCIRun(ctx *sql.Context, wm *doltWorkflowManager, config *WorkflowConfig, commit hash.Hash) (doltdb.CIRun, error) {
+	run := doltdb.CIRun{
+		Workflow:   config.Name.Value,
+		CommitHash: commit.String(),
+		StartedAt:  time.Now(),
+	}
+	result, err := wm.runWorkflow(ctx, config)
+	run.FinishedAt = time.Now()
+	if err != nil {
+		run.Error = err.Error()
+		return run, err
+	}
+	for _, job := range result.Jobs {
+		if len(job.Steps) == 0 {
+			// Record the job, so that it is listed in the run, with an empty step.
+			run.Steps = append(run.Steps, doltdb.CIStepRun{
+				Job:        job.Name,
+				Status:     doltdb.CIRunStatusPassed,
+				StartedAt:  run.StartedAt,
+				FinishedAt: run.FinishedAt,
+			})
+			continue
+		}
+		for _, step := range job.Steps {
+			stepRun := doltdb.CIStepRun{
+				Job:        job.Name,
+				Step:       step.Name,
+				Status:     doltdb.CIRunStatusPassed,
+				StartedAt:  step.StartedAt,
+				FinishedAt: step.FinishedAt,
+			}
+			if !step.Passed() {
+				stepRun.Status = doltdb.CIRunStatusFailed
+				stepRun.Message = step.Err.Error()
+			}
+			run.Steps = append(run.Steps, stepRun)
+		}
+	}
+	return run, nil
+}
+
+// isMergeCommit returns whether the commit |h| has more than one parent.
+func isMergeCommit(ctx context.Context, ddb *doltdb.DoltDB, h hash.Hash) (bool, error) {
+	optCmt, err := ddb.ReadCommit(ctx, h)
+	if err != nil {
+		return false, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return false, doltdb.ErrGhostCommitEncountered
+	}
+	return cm.NumParents() > 1, nil
+}
+
+// pushTriggerMatchesBranch returns whether |config| has a push trigger which matches |branch|. A push trigger
+// without any branches matches every branch.
+func pushTriggerMatchesBranch(config *WorkflowConfig, branch string) bool {
+	if config.On.Push == nil {
+		return false
+	}
+	return branchesMatch(config.On.Push.Branches, branch)
+}
+
+// pullRequestTriggerMatchesBranch returns whether |config| has a pull_request trigger which matches a merge into
+// |branch|. A merge closes the pull request, so a trigger with activities only matches if they include closed. A
+// trigger without any branches matches every branch.
+func pullRequestTriggerMatchesBranch(config *WorkflowConfig, branch string) bool {
+	if config.On.PullRequest == nil {
+		return false
+	}
+	if len(config.On.PullRequest.Activities) > 0 {
+		closed := false
+		for _, a := range config.On.PullRequest.Activities {
+			if t, err := ToWorkflowEventTriggerActivityType(a.Value); err == nil && t == WorkflowEventTriggerTypeActivityClosed {
+				closed = true
+			}
+		}
+		if !closed {
+			return false
+		}
+	}
+	return branchesMatch(config.On.PullRequest.Branches, branch)
+}
+
+// branchesMatch returns whether |branch| is one of the trigger |branches|, or |branches| is empty.
+func branchesMatch(branches []yaml.Node, branch string) bool {
+	if len(branches) == 0 {
+		return true
+	}
+	for _, b := range branches {
+		if b.Value == branch {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CIController) newCommitHook(name string, ddb *doltdb.DoltDB) *ciCommitHook {
+	return &ciCommitHook{c: c, name: name, ddb: ddb}
+}
+
+// During engine initialization, called on the original set of
+// databases to install the CI commit hooks.
+func (c *CIController) ApplyCommitHooks(ctx context.Context, mrEnv *env.MultiRepoEnv, dbs ...dsess.SqlDatabase) error {
+	for _, db := range dbs {
+		denv := mrEnv.GetEnv(db.Name())
+		if denv == nil {
+			continue
+		}
+		ddb := denv.DoltDB(ctx)
+		ddb.PrependCommitHooks(ctx, c.newCommitHook(db.Name(), ddb))
+	}
+	return nil
+}
+
+func (c *CIController) InitDatabaseHook() sqle.InitDatabaseHook {
+	return func(ctx *sql.Context, _ *sqle.DoltDatabaseProvider, name string, env *env.DoltEnv, _ dsess.SqlDatabase) error {
+		ddb := env.DoltDB(ctx)
+		ddb.PrependCommitHooks(ctx, c.newCommitHook(name, ddb))
+		return nil
+	}
+}
+
+// The doltdb.CommitHook which watches for branch head updates and
+// requests workflow runs.
+type ciCommitHook struct {
+	c    *CIController
+	name string
+	ddb  *doltdb.DoltDB
+	out  io.Writer
+}
+
+var _ doltdb.CommitHook = (*ciCommitHook)(nil)
+
+// Execute implements CommitHook, requesting workflow runs for branch head updates.
+func (h *ciCommitHook) Execute(ctx context.Context, ds datas.Dataset, _ *doltdb.DoltDB) (func(context.Context) error, error) {
+	head, ok := ds.MaybeHeadAddr()
+	if !ok {
+		// The branch was deleted.
+		return nil, nil
+	}
+	if !ref.IsRef(ds.ID()) {
+		return nil, nil
+	}
+	rf, err := ref.Parse(ds.ID())
+	if err != nil {
+		return nil, err
+	}
+	if rf.GetType() != ref.BranchRefType {
+		return nil, nil
+	}
+
+	select {
+	case h.c.workCh <- ciWork{dbName: h.name, ddb: h.ddb, branch: rf.GetPath(), head: head}:
+	default:
+		// Never block the write on CI. If too many runs are pending, skip this one.
+		h.c.lgr.Warnf("dolt_ci: Too many pending workflow runs, skipping %s branch %s at %s", h.name, rf.GetPath(), head.String())
+	}
+	return nil, nil
+}
+
+// HandleError implements CommitHook
+func (h *ciCommitHook) HandleError(ctx context.Context, err error) error {
+	if h.out != nil {
+		h.out.Write([]byte(err.Error()))
+	}
+	return nil
+}
+
+// SetLogger implements CommitHook
+func (h *ciCommitHook) SetLogger(ctx context.Context, wr io.Writer) error {
+	h.out = wr
+	return nil
+}
+
+func (*ciCommitHook) ExecuteForWorkingSets() bool {
+	return false
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerMatchesBranch(t *testing.T) {
+	parse := func(t *testing.T, on string) *WorkflowConfig {
+		config, err := ParseWorkflowConfig(strings.NewReader("name: wf\non:\n" + on + "jobs:\n  - name: job\n    steps:\n      - name: step\n        assert_table: t1\n"))
+		require.NoError(t, err)
+		return config
+	}
+
+	tests := []struct {
+		name        string
+		on          string
+		branch      string
+		push        bool
+		pullRequest bool
+	}{
+		{"push without branches", "  push: {}\n", "main", true, false},
+		{"push to branch", "  push:\n    branches: [main]\n", "main", true, false},
+		{"push to other branch", "  push:\n    branches: [main]\n", "feature", false, false},
+		{"pull request without branches", "  pull_request: {}\n", "main", false, true},
+		{"pull request into branch", "  pull_request:\n    branches: [main]\n", "main", false, true},
+		{"pull request into other branch", "  pull_request:\n    branches: [main]\n", "feature", false, false},
+		{"pull request closed", "  pull_request:\n    branches: [main]\n    activities: [opened, closed]\n", "main", false, true},
+		{"pull request not closed", "  pull_request:\n    branches: [main]\n    activities: [opened, synchronized]\n", "main", false, false},
+		{"dispatch only", "  workflow_dispatch: {}\n", "main", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := parse(t, test.on)
+			assert.Equal(t, test.push, pushTriggerMatchesBranch(config, test.branch))
+			assert.Equal(t, test.pullRequest, pullRequestTriggerMatchesBranch(config, test.branch))
+		})
+	}
+}
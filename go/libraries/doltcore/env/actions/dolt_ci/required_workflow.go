@@ -0,0 +1,72 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func init() {
+	dprocedures.RunCIWorkflow = RunRequiredWorkflow
+}
+
+var _ dprocedures.RunCIWorkflowFunc = RunRequiredWorkflow
+
+// RunRequiredWorkflow implements dprocedures.RunCIWorkflowFunc. It runs the workflow named |workflow|, as it is stored
+// at |commit|, against |commit| of the database |dbName| in the session of |ctx|, and records the run in the CI run
+// log of |ddb|. It is used to run the workflows required to merge into a protected branch, which have to pass before
+// the merge is made, so unlike the runs of a CIController it runs synchronously, with the privileges of the user
+// merging.
+func RunRequiredWorkflow(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB, commit hash.Hash, workflow string, queryF func(*sql.Context, string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)) (bool, error) {
+	baseName, _ := dsess.SplitRevisionDbName(dbName)
+	prevDb := ctx.GetCurrentDatabase()
+	ctx.SetCurrentDatabase(baseName + dsess.DbRevisionDelimiter + commit.String())
+	defer ctx.SetCurrentDatabase(prevDb)
+
+	run := doltdb.CIRun{
+		Workflow:   workflow,
+		CommitHash: commit.String(),
+		StartedAt:  time.Now(),
+	}
+	hasTables, err := HasDoltCITables(ctx)
+	if err == nil && !hasTables {
+		err = fmt.Errorf("no workflows are stored at %s", commit.String())
+	}
+	if err == nil {
+		wm := NewWorkflowManager("", "", queryF)
+		var config *WorkflowConfig
+		config, err = wm.getWorkflowConfig(ctx, workflow)
+		if err == nil {
+			run, _ = newCIRun(ctx, wm, config, commit)
+		}
+	}
+	if err != nil {
+		run.Error = err.Error()
+		run.FinishedAt = time.Now()
+	}
+
+	if _, err = ddb.RecordCIRun(ctx, run); err != nil {
+		return false, err
+	}
+	return run.Passed(), nil
+}
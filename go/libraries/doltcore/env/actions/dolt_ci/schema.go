@@ -15,6 +15,7 @@
 package dolt_ci
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -28,9 +29,14 @@ import (
 // WrappedTableName is a struct that wraps a doltdb.TableName
 // and specifies whether the tables should still be created.
 // Deprecated tables will have Deprecated: true
+// Tables added after dolt ci was first released will have Optional: true.
+// A database initialized before an optional table was added is treated
+// as if the table were empty, and the table is created the next time a
+// workflow is stored or dolt ci init is run.
 type WrappedTableName struct {
 	TableName  doltdb.TableName
 	Deprecated bool
+	Optional   bool
 }
 
 type WrappedTableNameSlice []WrappedTableName
@@ -45,6 +51,17 @@ func (w WrappedTableNameSlice) ActiveTableNames() []doltdb.TableName {
 	return tableNames
 }
 
+// RequiredTableNames returns the names of the active tables which are not optional.
+func (w WrappedTableNameSlice) RequiredTableNames() []doltdb.TableName {
+	tableNames := make([]doltdb.TableName, 0)
+	for _, wrapt := range w {
+		if !wrapt.Deprecated && !wrapt.Optional {
+			tableNames = append(tableNames, wrapt.TableName)
+		}
+	}
+	return tableNames
+}
+
 // ExpectedDoltCITablesOrdered contains the tables names for the dolt ci workflow tables, in parent to child table order.
 // This is exported for use in DoltHub/DoltLab.
 var ExpectedDoltCITablesOrdered = WrappedTableNameSlice{
@@ -57,22 +74,30 @@ var ExpectedDoltCITablesOrdered = WrappedTableNameSlice{
 	{TableName: doltdb.TableName{Name: doltdb.WorkflowStepsTableName}},
 	{TableName: doltdb.TableName{Name: doltdb.WorkflowSavedQueryStepsTableName}},
 	{TableName: doltdb.TableName{Name: doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsTableName}},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowSqlAssertionStepsTableName}, Optional: true},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowSchemaAssertionStepsTableName}, Optional: true},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowRowCountStepsTableName}, Optional: true},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowDiffSizeStepsTableName}, Optional: true},
+	{TableName: doltdb.TableName{Name: doltdb.WorkflowVerifyConstraintsStepsTableName}, Optional: true},
 }
 
+// ErrIncompleteDoltCITables is returned when only some of the required dolt ci tables exist.
+var ErrIncompleteDoltCITables = errors.New("found some but not all of required dolt ci tables, run 'dolt ci init' to create the missing tables")
+
 type queryFunc func(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)
 
-// HasDoltCITables reports whether a database has all expected dolt_ci tables which store continuous integration config.
-// If the database has only some of the expected tables, an error is returned.
+// HasDoltCITables reports whether a database has all required dolt_ci tables which store continuous integration config.
+// If the database has only some of the required tables, an error is returned. Optional tables are not checked.
 func HasDoltCITables(ctx *sql.Context) (bool, error) {
 	dbName := ctx.GetCurrentDatabase()
 	dSess := dsess.DSessFromSess(ctx.Session)
-	ws, err := dSess.WorkingSet(ctx, dbName)
-	if err != nil {
-		return false, err
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return false, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	root := ws.WorkingRoot()
-	activeOnly := ExpectedDoltCITablesOrdered.ActiveTableNames()
+	root := roots.Working
+	activeOnly := ExpectedDoltCITablesOrdered.RequiredTableNames()
 
 	exists := 0
 	var hasSome bool
@@ -93,7 +118,7 @@ func HasDoltCITables(ctx *sql.Context) (bool, error) {
 		return false, nil
 	}
 	if hasSome && !hasAll {
-		return true, fmt.Errorf("found some but not all of required dolt ci tables")
+		return true, ErrIncompleteDoltCITables
 	}
 	return true, nil
 }
@@ -122,6 +147,28 @@ func getExistingDoltCITables(ctx *sql.Context) ([]doltdb.TableName, error) {
 	return existing, nil
 }
 
+// getExistingActiveDoltCITables returns the names of the dolt_ci tables which are not deprecated and exist in the
+// working root, in parent to child table order.
+func getExistingActiveDoltCITables(ctx *sql.Context) ([]doltdb.TableName, error) {
+	existing, err := getExistingDoltCITables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]doltdb.TableName, 0, len(existing))
+	for _, wrapt := range ExpectedDoltCITablesOrdered {
+		if wrapt.Deprecated {
+			continue
+		}
+		for _, tn := range existing {
+			if tn == wrapt.TableName {
+				active = append(active, tn)
+				break
+			}
+		}
+	}
+	return active, nil
+}
+
 func sqlWriteQuery(ctx *sql.Context, queryFunc queryFunc, query string) error {
 	_, rowIter, _, err := queryFunc(ctx, query)
 	if err != nil {
@@ -144,7 +191,7 @@ func commitCIDestroy(ctx *sql.Context, queryFunc queryFunc, tableNames []doltdb.
 	return sqlWriteQuery(ctx, queryFunc, fmt.Sprintf("CALL DOLT_COMMIT('-m' 'Successfully destroyed Dolt CI', '--author', '%s <%s>');", commiterName, commiterEmail))
 }
 
-func commitCIInit(ctx *sql.Context, queryFunc queryFunc, tableNames []doltdb.TableName, commiterName, commiterEmail string) error {
+func commitCIInit(ctx *sql.Context, queryFunc queryFunc, tableNames []doltdb.TableName, commiterName, commiterEmail string, upgrade bool) error {
 	// stage table in reverse order so child tables
 	// are staged before parent tables
 	for i := len(tableNames) - 1; i >= 0; i-- {
@@ -154,7 +201,11 @@ func commitCIInit(ctx *sql.Context, queryFunc queryFunc, tableNames []doltdb.Tab
 			return err
 		}
 	}
-	return sqlWriteQuery(ctx, queryFunc, fmt.Sprintf("CALL DOLT_COMMIT('-m' 'Successfully initialized Dolt CI', '--author', '%s <%s>');", commiterName, commiterEmail))
+	message := "Successfully initialized Dolt CI"
+	if upgrade {
+		message = "Successfully upgraded Dolt CI"
+	}
+	return sqlWriteQuery(ctx, queryFunc, fmt.Sprintf("CALL DOLT_COMMIT('-m' '%s', '--author', '%s <%s>');", message, commiterName, commiterEmail))
 }
 
 // DestroyDoltCITables drops all dolt_ci tables and creates a new Dolt commit.
@@ -196,28 +247,78 @@ func CreateDoltCITables(ctx *sql.Context, db sqle.Database, queryFunc queryFunc,
 		return err
 	}
 
-	orderedCreateTableQueries := []string{
-		createWorkflowsTableQuery(),
-		createWorkflowEventsTableQuery(),
-		createWorkflowEventTriggersTableQuery(),
-		createWorkflowEventTriggerBranchesTableQuery(),
-		createWorkflowJobsTableQuery(),
-		createWorkflowStepsTableQuery(),
-		createWorkflowSavedQueryStepsTableQuery(),
-		createWorkflowSavedQueryStepExpectedRowColumnResultsTableQuery(),
-		deleteAllFromWorkflowsTableQuery(), // as last step run delete to create resolve all indexes/fks
+	newCtx := doltdb.ContextWithDoltCICreateBypassKey(ctx)
+	upgrade, err := createMissingDoltCITables(newCtx, queryFunc)
+	if err != nil {
+		return err
 	}
 
-	newCtx := doltdb.ContextWithDoltCICreateBypassKey(ctx)
+	return commitCIInit(newCtx, queryFunc, ExpectedDoltCITablesOrdered.ActiveTableNames(), commiterName, commiterEmail, upgrade)
+}
+
+// createMissingDoltCITables creates the dolt_ci tables which do not exist yet. It reports whether any of the tables
+// already existed, meaning the database was upgraded rather than initialized. |ctx| must allow the creation of
+// dolt_ci tables.
+func createMissingDoltCITables(ctx *sql.Context, queryFunc queryFunc) (bool, error) {
+	orderedCreateTableQueries := []struct {
+		tableName string
+		query     string
+	}{
+		{doltdb.WorkflowsTableName, createWorkflowsTableQuery()},
+		{doltdb.WorkflowEventsTableName, createWorkflowEventsTableQuery()},
+		{doltdb.WorkflowEventTriggersTableName, createWorkflowEventTriggersTableQuery()},
+		{doltdb.WorkflowEventTriggerBranchesTableName, createWorkflowEventTriggerBranchesTableQuery()},
+		{doltdb.WorkflowJobsTableName, createWorkflowJobsTableQuery()},
+		{doltdb.WorkflowStepsTableName, createWorkflowStepsTableQuery()},
+		{doltdb.WorkflowSavedQueryStepsTableName, createWorkflowSavedQueryStepsTableQuery()},
+		{doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsTableName, createWorkflowSavedQueryStepExpectedRowColumnResultsTableQuery()},
+		{doltdb.WorkflowSqlAssertionStepsTableName, createWorkflowSqlAssertionStepsTableQuery()},
+		{doltdb.WorkflowSchemaAssertionStepsTableName, createWorkflowSchemaAssertionStepsTableQuery()},
+		{doltdb.WorkflowRowCountStepsTableName, createWorkflowRowCountStepsTableQuery()},
+		{doltdb.WorkflowDiffSizeStepsTableName, createWorkflowDiffSizeStepsTableQuery()},
+		{doltdb.WorkflowVerifyConstraintsStepsTableName, createWorkflowVerifyConstraintsStepsTableQuery()},
+	}
+
+	// only create the tables that are missing, so that databases initialized
+	// before newer tables were added can be upgraded in place
+	existing, err := getExistingDoltCITables(ctx)
+	if err != nil {
+		return false, err
+	}
+	existingNames := make(map[string]struct{})
+	for _, tn := range existing {
+		existingNames[tn.Name] = struct{}{}
+	}
+	upgrade := len(existingNames) > 0
 
-	for _, query := range orderedCreateTableQueries {
-		err := sqlWriteQuery(newCtx, queryFunc, query)
+	created := make([]string, 0)
+	for _, q := range orderedCreateTableQueries {
+		if _, ok := existingNames[q.tableName]; ok {
+			continue
+		}
+		err := sqlWriteQuery(ctx, queryFunc, q.query)
 		if err != nil {
-			return err
+			return false, err
+		}
+		created = append(created, q.tableName)
+	}
+
+	// as last step run delete to create resolve all indexes/fks
+	if !upgrade {
+		err = sqlWriteQuery(ctx, queryFunc, deleteAllFromTableQuery(doltdb.WorkflowsTableName))
+		if err != nil {
+			return false, err
+		}
+	} else {
+		for _, tableName := range created {
+			err = sqlWriteQuery(ctx, queryFunc, deleteAllFromTableQuery(tableName))
+			if err != nil {
+				return false, err
+			}
 		}
 	}
 
-	return commitCIInit(newCtx, queryFunc, ExpectedDoltCITablesOrdered.ActiveTableNames(), commiterName, commiterEmail)
+	return upgrade, nil
 }
 
 func createWorkflowsTableQuery() string {
@@ -252,6 +353,26 @@ func createWorkflowSavedQueryStepExpectedRowColumnResultsTableQuery() string {
 	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key,`%s` int not null, `%s` int not null,`%s` bigint not null,`%s` bigint not null,`%s` datetime(6) not null,`%s` datetime(6) not null,`%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsTableName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsIdPkColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedColumnCountComparisonTypeColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedRowCountComparisonTypeColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedColumnCountColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedRowCountColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsCreatedAtColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsUpdatedAtColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsSavedQueryStepIdFkColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsSavedQueryStepIdFkColName, doltdb.WorkflowSavedQueryStepsTableName, doltdb.WorkflowSavedQueryStepsIdPkColName)
 }
 
-func deleteAllFromWorkflowsTableQuery() string {
-	return fmt.Sprintf("delete from %s;", doltdb.WorkflowsTableName)
+func createWorkflowSqlAssertionStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` text collate utf8mb4_0900_ai_ci not null, `%s` text collate utf8mb4_0900_ai_ci not null, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowSqlAssertionStepsTableName, doltdb.WorkflowSqlAssertionStepsIdPkColName, doltdb.WorkflowSqlAssertionStepsQueryColName, doltdb.WorkflowSqlAssertionStepsExpectedResultsColName, doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
+func createWorkflowSchemaAssertionStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` text collate utf8mb4_0900_ai_ci not null, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowSchemaAssertionStepsTableName, doltdb.WorkflowSchemaAssertionStepsIdPkColName, doltdb.WorkflowSchemaAssertionStepsTableNameColName, doltdb.WorkflowSchemaAssertionStepsExpectedColumnsColName, doltdb.WorkflowSchemaAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowSchemaAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
+func createWorkflowRowCountStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` int not null, `%s` bigint not null, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowRowCountStepsTableName, doltdb.WorkflowRowCountStepsIdPkColName, doltdb.WorkflowRowCountStepsBaseRefColName, doltdb.WorkflowRowCountStepsTableNameColName, doltdb.WorkflowRowCountStepsComparisonTypeColName, doltdb.WorkflowRowCountStepsRowCountColName, doltdb.WorkflowRowCountStepsWorkflowStepIdFkColName, doltdb.WorkflowRowCountStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
+func createWorkflowDiffSizeStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` varchar(1024) collate utf8mb4_0900_ai_ci not null, `%s` int not null, `%s` bigint not null, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowDiffSizeStepsTableName, doltdb.WorkflowDiffSizeStepsIdPkColName, doltdb.WorkflowDiffSizeStepsBaseRefColName, doltdb.WorkflowDiffSizeStepsTableNameColName, doltdb.WorkflowDiffSizeStepsComparisonTypeColName, doltdb.WorkflowDiffSizeStepsRowCountColName, doltdb.WorkflowDiffSizeStepsWorkflowStepIdFkColName, doltdb.WorkflowDiffSizeStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
+func createWorkflowVerifyConstraintsStepsTableQuery() string {
+	return fmt.Sprintf("create table %s (`%s` varchar(36) primary key, `%s` text collate utf8mb4_0900_ai_ci not null, `%s` varchar(36) not null, foreign key (`%s`) references %s (`%s`) on delete cascade);", doltdb.WorkflowVerifyConstraintsStepsTableName, doltdb.WorkflowVerifyConstraintsStepsIdPkColName, doltdb.WorkflowVerifyConstraintsStepsTablesColName, doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName, doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName, doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsIdPkColName)
+}
+
+func deleteAllFromTableQuery(tableName string) string {
+	return fmt.Sprintf("delete from %s;", tableName)
 }
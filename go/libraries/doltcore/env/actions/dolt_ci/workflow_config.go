@@ -23,11 +23,204 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// Step is a single step of a job. The fields that are set determine the type of the step, and only
+// the fields of a single step type may be set.
 type Step struct {
-	Name            yaml.Node `yaml:"name"`
-	SavedQueryName  yaml.Node `yaml:"saved_query_name"`
+	Name yaml.Node `yaml:"name"`
+
+	// saved query steps
+	SavedQueryName  yaml.Node `yaml:"saved_query_name,omitempty"`
 	ExpectedColumns yaml.Node `yaml:"expected_columns,omitempty"`
 	ExpectedRows    yaml.Node `yaml:"expected_rows,omitempty"`
+
+	// sql assertion steps
+	Query           yaml.Node `yaml:"query,omitempty"`
+	ExpectedResults yaml.Node `yaml:"expected_results,omitempty"`
+
+	// schema assertion steps
+	AssertTable          yaml.Node `yaml:"assert_table,omitempty"`
+	ExpectedTableColumns yaml.Node `yaml:"expected_table_columns,omitempty"`
+
+	// row count steps
+	RowCountTable    yaml.Node `yaml:"row_count_table,omitempty"`
+	RowCountBase     yaml.Node `yaml:"row_count_base,omitempty"`
+	ExpectedRowCount yaml.Node `yaml:"expected_row_count,omitempty"`
+
+	// diff size steps
+	DiffBase         yaml.Node `yaml:"diff_base,omitempty"`
+	DiffTable        yaml.Node `yaml:"diff_table,omitempty"`
+	ExpectedDiffRows yaml.Node `yaml:"expected_diff_rows,omitempty"`
+
+	// verify constraints steps
+	VerifyConstraints yaml.Node `yaml:"verify_constraints,omitempty"`
+}
+
+// getStepType returns the WorkflowStepType of |step| based on which of its fields are set.
+func getStepType(step Step) (WorkflowStepType, error) {
+	fieldsByType := []struct {
+		stepType WorkflowStepType
+		fields   []yaml.Node
+	}{
+		{WorkflowStepTypeSavedQuery, []yaml.Node{step.SavedQueryName, step.ExpectedColumns, step.ExpectedRows}},
+		{WorkflowStepTypeSqlAssertion, []yaml.Node{step.Query, step.ExpectedResults}},
+		{WorkflowStepTypeSchemaAssertion, []yaml.Node{step.AssertTable, step.ExpectedTableColumns}},
+		{WorkflowStepTypeRowCount, []yaml.Node{step.RowCountTable, step.RowCountBase, step.ExpectedRowCount}},
+		{WorkflowStepTypeDiffSize, []yaml.Node{step.DiffBase, step.DiffTable, step.ExpectedDiffRows}},
+		{WorkflowStepTypeVerifyConstraints, []yaml.Node{step.VerifyConstraints}},
+	}
+
+	stepType := WorkflowStepTypeUnspecified
+	for _, t := range fieldsByType {
+		for _, field := range t.fields {
+			if isYamlNodeSet(field) {
+				if stepType != WorkflowStepTypeUnspecified && stepType != t.stepType {
+					return WorkflowStepTypeUnspecified, fmt.Errorf("step %s has fields of more than one step type", step.Name.Value)
+				}
+				stepType = t.stepType
+			}
+		}
+	}
+
+	if stepType == WorkflowStepTypeUnspecified {
+		return WorkflowStepTypeUnspecified, fmt.Errorf("step %s does not specify a step type", step.Name.Value)
+	}
+	return stepType, nil
+}
+
+func isYamlNodeSet(n yaml.Node) bool {
+	return n.Kind != 0
+}
+
+func validateStep(step Step) error {
+	stepType, err := getStepType(step)
+	if err != nil {
+		return err
+	}
+
+	switch stepType {
+	case WorkflowStepTypeSavedQuery:
+		if step.SavedQueryName.Value == "" {
+			return fmt.Errorf("step %s is missing saved_query_name", step.Name.Value)
+		}
+	case WorkflowStepTypeSqlAssertion:
+		if step.Query.Value == "" {
+			return fmt.Errorf("step %s is missing query", step.Name.Value)
+		}
+		if !isYamlNodeSet(step.ExpectedResults) {
+			return fmt.Errorf("step %s is missing expected_results", step.Name.Value)
+		}
+		if _, err := expectedResultsFromYamlNode(step.ExpectedResults); err != nil {
+			return fmt.Errorf("step %s has invalid expected_results: %w", step.Name.Value, err)
+		}
+	case WorkflowStepTypeSchemaAssertion:
+		if step.AssertTable.Value == "" {
+			return fmt.Errorf("step %s is missing assert_table", step.Name.Value)
+		}
+		if isYamlNodeSet(step.ExpectedTableColumns) {
+			if _, err := stringsFromYamlNode(step.ExpectedTableColumns); err != nil {
+				return fmt.Errorf("step %s has invalid expected_table_columns: %w", step.Name.Value, err)
+			}
+		}
+	case WorkflowStepTypeRowCount:
+		if step.RowCountTable.Value == "" {
+			return fmt.Errorf("step %s is missing row_count_table", step.Name.Value)
+		}
+		if step.ExpectedRowCount.Value == "" {
+			return fmt.Errorf("step %s is missing expected_row_count", step.Name.Value)
+		}
+	case WorkflowStepTypeDiffSize:
+		if step.DiffBase.Value == "" {
+			return fmt.Errorf("step %s is missing diff_base", step.Name.Value)
+		}
+		if step.ExpectedDiffRows.Value == "" {
+			return fmt.Errorf("step %s is missing expected_diff_rows", step.Name.Value)
+		}
+	case WorkflowStepTypeVerifyConstraints:
+		if _, err := stringsFromYamlNode(step.VerifyConstraints); err != nil {
+			return fmt.Errorf("step %s has invalid verify_constraints: %w", step.Name.Value, err)
+		}
+	}
+	return nil
+}
+
+// stringsFromYamlNode returns the values of a sequence of scalars.
+func stringsFromYamlNode(n yaml.Node) ([]string, error) {
+	if n.Kind != yaml.SequenceNode {
+		return nil, errors.New("expected a list")
+	}
+	values := make([]string, 0, len(n.Content))
+	for _, c := range n.Content {
+		if c.Kind != yaml.ScalarNode {
+			return nil, errors.New("expected a list of values")
+		}
+		values = append(values, c.Value)
+	}
+	return values, nil
+}
+
+// expectedResultsFromYamlNode returns the rows of a sequence of sequences of scalars. Null scalars are returned as nil.
+func expectedResultsFromYamlNode(n yaml.Node) ([][]*string, error) {
+	if n.Kind != yaml.SequenceNode {
+		return nil, errors.New("expected a list of rows")
+	}
+	rows := make([][]*string, 0, len(n.Content))
+	for _, r := range n.Content {
+		if r.Kind != yaml.SequenceNode {
+			return nil, errors.New("expected each row to be a list of values")
+		}
+		row := make([]*string, 0, len(r.Content))
+		for _, c := range r.Content {
+			if c.Kind != yaml.ScalarNode {
+				return nil, errors.New("expected each row to be a list of values")
+			}
+			if c.ShortTag() == "!!null" {
+				row = append(row, nil)
+			} else {
+				v := c.Value
+				row = append(row, &v)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func newSequenceYamlNode(values []yaml.Node) yaml.Node {
+	content := make([]*yaml.Node, len(values))
+	for i := range values {
+		content[i] = &values[i]
+	}
+	return yaml.Node{
+		Kind:    yaml.SequenceNode,
+		Style:   yaml.FlowStyle,
+		Content: content,
+	}
+}
+
+func newStringsYamlNode(values []string) yaml.Node {
+	nodes := make([]yaml.Node, len(values))
+	for i, v := range values {
+		nodes[i] = newScalarDoubleQuotedYamlNode(v)
+	}
+	return newSequenceYamlNode(nodes)
+}
+
+func newExpectedResultsYamlNode(rows [][]*string) yaml.Node {
+	nodes := make([]yaml.Node, len(rows))
+	for i, row := range rows {
+		values := make([]yaml.Node, len(row))
+		for j, v := range row {
+			if v == nil {
+				values[j] = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+			} else {
+				values[j] = newScalarDoubleQuotedYamlNode(*v)
+			}
+		}
+		nodes[i] = newSequenceYamlNode(values)
+	}
+	seq := newSequenceYamlNode(nodes)
+	seq.Style = 0
+	return seq
 }
 
 type Job struct {
@@ -156,8 +349,8 @@ func ValidateWorkflowConfig(workflow *WorkflowConfig) error {
 			} else {
 				steps[step.Name.Value] = true
 			}
-			if step.SavedQueryName.Value == "" {
-				return fmt.Errorf("invalid config: step %s is missing saved_query_name", step.Name.Value)
+			if err := validateStep(step); err != nil {
+				return fmt.Errorf("invalid config: %w", err)
 			}
 		}
 	}
@@ -95,3 +95,108 @@ jobs:
 
 	// todo: check expected stuff
 }
+
+func TestValidateWorkflowConfigStepTypes(t *testing.T) {
+	ymlTemplate := `name: test workflow
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: job
+    steps:
+      - name: step
+%s
+`
+
+	tests := []struct {
+		name         string
+		step         string
+		expectedType WorkflowStepType
+		expectedErr  string
+	}{
+		{
+			name:         "saved query",
+			step:         "        saved_query_name: sq",
+			expectedType: WorkflowStepTypeSavedQuery,
+		},
+		{
+			name:         "sql assertion",
+			step:         "        query: select 1, null\n        expected_results:\n          - [1, null]",
+			expectedType: WorkflowStepTypeSqlAssertion,
+		},
+		{
+			name:        "sql assertion missing expected results",
+			step:        "        query: select 1",
+			expectedErr: "invalid config: step step is missing expected_results",
+		},
+		{
+			name:        "sql assertion with invalid expected results",
+			step:        "        query: select 1\n        expected_results: [1]",
+			expectedErr: "invalid config: step step has invalid expected_results: expected each row to be a list of values",
+		},
+		{
+			name:         "schema assertion",
+			step:         "        assert_table: t1",
+			expectedType: WorkflowStepTypeSchemaAssertion,
+		},
+		{
+			name:         "row count",
+			step:         "        row_count_table: t1\n        expected_row_count: \"> 1\"",
+			expectedType: WorkflowStepTypeRowCount,
+		},
+		{
+			name:         "row count with base",
+			step:         "        row_count_table: t1\n        row_count_base: main\n        expected_row_count: \"> 1\"",
+			expectedType: WorkflowStepTypeRowCount,
+		},
+		{
+			name:        "row count base without table",
+			step:        "        row_count_base: main\n        expected_row_count: \"> 1\"",
+			expectedErr: "invalid config: step step is missing row_count_table",
+		},
+		{
+			name:        "diff size missing base",
+			step:        "        expected_diff_rows: \"< 10\"",
+			expectedErr: "invalid config: step step is missing diff_base",
+		},
+		{
+			name:         "diff size",
+			step:         "        diff_base: main\n        expected_diff_rows: \"< 10\"",
+			expectedType: WorkflowStepTypeDiffSize,
+		},
+		{
+			name:         "verify constraints",
+			step:         "        verify_constraints: []",
+			expectedType: WorkflowStepTypeVerifyConstraints,
+		},
+		{
+			name:        "mixed step types",
+			step:        "        saved_query_name: sq\n        assert_table: t1",
+			expectedErr: "invalid config: step step has fields of more than one step type",
+		},
+		{
+			name:        "no step type",
+			step:        "",
+			expectedErr: "invalid config: step step does not specify a step type",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wf, err := ParseWorkflowConfig(strings.NewReader(fmt.Sprintf(ymlTemplate, test.step)))
+			require.NoError(t, err)
+
+			err = ValidateWorkflowConfig(wf)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			stepType, err := getStepType(wf.Jobs[0].Steps[0])
+			require.NoError(t, err)
+			require.Equal(t, test.expectedType, stepType)
+		})
+	}
+}
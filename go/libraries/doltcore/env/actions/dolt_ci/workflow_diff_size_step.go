@@ -0,0 +1,27 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dolt_ci
+
+type WorkflowDiffSizeStepId string
+
+// WorkflowDiffSizeStep asserts that the number of rows changed since the merge base of HEAD and a base ref
+// satisfies a threshold. If TableName is empty, changes to all user tables are counted.
+type WorkflowDiffSizeStep struct {
+	Id               *WorkflowDiffSizeStepId                           `db:"id"`
+	WorkflowStepIdFK *WorkflowStepId                                   `db:"workflow_step_id_fk"`
+	BaseRef          string                                            `db:"base_ref"`
+	TableName        string                                            `db:"table_name"`
+	ComparisonType   WorkflowSavedQueryExpectedRowColumnComparisonType `db:"comparison_type"`
+	RowCount         int64                                             `db:"row_count"`
+}
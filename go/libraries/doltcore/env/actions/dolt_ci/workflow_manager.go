@@ -15,6 +15,7 @@
 package dolt_ci
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -48,6 +49,8 @@ type WorkflowManager interface {
 	GetWorkflowConfig(ctx *sql.Context, db sqle.Database, workflowName string) (*WorkflowConfig, error)
 	// StoreAndCommit creates or updates a workflow and creates a Dolt commit
 	StoreAndCommit(ctx *sql.Context, db sqle.Database, config *WorkflowConfig) error
+	// RunWorkflow runs each step of a workflow against the current database and returns the results.
+	RunWorkflow(ctx *sql.Context, db sqle.Database, workflowName string) (*WorkflowResult, error)
 }
 
 type doltWorkflowManager struct {
@@ -120,6 +123,26 @@ func (d *doltWorkflowManager) selectAllFromWorkflowEventTriggerBranchesTableByEv
 	return fmt.Sprintf("select * from %s where `%s` = '%s';", doltdb.WorkflowEventTriggerBranchesTableName, doltdb.WorkflowEventTriggerBranchesWorkflowEventTriggersIdFkColName, triggerID)
 }
 
+func (d *doltWorkflowManager) selectAllFromSqlAssertionStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowSqlAssertionStepsTableName, doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName, stepID)
+}
+
+func (d *doltWorkflowManager) selectAllFromSchemaAssertionStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowSchemaAssertionStepsTableName, doltdb.WorkflowSchemaAssertionStepsWorkflowStepIdFkColName, stepID)
+}
+
+func (d *doltWorkflowManager) selectAllFromRowCountStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowRowCountStepsTableName, doltdb.WorkflowRowCountStepsWorkflowStepIdFkColName, stepID)
+}
+
+func (d *doltWorkflowManager) selectAllFromDiffSizeStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowDiffSizeStepsTableName, doltdb.WorkflowDiffSizeStepsWorkflowStepIdFkColName, stepID)
+}
+
+func (d *doltWorkflowManager) selectAllFromVerifyConstraintsStepsTableByWorkflowStepIdQuery(stepID string) string {
+	return fmt.Sprintf("select * from %s where `%s` = '%s' limit 1;", doltdb.WorkflowVerifyConstraintsStepsTableName, doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName, stepID)
+}
+
 // inserts
 
 func (d *doltWorkflowManager) insertIntoWorkflowsTableQuery(workflowName string) (string, string) {
@@ -161,14 +184,39 @@ func (d *doltWorkflowManager) insertIntoWorkflowSavedQueryStepExpectedRowColumnR
 	return expectedResultID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`,`%s`, `%s`, `%s`, `%s`, `%s`) values ('%s', '%s', %d, %d, %d, %d, now(), now());", doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsTableName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsIdPkColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsSavedQueryStepIdFkColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedColumnCountComparisonTypeColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedRowCountComparisonTypeColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedColumnCountColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsExpectedRowCountColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsCreatedAtColName, doltdb.WorkflowSavedQueryStepExpectedRowColumnResultsUpdatedAtColName, expectedResultID, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
 }
 
+func (d *doltWorkflowManager) insertIntoWorkflowSqlAssertionStepsTableQuery(stepID, query, expectedResults string) (string, string) {
+	sqlAssertionStepID := uuid.NewString()
+	return sqlAssertionStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`, `%s`) values ('%s', '%s', '%s', '%s');", doltdb.WorkflowSqlAssertionStepsTableName, doltdb.WorkflowSqlAssertionStepsIdPkColName, doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowSqlAssertionStepsQueryColName, doltdb.WorkflowSqlAssertionStepsExpectedResultsColName, sqlAssertionStepID, stepID, escapeSqlString(query), escapeSqlString(expectedResults))
+}
+
+func (d *doltWorkflowManager) insertIntoWorkflowSchemaAssertionStepsTableQuery(stepID, tableName, expectedColumns string) (string, string) {
+	schemaAssertionStepID := uuid.NewString()
+	return schemaAssertionStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`, `%s`) values ('%s', '%s', '%s', '%s');", doltdb.WorkflowSchemaAssertionStepsTableName, doltdb.WorkflowSchemaAssertionStepsIdPkColName, doltdb.WorkflowSchemaAssertionStepsWorkflowStepIdFkColName, doltdb.WorkflowSchemaAssertionStepsTableNameColName, doltdb.WorkflowSchemaAssertionStepsExpectedColumnsColName, schemaAssertionStepID, stepID, escapeSqlString(tableName), escapeSqlString(expectedColumns))
+}
+
+func (d *doltWorkflowManager) insertIntoWorkflowRowCountStepsTableQuery(stepID, baseRef, tableName string, comparisonType int, rowCount int64) (string, string) {
+	rowCountStepID := uuid.NewString()
+	return rowCountStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`, `%s`, `%s`, `%s`) values ('%s', '%s', '%s', '%s', %d, %d);", doltdb.WorkflowRowCountStepsTableName, doltdb.WorkflowRowCountStepsIdPkColName, doltdb.WorkflowRowCountStepsWorkflowStepIdFkColName, doltdb.WorkflowRowCountStepsBaseRefColName, doltdb.WorkflowRowCountStepsTableNameColName, doltdb.WorkflowRowCountStepsComparisonTypeColName, doltdb.WorkflowRowCountStepsRowCountColName, rowCountStepID, stepID, escapeSqlString(baseRef), escapeSqlString(tableName), comparisonType, rowCount)
+}
+
+func (d *doltWorkflowManager) insertIntoWorkflowDiffSizeStepsTableQuery(stepID, baseRef, tableName string, comparisonType int, rowCount int64) (string, string) {
+	diffSizeStepID := uuid.NewString()
+	return diffSizeStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`, `%s`, `%s`, `%s`) values ('%s', '%s', '%s', '%s', %d, %d);", doltdb.WorkflowDiffSizeStepsTableName, doltdb.WorkflowDiffSizeStepsIdPkColName, doltdb.WorkflowDiffSizeStepsWorkflowStepIdFkColName, doltdb.WorkflowDiffSizeStepsBaseRefColName, doltdb.WorkflowDiffSizeStepsTableNameColName, doltdb.WorkflowDiffSizeStepsComparisonTypeColName, doltdb.WorkflowDiffSizeStepsRowCountColName, diffSizeStepID, stepID, escapeSqlString(baseRef), escapeSqlString(tableName), comparisonType, rowCount)
+}
+
+func (d *doltWorkflowManager) insertIntoWorkflowVerifyConstraintsStepsTableQuery(stepID, tables string) (string, string) {
+	verifyConstraintsStepID := uuid.NewString()
+	return verifyConstraintsStepID, fmt.Sprintf("insert into %s (`%s`, `%s`, `%s`) values ('%s', '%s', '%s');", doltdb.WorkflowVerifyConstraintsStepsTableName, doltdb.WorkflowVerifyConstraintsStepsIdPkColName, doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName, doltdb.WorkflowVerifyConstraintsStepsTablesColName, verifyConstraintsStepID, stepID, escapeSqlString(tables))
+}
+
 // updates
 
 func (d *doltWorkflowManager) updateWorkflowJobsTableQuery(jobID, jobName string) string {
-	return fmt.Sprintf("update %s set `%s` = '%s', `%s` = now(), where `%s` = '%s';", doltdb.WorkflowJobsTableName, doltdb.WorkflowJobsNameColName, jobName, doltdb.WorkflowJobsUpdatedAtColName, doltdb.WorkflowJobsIdPkColName, jobID)
+	return fmt.Sprintf("update %s set `%s` = '%s', `%s` = now() where `%s` = '%s';", doltdb.WorkflowJobsTableName, doltdb.WorkflowJobsNameColName, jobName, doltdb.WorkflowJobsUpdatedAtColName, doltdb.WorkflowJobsIdPkColName, jobID)
 }
 
 func (d *doltWorkflowManager) updateWorkflowStepsTableQuery(stepID string, stepOrder int) string {
-	return fmt.Sprintf("update %s set `%s` = %d, `%s` = now(), where `%s` = '%s';", doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsStepOrderColName, stepOrder, doltdb.WorkflowStepsUpdatedAtColName, doltdb.WorkflowStepsIdPkColName, stepID)
+	return fmt.Sprintf("update %s set `%s` = %d, `%s` = now() where `%s` = '%s';", doltdb.WorkflowStepsTableName, doltdb.WorkflowStepsStepOrderColName, stepOrder, doltdb.WorkflowStepsUpdatedAtColName, doltdb.WorkflowStepsIdPkColName, stepID)
 }
 
 func (d *doltWorkflowManager) updateWorkflowSavedQueryStepsTableQuery(savedQueryStepID, savedQueryName string, expectedResultsType int) string {
@@ -517,6 +565,172 @@ func (d *doltWorkflowManager) newWorkflowEventTriggerBranch(cvs columnValues) (*
 	return tb, nil
 }
 
+func (d *doltWorkflowManager) newWorkflowSqlAssertionStep(cvs columnValues) (*WorkflowSqlAssertionStep, error) {
+	sa := &WorkflowSqlAssertionStep{}
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowSqlAssertionStepsIdPkColName:
+			id := WorkflowSqlAssertionStepId(cv.Value)
+			sa.Id = &id
+		case doltdb.WorkflowSqlAssertionStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			sa.WorkflowStepIdFK = &id
+		case doltdb.WorkflowSqlAssertionStepsQueryColName:
+			sa.Query = cv.Value
+		case doltdb.WorkflowSqlAssertionStepsExpectedResultsColName:
+			sa.ExpectedResults = cv.Value
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown sql assertion step column: %s", cv.ColumnName))
+		}
+	}
+
+	return sa, nil
+}
+
+func (d *doltWorkflowManager) newWorkflowSchemaAssertionStep(cvs columnValues) (*WorkflowSchemaAssertionStep, error) {
+	sa := &WorkflowSchemaAssertionStep{}
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowSchemaAssertionStepsIdPkColName:
+			id := WorkflowSchemaAssertionStepId(cv.Value)
+			sa.Id = &id
+		case doltdb.WorkflowSchemaAssertionStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			sa.WorkflowStepIdFK = &id
+		case doltdb.WorkflowSchemaAssertionStepsTableNameColName:
+			sa.TableName = cv.Value
+		case doltdb.WorkflowSchemaAssertionStepsExpectedColumnsColName:
+			sa.ExpectedColumns = cv.Value
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown schema assertion step column: %s", cv.ColumnName))
+		}
+	}
+
+	return sa, nil
+}
+
+func (d *doltWorkflowManager) newWorkflowRowCountStep(cvs columnValues) (*WorkflowRowCountStep, error) {
+	rc := &WorkflowRowCountStep{}
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowRowCountStepsIdPkColName:
+			id := WorkflowRowCountStepId(cv.Value)
+			rc.Id = &id
+		case doltdb.WorkflowRowCountStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			rc.WorkflowStepIdFK = &id
+		case doltdb.WorkflowRowCountStepsBaseRefColName:
+			rc.BaseRef = cv.Value
+		case doltdb.WorkflowRowCountStepsTableNameColName:
+			rc.TableName = cv.Value
+		case doltdb.WorkflowRowCountStepsComparisonTypeColName:
+			i, err := strconv.Atoi(cv.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			t, err := ToWorkflowSavedQueryExpectedRowColumnComparisonResultType(i)
+			if err != nil {
+				return nil, err
+			}
+
+			rc.ComparisonType = t
+		case doltdb.WorkflowRowCountStepsRowCountColName:
+			i, err := strconv.ParseInt(cv.Value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			rc.RowCount = i
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown row count step column: %s", cv.ColumnName))
+		}
+	}
+
+	return rc, nil
+}
+
+func (d *doltWorkflowManager) newWorkflowDiffSizeStep(cvs columnValues) (*WorkflowDiffSizeStep, error) {
+	ds := &WorkflowDiffSizeStep{}
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowDiffSizeStepsIdPkColName:
+			id := WorkflowDiffSizeStepId(cv.Value)
+			ds.Id = &id
+		case doltdb.WorkflowDiffSizeStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			ds.WorkflowStepIdFK = &id
+		case doltdb.WorkflowDiffSizeStepsBaseRefColName:
+			ds.BaseRef = cv.Value
+		case doltdb.WorkflowDiffSizeStepsTableNameColName:
+			ds.TableName = cv.Value
+		case doltdb.WorkflowDiffSizeStepsComparisonTypeColName:
+			i, err := strconv.Atoi(cv.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			t, err := ToWorkflowSavedQueryExpectedRowColumnComparisonResultType(i)
+			if err != nil {
+				return nil, err
+			}
+
+			ds.ComparisonType = t
+		case doltdb.WorkflowDiffSizeStepsRowCountColName:
+			i, err := strconv.ParseInt(cv.Value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			ds.RowCount = i
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown diff size step column: %s", cv.ColumnName))
+		}
+	}
+
+	return ds, nil
+}
+
+func (d *doltWorkflowManager) newWorkflowVerifyConstraintsStep(cvs columnValues) (*WorkflowVerifyConstraintsStep, error) {
+	vc := &WorkflowVerifyConstraintsStep{}
+
+	for _, cv := range cvs {
+		if cv == nil {
+			continue
+		}
+		switch cv.ColumnName {
+		case doltdb.WorkflowVerifyConstraintsStepsIdPkColName:
+			id := WorkflowVerifyConstraintsStepId(cv.Value)
+			vc.Id = &id
+		case doltdb.WorkflowVerifyConstraintsStepsWorkflowStepIdFkColName:
+			id := WorkflowStepId(cv.Value)
+			vc.WorkflowStepIdFK = &id
+		case doltdb.WorkflowVerifyConstraintsStepsTablesColName:
+			vc.Tables = cv.Value
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown verify constraints step column: %s", cv.ColumnName))
+		}
+	}
+
+	return vc, nil
+}
+
 func (d *doltWorkflowManager) validateWorkflowTables(ctx *sql.Context) error {
 	dbName := ctx.GetCurrentDatabase()
 	dSess := dsess.DSessFromSess(ctx.Session)
@@ -545,7 +759,7 @@ func (d *doltWorkflowManager) validateWorkflowTables(ctx *sql.Context) error {
 		}
 	}
 
-	activeOnly := ExpectedDoltCITablesOrdered.ActiveTableNames()
+	activeOnly := ExpectedDoltCITablesOrdered.RequiredTableNames()
 	for _, tn := range activeOnly {
 		_, ok := tableMap[tn.Name]
 		if !ok {
@@ -651,6 +865,111 @@ func (d *doltWorkflowManager) getWorkflowSavedQueryStepByStepId(ctx *sql.Context
 	return savedQuerySteps[0], nil
 }
 
+func (d *doltWorkflowManager) getWorkflowSqlAssertionStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (*WorkflowSqlAssertionStep, error) {
+	query := d.selectAllFromSqlAssertionStepsTableByWorkflowStepIdQuery(string(stepID))
+	steps := make([]*WorkflowSqlAssertionStep, 0)
+	cb := func(cbCtx *sql.Context, cvs columnValues) error {
+		s, rerr := d.newWorkflowSqlAssertionStep(cvs)
+		if rerr != nil {
+			return rerr
+		}
+		steps = append(steps, s)
+		return nil
+	}
+	err := d.sqlReadQuery(ctx, query, cb)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) != 1 {
+		return nil, errors.New(fmt.Sprintf("expected one sql assertion step for step: %s", stepID))
+	}
+	return steps[0], nil
+}
+
+func (d *doltWorkflowManager) getWorkflowSchemaAssertionStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (*WorkflowSchemaAssertionStep, error) {
+	query := d.selectAllFromSchemaAssertionStepsTableByWorkflowStepIdQuery(string(stepID))
+	steps := make([]*WorkflowSchemaAssertionStep, 0)
+	cb := func(cbCtx *sql.Context, cvs columnValues) error {
+		s, rerr := d.newWorkflowSchemaAssertionStep(cvs)
+		if rerr != nil {
+			return rerr
+		}
+		steps = append(steps, s)
+		return nil
+	}
+	err := d.sqlReadQuery(ctx, query, cb)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) != 1 {
+		return nil, errors.New(fmt.Sprintf("expected one schema assertion step for step: %s", stepID))
+	}
+	return steps[0], nil
+}
+
+func (d *doltWorkflowManager) getWorkflowRowCountStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (*WorkflowRowCountStep, error) {
+	query := d.selectAllFromRowCountStepsTableByWorkflowStepIdQuery(string(stepID))
+	steps := make([]*WorkflowRowCountStep, 0)
+	cb := func(cbCtx *sql.Context, cvs columnValues) error {
+		s, rerr := d.newWorkflowRowCountStep(cvs)
+		if rerr != nil {
+			return rerr
+		}
+		steps = append(steps, s)
+		return nil
+	}
+	err := d.sqlReadQuery(ctx, query, cb)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) != 1 {
+		return nil, errors.New(fmt.Sprintf("expected one row count step for step: %s", stepID))
+	}
+	return steps[0], nil
+}
+
+func (d *doltWorkflowManager) getWorkflowDiffSizeStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (*WorkflowDiffSizeStep, error) {
+	query := d.selectAllFromDiffSizeStepsTableByWorkflowStepIdQuery(string(stepID))
+	steps := make([]*WorkflowDiffSizeStep, 0)
+	cb := func(cbCtx *sql.Context, cvs columnValues) error {
+		s, rerr := d.newWorkflowDiffSizeStep(cvs)
+		if rerr != nil {
+			return rerr
+		}
+		steps = append(steps, s)
+		return nil
+	}
+	err := d.sqlReadQuery(ctx, query, cb)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) != 1 {
+		return nil, errors.New(fmt.Sprintf("expected one diff size step for step: %s", stepID))
+	}
+	return steps[0], nil
+}
+
+func (d *doltWorkflowManager) getWorkflowVerifyConstraintsStepByStepId(ctx *sql.Context, stepID WorkflowStepId) (*WorkflowVerifyConstraintsStep, error) {
+	query := d.selectAllFromVerifyConstraintsStepsTableByWorkflowStepIdQuery(string(stepID))
+	steps := make([]*WorkflowVerifyConstraintsStep, 0)
+	cb := func(cbCtx *sql.Context, cvs columnValues) error {
+		s, rerr := d.newWorkflowVerifyConstraintsStep(cvs)
+		if rerr != nil {
+			return rerr
+		}
+		steps = append(steps, s)
+		return nil
+	}
+	err := d.sqlReadQuery(ctx, query, cb)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) != 1 {
+		return nil, errors.New(fmt.Sprintf("expected one verify constraints step for step: %s", stepID))
+	}
+	return steps[0], nil
+}
+
 func (d *doltWorkflowManager) listWorkflowStepsByJobId(ctx *sql.Context, jobID WorkflowJobId) ([]*WorkflowStep, error) {
 	query := d.selectAllFromWorkflowStepsTableByWorkflowJobIdQuery(string(jobID))
 	return d.retrieveWorkflowSteps(ctx, query)
@@ -1283,7 +1602,31 @@ func (d *doltWorkflowManager) updateExistingWorkflow(ctx *sql.Context, config *W
 						}
 					}
 
-					if configStep.SavedQueryName.Value != "" {
+					configStepType, err := getStepType(configStep)
+					if err != nil {
+						return err
+					}
+
+					rewrite := step.StepType != configStepType
+					if !rewrite && configStepType != WorkflowStepTypeSavedQuery {
+						rewrite, err = d.workflowStepChanged(ctx, step, configStep)
+						if err != nil {
+							return err
+						}
+					}
+
+					if rewrite {
+						// steps that change type or content are replaced, which removes their
+						// type specific rows by cascade
+						err = d.deleteWorkflowStep(ctx, *step.Id)
+						if err != nil {
+							return err
+						}
+						err = d.writeWorkflowStep(ctx, *job.Id, configStep, stepOrder)
+						if err != nil {
+							return err
+						}
+					} else if configStepType == WorkflowStepTypeSavedQuery {
 						savedQueryStep, err := d.getWorkflowSavedQueryStepByStepId(ctx, *step.Id)
 						if err != nil {
 							return err
@@ -1355,28 +1698,7 @@ func (d *doltWorkflowManager) updateExistingWorkflow(ctx *sql.Context, config *W
 					return errors.New("failed to get step order")
 				}
 
-				stepOrder := orderIdx + 1
-				stepID, err := d.writeWorkflowStepRow(ctx, *job.Id, step.Name.Value, stepOrder, WorkflowStepTypeSavedQuery)
-				if err != nil {
-					return err
-				}
-
-				savedQueryStepID, err := d.writeWorkflowSavedQueryStepRow(ctx, stepID, step.SavedQueryName.Value, WorkflowSavedQueryExpectedResultsTypeRowColumnCount)
-				if err != nil {
-					return err
-				}
-
-				expectedColumnComparisonType, expectedColumnCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedColumns.Value)
-				if err != nil {
-					return err
-				}
-
-				expectedRowComparisonType, expectedRowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRows.Value)
-				if err != nil {
-					return err
-				}
-
-				_, err = d.writeWorkflowSavedQueryStepExpectedRowColumnResultRow(ctx, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
+				err = d.writeWorkflowStep(ctx, *job.Id, step, orderIdx+1)
 				if err != nil {
 					return err
 				}
@@ -1396,27 +1718,7 @@ func (d *doltWorkflowManager) updateExistingWorkflow(ctx *sql.Context, config *W
 			return err
 		}
 		for idx, step := range job.Steps {
-			stepID, err := d.writeWorkflowStepRow(ctx, jobID, step.Name.Value, idx+1, WorkflowStepTypeSavedQuery)
-			if err != nil {
-				return err
-			}
-
-			savedQueryStepID, err := d.writeWorkflowSavedQueryStepRow(ctx, stepID, step.SavedQueryName.Value, WorkflowSavedQueryExpectedResultsTypeRowColumnCount)
-			if err != nil {
-				return err
-			}
-
-			expectedColumnComparisonType, expectedColumnCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedColumns.Value)
-			if err != nil {
-				return err
-			}
-
-			expectedRowComparisonType, expectedRowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRows.Value)
-			if err != nil {
-				return err
-			}
-
-			_, err = d.writeWorkflowSavedQueryStepExpectedRowColumnResultRow(ctx, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
+			err = d.writeWorkflowStep(ctx, jobID, step, idx+1)
 			if err != nil {
 				return err
 			}
@@ -1560,6 +1862,259 @@ func (d *doltWorkflowManager) writeWorkflowSavedQueryStepExpectedRowColumnResult
 	return WorkflowSavedQueryExpectedRowColumnResultId(resultID), nil
 }
 
+func (d *doltWorkflowManager) writeWorkflowSqlAssertionStepRow(ctx *sql.Context, stepID WorkflowStepId, query, expectedResults string) (WorkflowSqlAssertionStepId, error) {
+	sqlAssertionStepID, q := d.insertIntoWorkflowSqlAssertionStepsTableQuery(string(stepID), query, expectedResults)
+	err := d.sqlWriteQuery(ctx, q)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowSqlAssertionStepId(sqlAssertionStepID), nil
+}
+
+func (d *doltWorkflowManager) writeWorkflowSchemaAssertionStepRow(ctx *sql.Context, stepID WorkflowStepId, tableName, expectedColumns string) (WorkflowSchemaAssertionStepId, error) {
+	schemaAssertionStepID, query := d.insertIntoWorkflowSchemaAssertionStepsTableQuery(string(stepID), tableName, expectedColumns)
+	err := d.sqlWriteQuery(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowSchemaAssertionStepId(schemaAssertionStepID), nil
+}
+
+func (d *doltWorkflowManager) writeWorkflowRowCountStepRow(ctx *sql.Context, stepID WorkflowStepId, baseRef, tableName string, comparisonType WorkflowSavedQueryExpectedRowColumnComparisonType, rowCount int64) (WorkflowRowCountStepId, error) {
+	rowCountStepID, query := d.insertIntoWorkflowRowCountStepsTableQuery(string(stepID), baseRef, tableName, int(comparisonType), rowCount)
+	err := d.sqlWriteQuery(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowRowCountStepId(rowCountStepID), nil
+}
+
+func (d *doltWorkflowManager) writeWorkflowDiffSizeStepRow(ctx *sql.Context, stepID WorkflowStepId, baseRef, tableName string, comparisonType WorkflowSavedQueryExpectedRowColumnComparisonType, rowCount int64) (WorkflowDiffSizeStepId, error) {
+	diffSizeStepID, query := d.insertIntoWorkflowDiffSizeStepsTableQuery(string(stepID), baseRef, tableName, int(comparisonType), rowCount)
+	err := d.sqlWriteQuery(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowDiffSizeStepId(diffSizeStepID), nil
+}
+
+func (d *doltWorkflowManager) writeWorkflowVerifyConstraintsStepRow(ctx *sql.Context, stepID WorkflowStepId, tables string) (WorkflowVerifyConstraintsStepId, error) {
+	verifyConstraintsStepID, query := d.insertIntoWorkflowVerifyConstraintsStepsTableQuery(string(stepID), tables)
+	err := d.sqlWriteQuery(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	return WorkflowVerifyConstraintsStepId(verifyConstraintsStepID), nil
+}
+
+// writeWorkflowStep writes the workflow step row for |step|, along with the rows specific to its step type.
+func (d *doltWorkflowManager) writeWorkflowStep(ctx *sql.Context, jobID WorkflowJobId, step Step, stepOrder int) error {
+	stepType, err := getStepType(step)
+	if err != nil {
+		return err
+	}
+
+	stepID, err := d.writeWorkflowStepRow(ctx, jobID, step.Name.Value, stepOrder, stepType)
+	if err != nil {
+		return err
+	}
+
+	switch stepType {
+	case WorkflowStepTypeSavedQuery:
+		resultType := WorkflowSavedQueryExpectedResultsTypeUnspecified
+		if step.ExpectedColumns.Value != "" || step.ExpectedRows.Value != "" {
+			resultType = WorkflowSavedQueryExpectedResultsTypeRowColumnCount
+		}
+
+		savedQueryStepID, err := d.writeWorkflowSavedQueryStepRow(ctx, stepID, step.SavedQueryName.Value, resultType)
+		if err != nil {
+			return err
+		}
+
+		if resultType == WorkflowSavedQueryExpectedResultsTypeRowColumnCount {
+			// insert into expected results
+			expectedColumnComparisonType, expectedColumnCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedColumns.Value)
+			if err != nil {
+				return err
+			}
+
+			expectedRowComparisonType, expectedRowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRows.Value)
+			if err != nil {
+				return err
+			}
+
+			_, err = d.writeWorkflowSavedQueryStepExpectedRowColumnResultRow(ctx, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
+			if err != nil {
+				return err
+			}
+		}
+	case WorkflowStepTypeSqlAssertion:
+		sa, err := d.sqlAssertionStepFromConfig(step)
+		if err != nil {
+			return err
+		}
+		_, err = d.writeWorkflowSqlAssertionStepRow(ctx, stepID, sa.Query, sa.ExpectedResults)
+		if err != nil {
+			return err
+		}
+	case WorkflowStepTypeSchemaAssertion:
+		sa, err := d.schemaAssertionStepFromConfig(step)
+		if err != nil {
+			return err
+		}
+		_, err = d.writeWorkflowSchemaAssertionStepRow(ctx, stepID, sa.TableName, sa.ExpectedColumns)
+		if err != nil {
+			return err
+		}
+	case WorkflowStepTypeRowCount:
+		rc, err := d.rowCountStepFromConfig(step)
+		if err != nil {
+			return err
+		}
+		_, err = d.writeWorkflowRowCountStepRow(ctx, stepID, rc.BaseRef, rc.TableName, rc.ComparisonType, rc.RowCount)
+		if err != nil {
+			return err
+		}
+	case WorkflowStepTypeDiffSize:
+		ds, err := d.diffSizeStepFromConfig(step)
+		if err != nil {
+			return err
+		}
+		_, err = d.writeWorkflowDiffSizeStepRow(ctx, stepID, ds.BaseRef, ds.TableName, ds.ComparisonType, ds.RowCount)
+		if err != nil {
+			return err
+		}
+	case WorkflowStepTypeVerifyConstraints:
+		vc, err := d.verifyConstraintsStepFromConfig(step)
+		if err != nil {
+			return err
+		}
+		_, err = d.writeWorkflowVerifyConstraintsStepRow(ctx, stepID, vc.Tables)
+		if err != nil {
+			return err
+		}
+	default:
+		return ErrUnknownWorkflowStepType
+	}
+
+	return nil
+}
+
+// workflowStepChanged returns whether the stored rows of an existing step, which is not a saved query step,
+// differ from |configStep|.
+func (d *doltWorkflowManager) workflowStepChanged(ctx *sql.Context, step *WorkflowStep, configStep Step) (bool, error) {
+	switch step.StepType {
+	case WorkflowStepTypeSqlAssertion:
+		stored, err := d.getWorkflowSqlAssertionStepByStepId(ctx, *step.Id)
+		if err != nil {
+			return false, err
+		}
+		sa, err := d.sqlAssertionStepFromConfig(configStep)
+		if err != nil {
+			return false, err
+		}
+		return stored.Query != sa.Query || stored.ExpectedResults != sa.ExpectedResults, nil
+	case WorkflowStepTypeSchemaAssertion:
+		stored, err := d.getWorkflowSchemaAssertionStepByStepId(ctx, *step.Id)
+		if err != nil {
+			return false, err
+		}
+		sa, err := d.schemaAssertionStepFromConfig(configStep)
+		if err != nil {
+			return false, err
+		}
+		return stored.TableName != sa.TableName || stored.ExpectedColumns != sa.ExpectedColumns, nil
+	case WorkflowStepTypeRowCount:
+		stored, err := d.getWorkflowRowCountStepByStepId(ctx, *step.Id)
+		if err != nil {
+			return false, err
+		}
+		rc, err := d.rowCountStepFromConfig(configStep)
+		if err != nil {
+			return false, err
+		}
+		return stored.BaseRef != rc.BaseRef || stored.TableName != rc.TableName || stored.ComparisonType != rc.ComparisonType || stored.RowCount != rc.RowCount, nil
+	case WorkflowStepTypeDiffSize:
+		stored, err := d.getWorkflowDiffSizeStepByStepId(ctx, *step.Id)
+		if err != nil {
+			return false, err
+		}
+		ds, err := d.diffSizeStepFromConfig(configStep)
+		if err != nil {
+			return false, err
+		}
+		return stored.BaseRef != ds.BaseRef || stored.TableName != ds.TableName || stored.ComparisonType != ds.ComparisonType || stored.RowCount != ds.RowCount, nil
+	case WorkflowStepTypeVerifyConstraints:
+		stored, err := d.getWorkflowVerifyConstraintsStepByStepId(ctx, *step.Id)
+		if err != nil {
+			return false, err
+		}
+		vc, err := d.verifyConstraintsStepFromConfig(configStep)
+		if err != nil {
+			return false, err
+		}
+		return stored.Tables != vc.Tables, nil
+	default:
+		return false, ErrUnknownWorkflowStepType
+	}
+}
+
+func (d *doltWorkflowManager) sqlAssertionStepFromConfig(step Step) (*WorkflowSqlAssertionStep, error) {
+	rows, err := expectedResultsFromYamlNode(step.ExpectedResults)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkflowSqlAssertionStep{Query: step.Query.Value, ExpectedResults: string(b)}, nil
+}
+
+func (d *doltWorkflowManager) schemaAssertionStepFromConfig(step Step) (*WorkflowSchemaAssertionStep, error) {
+	columns := make([]string, 0)
+	if isYamlNodeSet(step.ExpectedTableColumns) {
+		var err error
+		columns, err = stringsFromYamlNode(step.ExpectedTableColumns)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b, err := json.Marshal(columns)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkflowSchemaAssertionStep{TableName: step.AssertTable.Value, ExpectedColumns: string(b)}, nil
+}
+
+func (d *doltWorkflowManager) rowCountStepFromConfig(step Step) (*WorkflowRowCountStep, error) {
+	comparisonType, rowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRowCount.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkflowRowCountStep{BaseRef: step.RowCountBase.Value, TableName: step.RowCountTable.Value, ComparisonType: comparisonType, RowCount: rowCount}, nil
+}
+
+func (d *doltWorkflowManager) diffSizeStepFromConfig(step Step) (*WorkflowDiffSizeStep, error) {
+	comparisonType, rowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedDiffRows.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkflowDiffSizeStep{BaseRef: step.DiffBase.Value, TableName: step.DiffTable.Value, ComparisonType: comparisonType, RowCount: rowCount}, nil
+}
+
+func (d *doltWorkflowManager) verifyConstraintsStepFromConfig(step Step) (*WorkflowVerifyConstraintsStep, error) {
+	tables, err := stringsFromYamlNode(step.VerifyConstraints)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(tables)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkflowVerifyConstraintsStep{Tables: string(b)}, nil
+}
+
 func (d *doltWorkflowManager) parseSavedQueryExpectedResultString(str string) (WorkflowSavedQueryExpectedRowColumnComparisonType, int64, error) {
 	if str == "" {
 		return WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified, 0, nil
@@ -1727,49 +2282,10 @@ func (d *doltWorkflowManager) createWorkflow(ctx *sql.Context, config *WorkflowC
 
 		// handle steps
 		for idx, step := range job.Steps {
-			// insert into step
-			order := idx + 1
-
-			var stepType WorkflowStepType
-			if step.SavedQueryName.Value != "" {
-				stepType = WorkflowStepTypeSavedQuery
-			}
-
-			stepID, err := d.writeWorkflowStepRow(ctx, jobID, step.Name.Value, order, stepType)
+			err = d.writeWorkflowStep(ctx, jobID, step, idx+1)
 			if err != nil {
 				return err
 			}
-
-			// insert into saved query steps
-			if stepType == WorkflowStepTypeSavedQuery {
-				resultType := WorkflowSavedQueryExpectedResultsTypeUnspecified
-				if step.ExpectedColumns.Value != "" || step.ExpectedRows.Value != "" {
-					resultType = WorkflowSavedQueryExpectedResultsTypeRowColumnCount
-				}
-
-				savedQueryStepID, err := d.writeWorkflowSavedQueryStepRow(ctx, stepID, step.SavedQueryName.Value, resultType)
-				if err != nil {
-					return err
-				}
-
-				if resultType == WorkflowSavedQueryExpectedResultsTypeRowColumnCount {
-					// insert into expected results
-					expectedColumnComparisonType, expectedColumnCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedColumns.Value)
-					if err != nil {
-						return err
-					}
-
-					expectedRowComparisonType, expectedRowCount, err := d.parseSavedQueryExpectedResultString(step.ExpectedRows.Value)
-					if err != nil {
-						return err
-					}
-
-					_, err = d.writeWorkflowSavedQueryStepExpectedRowColumnResultRow(ctx, savedQueryStepID, expectedColumnComparisonType, expectedRowComparisonType, expectedColumnCount, expectedRowCount)
-					if err != nil {
-						return err
-					}
-				}
-			}
 		}
 	}
 	return nil
@@ -1866,54 +2382,142 @@ func (d *doltWorkflowManager) getWorkflowConfig(ctx *sql.Context, workflowName s
 		})
 
 		for _, stp := range stps {
-			if stp.StepType == WorkflowStepTypeSavedQuery {
-				savedQueryStep, err := d.getWorkflowSavedQueryStepByStepId(ctx, *stp.Id)
+			step, err := d.getWorkflowStepConfig(ctx, stp)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		}
+
+		job := Job{
+			Name:  newScalarDoubleQuotedYamlNode(jb.Name),
+			Steps: steps,
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	config.Jobs = jobs
+	return config, nil
+}
+
+// getWorkflowStepConfig returns the Step config for a stored workflow step.
+func (d *doltWorkflowManager) getWorkflowStepConfig(ctx *sql.Context, stp *WorkflowStep) (Step, error) {
+	step := Step{
+		Name: newScalarDoubleQuotedYamlNode(stp.Name),
+	}
+
+	switch stp.StepType {
+	case WorkflowStepTypeSavedQuery:
+		savedQueryStep, err := d.getWorkflowSavedQueryStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
+
+		step.SavedQueryName = newScalarDoubleQuotedYamlNode(savedQueryStep.SavedQueryName)
+
+		if savedQueryStep.SavedQueryExpectedResultsType == WorkflowSavedQueryExpectedResultsTypeRowColumnCount {
+			expectedResult, err := d.getWorkflowSavedQueryExpectedRowColumnResultBySavedQueryStepId(ctx, *savedQueryStep.Id)
+			if err != nil {
+				return Step{}, err
+			}
+
+			if expectedResult.ExpectedColumnCountComparisonType != WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
+				expectedColumnsStr, err := d.toSavedQueryExpectedResultString(expectedResult.ExpectedColumnCountComparisonType, expectedResult.ExpectedColumnCount)
 				if err != nil {
-					return nil, err
+					return Step{}, err
 				}
+				step.ExpectedColumns = newScalarDoubleQuotedYamlNode(expectedColumnsStr)
+			}
 
-				step := Step{
-					Name:           newScalarDoubleQuotedYamlNode(stp.Name),
-					SavedQueryName: newScalarDoubleQuotedYamlNode(savedQueryStep.SavedQueryName),
+			if expectedResult.ExpectedRowCountComparisonType != WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
+				expectedRowsStr, err := d.toSavedQueryExpectedResultString(expectedResult.ExpectedRowCountComparisonType, expectedResult.ExpectedRowCount)
+				if err != nil {
+					return Step{}, err
 				}
+				step.ExpectedRows = newScalarDoubleQuotedYamlNode(expectedRowsStr)
+			}
+		}
+	case WorkflowStepTypeSqlAssertion:
+		sa, err := d.getWorkflowSqlAssertionStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
 
-				if savedQueryStep.SavedQueryExpectedResultsType == WorkflowSavedQueryExpectedResultsTypeRowColumnCount {
-					expectedResult, err := d.getWorkflowSavedQueryExpectedRowColumnResultBySavedQueryStepId(ctx, *savedQueryStep.Id)
-					if err != nil {
-						return nil, err
-					}
+		var rows [][]*string
+		err = json.Unmarshal([]byte(sa.ExpectedResults), &rows)
+		if err != nil {
+			return Step{}, err
+		}
 
-					if expectedResult.ExpectedColumnCountComparisonType != WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
-						expectedColumnsStr, err := d.toSavedQueryExpectedResultString(expectedResult.ExpectedColumnCountComparisonType, expectedResult.ExpectedColumnCount)
-						if err != nil {
-							return nil, err
-						}
-						step.ExpectedColumns = newScalarDoubleQuotedYamlNode(expectedColumnsStr)
-					}
+		step.Query = newScalarDoubleQuotedYamlNode(sa.Query)
+		step.ExpectedResults = newExpectedResultsYamlNode(rows)
+	case WorkflowStepTypeSchemaAssertion:
+		sa, err := d.getWorkflowSchemaAssertionStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
 
-					if expectedResult.ExpectedRowCountComparisonType != WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
-						expectedRowsStr, err := d.toSavedQueryExpectedResultString(expectedResult.ExpectedRowCountComparisonType, expectedResult.ExpectedRowCount)
-						if err != nil {
-							return nil, err
-						}
-						step.ExpectedRows = newScalarDoubleQuotedYamlNode(expectedRowsStr)
-					}
-				}
+		var columns []string
+		err = json.Unmarshal([]byte(sa.ExpectedColumns), &columns)
+		if err != nil {
+			return Step{}, err
+		}
 
-				steps = append(steps, step)
-			}
+		step.AssertTable = newScalarDoubleQuotedYamlNode(sa.TableName)
+		if len(columns) > 0 {
+			step.ExpectedTableColumns = newStringsYamlNode(columns)
+		}
+	case WorkflowStepTypeRowCount:
+		rc, err := d.getWorkflowRowCountStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
 		}
 
-		job := Job{
-			Name:  newScalarDoubleQuotedYamlNode(jb.Name),
-			Steps: steps,
+		expectedRowCountStr, err := d.toSavedQueryExpectedResultString(rc.ComparisonType, rc.RowCount)
+		if err != nil {
+			return Step{}, err
 		}
 
-		jobs = append(jobs, job)
+		step.RowCountTable = newScalarDoubleQuotedYamlNode(rc.TableName)
+		if rc.BaseRef != "" {
+			step.RowCountBase = newScalarDoubleQuotedYamlNode(rc.BaseRef)
+		}
+		step.ExpectedRowCount = newScalarDoubleQuotedYamlNode(expectedRowCountStr)
+	case WorkflowStepTypeDiffSize:
+		ds, err := d.getWorkflowDiffSizeStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
+
+		expectedDiffRowsStr, err := d.toSavedQueryExpectedResultString(ds.ComparisonType, ds.RowCount)
+		if err != nil {
+			return Step{}, err
+		}
+
+		step.DiffBase = newScalarDoubleQuotedYamlNode(ds.BaseRef)
+		if ds.TableName != "" {
+			step.DiffTable = newScalarDoubleQuotedYamlNode(ds.TableName)
+		}
+		step.ExpectedDiffRows = newScalarDoubleQuotedYamlNode(expectedDiffRowsStr)
+	case WorkflowStepTypeVerifyConstraints:
+		vc, err := d.getWorkflowVerifyConstraintsStepByStepId(ctx, *stp.Id)
+		if err != nil {
+			return Step{}, err
+		}
+
+		var tables []string
+		err = json.Unmarshal([]byte(vc.Tables), &tables)
+		if err != nil {
+			return Step{}, err
+		}
+
+		step.VerifyConstraints = newStringsYamlNode(tables)
+	default:
+		return Step{}, ErrUnknownWorkflowStepType
 	}
 
-	config.Jobs = jobs
-	return config, nil
+	return step, nil
 }
 
 func (d *doltWorkflowManager) storeFromConfig(ctx *sql.Context, config *WorkflowConfig) error {
@@ -1965,7 +2569,24 @@ func (d *doltWorkflowManager) RemoveWorkflow(ctx *sql.Context, db sqle.Database,
 	if err != nil {
 		return err
 	}
-	return d.commitRemoveWorkflow(ctx, ExpectedDoltCITablesOrdered.ActiveTableNames(), workflowName)
+
+	// optional tables may not exist in databases initialized by older versions of dolt
+	tableNames, err := getExistingActiveDoltCITables(ctx)
+	if err != nil {
+		return err
+	}
+	return d.commitRemoveWorkflow(ctx, tableNames, workflowName)
+}
+
+func (d *doltWorkflowManager) RunWorkflow(ctx *sql.Context, db sqle.Database, workflowName string) (*WorkflowResult, error) {
+	if err := dsess.CheckAccessForDb(ctx, db, branch_control.Permissions_Read); err != nil {
+		return nil, err
+	}
+	config, err := d.getWorkflowConfig(ctx, workflowName)
+	if err != nil {
+		return nil, err
+	}
+	return d.runWorkflow(ctx, config)
 }
 
 func (d *doltWorkflowManager) StoreAndCommit(ctx *sql.Context, db sqle.Database, config *WorkflowConfig) error {
@@ -1973,7 +2594,13 @@ func (d *doltWorkflowManager) StoreAndCommit(ctx *sql.Context, db sqle.Database,
 		return err
 	}
 
-	err := d.storeFromConfig(ctx, config)
+	// create any optional tables missing from databases initialized by older versions of dolt
+	_, err := createMissingDoltCITables(doltdb.ContextWithDoltCICreateBypassKey(ctx), d.queryFunc)
+	if err != nil {
+		return err
+	}
+
+	err = d.storeFromConfig(ctx, config)
 	if err != nil {
 		return err
 	}
@@ -1981,6 +2608,12 @@ func (d *doltWorkflowManager) StoreAndCommit(ctx *sql.Context, db sqle.Database,
 	return d.commitWorkflow(ctx, ExpectedDoltCITablesOrdered.ActiveTableNames(), config.Name.Value)
 }
 
+// escapeSqlString escapes |s| for use inside a single quoted SQL string literal.
+func escapeSqlString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "'", "''")
+}
+
 func newScalarDoubleQuotedYamlNode(value string) yaml.Node {
 	return yaml.Node{
 		Kind:  yaml.ScalarNode,
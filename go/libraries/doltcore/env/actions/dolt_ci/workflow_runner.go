@@ -0,0 +1,464 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+// WorkflowStepResult is the outcome of running a single workflow step.
+type WorkflowStepResult struct {
+	Name string
+	// Err is nil if the step passed. Otherwise, it describes why the step failed.
+	Err        error
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Passed returns whether the step passed.
+func (r *WorkflowStepResult) Passed() bool {
+	return r.Err == nil
+}
+
+// WorkflowJobResult is the outcome of running all steps of a workflow job.
+type WorkflowJobResult struct {
+	Name  string
+	Steps []*WorkflowStepResult
+}
+
+// Passed returns whether all steps of the job passed.
+func (r *WorkflowJobResult) Passed() bool {
+	for _, s := range r.Steps {
+		if !s.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// WorkflowResult is the outcome of running all jobs of a workflow.
+type WorkflowResult struct {
+	Name string
+	Jobs []*WorkflowJobResult
+}
+
+// Passed returns whether all jobs of the workflow passed.
+func (r *WorkflowResult) Passed() bool {
+	for _, j := range r.Jobs {
+		if !j.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *doltWorkflowManager) runWorkflow(ctx *sql.Context, config *WorkflowConfig) (*WorkflowResult, error) {
+	result := &WorkflowResult{Name: config.Name.Value}
+	for _, job := range config.Jobs {
+		jobResult := &WorkflowJobResult{Name: job.Name.Value}
+		for _, step := range job.Steps {
+			stepResult, err := d.runWorkflowStep(ctx, step)
+			if err != nil {
+				return nil, err
+			}
+			jobResult.Steps = append(jobResult.Steps, stepResult)
+		}
+		result.Jobs = append(result.Jobs, jobResult)
+	}
+	return result, nil
+}
+
+// runWorkflowStep runs a single step. Failures of the step itself are recorded in the returned
+// WorkflowStepResult, while the returned error is reserved for failures to run the step at all.
+func (d *doltWorkflowManager) runWorkflowStep(ctx *sql.Context, step Step) (*WorkflowStepResult, error) {
+	result := &WorkflowStepResult{Name: step.Name.Value, StartedAt: time.Now()}
+
+	stepType, err := getStepType(step)
+	if err != nil {
+		return nil, err
+	}
+
+	var failure string
+	switch stepType {
+	case WorkflowStepTypeSavedQuery:
+		failure, err = d.runSavedQueryStep(ctx, step)
+	case WorkflowStepTypeSqlAssertion:
+		failure, err = d.runSqlAssertionStep(ctx, step)
+	case WorkflowStepTypeSchemaAssertion:
+		failure, err = d.runSchemaAssertionStep(ctx, step)
+	case WorkflowStepTypeRowCount:
+		failure, err = d.runRowCountStep(ctx, step)
+	case WorkflowStepTypeDiffSize:
+		failure, err = d.runDiffSizeStep(ctx, step)
+	case WorkflowStepTypeVerifyConstraints:
+		failure, err = d.runVerifyConstraintsStep(ctx, step)
+	default:
+		return nil, ErrUnknownWorkflowStepType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.FinishedAt = time.Now()
+	if failure != "" {
+		result.Err = errors.New(failure)
+	}
+	return result, nil
+}
+
+// runSavedQueryStep and the other step runners return a description of why the step failed, or an empty
+// string if the step passed.
+func (d *doltWorkflowManager) runSavedQueryStep(ctx *sql.Context, step Step) (string, error) {
+	query, err := d.getSavedQuery(ctx, step.SavedQueryName.Value)
+	if err != nil {
+		if errors.Is(err, doltdb.ErrTableNotFound) || dtables.ErrQueryNotFound.Is(err) {
+			return fmt.Sprintf("saved query not found: %s", step.SavedQueryName.Value), nil
+		}
+		return "", err
+	}
+
+	sch, rows, failure := d.runStepQuery(ctx, query)
+	if failure != nil {
+		return failure.Error(), nil
+	}
+
+	if step.ExpectedColumns.Value != "" {
+		msg, err := d.checkExpectedCount("columns", step.ExpectedColumns.Value, int64(len(sch)))
+		if err != nil {
+			return "", err
+		}
+		if msg != "" {
+			return msg, nil
+		}
+	}
+
+	if step.ExpectedRows.Value != "" {
+		msg, err := d.checkExpectedCount("rows", step.ExpectedRows.Value, int64(len(rows)))
+		if err != nil {
+			return "", err
+		}
+		if msg != "" {
+			return msg, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (d *doltWorkflowManager) runSqlAssertionStep(ctx *sql.Context, step Step) (string, error) {
+	expected, err := expectedResultsFromYamlNode(step.ExpectedResults)
+	if err != nil {
+		return "", err
+	}
+
+	sch, rows, failure := d.runStepQuery(ctx, step.Query.Value)
+	if failure != nil {
+		return failure.Error(), nil
+	}
+
+	actual := make([][]*string, len(rows))
+	for i, row := range rows {
+		actual[i] = make([]*string, len(row))
+		for j, val := range row {
+			if val == nil {
+				continue
+			}
+			str, err := toUtf8StringValue(ctx, sch[j], val)
+			if err != nil {
+				return "", err
+			}
+			actual[i][j] = &str
+		}
+	}
+
+	if len(actual) != len(expected) {
+		return fmt.Sprintf("expected %d rows, got %d", len(expected), len(actual)), nil
+	}
+	for i := range expected {
+		if len(actual[i]) != len(expected[i]) {
+			return fmt.Sprintf("row %d: expected %d columns, got %d", i+1, len(expected[i]), len(actual[i])), nil
+		}
+		for j := range expected[i] {
+			if !nullableStringsEqual(expected[i][j], actual[i][j]) {
+				return fmt.Sprintf("row %d, column %s: expected %s, got %s", i+1, sch[j].Name, nullableStringToString(expected[i][j]), nullableStringToString(actual[i][j])), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func (d *doltWorkflowManager) runSchemaAssertionStep(ctx *sql.Context, step Step) (string, error) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return "", fmt.Errorf("roots not found in database %s", dbName)
+	}
+
+	tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, roots.Working, doltdb.TableName{Name: step.AssertTable.Value})
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return fmt.Sprintf("table not found: %s", step.AssertTable.Value), nil
+	}
+
+	if !isYamlNodeSet(step.ExpectedTableColumns) {
+		return "", nil
+	}
+
+	columns, err := stringsFromYamlNode(step.ExpectedTableColumns)
+	if err != nil {
+		return "", err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	missing := make([]string, 0)
+	for _, column := range columns {
+		if _, ok := sch.GetAllCols().GetByNameCaseInsensitive(column); !ok {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Sprintf("table %s is missing columns: %s", step.AssertTable.Value, strings.Join(missing, ", ")), nil
+	}
+
+	return "", nil
+}
+
+// runRowCountStep counts the rows of the step's table at HEAD. If the step has a base ref, the count at the merge
+// base of the base ref and HEAD is subtracted, so that the threshold applies to the change in the number of rows.
+func (d *doltWorkflowManager) runRowCountStep(ctx *sql.Context, step Step) (string, error) {
+	table := sql.QuoteIdentifier(step.RowCountTable.Value)
+	count, failure, err := d.countRows(ctx, fmt.Sprintf("select count(*) from %s;", table))
+	if err != nil || failure != "" {
+		return failure, err
+	}
+
+	kind := "rows"
+	if base := step.RowCountBase.Value; base != "" {
+		_, rows, qerr := d.runStepQuery(ctx, fmt.Sprintf("select dolt_merge_base('%s', 'HEAD');", escapeSqlString(base)))
+		if qerr != nil {
+			return qerr.Error(), nil
+		}
+		mergeBase := fmt.Sprint(rows[0][0])
+
+		// a table which does not exist at the merge base had no rows
+		baseCount, failure, err := d.countRows(ctx, fmt.Sprintf("select count(*) from %s as of '%s';", table, escapeSqlString(mergeBase)))
+		if err != nil {
+			return "", err
+		} else if failure != "" && !strings.Contains(failure, "table not found") {
+			return failure, nil
+		}
+		count -= baseCount
+		kind = fmt.Sprintf("change in rows since the merge base with %s", base)
+	}
+
+	msg, err := d.checkExpectedCount(kind, step.ExpectedRowCount.Value, count)
+	if err != nil {
+		return "", err
+	}
+	if msg != "" {
+		return msg, nil
+	}
+	return "", nil
+}
+
+// countRows runs the count query |query|. Query errors are returned as a step failure.
+func (d *doltWorkflowManager) countRows(ctx *sql.Context, query string) (int64, string, error) {
+	_, rows, failure := d.runStepQuery(ctx, query)
+	if failure != nil {
+		return 0, failure.Error(), nil
+	}
+	count, err := rowInt64(ctx, rows[0][0])
+	if err != nil {
+		return 0, "", err
+	}
+	return count, "", nil
+}
+
+// runDiffSizeStep counts the rows added, deleted and modified between the merge base of the step's
+// base ref and HEAD, and HEAD.
+func (d *doltWorkflowManager) runDiffSizeStep(ctx *sql.Context, step Step) (string, error) {
+	query := fmt.Sprintf("select table_name, rows_added, rows_deleted, rows_modified from dolt_diff_stat('%s...HEAD');", escapeSqlString(step.DiffBase.Value))
+	if step.DiffTable.Value != "" {
+		query = fmt.Sprintf("select table_name, rows_added, rows_deleted, rows_modified from dolt_diff_stat('%s...HEAD', '%s');", escapeSqlString(step.DiffBase.Value), escapeSqlString(step.DiffTable.Value))
+	}
+
+	_, rows, failure := d.runStepQuery(ctx, query)
+	if failure != nil {
+		return failure.Error(), nil
+	}
+
+	var changed int64
+	for _, row := range rows {
+		tableName := fmt.Sprint(row[0])
+		if doltdb.HasDoltPrefix(tableName) || doltdb.HasDoltCIPrefix(tableName) {
+			continue
+		}
+		for _, val := range row[1:] {
+			if val == nil {
+				continue
+			}
+			i, err := rowInt64(ctx, val)
+			if err != nil {
+				return "", err
+			}
+			changed += i
+		}
+	}
+
+	msg, err := d.checkExpectedCount("changed rows", step.ExpectedDiffRows.Value, changed)
+	if err != nil {
+		return "", err
+	}
+	if msg != "" {
+		return msg, nil
+	}
+	return "", nil
+}
+
+func (d *doltWorkflowManager) runVerifyConstraintsStep(ctx *sql.Context, step Step) (string, error) {
+	tables, err := stringsFromYamlNode(step.VerifyConstraints)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"'--all'", "'--output-only'"}
+	for _, table := range tables {
+		args = append(args, fmt.Sprintf("'%s'", escapeSqlString(table)))
+	}
+
+	_, rows, failure := d.runStepQuery(ctx, fmt.Sprintf("call dolt_verify_constraints(%s);", strings.Join(args, ", ")))
+	if failure != nil {
+		return failure.Error(), nil
+	}
+
+	violations, err := rowInt64(ctx, rows[0][0])
+	if err != nil {
+		return "", err
+	}
+	if violations != 0 {
+		return "constraint violations found", nil
+	}
+	return "", nil
+}
+
+// runStepQuery runs |query| and returns its schema and rows. Query errors are returned as a step failure.
+func (d *doltWorkflowManager) runStepQuery(ctx *sql.Context, query string) (sql.Schema, []sql.Row, error) {
+	sch, rowIter, _, err := d.queryFunc(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query error: %w", err)
+	}
+
+	rows, err := sql.RowIterToRows(ctx, rowIter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query error: %w", err)
+	}
+	return sch, rows, nil
+}
+
+func (d *doltWorkflowManager) getSavedQuery(ctx *sql.Context, savedQueryName string) (string, error) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return "", fmt.Errorf("roots not found in database %s", dbName)
+	}
+
+	sq, err := dtables.RetrieveFromQueryCatalog(ctx, roots.Working, savedQueryName)
+	if err != nil {
+		return "", err
+	}
+	return sq.Query, nil
+}
+
+// checkExpectedCount compares |actual| against the |expected| comparison string of a step and returns
+// a description of the failed assertion, or an empty string if the assertion holds.
+func (d *doltWorkflowManager) checkExpectedCount(kind, expected string, actual int64) (string, error) {
+	comparisonType, count, err := d.parseSavedQueryExpectedResultString(expected)
+	if err != nil {
+		return "", err
+	}
+
+	var ok bool
+	switch comparisonType {
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeEquals:
+		ok = actual == count
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeNotEquals:
+		ok = actual != count
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeGreaterThan:
+		ok = actual > count
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeGreaterThanOrEqual:
+		ok = actual >= count
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeLessThan:
+		ok = actual < count
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeLessThanOrEqual:
+		ok = actual <= count
+	case WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified:
+		ok = true
+	default:
+		return "", errors.New("unknown comparison type")
+	}
+
+	if ok {
+		return "", nil
+	}
+
+	expectedStr, err := d.toSavedQueryExpectedResultString(comparisonType, count)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("expected %s %s, got %d", kind, expectedStr, actual), nil
+}
+
+func rowInt64(ctx *sql.Context, val interface{}) (int64, error) {
+	i, _, err := types.Int64.Convert(ctx, val)
+	if err != nil {
+		return 0, err
+	}
+	return i.(int64), nil
+}
+
+func nullableStringsEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func nullableStringToString(s *string) string {
+	if s == nil {
+		return "NULL"
+	}
+	return fmt.Sprintf("'%s'", *s)
+}
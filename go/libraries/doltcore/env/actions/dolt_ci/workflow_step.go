@@ -26,6 +26,11 @@ type WorkflowStepType int
 const (
 	WorkflowStepTypeUnspecified WorkflowStepType = iota
 	WorkflowStepTypeSavedQuery
+	WorkflowStepTypeSqlAssertion
+	WorkflowStepTypeSchemaAssertion
+	WorkflowStepTypeRowCount
+	WorkflowStepTypeDiffSize
+	WorkflowStepTypeVerifyConstraints
 )
 
 type WorkflowStepId string
@@ -45,6 +50,16 @@ func ToWorkflowStepType(t int) (WorkflowStepType, error) {
 	switch t {
 	case int(WorkflowStepTypeSavedQuery):
 		return WorkflowStepTypeSavedQuery, nil
+	case int(WorkflowStepTypeSqlAssertion):
+		return WorkflowStepTypeSqlAssertion, nil
+	case int(WorkflowStepTypeSchemaAssertion):
+		return WorkflowStepTypeSchemaAssertion, nil
+	case int(WorkflowStepTypeRowCount):
+		return WorkflowStepTypeRowCount, nil
+	case int(WorkflowStepTypeDiffSize):
+		return WorkflowStepTypeDiffSize, nil
+	case int(WorkflowStepTypeVerifyConstraints):
+		return WorkflowStepTypeVerifyConstraints, nil
 	default:
 		return WorkflowStepTypeUnspecified, ErrUnknownWorkflowStepType
 	}
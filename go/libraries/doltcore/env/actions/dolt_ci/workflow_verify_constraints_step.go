@@ -0,0 +1,24 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dolt_ci
+
+type WorkflowVerifyConstraintsStepId string
+
+// WorkflowVerifyConstraintsStep asserts that dolt_verify_constraints finds no violations.
+type WorkflowVerifyConstraintsStep struct {
+	Id               *WorkflowVerifyConstraintsStepId `db:"id"`
+	WorkflowStepIdFK *WorkflowStepId                  `db:"workflow_step_id_fk"`
+	// Tables is a JSON array of the tables to verify. An empty array verifies all tables.
+	Tables string `db:"tables"`
+}
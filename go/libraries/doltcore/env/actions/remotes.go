@@ -24,6 +24,7 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
@@ -43,6 +44,7 @@ var ErrFailedToDeleteRemote = errors.New("failed to delete remote")
 var ErrFailedToGetRemoteDb = errors.New("failed to get remote db")
 var ErrUnknownPushErr = errors.New("unknown push error")
 var ErrShallowPushImpossible = errors.New("shallow repository missing chunks to complete push")
+var ErrCannotPushToBundle = errors.New("cannot push to a bundle; bundle remotes are read only")
 
 type ProgStarter func(ctx context.Context) (*sync.WaitGroup, chan pull.Stats)
 type ProgStopper func(cancel context.CancelFunc, wg *sync.WaitGroup, statsCh chan pull.Stats)
@@ -103,6 +105,10 @@ func Push(ctx context.Context, tempTableDir string, mode ref.UpdateMode, destRef
 // DoPush returns a message about whether the push was successful for each branch or a tag.
 // This includes if there is a new remote branch created, upstream is set or push was rejected for a branch.
 func DoPush[C doltdb.Context](ctx C, pushMeta *env.PushOptions[C], progStarter ProgStarter, progStopper ProgStopper) (returnMsg string, err error) {
+	if strings.HasPrefix(pushMeta.Remote.Url, dbfactory.BundleScheme+"://") {
+		return "", ErrCannotPushToBundle
+	}
+
 	var successPush, setUpstreamPush, failedPush []string
 	for _, targets := range pushMeta.Targets {
 		err = push(ctx, pushMeta.Rsr, pushMeta.TmpDir, pushMeta.SrcDb, pushMeta.DestDb, pushMeta.Remote, targets, progStarter, progStopper)
@@ -403,6 +409,78 @@ func FetchFollowTags(ctx context.Context, tempTableDir string, srcDB, destDB *do
 	return nil
 }
 
+// PushNotes copies the notes in |srcDB| to |destDB|, overwriting any note in |destDB| for the same commit. Only notes
+// on commits which have already been pushed to |destDB| are copied. Returns the number of notes copied.
+func PushNotes(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper) (int, error) {
+	return copyNotes(ctx, tempTableDir, srcDB, destDB, progStarter, progStopper)
+}
+
+// FetchNotes copies the notes in the remote database |srcDB| to |destDB|, overwriting any local note for the same
+// commit. Only notes on commits which have already been fetched into |destDB| are copied. Returns the number of notes
+// copied.
+func FetchNotes(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper) (int, error) {
+	return copyNotes(ctx, tempTableDir, srcDB, destDB, progStarter, progStopper)
+}
+
+func copyNotes(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper) (int, error) {
+	srcNotes, err := srcDB.GetNoteRefsWithHashes(ctx)
+	if err != nil {
+		return 0, err
+	}
+	destNotes, err := destDB.GetNoteRefsWithHashes(ctx)
+	if err != nil {
+		return 0, err
+	}
+	destAddrs := make(map[string]hash.Hash, len(destNotes))
+	for _, n := range destNotes {
+		destAddrs[n.Ref.GetPath()] = n.Hash
+	}
+
+	copied := 0
+	for _, n := range srcNotes {
+		if addr, ok := destAddrs[n.Ref.GetPath()]; ok && addr == n.Hash {
+			continue
+		}
+
+		cmHash, ok := hash.MaybeParse(n.Ref.GetPath())
+		if !ok {
+			continue
+		}
+		has, err := destDB.Has(ctx, cmHash)
+		if err != nil {
+			return copied, err
+		}
+		if !has {
+			continue
+		}
+		optCmt, err := destDB.ReadCommit(ctx, cmHash)
+		if err != nil {
+			return copied, err
+		}
+		if _, ok := optCmt.ToCommit(); !ok {
+			continue
+		}
+
+		newCtx, cancelFunc := context.WithCancel(ctx)
+		wg, statsCh := progStarter(newCtx)
+		err = destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{n.Hash}, statsCh, nil)
+		progStopper(cancelFunc, wg, statsCh)
+		if err == pull.ErrDBUpToDate {
+			err = nil
+		}
+		if err != nil {
+			return copied, err
+		}
+
+		if err = destDB.SetHead(ctx, n.Ref, n.Hash); err != nil {
+			return copied, err
+		}
+		copied++
+	}
+
+	return copied, nil
+}
+
 // FetchRemoteBranch fetches and returns the |Commit| corresponding to the remote ref given. Returns an error if the
 // remote reference doesn't exist or can't be fetched. Blocks until the fetch is complete.
 func FetchRemoteBranch(
@@ -607,6 +685,15 @@ func fetchRefSpecsWithDepth[C doltdb.Context](
 		}
 	}
 
+	// A sparse clone only fetches the tables it was cloned with.
+	if len(remote.Tables) > 0 {
+		placeholders, err := dbData.Ddb.PersistSparseTables(ctx, srcDB, toFetch, remote.Tables)
+		if err != nil {
+			return err
+		}
+		skipCmts.InsertAll(placeholders)
+	}
+
 	err = func() error {
 		newCtx := ctx
 		var statsCh chan pull.Stats
@@ -907,10 +994,20 @@ func SyncRoots(ctx context.Context, srcDb, destDb *doltdb.DoltDB, tempTableDir s
 		return err
 	}
 
+	return commitRootWithRetries(ctx, destDb, destRoot, func(last hash.Hash) (bool, error) {
+		return destDb.CommitRoot(ctx, srcRoot, last)
+	})
+}
+
+// commitRootWithRetries calls |commit| with the current root of |destDb| until it succeeds in setting a new root,
+// retrying a limited number of times if the root of |destDb| is changed concurrently. |destRoot| is the first root
+// |commit| is called with.
+func commitRootWithRetries(ctx context.Context, destDb *doltdb.DoltDB, destRoot hash.Hash, commit func(last hash.Hash) (bool, error)) error {
+	var err error
 	var numRetries int
 	var success bool
 	for err == nil && !success && numRetries < 10 {
-		success, err = destDb.CommitRoot(ctx, srcRoot, destRoot)
+		success, err = commit(destRoot)
 		if err == nil && !success {
 			destRoot, err = destDb.NomsRoot(ctx)
 			numRetries += 1
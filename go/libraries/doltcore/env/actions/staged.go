@@ -16,6 +16,7 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
@@ -161,7 +162,10 @@ func stageTables(ctx context.Context, roots doltdb.Roots, tbls []doltdb.TableNam
 func clearEmptyConflicts(ctx context.Context, tbls []doltdb.TableName, working doltdb.RootValue) (doltdb.RootValue, error) {
 	for _, tblName := range tbls {
 		tbl, ok, err := working.GetTable(ctx, tblName)
-		if err != nil {
+		if errors.Is(err, doltdb.ErrSparseTable) {
+			// the placeholder of a table left out of a sparse clone has no conflicts
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 		if !ok {
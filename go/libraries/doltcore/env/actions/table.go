@@ -16,6 +16,7 @@ package actions
 
 import (
 	"context"
+	"errors"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -104,6 +105,22 @@ func MoveTablesBetweenRoots(ctx context.Context, tbls []doltdb.TableName, src, d
 		}
 	}
 
+	// Tables left out of a sparse clone aren't diffed, so their placeholders are moved as they are.
+	for _, tblName := range tbls {
+		_, _, err = src.GetTable(ctx, tblName)
+		if !errors.Is(err, doltdb.ErrSparseTable) {
+			continue
+		}
+		h, _, err := src.GetTableHash(ctx, tblName)
+		if err != nil {
+			return nil, err
+		}
+		dest, err = dest.SetTableHash(ctx, tblName, h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	dest, err = dest.PutForeignKeyCollection(ctx, stagedFKs)
 	if err != nil {
 		return nil, err
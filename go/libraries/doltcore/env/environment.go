@@ -39,6 +39,7 @@ import (
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -122,6 +123,7 @@ func (dEnv *DoltEnv) LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBi
 			return err
 		}
 		dEnv.doltDB = ddb
+		dEnv.setLazyCloneSource(ddb)
 	}
 	return nil
 }
@@ -212,6 +214,9 @@ func LoadDoltDB(ctx context.Context, fs filesys.Filesys, urlStr string, dEnv *Do
 		dEnv.doltDB = ddb
 		dEnv.DBLoadError = dbLoadErr
 		dEnv.urlStr = urlStr
+		if dbLoadErr == nil {
+			dEnv.setLazyCloneSource(ddb)
+		}
 
 		if dbLoadErr == nil && dEnv.HasDoltDir() {
 			if !dEnv.HasDoltTempTableDir() {
@@ -354,8 +359,8 @@ func (dEnv *DoltEnv) HasDoltDir() bool {
 }
 
 func (dEnv *DoltEnv) HasDoltDataDir() bool {
-	exists, isDir := dEnv.FS.Exists(dbfactory.DoltDataDir)
-	return exists && isDir
+	_, ok, err := dbfactory.DataDirPath(dEnv.FS)
+	return ok && err == nil
 }
 
 // HasDoltSqlServerInfo returns true if this Dolt environment has a sql-server.info file, indicating
@@ -433,6 +438,16 @@ func (dEnv *DoltEnv) InitRepoWithTime(ctx context.Context, nbf *types.NomsBinFor
 }
 
 func (dEnv *DoltEnv) InitRepoWithCommitMetaGenerator(ctx context.Context, nbf *types.NomsBinFormat, branchName string, commitMeta datas.CommitMetaGenerator) error {
+	return dEnv.initRepo(ctx, nbf, branchName, commitMeta, nil)
+}
+
+// InitEncryptedRepoWithCommitMetaGenerator is like InitRepoWithCommitMetaGenerator, but the chunk data of the new
+// repository is encrypted at rest with the primary key of |keys|.
+func (dEnv *DoltEnv) InitEncryptedRepoWithCommitMetaGenerator(ctx context.Context, nbf *types.NomsBinFormat, branchName string, commitMeta datas.CommitMetaGenerator, keys *nbs.EncryptionKeys) error {
+	return dEnv.initRepo(ctx, nbf, branchName, commitMeta, keys)
+}
+
+func (dEnv *DoltEnv) initRepo(ctx context.Context, nbf *types.NomsBinFormat, branchName string, commitMeta datas.CommitMetaGenerator, keys *nbs.EncryptionKeys) error {
 	doltDir, err := dEnv.createDirectories(".")
 
 	if err != nil {
@@ -441,6 +456,10 @@ func (dEnv *DoltEnv) InitRepoWithCommitMetaGenerator(ctx context.Context, nbf *t
 
 	err = dEnv.configureRepo(doltDir)
 
+	if err == nil && keys != nil {
+		_, err = nbs.InitEncryption(ctx, filepath.Join(doltDir, dbfactory.DataDir), keys)
+	}
+
 	if err == nil {
 		err = dEnv.InitDBAndRepoStateWithCommitMetaGenerator(ctx, nbf, branchName, commitMeta)
 	}
@@ -0,0 +1,123 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// HookType is the point in an operation at which a repository hook runs.
+type HookType string
+
+const (
+	// PreCommitHook runs before a commit is created, and aborts the commit if it fails.
+	PreCommitHook HookType = "pre-commit"
+	// PreMergeHook runs before the result of a merge is applied, and aborts the merge if it fails.
+	PreMergeHook HookType = "pre-merge"
+	// PostCommitHook runs after a commit is created. Its failure is reported, but cannot undo the commit.
+	PostCommitHook HookType = "post-commit"
+)
+
+var hookConfigKeys = map[HookType][2]string{
+	PreCommitHook:  {config.PreCommitHookSqlKey, config.PreCommitHookExecKey},
+	PreMergeHook:   {config.PreMergeHookSqlKey, config.PreMergeHookExecKey},
+	PostCommitHook: {config.PostCommitHookSqlKey, config.PostCommitHookExecKey},
+}
+
+// RepoHook is a user defined hook, configured in the local config of a repository with the keys
+// hooks.<type>.sql and hooks.<type>.exec. A hook can have both a query and an executable, in which case the query runs
+// first.
+type RepoHook struct {
+	Type HookType
+	// Sql is a query which fails the hook if it returns any rows.
+	Sql string
+	// Exec is a command run by the shell, which fails the hook if it exits with a non-zero status.
+	Exec string
+}
+
+// IsEmpty returns whether neither a query nor an executable is configured for the hook.
+func (h RepoHook) IsEmpty() bool {
+	return h.Sql == "" && h.Exec == ""
+}
+
+// LoadRepoHook reads the hook of the type given from the local config of the repository in |fs|. The returned hook is
+// empty if the repository has no local config or doesn't configure the hook.
+func LoadRepoHook(fs filesys.ReadWriteFS, hookType HookType) (RepoHook, error) {
+	hook := RepoHook{Type: hookType}
+	keys, ok := hookConfigKeys[hookType]
+	if !ok {
+		return hook, fmt.Errorf("unknown hook type '%s'", hookType)
+	}
+
+	path := getLocalConfigPath()
+	if exists, _ := fs.Exists(path); !exists {
+		return hook, nil
+	}
+	cfg, err := config.FromFile(path, fs)
+	if err != nil {
+		return hook, err
+	}
+
+	hook.Sql = strings.TrimSpace(cfg.GetStringOrDefault(keys[0], ""))
+	hook.Exec = strings.TrimSpace(cfg.GetStringOrDefault(keys[1], ""))
+	return hook, nil
+}
+
+// RunExec runs the hook's executable in |dir|. |vars| are added to the environment of the process, along with
+// DOLT_HOOK, which is set to the type of the hook. Returns an error including the output of the process if it fails.
+func (h RepoHook) RunExec(ctx context.Context, dir string, vars map[string]string) error {
+	if h.Exec == "" {
+		return nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", h.Exec)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", h.Exec)
+	}
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "DOLT_HOOK="+string(h.Type))
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmd.Env = append(cmd.Env, name+"="+vars[name])
+	}
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(out.String())
+		if output == "" {
+			return fmt.Errorf("%s hook failed: %w", h.Type, err)
+		}
+		return fmt.Errorf("%s hook failed: %w\n%s", h.Type, err, output)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+// LazyCloneRemote returns the remote which a lazy clone fetches missing data from. This is the remote the database was
+// cloned from, unless the clone.lazyremote config value was changed.
+func (dEnv *DoltEnv) LazyCloneRemote() (Remote, error) {
+	name := "origin"
+	if dEnv.Config != nil {
+		name = dEnv.Config.GetStringOrDefault(config.LazyCloneRemoteKey, name)
+	}
+
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return NoRemote, err
+	}
+	r, ok := remotes.Get(name)
+	if !ok {
+		return NoRemote, fmt.Errorf("%w: '%s', which this lazy clone fetches missing data from", ErrRemoteNotFound, name)
+	}
+	return r, nil
+}
+
+// setLazyCloneSource makes |ddb|, if it is a lazy clone, fetch the data it is missing from its remote.
+func (dEnv *DoltEnv) setLazyCloneSource(ddb *doltdb.DoltDB) {
+	ddb.SetLazyCloneSource(func(ctx context.Context) (*doltdb.DoltDB, error) {
+		r, err := dEnv.LazyCloneRemote()
+		if err != nil {
+			return nil, err
+		}
+		return r.GetRemoteDB(ctx, ddb.Format(), dEnv)
+	})
+}
@@ -31,6 +31,9 @@ const (
 	GlobalConfigFile = "config_global.json"
 
 	repoStateFile = "repo_state.json"
+
+	worktreesFile     = "worktrees.json"
+	worktreesLockFile = "worktrees.lock"
 )
 
 // HomeDirProvider is a function that returns the users home directory.  This is where global dolt state is stored for
@@ -87,6 +90,14 @@ func getRepoStateFile() string {
 	return filepath.Join(dbfactory.DoltDir, repoStateFile)
 }
 
+func getWorktreesFile() string {
+	return filepath.Join(dbfactory.DoltDir, worktreesFile)
+}
+
+func getWorktreesLockFile() string {
+	return filepath.Join(dbfactory.DoltDir, worktreesLockFile)
+}
+
 func getHomeDir(hdp HomeDirProvider) (string, error) {
 	homeDir, err := hdp()
 	if err != nil {
@@ -76,10 +76,12 @@ type Remote struct {
 	Url        string            `json:"url"`
 	FetchSpecs []string          `json:"fetch_specs"`
 	Params     map[string]string `json:"params"`
+	// Tables is the set of tables fetched from this remote by a sparse clone. All tables are fetched when it is empty.
+	Tables []string `json:"tables,omitempty"`
 }
 
 func NewRemote(name, url string, params map[string]string) Remote {
-	return Remote{name, url, []string{"refs/heads/*:refs/remotes/" + name + "/*"}, params}
+	return Remote{Name: name, Url: url, FetchSpecs: []string{"refs/heads/*:refs/remotes/" + name + "/*"}, Params: params}
 }
 
 func (r *Remote) GetParam(pName string) (string, bool) {
@@ -648,7 +650,25 @@ func GetAbsRemoteUrl(fs filesys2.Filesys, cfg config.ReadableConfig, urlArg stri
 		return "", "", err
 	}
 
+	if u.Scheme == "" && fs != nil {
+		// a path to a bundle file is a bundle remote
+		if exists, isDir := fs.Exists(urlArg); exists && !isDir {
+			absPath, err := fs.Abs(urlArg)
+			if err != nil {
+				return "", "", err
+			}
+			return dbfactory.BundleScheme, dbfactory.BundleScheme + "://" + filepath.ToSlash(absPath), nil
+		}
+	}
+
 	if u.Scheme != "" && fs != nil {
+		if u.Scheme == dbfactory.BundleScheme {
+			absPath, err := fs.Abs(filepath.Clean(u.Host + u.Path))
+			if err != nil {
+				return "", "", err
+			}
+			return u.Scheme, u.Scheme + "://" + filepath.ToSlash(absPath), nil
+		}
 		if u.Scheme == dbfactory.FileScheme || u.Scheme == dbfactory.LocalBSScheme {
 			absUrl, err := getAbsFileRemoteUrl(u, fs)
 
@@ -0,0 +1,359 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dolthub/fslock"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var ErrWorktreeNotFound = errors.New("not a linked worktree of this repository")
+var ErrRemoveMainWorktree = errors.New("the main worktree cannot be removed")
+var ErrRemoveCurrentWorktree = errors.New("the current worktree cannot be removed")
+var ErrWorktreesUnsupported = errors.New("worktrees are only supported for repositories on the local filesystem")
+var ErrWorktreesLocked = errors.New("timed out waiting for the worktrees lock of the repository")
+
+const worktreesLockTimeout = 10 * time.Second
+
+// ErrBranchCheckedOutInWorktree is returned when a branch is checked out, or a worktree is added, for a branch which is
+// already checked out in another worktree.
+type ErrBranchCheckedOutInWorktree struct {
+	Branch string
+	Path   string
+}
+
+func (e ErrBranchCheckedOutInWorktree) Error() string {
+	return fmt.Sprintf("branch '%s' is already checked out in worktree '%s'", e.Branch, e.Path)
+}
+
+// A repository can have several worktrees, each of which is a directory with its own .dolt/repo_state.json, and so its
+// own HEAD. The main worktree is the directory whose .dolt directory holds the chunk store. In place of a data
+// directory, a linked worktree has a data dir pointer file naming the chunk store of the main worktree (see
+// dbfactory.DataDirPath). Since working sets are stored in the chunk store per branch, each worktree gets its own
+// working set as long as no two worktrees check out the same branch, which is enforced wherever a branch is checked
+// out, renamed, deleted or force updated, while holding the worktrees lock (see LockWorktrees), and wherever a working
+// set is written.
+//
+// worktreeConfig is stored in .dolt/worktrees.json. In the main worktree, it lists the linked worktrees. In a linked
+// worktree, it records the main worktree.
+type worktreeConfig struct {
+	Main      string   `json:"main,omitempty"`
+	Worktrees []string `json:"worktrees,omitempty"`
+}
+
+// Worktree describes a worktree of a repository.
+type Worktree struct {
+	// Path is the absolute path of the worktree directory.
+	Path string
+	// Head is the branch checked out in the worktree. It is nil if the worktree could not be read, for example because
+	// its directory was deleted.
+	Head ref.DoltRef
+	// Main is true for the main worktree, which holds the chunk store.
+	Main bool
+}
+
+func loadWorktreeConfig(fs filesys.ReadWriteFS) (*worktreeConfig, error) {
+	path := getWorktreesFile()
+	if exists, _ := fs.Exists(path); !exists {
+		return &worktreeConfig{}, nil
+	}
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg worktreeConfig
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (cfg *worktreeConfig) save(fs filesys.ReadWriteFS) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(getWorktreesFile(), data, os.ModePerm)
+}
+
+// mainWorktree returns the path, filesystem and worktree config of the main worktree of the repository whose .dolt
+// directory is in |fs|.
+func mainWorktree(fs filesys.Filesys) (string, filesys.Filesys, *worktreeConfig, error) {
+	cfg, err := loadWorktreeConfig(fs)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if cfg.Main == "" {
+		path, err := fs.Abs("")
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return path, fs, cfg, nil
+	}
+
+	mainFS, err := fs.WithWorkingDir(cfg.Main)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	mainCfg, err := loadWorktreeConfig(mainFS)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return cfg.Main, mainFS, mainCfg, nil
+}
+
+// worktrees returns the worktrees of the repository whose .dolt directory is in |fs|, starting with the main worktree.
+func worktrees(fs filesys.Filesys) ([]Worktree, error) {
+	mainPath, _, cfg, err := mainWorktree(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees := make([]Worktree, 0, len(cfg.Worktrees)+1)
+	for i, path := range append([]string{mainPath}, cfg.Worktrees...) {
+		wt := Worktree{Path: path, Main: i == 0}
+		if wtFS, err := fs.WithWorkingDir(path); err == nil {
+			if rs, err := LoadRepoState(wtFS); err == nil {
+				wt.Head = rs.CWBHeadRef()
+			}
+		}
+		worktrees = append(worktrees, wt)
+	}
+	return worktrees, nil
+}
+
+// LockWorktrees takes the worktrees lock of the repository whose .dolt directory is in |fs|. The lock file is in the
+// .dolt directory of the main worktree, so it is shared by all worktrees of the repository. Worktrees are only added
+// or removed, and branches are only checked out, renamed, deleted or force updated, while holding it, so that
+// checking that a branch is not checked out in another worktree and acting on it can't race. The returned function
+// releases the lock.
+func LockWorktrees(fs filesys.Filesys) (func(), error) {
+	if _, ok := fs.(*filesys.InMemFS); ok {
+		return func() {}, nil
+	}
+	_, mainFS, _, err := mainWorktree(fs)
+	if err != nil {
+		return nil, err
+	}
+	if _, isDir := mainFS.Exists(dbfactory.DoltDir); !isDir {
+		return func() {}, nil
+	}
+	path, err := mainFS.Abs(getWorktreesLockFile())
+	if err != nil {
+		return nil, err
+	}
+	lck := fslock.New(path)
+	err = lck.LockWithTimeout(worktreesLockTimeout)
+	if errors.Is(err, fslock.ErrTimeout) {
+		return nil, ErrWorktreesLocked
+	} else if errors.Is(err, os.ErrPermission) {
+		// Worktrees can't be added to a repository which can't be written to.
+		return func() {}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return func() { _ = lck.Unlock() }, nil
+}
+
+// Worktrees returns the worktrees of this environment's repository, starting with the main worktree.
+func (dEnv *DoltEnv) Worktrees() ([]Worktree, error) {
+	return worktrees(dEnv.FS)
+}
+
+// CheckBranchNotInOtherWorktree returns ErrBranchCheckedOutInWorktree if |branch| is checked out in any worktree of
+// the repository whose .dolt directory is in |fs| other than that one.
+func CheckBranchNotInOtherWorktree(fs filesys.Filesys, branch string) error {
+	cfg, err := loadWorktreeConfig(fs)
+	if err != nil {
+		return err
+	}
+	if cfg.Main == "" && len(cfg.Worktrees) == 0 {
+		return nil
+	}
+	current, err := fs.Abs("")
+	if err != nil {
+		return err
+	}
+	return checkBranchNotInWorktree(fs, branch, current)
+}
+
+// checkBranchNotInWorktree returns ErrBranchCheckedOutInWorktree if |branch| is checked out in any worktree of the
+// repository whose .dolt directory is in |fs| other than the one at |exclude|.
+func checkBranchNotInWorktree(fs filesys.Filesys, branch string, exclude string) error {
+	worktrees, err := worktrees(fs)
+	if err != nil {
+		return err
+	}
+	branchRef := ref.NewBranchRef(branch)
+	for _, wt := range worktrees {
+		if wt.Path != exclude && wt.Head != nil && ref.Equals(wt.Head, branchRef) {
+			return ErrBranchCheckedOutInWorktree{Branch: branch, Path: wt.Path}
+		}
+	}
+	return nil
+}
+
+// AddWorktree creates a linked worktree at |path| with |branch| checked out. The worktree shares this repository's
+// chunk store, and starts with a copy of the main worktree's remotes and branch configuration.
+func (dEnv *DoltEnv) AddWorktree(ctx context.Context, path string, branch string) error {
+	if _, ok := dEnv.FS.(*filesys.InMemFS); ok {
+		return ErrWorktreesUnsupported
+	}
+
+	unlock, err := LockWorktrees(dEnv.FS)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	mainPath, mainFS, cfg, err := mainWorktree(dEnv.FS)
+	if err != nil {
+		return err
+	}
+	absPath, err := dEnv.FS.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	name, ok, err := dEnv.DoltDB(ctx).HasBranch(ctx, branch)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", doltdb.ErrBranchNotFound, branch)
+	}
+	branch = name
+	if err = checkBranchNotInWorktree(dEnv.FS, branch, ""); err != nil {
+		return err
+	}
+
+	if exists, isDir := dEnv.FS.Exists(absPath); exists && !isDir {
+		return fmt.Errorf("%w: '%s' exists but it's a file not a directory", ErrCannotCreateDirPathIsFile, absPath)
+	} else if exists {
+		if doltDirExists, _ := dEnv.FS.Exists(filepath.Join(absPath, dbfactory.DoltDir)); doltDirExists {
+			return fmt.Errorf("%w: .dolt directory already exists at '%s'", ErrCannotCreateDoltDirAlreadyExists, absPath)
+		}
+	}
+
+	mainRS, err := LoadRepoState(mainFS)
+	if err != nil {
+		return err
+	}
+
+	wtFS, err := dEnv.FS.WithWorkingDir(absPath)
+	if err != nil {
+		return err
+	}
+	if err = wtFS.MkDirs(dbfactory.DoltDir); err != nil {
+		return err
+	}
+	err = initWorktree(wtFS, mainPath, mainRS, branch)
+	if err != nil {
+		_ = wtFS.Delete(dbfactory.DoltDir, true)
+		return err
+	}
+
+	cfg.Worktrees = append(cfg.Worktrees, absPath)
+	return cfg.save(mainFS)
+}
+
+func initWorktree(wtFS filesys.Filesys, mainPath string, mainRS *RepoState, branch string) error {
+	err := dbfactory.WriteDataDirPointer(wtFS, filepath.Join(mainPath, dbfactory.DoltDataDir))
+	if err != nil {
+		return err
+	}
+
+	rs := &RepoState{
+		Head:     ref.MarshalableRef{Ref: ref.NewBranchRef(branch)},
+		Remotes:  mainRS.Remotes,
+		Backups:  mainRS.Backups,
+		Branches: mainRS.Branches,
+	}
+	if err = rs.Save(wtFS); err != nil {
+		return err
+	}
+
+	wtCfg := &worktreeConfig{Main: mainPath}
+	return wtCfg.save(wtFS)
+}
+
+// RemoveWorktree removes the linked worktree at |path|. Only the worktree's .dolt directory is deleted, along with the
+// worktree directory if it is then empty. Uncommitted changes in the worktree are kept in the working set of its
+// branch.
+func (dEnv *DoltEnv) RemoveWorktree(path string) error {
+	unlock, err := LockWorktrees(dEnv.FS)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	mainPath, mainFS, cfg, err := mainWorktree(dEnv.FS)
+	if err != nil {
+		return err
+	}
+	absPath, err := dEnv.FS.Abs(path)
+	if err != nil {
+		return err
+	}
+	current, err := dEnv.FS.Abs("")
+	if err != nil {
+		return err
+	}
+
+	if absPath == mainPath {
+		return ErrRemoveMainWorktree
+	}
+	if absPath == current {
+		return ErrRemoveCurrentWorktree
+	}
+	idx := -1
+	for i, wt := range cfg.Worktrees {
+		if wt == absPath {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrWorktreeNotFound, path)
+	}
+
+	// A linked worktree has a data dir pointer file rather than a data directory, so deleting its .dolt directory can
+	// never touch the chunk store. Refuse to delete one which has a data directory of its own.
+	if _, isDir := dEnv.FS.Exists(filepath.Join(absPath, dbfactory.DoltDataDir)); isDir {
+		return fmt.Errorf("%w: %s has a data directory of its own", ErrWorktreeNotFound, path)
+	}
+	if exists, _ := dEnv.FS.Exists(filepath.Join(absPath, dbfactory.DoltDir)); exists {
+		if err = dEnv.FS.Delete(filepath.Join(absPath, dbfactory.DoltDir), true); err != nil {
+			return err
+		}
+	}
+	// Only succeeds if the directory is empty.
+	_ = dEnv.FS.Delete(absPath, false)
+
+	cfg.Worktrees = append(cfg.Worktrees[:idx], cfg.Worktrees[idx+1:]...)
+	return cfg.save(mainFS)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolthub/fslock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestWorktrees(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	homeDir := filepath.Join(root, "home")
+	mainDir := filepath.Join(root, "main")
+	wtDir := filepath.Join(root, "wt")
+	require.NoError(t, os.MkdirAll(homeDir, os.ModePerm))
+	require.NoError(t, os.MkdirAll(mainDir, os.ModePerm))
+
+	dEnv := createFileTestEnv(t, mainDir, homeDir)
+	require.NoError(t, dEnv.InitRepo(ctx, types.Format_Default, "bill billerson", "bill@billerson.com", DefaultInitBranch))
+	defer dEnv.DoltDB(ctx).Close()
+
+	ddb := dEnv.DoltDB(ctx)
+	head, err := ddb.ResolveCommitRef(ctx, dEnv.RepoState.CWBHeadRef())
+	require.NoError(t, err)
+	for _, branch := range []string{"b1", "b2"} {
+		require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef(branch), head, nil))
+	}
+
+	// No worktrees have been added yet, so every branch is free.
+	require.NoError(t, CheckBranchNotInOtherWorktree(dEnv.FS, "b1"))
+
+	require.NoError(t, dEnv.AddWorktree(ctx, wtDir, "b1"))
+	err = dEnv.AddWorktree(ctx, filepath.Join(root, "wt2"), "b1")
+	assert.ErrorAs(t, err, &ErrBranchCheckedOutInWorktree{})
+	err = dEnv.AddWorktree(ctx, filepath.Join(root, "wt2"), DefaultInitBranch)
+	assert.ErrorAs(t, err, &ErrBranchCheckedOutInWorktree{})
+
+	t.Run("data dir pointer", func(t *testing.T) {
+		info, err := os.Lstat(filepath.Join(wtDir, dbfactory.DoltDataDir))
+		require.NoError(t, err)
+		assert.True(t, info.Mode().IsRegular())
+
+		wtFS, err := filesys.LocalFilesysWithWorkingDir(wtDir)
+		require.NoError(t, err)
+		path, ok, err := dbfactory.DataDirPath(wtFS)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, filepath.Join(mainDir, dbfactory.DoltDataDir), path)
+	})
+
+	wtEnv := createFileTestEnv(t, wtDir, homeDir)
+	require.NoError(t, wtEnv.DBLoadError)
+	assert.Equal(t, ref.NewBranchRef("b1"), wtEnv.RepoState.CWBHeadRef())
+	assert.True(t, wtEnv.HasDoltDataDir())
+
+	t.Run("list", func(t *testing.T) {
+		for _, e := range []*DoltEnv{dEnv, wtEnv} {
+			wts, err := e.Worktrees()
+			require.NoError(t, err)
+			require.Len(t, wts, 2)
+			assert.True(t, wts[0].Main)
+			assert.Equal(t, mainDir, wts[0].Path)
+			assert.Equal(t, ref.NewBranchRef(DefaultInitBranch), wts[0].Head)
+			assert.False(t, wts[1].Main)
+			assert.Equal(t, wtDir, wts[1].Path)
+			assert.Equal(t, ref.NewBranchRef("b1"), wts[1].Head)
+		}
+	})
+
+	t.Run("check branch", func(t *testing.T) {
+		var wtErr ErrBranchCheckedOutInWorktree
+		require.ErrorAs(t, CheckBranchNotInOtherWorktree(dEnv.FS, "b1"), &wtErr)
+		assert.Equal(t, wtDir, wtErr.Path)
+		require.ErrorAs(t, CheckBranchNotInOtherWorktree(wtEnv.FS, DefaultInitBranch), &wtErr)
+		assert.Equal(t, mainDir, wtErr.Path)
+
+		// A worktree's own branch, and branches not checked out anywhere, are free.
+		assert.NoError(t, CheckBranchNotInOtherWorktree(dEnv.FS, DefaultInitBranch))
+		assert.NoError(t, CheckBranchNotInOtherWorktree(wtEnv.FS, "b1"))
+		assert.NoError(t, CheckBranchNotInOtherWorktree(dEnv.FS, "b2"))
+		assert.NoError(t, CheckBranchNotInOtherWorktree(wtEnv.FS, "b2"))
+	})
+
+	t.Run("lock", func(t *testing.T) {
+		// The lock is in the main worktree, so it is shared with the linked worktrees.
+		unlock, err := LockWorktrees(wtEnv.FS)
+		require.NoError(t, err)
+		lck := fslock.New(filepath.Join(mainDir, getWorktreesLockFile()))
+		assert.ErrorIs(t, lck.TryLock(), fslock.ErrLocked)
+		unlock()
+		require.NoError(t, lck.TryLock())
+		require.NoError(t, lck.Unlock())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		assert.ErrorIs(t, wtEnv.RemoveWorktree(mainDir), ErrRemoveMainWorktree)
+		assert.ErrorIs(t, wtEnv.RemoveWorktree(wtDir), ErrRemoveCurrentWorktree)
+		assert.ErrorIs(t, dEnv.RemoveWorktree(filepath.Join(root, "wt2")), ErrWorktreeNotFound)
+
+		require.NoError(t, dEnv.RemoveWorktree(wtDir))
+		_, err := os.Stat(wtDir)
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(filepath.Join(mainDir, dbfactory.DoltDataDir))
+		assert.NoError(t, err)
+
+		wts, err := dEnv.Worktrees()
+		require.NoError(t, err)
+		assert.Len(t, wts, 1)
+		assert.NoError(t, CheckBranchNotInOtherWorktree(dEnv.FS, "b1"))
+	})
+}
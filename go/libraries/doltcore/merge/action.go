@@ -42,6 +42,7 @@ type MergeSpec struct {
 	NoCommit        bool
 	NoEdit          bool
 	Force           bool
+	NoVerify        bool
 	Email           string
 	Name            string
 	Date            time.Time
@@ -73,6 +74,12 @@ func WithForce(force bool) MergeSpecOpt {
 	}
 }
 
+func WithNoVerify(noVerify bool) MergeSpecOpt {
+	return func(ms *MergeSpec) {
+		ms.NoVerify = noVerify
+	}
+}
+
 func WithSquash(squash bool) MergeSpecOpt {
 	return func(ms *MergeSpec) {
 		ms.Squash = squash
@@ -15,6 +15,7 @@
 package merge
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -52,6 +53,7 @@ func rebuildFullTextIndexes(ctx *sql.Context, mergedRoot, ourRoot, theirRoot dol
 	// tables, which no longer have a parent table, will be deleted, for
 	// example, because they will not appear in this set.
 	doNotDeleteTables := make(map[string]struct{})
+	hasSparseTables := false
 
 	// The following loop will populate |doNotDeleteTables| and
 	// |tablesToRebuild|.
@@ -82,7 +84,12 @@ func rebuildFullTextIndexes(ctx *sql.Context, mergedRoot, ourRoot, theirRoot dol
 		doNotDeleteTables[tblName] = struct{}{}
 
 		tbl, ok, err := mergedRoot.GetTable(ctx, doltdb.TableName{Name: tblName})
-		if err != nil {
+		if errors.Is(err, doltdb.ErrSparseTable) {
+			// The schema of a table left out of a sparse clone can't be read, so its pseudo-index tables can't be
+			// told apart from orphaned ones.
+			hasSparseTables = true
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 		if !ok {
@@ -120,6 +127,9 @@ func rebuildFullTextIndexes(ctx *sql.Context, mergedRoot, ourRoot, theirRoot dol
 	}
 
 	// Our last loop removes any orphaned pseudo-index tables
+	if hasSparseTables {
+		return mergedRoot, nil
+	}
 	for _, tblName := range allTableNames {
 		if _, doNotDelete := doNotDeleteTables[tblName]; doNotDelete || !doltdb.IsFullTextTable(tblName) {
 			continue
@@ -252,7 +252,17 @@ func MergeRoots(
 	for _, tblName := range tblNames {
 		mergedTable, stats, err := merger.MergeTable(ctx, tblName, opts, mergeOpts)
 
-		if errors.Is(ErrTableDeletedAndModified, err) && doltdb.IsFullTextTable(tblName.Name) {
+		if errors.Is(err, doltdb.ErrSparseTable) {
+			mergedRoot, stats, err = mergeSparseTable(ctx, mergedRoot, ourRoot, theirRoot, ancRoot, tblName)
+			if err != nil {
+				return nil, err
+			}
+			tblToStats[tblName] = stats
+			if stats.Operation != TableUnmodified {
+				visitedTables[tblName.Name] = struct{}{}
+			}
+			continue
+		} else if errors.Is(ErrTableDeletedAndModified, err) && doltdb.IsFullTextTable(tblName.Name) {
 			// If a Full-Text table was both modified and deleted, then we want to ignore the deletion.
 			// If there's a true conflict, then the parent table will catch the conflict.
 			stats = &MergeStats{Operation: TableModified}
@@ -426,6 +436,41 @@ func MergeRoots(
 	}, nil
 }
 
+// mergeSparseTable merges |tblName| when it is the placeholder of a table left out of a sparse clone on one of the
+// sides of the merge. The data of the placeholder can't be read, so the table can only be merged if at most one side
+// changed it.
+func mergeSparseTable(ctx context.Context, mergedRoot, ourRoot, theirRoot, ancRoot doltdb.RootValue, tblName doltdb.TableName) (doltdb.RootValue, *MergeStats, error) {
+	ourHash, _, err := ourRoot.GetTableHash(ctx, tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+	theirHash, theirsExists, err := theirRoot.GetTableHash(ctx, tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+	ancHash, ancExists, err := ancRoot.GetTableHash(ctx, tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case ourHash == theirHash || theirHash == ancHash:
+		return mergedRoot, &MergeStats{Operation: TableUnmodified}, nil
+	case ourHash == ancHash && !theirsExists:
+		mergedRoot, err = mergedRoot.RemoveTables(ctx, false, true, tblName)
+		return mergedRoot, &MergeStats{Operation: TableRemoved}, err
+	case ourHash == ancHash:
+		op := TableModified
+		if !ancExists {
+			op = TableAdded
+		}
+		mergedRoot, err = mergedRoot.SetTableHash(ctx, tblName, theirHash)
+		return mergedRoot, &MergeStats{Operation: op}, err
+	default:
+		return nil, nil, fmt.Errorf("%w: %s was changed on both sides of the merge", doltdb.ErrSparseTable, tblName.Name)
+	}
+}
+
 // mergeCVsWithStash merges the table constraint violations in |stash| with |root|.
 // Returns an updated root with all the merged CVs.
 func mergeCVsWithStash(ctx context.Context, root doltdb.RootValue, stash *violationStash) (doltdb.RootValue, error) {
@@ -478,7 +523,10 @@ func checkForConflicts(tblToStats map[doltdb.TableName]*MergeStats) bool {
 func getConstraintViolationStats(ctx context.Context, root doltdb.RootValue, tblToStats map[doltdb.TableName]*MergeStats) error {
 	for tblName, stats := range tblToStats {
 		tbl, ok, err := root.GetTable(ctx, tblName)
-		if err != nil {
+		if errors.Is(err, doltdb.ErrSparseTable) {
+			// tables left out of a sparse clone are taken from one side of the merge without violations
+			continue
+		} else if err != nil {
 			return err
 		}
 		if ok {
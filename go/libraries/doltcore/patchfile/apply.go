@@ -0,0 +1,395 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patchfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var ErrPatchDoesNotApply = errors.New("patch does not apply")
+
+// Roots returns the roots needed to apply |c| on top of |root| with a three-way merge. |base| is |root| with the
+// rows changed by |c| set to their values before the commit, and |theirs| is |root| with them set to their values
+// after it. Merging |theirs| into |root| with |base| as the ancestor applies the commit, and reports conflicts for
+// rows that |root| has changed as well.
+//
+// Tables are matched by name and columns by position, name and type, so the tables changed by |c| must have the
+// same columns in |root| as they had before the commit.
+func (c *Commit) Roots(ctx *sql.Context, root doltdb.RootValue) (base, theirs doltdb.RootValue, err error) {
+	base, theirs = root, root
+	for _, tc := range c.Tables {
+		if base, theirs, err = tc.apply(ctx, root, base, theirs); err != nil {
+			return nil, nil, err
+		}
+	}
+	return base, theirs, nil
+}
+
+func (tc TableChange) apply(ctx *sql.Context, root, base, theirs doltdb.RootValue) (doltdb.RootValue, doltdb.RootValue, error) {
+	ns, vrw := root.NodeStore(), root.VRW()
+	fromSch, err := deserializeSchema(ctx, tc.FromSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	toSch, err := deserializeSchema(ctx, tc.ToSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the table before the commit: our table, with the patch's old rows
+	var ourSch schema.Schema
+	var baseRows prolly.Map
+	if fromSch != nil {
+		tbl, name, ok, err := doltdb.GetTableInsensitive(ctx, root, doltdb.TableName{Name: tc.FromName})
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: table %s does not exist", ErrPatchDoesNotApply, tc.FromName)
+		}
+		if ourSch, err = tbl.GetSchema(ctx); err != nil {
+			return nil, nil, err
+		}
+		if !sameColumns(ourSch, fromSch) {
+			return nil, nil, fmt.Errorf("%w: the columns of table %s do not match the patch", ErrPatchDoesNotApply, tc.FromName)
+		}
+		ourRows, err := rowData(ctx, tbl)
+		if err != nil {
+			return nil, nil, err
+		}
+		if baseRows, err = tc.applyRows(ctx, ourRows, newRowLayout(ourSch, ns), fromSch, toSch, true); err != nil {
+			return nil, nil, err
+		}
+		baseTbl, err := withRows(ctx, tbl, name, baseRows)
+		if err != nil {
+			return nil, nil, err
+		}
+		if base, err = base.PutTable(ctx, doltdb.TableName{Name: name}, baseTbl); err != nil {
+			return nil, nil, err
+		}
+		if strings.EqualFold(tc.FromName, tc.ToName) {
+			tc.ToName = name
+		}
+		tc.FromName = name
+	}
+
+	if toSch == nil {
+		theirs, err = theirs.RemoveTables(ctx, false, true, doltdb.TableName{Name: tc.FromName})
+		return base, theirs, err
+	}
+
+	// the table after the commit: the table before it, in the new schema, with the patch's new rows
+	var theirSch schema.Schema
+	var theirRows prolly.Map
+	switch {
+	case fromSch != nil && bytes.Equal(tc.FromSchema, tc.ToSchema):
+		theirSch = ourSch
+		theirRows = baseRows
+	case fromSch != nil:
+		if theirSch, err = retag(ctx, root, tc.ToName, toSch, ourSch, fromSch); err != nil {
+			return nil, nil, err
+		}
+		if theirRows, err = migrateRows(ctx, baseRows, ourSch, theirSch); err != nil {
+			return nil, nil, err
+		}
+	default:
+		tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, root, doltdb.TableName{Name: tc.ToName})
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			if ourSch, err = tbl.GetSchema(ctx); err != nil {
+				return nil, nil, err
+			}
+		}
+		if theirSch, err = retag(ctx, root, tc.ToName, toSch, ourSch, nil); err != nil {
+			return nil, nil, err
+		}
+		l := newRowLayout(theirSch, ns)
+		if theirRows, err = prolly.NewMapFromTuples(ctx, ns, l.kd, l.vd); err != nil {
+			return nil, nil, err
+		}
+	}
+	if theirRows, err = tc.applyRows(ctx, theirRows, newRowLayout(theirSch, ns), fromSch, toSch, false); err != nil {
+		return nil, nil, err
+	}
+
+	theirTbl, err := newTable(ctx, vrw, ns, tc.ToName, theirSch, theirRows)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fromSch != nil && tc.FromName != tc.ToName {
+		if theirs, err = theirs.RemoveTables(ctx, true, true, doltdb.TableName{Name: tc.FromName}); err != nil {
+			return nil, nil, err
+		}
+	}
+	theirs, err = theirs.PutTable(ctx, doltdb.TableName{Name: tc.ToName}, theirTbl)
+	return base, theirs, err
+}
+
+// applyRows sets the rows changed by |tc| in |m|, which has layout |l|, to their old values if |old| is true, or
+// to their new values otherwise.
+func (tc TableChange) applyRows(ctx *sql.Context, m prolly.Map, l rowLayout, fromSch, toSch schema.Schema, old bool) (prolly.Map, error) {
+	ns := m.NodeStore()
+	var fromCols, toCols []schema.Column
+	if fromSch != nil {
+		fromCols = newRowLayout(fromSch, ns).cols
+	}
+	if toSch != nil {
+		toCols = newRowLayout(toSch, ns).cols
+	}
+
+	mut := m.Mutate()
+	for _, rc := range tc.Rows {
+		row, cols, other, otherCols := rc.To, toCols, rc.From, fromCols
+		if old {
+			row, cols, other, otherCols = rc.From, fromCols, rc.To, toCols
+		}
+		if row != nil {
+			if len(row) != len(cols) {
+				return prolly.Map{}, fmt.Errorf("%w: malformed row in table %s", ErrPatchDoesNotApply, tc.ToName)
+			}
+			vals, err := decode(ctx, cols, row)
+			if err != nil {
+				return prolly.Map{}, err
+			}
+			k, v, err := l.build(ctx, vals, ns, ns.Pool())
+			if err != nil {
+				return prolly.Map{}, err
+			}
+			if err = mut.Put(ctx, k, v); err != nil {
+				return prolly.Map{}, err
+			}
+			continue
+		}
+
+		// the row does not exist on this side of the commit; delete it by the key from the other side, which is
+		// laid out the same way
+		if len(other) < l.kd.Count() {
+			return prolly.Map{}, fmt.Errorf("%w: malformed row in table %s", ErrPatchDoesNotApply, tc.ToName)
+		}
+		vals, err := decode(ctx, otherCols[:l.kd.Count()], other[:l.kd.Count()])
+		if err != nil {
+			return prolly.Map{}, err
+		}
+		keyLayout := rowLayout{cols: l.cols[:l.kd.Count()], kd: l.kd, vd: l.vd}
+		k, _, err := keyLayout.build(ctx, vals, ns, ns.Pool())
+		if err != nil {
+			return prolly.Map{}, err
+		}
+		if err = mut.Delete(ctx, k); err != nil {
+			return prolly.Map{}, err
+		}
+	}
+	return mut.Map(ctx)
+}
+
+// migrateRows rewrites the rows of |m| from schema |from| to schema |to|, matching columns by tag. Columns that
+// are not in |from| are NULL.
+func migrateRows(ctx *sql.Context, m prolly.Map, from, to schema.Schema) (prolly.Map, error) {
+	ns := m.NodeStore()
+	fromLayout, toLayout := newRowLayout(from, ns), newRowLayout(to, ns)
+	positions := make([]int, len(toLayout.cols))
+	for i, col := range toLayout.cols {
+		positions[i] = -1
+		for j, fromCol := range fromLayout.cols {
+			if fromCol.Tag == col.Tag {
+				positions[i] = j
+			}
+		}
+	}
+
+	empty, err := prolly.NewMapFromTuples(ctx, ns, toLayout.kd, toLayout.vd)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	mut := empty.Mutate()
+	err = iterRows(ctx, m, func(k, v val.Tuple) error {
+		fromVals, err := fromLayout.values(ctx, k, v, ns)
+		if err != nil {
+			return err
+		}
+		toVals := make([]interface{}, len(positions))
+		for i, pos := range positions {
+			if pos >= 0 {
+				toVals[i] = fromVals[pos]
+			}
+		}
+		k, v, err = toLayout.build(ctx, toVals, ns, ns.Pool())
+		if err != nil {
+			return err
+		}
+		return mut.Put(ctx, k, v)
+	})
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return mut.Map(ctx)
+}
+
+// retag returns |sch| with the tags of the columns it shares with |ours| replaced by those of |ours|, and new
+// tags for its other columns. A column is shared if |ours| has a column with its name, or with the name it had in
+// |from|.
+func retag(ctx *sql.Context, root doltdb.RootValue, tableName string, sch, ours, from schema.Schema) (schema.Schema, error) {
+	cols := sch.GetAllCols().GetColumns()
+	tags := make(map[uint64]uint64, len(cols))
+	var newCols []uint64
+	var newNames []string
+	var newKinds []types.NomsKind
+	for _, col := range cols {
+		name := col.Name
+		if from != nil {
+			if fromCol, ok := from.GetAllCols().GetByTag(col.Tag); ok {
+				name = fromCol.Name
+			}
+		}
+		if ours != nil {
+			if ourCol, ok := ours.GetAllCols().GetByNameCaseInsensitive(name); ok {
+				tags[col.Tag] = ourCol.Tag
+				continue
+			}
+		}
+		newCols = append(newCols, col.Tag)
+		newNames = append(newNames, col.Name)
+		newKinds = append(newKinds, col.Kind)
+	}
+
+	newTags, err := doltdb.GenerateTagsForNewColumns(ctx, root, doltdb.TableName{Name: tableName}, newNames, newKinds, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, tag := range newCols {
+		tags[tag] = newTags[i]
+	}
+	return withTags(sch, tags)
+}
+
+// withTags returns |sch| with its column tags replaced according to |tags|.
+func withTags(sch schema.Schema, tags map[uint64]uint64) (schema.Schema, error) {
+	cols := sch.GetAllCols().GetColumns()
+	for i := range cols {
+		cols[i].Tag = tags[cols[i].Tag]
+	}
+	newSch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, err
+	}
+	if err = newSch.SetPkOrdinals(sch.GetPkOrdinals()); err != nil {
+		return nil, err
+	}
+	newSch.SetCollation(sch.GetCollation())
+	newSch.SetComment(sch.GetComment())
+
+	for _, idx := range sch.Indexes().AllIndexes() {
+		props := schema.IndexProperties{
+			IsUnique:           idx.IsUnique(),
+			IsSpatial:          idx.IsSpatial(),
+			IsFullText:         idx.IsFullText(),
+			IsUserDefined:      idx.IsUserDefined(),
+			Comment:            idx.Comment(),
+			FullTextProperties: idx.FullTextProperties(),
+			IsVector:           idx.IsVector(),
+			VectorProperties:   idx.VectorProperties(),
+		}
+		if _, err = newSch.Indexes().AddIndexByColNames(idx.Name(), idx.ColumnNames(), idx.PrefixLengths(), props); err != nil {
+			return nil, err
+		}
+	}
+	for _, check := range sch.Checks().AllChecks() {
+		if _, err = newSch.Checks().AddCheck(check.Name(), check.Expression(), check.Enforced()); err != nil {
+			return nil, err
+		}
+	}
+	return newSch, nil
+}
+
+// sameColumns returns whether |a| and |b| have the same stored columns, in the same order and with the same names
+// and types.
+func sameColumns(a, b schema.Schema) bool {
+	ac, bc := newRowLayout(a, nil).cols, newRowLayout(b, nil).cols
+	if len(ac) != len(bc) || a.GetPKCols().Size() != b.GetPKCols().Size() {
+		return false
+	}
+	for i := range ac {
+		if !strings.EqualFold(ac[i].Name, bc[i].Name) || !ac[i].TypeInfo.Equals(bc[i].TypeInfo) {
+			return false
+		}
+	}
+	return true
+}
+
+// withRows returns |tbl| with its rows replaced by |rows| and its secondary indexes rebuilt.
+func withRows(ctx *sql.Context, tbl *doltdb.Table, name string, rows prolly.Map) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(rows)); err != nil {
+		return nil, err
+	}
+	return rebuildIndexes(ctx, tbl, name, sch, rows)
+}
+
+// newTable returns a new table with schema |sch| and rows |rows|.
+func newTable(ctx *sql.Context, vrw types.ValueReadWriter, ns tree.NodeStore, name string, sch schema.Schema, rows prolly.Map) (*doltdb.Table, error) {
+	indexes, err := durable.NewIndexSetWithEmptyIndexes(ctx, vrw, ns, sch)
+	if err != nil {
+		return nil, err
+	}
+	tbl, err := doltdb.NewTable(ctx, vrw, ns, sch, durable.IndexFromProllyMap(rows), indexes, nil)
+	if err != nil {
+		return nil, err
+	}
+	return rebuildIndexes(ctx, tbl, name, sch, rows)
+}
+
+func rebuildIndexes(ctx *sql.Context, tbl *doltdb.Table, name string, sch schema.Schema, rows prolly.Map) (*doltdb.Table, error) {
+	for _, idx := range sch.Indexes().AllIndexes() {
+		data, err := creation.BuildSecondaryProllyIndex(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), sch, name, idx, rows)
+		if err != nil {
+			return nil, err
+		}
+		if tbl, err = tbl.SetIndexRows(ctx, idx.Name(), data); err != nil {
+			return nil, err
+		}
+	}
+	return tbl, nil
+}
+
+func deserializeSchema(ctx *sql.Context, buf []byte) (schema.Schema, error) {
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	sch, err := encoding.DeserializeSchemaFromBytes(ctx, buf)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotAPatch, err.Error())
+	}
+	return sch, nil
+}
@@ -0,0 +1,305 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package patchfile reads and writes portable patches: JSON documents holding the schema and row changes of a
+// range of commits, along with their commit metadata. A patch does not reference any chunks of the database it
+// was written from, so it can be applied to any database with the same tables, including unrelated ones.
+package patchfile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	// FormatName identifies a patch document.
+	FormatName = "dolt-patch"
+	// FormatVersion is the version of the patch format written by this package.
+	FormatVersion = 1
+)
+
+var ErrNotAPatch = errors.New("not a dolt patch")
+
+// Patch is the changes of a range of commits, oldest first.
+type Patch struct {
+	Format  string   `json:"format"`
+	Version int      `json:"version"`
+	Commits []Commit `json:"commits"`
+}
+
+// Commit is the metadata and changes of a single commit.
+type Commit struct {
+	// Hash is the hash of the commit in the database the patch was written from.
+	Hash    string        `json:"hash"`
+	Author  string        `json:"author"`
+	Email   string        `json:"email"`
+	Date    time.Time     `json:"date"`
+	Message string        `json:"message"`
+	Tables  []TableChange `json:"tables"`
+}
+
+// TableChange is the change to a table made by a commit. FromName and FromSchema are empty for added tables,
+// ToName and ToSchema for dropped ones.
+type TableChange struct {
+	FromName string `json:"from_name,omitempty"`
+	ToName   string `json:"to_name,omitempty"`
+	// FromSchema and ToSchema are the table's schemas, serialized as Flatbuffers.
+	FromSchema []byte      `json:"from_schema,omitempty"`
+	ToSchema   []byte      `json:"to_schema,omitempty"`
+	Rows       []RowChange `json:"rows"`
+}
+
+// RowChange is a changed row. From and To hold the values of the row's stored columns, primary key columns first,
+// in FromSchema and ToSchema respectively. From is empty for added rows and To for removed ones. NULL values are
+// written as JSON null.
+type RowChange struct {
+	From []*string `json:"from,omitempty"`
+	To   []*string `json:"to,omitempty"`
+}
+
+// Read reads a patch written by Write.
+func Read(r io.Reader) (*Patch, error) {
+	var p Patch
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotAPatch, err.Error())
+	}
+	if p.Format != FormatName {
+		return nil, ErrNotAPatch
+	}
+	if p.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported patch version %d", p.Version)
+	}
+	return &p, nil
+}
+
+// Write writes |p| as JSON.
+func (p *Patch) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// Format returns a patch with the changes of the commits reachable from |to| but not from |from|, oldest first.
+// Merge commits are skipped; the changes they merge are formatted with the commits that made them.
+func Format(ctx *sql.Context, ddb *doltdb.DoltDB, from, to *doltdb.Commit) (*Patch, error) {
+	fromHash, err := from.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	toHash, err := to.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	cms, err := commitwalk.GetDotDotRevisions(ctx, ddb, []hash.Hash{toHash}, ddb, []hash.Hash{fromHash}, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Patch{Format: FormatName, Version: FormatVersion, Commits: []Commit{}}
+	for i := len(cms) - 1; i >= 0; i-- {
+		cm, ok := cms[i].ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		if cm.NumParents() != 1 {
+			continue
+		}
+		c, err := formatCommit(ctx, ddb, cm)
+		if err != nil {
+			return nil, err
+		}
+		p.Commits = append(p.Commits, c)
+	}
+	return p, nil
+}
+
+func formatCommit(ctx *sql.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) (Commit, error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return Commit{}, err
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return Commit{}, err
+	}
+	optCmt, err := ddb.ResolveParent(ctx, cm, 0)
+	if err != nil {
+		return Commit{}, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return Commit{}, doltdb.ErrGhostCommitEncountered
+	}
+	fromRoot, err := parent.GetRootValue(ctx)
+	if err != nil {
+		return Commit{}, err
+	}
+	toRoot, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return Commit{}, err
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return Commit{}, err
+	}
+	slices.SortFunc(deltas, func(a, b diff.TableDelta) int {
+		return strings.Compare(a.CurName(), b.CurName())
+	})
+
+	c := Commit{
+		Hash:    h.String(),
+		Author:  meta.Name,
+		Email:   meta.Email,
+		Date:    meta.Time().UTC(),
+		Message: meta.Description,
+		Tables:  []TableChange{},
+	}
+	for _, delta := range deltas {
+		changed, err := delta.HasHashChanged()
+		if err != nil {
+			return Commit{}, err
+		}
+		if !changed {
+			continue
+		}
+		tc, err := formatTable(ctx, delta)
+		if err != nil {
+			return Commit{}, fmt.Errorf("commit %s: %w", h.String(), err)
+		}
+		c.Tables = append(c.Tables, tc)
+	}
+	return c, nil
+}
+
+func formatTable(ctx *sql.Context, delta diff.TableDelta) (TableChange, error) {
+	keyless, err := delta.IsKeyless(ctx)
+	if err != nil {
+		return TableChange{}, err
+	}
+	if keyless {
+		return TableChange{}, fmt.Errorf("table %s has no primary key; keyless tables cannot be written to a patch", delta.CurName())
+	}
+
+	var tc TableChange
+	var fromRows, toRows prolly.Map
+	var fromLayout, toLayout rowLayout
+	if delta.FromTable != nil {
+		tc.FromName = delta.FromName.Name
+		if tc.FromSchema, err = encoding.SerializeSchemaToBytes(delta.FromSch); err != nil {
+			return TableChange{}, err
+		}
+		if fromRows, err = rowData(ctx, delta.FromTable); err != nil {
+			return TableChange{}, err
+		}
+		fromLayout = newRowLayout(delta.FromSch, fromRows.NodeStore())
+	}
+	if delta.ToTable != nil {
+		tc.ToName = delta.ToName.Name
+		if tc.ToSchema, err = encoding.SerializeSchemaToBytes(delta.ToSch); err != nil {
+			return TableChange{}, err
+		}
+		if toRows, err = rowData(ctx, delta.ToTable); err != nil {
+			return TableChange{}, err
+		}
+		toLayout = newRowLayout(delta.ToSch, toRows.NodeStore())
+	}
+
+	tc.Rows = []RowChange{}
+	switch {
+	case delta.FromTable == nil:
+		err = iterRows(ctx, toRows, func(k, v val.Tuple) error {
+			to, err := toLayout.encode(ctx, k, v, toRows.NodeStore())
+			tc.Rows = append(tc.Rows, RowChange{To: to})
+			return err
+		})
+	case delta.ToTable == nil:
+		err = iterRows(ctx, fromRows, func(k, v val.Tuple) error {
+			from, err := fromLayout.encode(ctx, k, v, fromRows.NodeStore())
+			tc.Rows = append(tc.Rows, RowChange{From: from})
+			return err
+		})
+	default:
+		if !fromLayout.kd.Equals(toLayout.kd) {
+			return TableChange{}, fmt.Errorf("the primary key of table %s changed; primary key changes cannot be written to a patch", delta.CurName())
+		}
+		err = prolly.DiffMaps(ctx, fromRows, toRows, false, func(_ context.Context, d tree.Diff) error {
+			var rc RowChange
+			var err error
+			if d.From != nil {
+				if rc.From, err = fromLayout.encode(ctx, val.Tuple(d.Key), val.Tuple(d.From), fromRows.NodeStore()); err != nil {
+					return err
+				}
+			}
+			if d.To != nil {
+				if rc.To, err = toLayout.encode(ctx, val.Tuple(d.Key), val.Tuple(d.To), toRows.NodeStore()); err != nil {
+					return err
+				}
+			}
+			tc.Rows = append(tc.Rows, rc)
+			return nil
+		})
+		if err == io.EOF {
+			err = nil
+		}
+	}
+	if err != nil {
+		return TableChange{}, err
+	}
+	return tc, nil
+}
+
+func rowData(ctx *sql.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
+
+func iterRows(ctx *sql.Context, m prolly.Map, cb func(k, v val.Tuple) error) error {
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err = cb(k, v); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patchfile
+
+import (
+	"encoding/base64"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// Values in a patch are written as their MySQL text representation, so that they do not depend on how a
+// particular database stores them. Values of binary types are base64 encoded.
+
+func isBinary(typ sql.Type) bool {
+	return types.IsBinaryType(typ) || types.IsBit(typ) || types.IsGeometry(typ)
+}
+
+func encodeValue(ctx *sql.Context, typ sql.Type, v interface{}) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	sv, err := typ.SQL(ctx, nil, v)
+	if err != nil {
+		return nil, err
+	}
+	var s string
+	if isBinary(typ) {
+		s = base64.StdEncoding.EncodeToString(sv.Raw())
+	} else {
+		s = sv.ToString()
+	}
+	return &s, nil
+}
+
+func decodeValue(ctx *sql.Context, typ sql.Type, s *string) (interface{}, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var v interface{} = *s
+	if isBinary(typ) {
+		b, err := base64.StdEncoding.DecodeString(*s)
+		if err != nil {
+			return nil, err
+		}
+		v = b
+	}
+	v, _, err := typ.Convert(ctx, v)
+	return v, err
+}
+
+// rowLayout describes the stored columns of a schema in the order of its key and value tuples.
+type rowLayout struct {
+	cols   []schema.Column
+	kd, vd val.TupleDesc
+}
+
+func newRowLayout(sch schema.Schema, ns tree.NodeStore) rowLayout {
+	cols := append([]schema.Column{}, sch.GetPKCols().GetColumns()...)
+	for _, col := range sch.GetNonPKCols().GetColumns() {
+		if !col.Virtual {
+			cols = append(cols, col)
+		}
+	}
+	return rowLayout{cols: cols, kd: sch.GetKeyDescriptor(ns), vd: sch.GetValueDescriptor(ns)}
+}
+
+// values returns the values of the row |k|, |v| in layout order.
+func (l rowLayout) values(ctx *sql.Context, k, v val.Tuple, ns tree.NodeStore) ([]interface{}, error) {
+	vals := make([]interface{}, len(l.cols))
+	for i := range vals {
+		var err error
+		if i < l.kd.Count() {
+			vals[i], err = tree.GetField(ctx, l.kd, i, k, ns)
+		} else {
+			vals[i], err = tree.GetField(ctx, l.vd, i-l.kd.Count(), v, ns)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return vals, nil
+}
+
+// encode returns the patch representation of the row |k|, |v|.
+func (l rowLayout) encode(ctx *sql.Context, k, v val.Tuple, ns tree.NodeStore) ([]*string, error) {
+	if k == nil {
+		return nil, nil
+	}
+	vals, err := l.values(ctx, k, v, ns)
+	if err != nil {
+		return nil, err
+	}
+	row := make([]*string, len(vals))
+	for i, col := range l.cols {
+		if row[i], err = encodeValue(ctx, col.TypeInfo.ToSqlType(), vals[i]); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// decode returns the values of a patch row written with the columns of |src|.
+func decode(ctx *sql.Context, src []schema.Column, row []*string) ([]interface{}, error) {
+	vals := make([]interface{}, len(row))
+	for i, s := range row {
+		var err error
+		if vals[i], err = decodeValue(ctx, src[i].TypeInfo.ToSqlType(), s); err != nil {
+			return nil, err
+		}
+	}
+	return vals, nil
+}
+
+// build returns the key and value tuples for |vals|, which are in layout order.
+func (l rowLayout) build(ctx *sql.Context, vals []interface{}, ns tree.NodeStore, bp pool.BuffPool) (k, v val.Tuple, err error) {
+	kb := val.NewTupleBuilder(l.kd, ns)
+	vb := val.NewTupleBuilder(l.vd, ns)
+	for i, col := range l.cols {
+		var field interface{}
+		if vals[i] != nil {
+			if field, _, err = col.TypeInfo.ToSqlType().Convert(ctx, vals[i]); err != nil {
+				return nil, nil, err
+			}
+		}
+		if i < l.kd.Count() {
+			err = tree.PutField(ctx, ns, kb, i, field)
+		} else {
+			err = tree.PutField(ctx, ns, vb, i-l.kd.Count(), field)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if k, err = kb.Build(bp); err != nil {
+		return nil, nil, err
+	}
+	// value tuples are built permissively, as rows migrated to a new schema may not have values for new NOT NULL
+	// columns until the patch's row changes are applied
+	v, err = vb.BuildPermissive(bp)
+	return k, v, err
+}
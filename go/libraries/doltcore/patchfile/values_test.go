@@ -0,0 +1,86 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patchfile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+func TestRowRoundTrip(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	ns := tree.NewTestNodeStore()
+
+	sqlSch := sql.Schema{
+		{Name: "id", Type: gmstypes.Int64, PrimaryKey: true},
+		{Name: "name", Type: gmstypes.MustCreateStringWithDefaults(sqltypes.VarChar, 20), PrimaryKey: true},
+		{Name: "dec", Type: gmstypes.MustCreateDecimalType(10, 2), Nullable: true},
+		{Name: "dt", Type: gmstypes.DatetimeMaxPrecision, Nullable: true},
+		{Name: "bl", Type: gmstypes.Blob, Nullable: true},
+		{Name: "txt", Type: gmstypes.LongText, Nullable: true},
+		{Name: "en", Type: gmstypes.MustCreateEnumType([]string{"red", "green"}, sql.Collation_Default), Nullable: true},
+		{Name: "st", Type: gmstypes.MustCreateSetType([]string{"a", "b", "c"}, sql.Collation_Default), Nullable: true},
+		{Name: "bt", Type: gmstypes.MustCreateBitType(8), Nullable: true},
+		{Name: "yr", Type: gmstypes.Year, Nullable: true},
+		{Name: "tm", Type: gmstypes.Time, Nullable: true},
+		{Name: "fl", Type: gmstypes.Float64, Nullable: true},
+		{Name: "pt", Type: gmstypes.PointType{}, Nullable: true},
+	}
+	cols := make([]schema.Column, len(sqlSch))
+	for i, col := range sqlSch {
+		var err error
+		cols[i], err = sqlutil.ToDoltCol(uint64(i+1), col)
+		require.NoError(t, err)
+	}
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	require.NoError(t, err)
+	l := newRowLayout(sch, ns)
+
+	rows := [][]interface{}{
+		{
+			int64(1), "one", "12.50", time.Date(2024, 2, 29, 13, 14, 15, 123456000, time.UTC),
+			[]byte{0, 1, 2, 255}, "multi\nline 'text'", "green", "a,c", uint64(5), int16(2024), "-12:34:56", 1.25,
+			gmstypes.Point{X: 1.5, Y: -2},
+		},
+		{int64(-2), "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+	}
+	for _, vals := range rows {
+		k, v, err := l.build(ctx, vals, ns, ns.Pool())
+		require.NoError(t, err)
+
+		encoded, err := l.encode(ctx, k, v, ns)
+		require.NoError(t, err)
+		require.Len(t, encoded, len(cols))
+		for i, s := range encoded {
+			require.Equal(t, vals[i] == nil, s == nil, "column %s", cols[i].Name)
+		}
+
+		decoded, err := decode(ctx, l.cols, encoded)
+		require.NoError(t, err)
+		k2, v2, err := l.build(ctx, decoded, ns, ns.Pool())
+		require.NoError(t, err)
+		require.Equal(t, k, k2)
+		require.Equal(t, v, v2)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+// NoteRef is a reference to the note attached to a commit. The path of a note ref is the hash of the commit it
+// annotates, e.g. refs/notes/a5u0d2f6tbnjpvc7b2ab4d6m8m2hqdvv.
+type NoteRef struct {
+	commit string
+}
+
+var _ DoltRef = NoteRef{}
+
+// NewNoteRef creates a reference to the note for the commit with the given hash string.
+func NewNoteRef(commit string) NoteRef {
+	return NoteRef{commit}
+}
+
+// GetType will return NotesRefType
+func (nr NoteRef) GetType() RefType {
+	return NotesRefType
+}
+
+// GetPath returns the hash of the annotated commit
+func (nr NoteRef) GetPath() string {
+	return nr.commit
+}
+
+// String returns the fully qualified reference name e.g. refs/notes/a5u0d2f6tbnjpvc7b2ab4d6m8m2hqdvv
+func (nr NoteRef) String() string {
+	return String(nr)
+}
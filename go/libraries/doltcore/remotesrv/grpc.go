@@ -513,6 +513,9 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 		code := codes.Internal
 		if errors.Is(err, nbs.ErrDanglingRef) || errors.Is(err, nbs.ErrTableFileNotFound) {
 			code = codes.FailedPrecondition
+		} else if s, ok := status.FromError(err); ok {
+			code = s.Code()
+			err = errors.New(s.Message())
 		}
 		return nil, status.Errorf(code, "failed to commit: %v", err)
 	}
@@ -41,6 +41,9 @@ type Server struct {
 	grpcSrv *grpc.Server
 	httpSrv http.Server
 
+	// connHandler serves both gRPC and HTTP requests made over the same HTTP/2 connection.
+	connHandler http.Handler
+
 	grpcHttpReqsWG sync.WaitGroup
 
 	tlsConfig *tls.Config
@@ -106,8 +109,9 @@ func NewServer(args ServerArgs) (*Server, error) {
 	if args.HttpInterceptor != nil {
 		handler = args.HttpInterceptor(handler)
 	}
+	s.connHandler = s.grpcRoutingHandler(s.grpcSrv, handler)
 	if args.HttpListenAddr == args.GrpcListenAddr {
-		handler = s.grpcMultiplexHandler(s.grpcSrv, handler)
+		handler = h2c.NewHandler(s.connHandler, &http2.Server{})
 	} else {
 		s.wg.Add(2)
 	}
@@ -121,9 +125,8 @@ func NewServer(args ServerArgs) (*Server, error) {
 	return s, nil
 }
 
-func (s *Server) grpcMultiplexHandler(grpcSrv *grpc.Server, handler http.Handler) http.Handler {
-	h2s := &http2.Server{}
-	newHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) grpcRoutingHandler(grpcSrv *grpc.Server, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
 			s.grpcHttpReqsWG.Add(1)
 			defer s.grpcHttpReqsWG.Done()
@@ -132,7 +135,6 @@ func (s *Server) grpcMultiplexHandler(grpcSrv *grpc.Server, handler http.Handler
 			handler.ServeHTTP(w, r)
 		}
 	})
-	return h2c.NewHandler(newHandler, h2s)
 }
 
 type Listeners struct {
@@ -183,6 +185,31 @@ func (s *Server) Listeners() (Listeners, error) {
 	return Listeners{http: httpListener, grpc: grpcListener}, nil
 }
 
+// ServeHTTP2Conns serves gRPC and HTTP requests made over the connections accepted from |lis|, which must speak
+// HTTP/2 without TLS, until |lis| stops accepting connections and the accepted connections are closed. Unlike Serve,
+// it does not listen on the server's addresses, so it can serve connections which are not TCP connections.
+func (s *Server) ServeHTTP2Conns(ctx context.Context, lis net.Listener) {
+	h2s := &http2.Server{}
+	var wg sync.WaitGroup
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			h2s.ServeConn(conn, &http2.ServeConnOpts{
+				Context: ctx,
+				Handler: s.connHandler,
+			})
+		}()
+	}
+	wg.Wait()
+	s.grpcHttpReqsWG.Wait()
+}
+
 // Can be used to register more services on the server.
 // Should only be accessed before `Serve` is called.
 func (s *Server) GrpcServer() *grpc.Server {
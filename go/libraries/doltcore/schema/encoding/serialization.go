@@ -51,6 +51,19 @@ func SerializeSchema(ctx context.Context, vrw types.ValueReadWriter, sch schema.
 	return v, nil
 }
 
+// SerializeSchemaToBytes serializes a schema.Schema as a Flatbuffer message without writing it to a ValueStore.
+func SerializeSchemaToBytes(sch schema.Schema) ([]byte, error) {
+	return serializeSchemaAsFlatbuffer(sch)
+}
+
+// DeserializeSchemaFromBytes deserializes a schema.Schema from a Flatbuffer message created by SerializeSchemaToBytes.
+func DeserializeSchemaFromBytes(ctx context.Context, buf []byte) (schema.Schema, error) {
+	if serial.GetFileID(buf) != serial.TableSchemaFileID {
+		return nil, fmt.Errorf("invalid serialized schema")
+	}
+	return deserializeSchemaFromFlatbuffer(ctx, buf)
+}
+
 func serializeSchemaAsFlatbuffer(sch schema.Schema) ([]byte, error) {
 	b := fb.NewBuilder(1024)
 	columns := serializeSchemaColumns(b, sch)
@@ -75,6 +75,11 @@ const (
 	DefaultMaxLoggedQueryLen         = 0
 	DefaultEncodeLoggedQuery         = false
 	DefaultCompressionLevel          = 0
+	DefaultHTTPAPIPort               = 8080
+	DefaultLongQueryTimeMillis       = 10000
+	DefaultSlowQueryLogFormat        = LogFormat_Text
+	DefaultSlowQueryLogMaxSizeMB     = 100
+	DefaultSlowQueryLogMaxBackups    = 5
 )
 
 func ptr[T any](t T) *T {
@@ -108,6 +113,51 @@ type ClusterConfig interface {
 	BootstrapRole() string
 	BootstrapEpoch() int
 	RemotesAPIConfig() ClusterRemotesAPIConfig
+	AutomaticFailover() ClusterAutomaticFailoverConfig
+}
+
+// ClusterAutomaticFailoverConfig configures the automatic promotion of a
+// standby when the primary of the cluster stops sending heartbeats.
+type ClusterAutomaticFailoverConfig interface {
+	Enable() bool
+	HeartbeatIntervalMillis() uint64
+	FailoverTimeoutMillis() uint64
+}
+
+// HTTPAPIConfig configures an HTTP listener which accepts SQL queries in JSON
+// requests and streams their results back as JSON.
+type HTTPAPIConfig interface {
+	Host() string
+	Port() int
+	// AllowedOrigins are the origins from which browsers may make cross-origin
+	// requests to the HTTP API. "*" allows every origin.
+	AllowedOrigins() []string
+}
+
+// SlowQueryLogConfig configures the slow query log, which records the queries that
+// take longer than a threshold to run along with their plans.
+type SlowQueryLogConfig interface {
+	// LongQueryTimeMillis is how long, in milliseconds, a query must run for to be
+	// logged.
+	LongQueryTimeMillis() uint64
+	// File is the path of the file slow queries are written to. If empty, slow
+	// queries are only recorded in the dolt_slow_queries system table.
+	File() string
+	// Format is the format entries are written to File in.
+	Format() LogFormat
+	// MaxSizeMB is the size File can grow to before it is rotated.
+	MaxSizeMB() int
+	// MaxBackups is the number of rotated files to keep. Older ones are deleted.
+	MaxBackups() int
+}
+
+// CIConfig configures running the Dolt CI workflows of a database when one of its
+// branch heads advances.
+type CIConfig interface {
+	// User is the account, at localhost, which workflows are run as. Workflow queries
+	// are limited to the privileges of this account, so it should only be granted
+	// the privileges workflows need, such as SELECT.
+	User() string
 }
 
 type ClusterRemotesAPIConfig interface {
@@ -214,6 +264,14 @@ type ServerConfig interface {
 	RemotesapiPort() *int
 	// RemotesapiReadOnly is true if the remotesapi interface should be read only.
 	RemotesapiReadOnly() *bool
+	// HTTPAPIConfig is the configuration for serving SQL queries over HTTP with this sql-server instance. It is nil if
+	// the HTTP API is not enabled.
+	HTTPAPIConfig() HTTPAPIConfig
+	// SlowQueryLogConfig is the configuration for logging slow queries. It is nil if the slow query log is not enabled.
+	SlowQueryLogConfig() SlowQueryLogConfig
+	// CIConfig is the configuration for running Dolt CI workflows when a branch head advances. It is nil if workflows
+	// are not run.
+	CIConfig() CIConfig
 	// ClusterConfig is the configuration for clustering in this sql-server.
 	ClusterConfig() ClusterConfig
 	// EventSchedulerStatus is the configuration for enabling or disabling the event scheduler in this server.
@@ -302,9 +360,57 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if err := ValidateHTTPAPIConfig(config.HTTPAPIConfig()); err != nil {
+		return err
+	}
+	if err := ValidateSlowQueryLogConfig(config.SlowQueryLogConfig()); err != nil {
+		return err
+	}
+	if err := ValidateCIConfig(config.CIConfig()); err != nil {
+		return err
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
+func ValidateHTTPAPIConfig(config HTTPAPIConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.Host() != "localhost" && net.ParseIP(config.Host()) == nil {
+		return fmt.Errorf("http_api: host: is not a valid IP: %v", config.Host())
+	}
+	if config.Port() < 1024 || config.Port() > 65535 {
+		return fmt.Errorf("http_api: port: is not in the range between 1024-65535: %d", config.Port())
+	}
+	return nil
+}
+
+func ValidateSlowQueryLogConfig(config SlowQueryLogConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.Format() != LogFormat_Text && config.Format() != LogFormat_JSON {
+		return fmt.Errorf("slow_query_log: format: must be one of text or json, got: %v", config.Format())
+	}
+	if config.MaxSizeMB() <= 0 {
+		return fmt.Errorf("slow_query_log: max_size_mb: must be greater than 0: %d", config.MaxSizeMB())
+	}
+	if config.MaxBackups() < 0 {
+		return fmt.Errorf("slow_query_log: max_backups: must not be negative: %d", config.MaxBackups())
+	}
+	return nil
+}
+
+func ValidateCIConfig(config CIConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.User() == "" {
+		return fmt.Errorf("ci: user: must be set to the account workflows are run as")
+	}
+	return nil
+}
+
 const (
 	HostKey                         = "host"
 	PortKey                         = "port"
@@ -340,6 +446,9 @@ const (
 	SocketKey                       = "socket"
 	RemotesapiPortKey               = "remotesapi_port"
 	RemotesapiReadOnlyKey           = "remotesapi_read_only"
+	HTTPAPIConfigKey                = "http_api_config"
+	SlowQueryLogConfigKey           = "slow_query_log_config"
+	CIConfigKey                     = "ci_config"
 	ClusterConfigKey                = "cluster_config"
 	EventSchedulerKey               = "event_scheduler"
 )
@@ -412,6 +521,17 @@ func ValidateClusterConfig(config ClusterConfig) error {
 	if config.RemotesAPIConfig().TLSKey() != "" && config.RemotesAPIConfig().TLSCert() == "" {
 		return fmt.Errorf("cluster: remotesapi: tls_cert: must supply a tls_cert if you supply a tls_key")
 	}
+	if failover := config.AutomaticFailover(); failover.Enable() {
+		if len(remotes) < 2 {
+			return fmt.Errorf("cluster: automatic_failover: requires at least two standby_remotes, so that a majority of the cluster can elect a new primary")
+		}
+		if failover.HeartbeatIntervalMillis() == 0 {
+			return fmt.Errorf("cluster: automatic_failover: heartbeat_interval_millis: must be > 0")
+		}
+		if failover.FailoverTimeoutMillis() <= failover.HeartbeatIntervalMillis() {
+			return fmt.Errorf("cluster: automatic_failover: failover_timeout_millis: is %d but must be greater than heartbeat_interval_millis, %d", failover.FailoverTimeoutMillis(), failover.HeartbeatIntervalMillis())
+		}
+	}
 	return nil
 }
 
@@ -122,6 +122,117 @@ func (r RemotesapiYAMLConfig) ReadOnly() bool {
 	return *r.ReadOnly_
 }
 
+type HTTPAPIYAMLConfig struct {
+	Host_           *string  `yaml:"host,omitempty" minver:"TBD"`
+	Port_           *int     `yaml:"port,omitempty" minver:"TBD"`
+	AllowedOrigins_ []string `yaml:"allowed_origins,omitempty" minver:"TBD"`
+}
+
+func (h *HTTPAPIYAMLConfig) Host() string {
+	if h.Host_ == nil {
+		return DefaultHost
+	}
+	return *h.Host_
+}
+
+func (h *HTTPAPIYAMLConfig) Port() int {
+	if h.Port_ == nil {
+		return DefaultHTTPAPIPort
+	}
+	return *h.Port_
+}
+
+func (h *HTTPAPIYAMLConfig) AllowedOrigins() []string {
+	return h.AllowedOrigins_
+}
+
+func httpAPIConfigAsYAMLConfig(config HTTPAPIConfig) *HTTPAPIYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	return &HTTPAPIYAMLConfig{
+		Host_:           ptr(config.Host()),
+		Port_:           ptr(config.Port()),
+		AllowedOrigins_: config.AllowedOrigins(),
+	}
+}
+
+type SlowQueryYAMLConfig struct {
+	LongQueryTimeMillis_ *uint64 `yaml:"long_query_time_millis,omitempty" minver:"TBD"`
+	File_                *string `yaml:"file,omitempty" minver:"TBD"`
+	Format_              *string `yaml:"format,omitempty" minver:"TBD"`
+	MaxSizeMB_           *int    `yaml:"max_size_mb,omitempty" minver:"TBD"`
+	MaxBackups_          *int    `yaml:"max_backups,omitempty" minver:"TBD"`
+}
+
+func (s *SlowQueryYAMLConfig) LongQueryTimeMillis() uint64 {
+	if s.LongQueryTimeMillis_ == nil {
+		return DefaultLongQueryTimeMillis
+	}
+	return *s.LongQueryTimeMillis_
+}
+
+func (s *SlowQueryYAMLConfig) File() string {
+	if s.File_ == nil {
+		return ""
+	}
+	return *s.File_
+}
+
+func (s *SlowQueryYAMLConfig) Format() LogFormat {
+	if s.Format_ == nil {
+		return DefaultSlowQueryLogFormat
+	}
+	return LogFormat(strings.ToLower(*s.Format_))
+}
+
+func (s *SlowQueryYAMLConfig) MaxSizeMB() int {
+	if s.MaxSizeMB_ == nil {
+		return DefaultSlowQueryLogMaxSizeMB
+	}
+	return *s.MaxSizeMB_
+}
+
+func (s *SlowQueryYAMLConfig) MaxBackups() int {
+	if s.MaxBackups_ == nil {
+		return DefaultSlowQueryLogMaxBackups
+	}
+	return *s.MaxBackups_
+}
+
+func slowQueryLogConfigAsYAMLConfig(config SlowQueryLogConfig) *SlowQueryYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	return &SlowQueryYAMLConfig{
+		LongQueryTimeMillis_: ptr(config.LongQueryTimeMillis()),
+		File_:                ptr(config.File()),
+		Format_:              ptr(string(config.Format())),
+		MaxSizeMB_:           ptr(config.MaxSizeMB()),
+		MaxBackups_:          ptr(config.MaxBackups()),
+	}
+}
+
+type CIYAMLConfig struct {
+	User_ *string `yaml:"user,omitempty" minver:"TBD"`
+}
+
+func (c *CIYAMLConfig) User() string {
+	if c.User_ == nil {
+		return ""
+	}
+	return *c.User_
+}
+
+func ciConfigAsYAMLConfig(config CIConfig) *CIYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	return &CIYAMLConfig{
+		User_: ptr(config.User()),
+	}
+}
+
 type UserSessionVars struct {
 	Name string                 `yaml:"name"`
 	Vars map[string]interface{} `yaml:"vars"`
@@ -140,6 +251,9 @@ type YAMLConfig struct {
 	DataDirStr        *string                `yaml:"data_dir,omitempty"`
 	CfgDirStr         *string                `yaml:"cfg_dir,omitempty"`
 	RemotesapiConfig  RemotesapiYAMLConfig   `yaml:"remotesapi,omitempty"`
+	HTTPAPICfg        *HTTPAPIYAMLConfig     `yaml:"http_api,omitempty" minver:"TBD"`
+	SlowQueryLogCfg   *SlowQueryYAMLConfig   `yaml:"slow_query_log,omitempty" minver:"TBD"`
+	CICfg             *CIYAMLConfig          `yaml:"ci,omitempty" minver:"TBD"`
 	PrivilegeFile     *string                `yaml:"privilege_file,omitempty"`
 	BranchControlFile *string                `yaml:"branch_control_file,omitempty"`
 	// TODO: Rename to UserVars_
@@ -221,6 +335,9 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			Port_:     cfg.RemotesapiPort(),
 			ReadOnly_: cfg.RemotesapiReadOnly(),
 		},
+		HTTPAPICfg:        httpAPIConfigAsYAMLConfig(cfg.HTTPAPIConfig()),
+		SlowQueryLogCfg:   slowQueryLogConfigAsYAMLConfig(cfg.SlowQueryLogConfig()),
+		CICfg:             ciConfigAsYAMLConfig(cfg.CIConfig()),
 		ClusterCfg:        clusterConfigAsYAMLConfig(cfg.ClusterConfig()),
 		PrivilegeFile:     ptr(cfg.PrivilegeFilePath()),
 		BranchControlFile: ptr(cfg.BranchControlFilePath()),
@@ -248,6 +365,18 @@ func clusterConfigAsYAMLConfig(config ClusterConfig) *ClusterYAMLConfig {
 			URLMatches: config.RemotesAPIConfig().ServerNameURLMatches(),
 			DNSMatches: config.RemotesAPIConfig().ServerNameDNSMatches(),
 		},
+		AutomaticFailover_: automaticFailoverAsYAMLConfig(config.AutomaticFailover()),
+	}
+}
+
+func automaticFailoverAsYAMLConfig(config ClusterAutomaticFailoverConfig) *ClusterAutomaticFailoverYAMLConfig {
+	if !config.Enable() {
+		return nil
+	}
+	return &ClusterAutomaticFailoverYAMLConfig{
+		Enable_:                  ptr(true),
+		HeartbeatIntervalMillis_: ptr(config.HeartbeatIntervalMillis()),
+		FailoverTimeoutMillis_:   ptr(config.FailoverTimeoutMillis()),
 	}
 }
 
@@ -293,6 +422,9 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			Port_:     zeroIf(cfg.RemotesapiPort(), !cfg.ValueSet(RemotesapiPortKey)),
 			ReadOnly_: zeroIf(cfg.RemotesapiReadOnly(), !cfg.ValueSet(RemotesapiReadOnlyKey)),
 		},
+		HTTPAPICfg:        zeroIf(httpAPIConfigAsYAMLConfig(cfg.HTTPAPIConfig()), !cfg.ValueSet(HTTPAPIConfigKey)),
+		SlowQueryLogCfg:   zeroIf(slowQueryLogConfigAsYAMLConfig(cfg.SlowQueryLogConfig()), !cfg.ValueSet(SlowQueryLogConfigKey)),
+		CICfg:             zeroIf(ciConfigAsYAMLConfig(cfg.CIConfig()), !cfg.ValueSet(CIConfigKey)),
 		ClusterCfg:        zeroIf(clusterConfigAsYAMLConfig(cfg.ClusterConfig()), !cfg.ValueSet(ClusterConfigKey)),
 		PrivilegeFile:     zeroIf(ptr(cfg.PrivilegeFilePath()), !cfg.ValueSet(PrivilegeFilePathKey)),
 		BranchControlFile: zeroIf(ptr(cfg.BranchControlFilePath()), !cfg.ValueSet(BranchControlFilePathKey)),
@@ -396,6 +528,30 @@ func (cfg YAMLConfig) withPlaceholdersFilledIn() YAMLConfig {
 		withPlaceholders.RemotesapiConfig.ReadOnly_ = ptr(false)
 	}
 
+	if withPlaceholders.HTTPAPICfg == nil {
+		withPlaceholders.HTTPAPICfg = &HTTPAPIYAMLConfig{
+			Host_:           ptr(DefaultHost),
+			Port_:           ptr(DefaultHTTPAPIPort),
+			AllowedOrigins_: []string{"https://example.com"},
+		}
+	}
+
+	if withPlaceholders.SlowQueryLogCfg == nil {
+		withPlaceholders.SlowQueryLogCfg = &SlowQueryYAMLConfig{
+			LongQueryTimeMillis_: ptr(uint64(DefaultLongQueryTimeMillis)),
+			File_:                ptr("slow_query.log"),
+			Format_:              ptr(string(DefaultSlowQueryLogFormat)),
+			MaxSizeMB_:           ptr(DefaultSlowQueryLogMaxSizeMB),
+			MaxBackups_:          ptr(DefaultSlowQueryLogMaxBackups),
+		}
+	}
+
+	if withPlaceholders.CICfg == nil {
+		withPlaceholders.CICfg = &CIYAMLConfig{
+			User_: ptr("ci"),
+		}
+	}
+
 	if withPlaceholders.ClusterCfg == nil {
 		withPlaceholders.ClusterCfg = &ClusterYAMLConfig{
 			StandbyRemotes_: []StandbyRemoteYAMLConfig{
@@ -425,6 +581,11 @@ func (cfg YAMLConfig) withPlaceholdersFilledIn() YAMLConfig {
 					"standby_replica_two.svc.cluster.local",
 				},
 			},
+			AutomaticFailover_: &ClusterAutomaticFailoverYAMLConfig{
+				Enable_:                  ptr(false),
+				HeartbeatIntervalMillis_: ptr(uint64(defaultHeartbeatIntervalMillis)),
+				FailoverTimeoutMillis_:   ptr(uint64(defaultFailoverTimeoutMillis)),
+			},
 		}
 	}
 
@@ -861,6 +1022,27 @@ func (cfg YAMLConfig) GoldenMysqlConnectionString() (s string) {
 	return
 }
 
+func (cfg YAMLConfig) HTTPAPIConfig() HTTPAPIConfig {
+	if cfg.HTTPAPICfg == nil {
+		return nil
+	}
+	return cfg.HTTPAPICfg
+}
+
+func (cfg YAMLConfig) SlowQueryLogConfig() SlowQueryLogConfig {
+	if cfg.SlowQueryLogCfg == nil {
+		return nil
+	}
+	return cfg.SlowQueryLogCfg
+}
+
+func (cfg YAMLConfig) CIConfig() CIConfig {
+	if cfg.CICfg == nil {
+		return nil
+	}
+	return cfg.CICfg
+}
+
 func (cfg YAMLConfig) ClusterConfig() ClusterConfig {
 	if cfg.ClusterCfg == nil {
 		return nil
@@ -890,10 +1072,11 @@ func (cfg YAMLConfig) EventSchedulerStatus() string {
 }
 
 type ClusterYAMLConfig struct {
-	StandbyRemotes_ []StandbyRemoteYAMLConfig   `yaml:"standby_remotes"`
-	BootstrapRole_  string                      `yaml:"bootstrap_role"`
-	BootstrapEpoch_ int                         `yaml:"bootstrap_epoch"`
-	RemotesAPI      ClusterRemotesAPIYAMLConfig `yaml:"remotesapi"`
+	StandbyRemotes_    []StandbyRemoteYAMLConfig           `yaml:"standby_remotes"`
+	BootstrapRole_     string                              `yaml:"bootstrap_role"`
+	BootstrapEpoch_    int                                 `yaml:"bootstrap_epoch"`
+	RemotesAPI         ClusterRemotesAPIYAMLConfig         `yaml:"remotesapi"`
+	AutomaticFailover_ *ClusterAutomaticFailoverYAMLConfig `yaml:"automatic_failover,omitempty" minver:"TBD"`
 }
 
 type StandbyRemoteYAMLConfig struct {
@@ -929,6 +1112,45 @@ func (c *ClusterYAMLConfig) RemotesAPIConfig() ClusterRemotesAPIConfig {
 	return c.RemotesAPI
 }
 
+func (c *ClusterYAMLConfig) AutomaticFailover() ClusterAutomaticFailoverConfig {
+	if c.AutomaticFailover_ == nil {
+		return &ClusterAutomaticFailoverYAMLConfig{}
+	}
+	return c.AutomaticFailover_
+}
+
+const (
+	defaultHeartbeatIntervalMillis = 1000
+	defaultFailoverTimeoutMillis   = 5000
+)
+
+type ClusterAutomaticFailoverYAMLConfig struct {
+	Enable_                  *bool   `yaml:"enable,omitempty" minver:"TBD"`
+	HeartbeatIntervalMillis_ *uint64 `yaml:"heartbeat_interval_millis,omitempty" minver:"TBD"`
+	FailoverTimeoutMillis_   *uint64 `yaml:"failover_timeout_millis,omitempty" minver:"TBD"`
+}
+
+func (c *ClusterAutomaticFailoverYAMLConfig) Enable() bool {
+	if c.Enable_ == nil {
+		return false
+	}
+	return *c.Enable_
+}
+
+func (c *ClusterAutomaticFailoverYAMLConfig) HeartbeatIntervalMillis() uint64 {
+	if c.HeartbeatIntervalMillis_ == nil {
+		return defaultHeartbeatIntervalMillis
+	}
+	return *c.HeartbeatIntervalMillis_
+}
+
+func (c *ClusterAutomaticFailoverYAMLConfig) FailoverTimeoutMillis() uint64 {
+	if c.FailoverTimeoutMillis_ == nil {
+		return defaultFailoverTimeoutMillis
+	}
+	return *c.FailoverTimeoutMillis_
+}
+
 type ClusterRemotesAPIYAMLConfig struct {
 	Addr_      string   `yaml:"address"`
 	Port_      int      `yaml:"port"`
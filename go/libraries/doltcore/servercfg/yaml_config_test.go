@@ -158,6 +158,117 @@ remotesapi:
 	require.Equal(t, 8000, *config.RemotesapiPort())
 }
 
+func TestUnmarshallHTTPAPI(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+listener:
+  port: 3306
+`))
+	require.NoError(t, err)
+	require.Nil(t, config.HTTPAPIConfig())
+
+	config, err = NewYamlConfig([]byte(`
+http_api:
+  port: 8081
+  allowed_origins:
+  - https://example.com
+`))
+	require.NoError(t, err)
+	require.NotNil(t, config.HTTPAPIConfig())
+	assert.Equal(t, "localhost", config.HTTPAPIConfig().Host())
+	assert.Equal(t, 8081, config.HTTPAPIConfig().Port())
+	assert.Equal(t, []string{"https://example.com"}, config.HTTPAPIConfig().AllowedOrigins())
+	require.NoError(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte(`
+http_api:
+  host: not-a-host
+`))
+	require.NoError(t, err)
+	assert.Error(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte(`
+http_api:
+  port: 80
+`))
+	require.NoError(t, err)
+	assert.Error(t, ValidateConfig(config))
+}
+
+func TestUnmarshallSlowQueryLog(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+listener:
+  port: 3306
+`))
+	require.NoError(t, err)
+	require.Nil(t, config.SlowQueryLogConfig())
+
+	config, err = NewYamlConfig([]byte(`
+slow_query_log: {}
+`))
+	require.NoError(t, err)
+	require.NotNil(t, config.SlowQueryLogConfig())
+	assert.Equal(t, uint64(DefaultLongQueryTimeMillis), config.SlowQueryLogConfig().LongQueryTimeMillis())
+	assert.Equal(t, "", config.SlowQueryLogConfig().File())
+	assert.Equal(t, LogFormat_Text, config.SlowQueryLogConfig().Format())
+	assert.Equal(t, DefaultSlowQueryLogMaxSizeMB, config.SlowQueryLogConfig().MaxSizeMB())
+	assert.Equal(t, DefaultSlowQueryLogMaxBackups, config.SlowQueryLogConfig().MaxBackups())
+	require.NoError(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte(`
+slow_query_log:
+  long_query_time_millis: 250
+  file: /var/log/dolt/slow.log
+  format: JSON
+  max_size_mb: 10
+  max_backups: 0
+`))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(250), config.SlowQueryLogConfig().LongQueryTimeMillis())
+	assert.Equal(t, "/var/log/dolt/slow.log", config.SlowQueryLogConfig().File())
+	assert.Equal(t, LogFormat_JSON, config.SlowQueryLogConfig().Format())
+	assert.Equal(t, 10, config.SlowQueryLogConfig().MaxSizeMB())
+	assert.Equal(t, 0, config.SlowQueryLogConfig().MaxBackups())
+	require.NoError(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte(`
+slow_query_log:
+  format: xml
+`))
+	require.NoError(t, err)
+	assert.Error(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte(`
+slow_query_log:
+  max_size_mb: 0
+`))
+	require.NoError(t, err)
+	assert.Error(t, ValidateConfig(config))
+}
+
+func TestUnmarshallCI(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+listener:
+  port: 3306
+`))
+	require.NoError(t, err)
+	require.Nil(t, config.CIConfig())
+
+	config, err = NewYamlConfig([]byte(`
+ci:
+  user: ci_runner
+`))
+	require.NoError(t, err)
+	require.NotNil(t, config.CIConfig())
+	assert.Equal(t, "ci_runner", config.CIConfig().User())
+	require.NoError(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte(`
+ci: {}
+`))
+	require.NoError(t, err)
+	assert.Error(t, ValidateConfig(config))
+}
+
 func TestUnmarshallCluster(t *testing.T) {
 	testStr := `
 cluster:
@@ -259,6 +370,62 @@ cluster:
   bootstrap_epoch: 0
   remotesapi:
     port: 50051
+`,
+			Error: true,
+		},
+		{
+			Name: "automatic_failover valid",
+			Config: `
+cluster:
+  standby_remotes:
+  - name: standby_one
+    remote_url_template: http://localhost:50051/{database}
+  - name: standby_two
+    remote_url_template: http://localhost:50052/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 0
+  remotesapi:
+    port: 50050
+  automatic_failover:
+    enable: true
+    heartbeat_interval_millis: 500
+    failover_timeout_millis: 3000
+`,
+			Error: false,
+		},
+		{
+			Name: "automatic_failover with one standby remote",
+			Config: `
+cluster:
+  standby_remotes:
+  - name: standby
+    remote_url_template: http://localhost:50051/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 0
+  remotesapi:
+    port: 50050
+  automatic_failover:
+    enable: true
+`,
+			Error: true,
+		},
+		{
+			Name: "automatic_failover timeout not greater than heartbeat interval",
+			Config: `
+cluster:
+  standby_remotes:
+  - name: standby_one
+    remote_url_template: http://localhost:50051/{database}
+  - name: standby_two
+    remote_url_template: http://localhost:50052/{database}
+  bootstrap_role: primary
+  bootstrap_epoch: 0
+  remotesapi:
+    port: 50050
+  automatic_failover:
+    enable: true
+    heartbeat_interval_millis: 1000
+    failover_timeout_millis: 1000
 `,
 			Error: true,
 		},
@@ -238,6 +238,14 @@ func (h *commithook) isCaughtUp() bool {
 	return h.nextHead == h.lastPushedHead
 }
 
+// Like isCaughtUp, but takes h.mu. Used by the primary's heartbeats when
+// automatic failover is enabled.
+func (h *commithook) caughtUp() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.isCaughtUp()
+}
+
 // called with h.mu locked.
 func (h *commithook) primaryNeedsInit() bool {
 	return h.role == RolePrimary && h.nextHead == (hash.Hash{})
@@ -114,6 +114,9 @@ type Controller struct {
 	remoteSrvDBCache         remotesrv.DBCache
 
 	sqlCtxFactory SqlContextFactory
+
+	// Non-nil if automatic failover is enabled.
+	failover *failover
 }
 
 type sqlvars interface {
@@ -208,6 +211,10 @@ func NewController(lgr *logrus.Logger, cfg servercfg.ClusterConfig, pCfg config.
 
 	ret.outstandingDropDatabases = make(map[string]*databaseDropReplication)
 
+	if failoverCfg := cfg.AutomaticFailover(); failoverCfg.Enable() {
+		ret.failover = newFailover(lgr, ret, failoverCfg)
+	}
+
 	return ret, nil
 }
 
@@ -228,6 +235,13 @@ func (c *Controller) Run() {
 		defer wg.Done()
 		c.bcReplication.Run()
 	}()
+	if c.failover != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.failover.Run()
+		}()
+	}
 	wg.Wait()
 	for _, client := range c.replicationClients {
 		client.closer()
@@ -238,6 +252,9 @@ func (c *Controller) GracefulStop() error {
 	c.jwks.GracefulStop()
 	c.mysqlDbPersister.GracefulStop()
 	c.bcReplication.GracefulStop()
+	if c.failover != nil {
+		c.failover.GracefulStop()
+	}
 	return nil
 }
 
@@ -710,7 +727,7 @@ func (c *Controller) RemoteSrvServerArgs(ctxFactory func(context.Context) (*sql.
 	args.Options = append(args.Options, c.ServerOptions()...)
 	args.HttpInterceptor = ctxInterceptor.HTTP(args.HttpInterceptor)
 	var err error
-	args.DBCache, err = sqle.RemoteSrvDBCache(sqle.GetInterceptorSqlContext, sqle.CreateUnknownDatabases)
+	args.DBCache, err = sqle.RemoteSrvDBCache(sqle.GetInterceptorSqlContext, sqle.CreateUnknownDatabases, sqle.IgnoreBranchPermissions, sqle.ReplicatedBranchProtection)
 	if err != nil {
 		return remotesrv.ServerArgs{}, err
 	}
@@ -781,6 +798,7 @@ func (c *Controller) RegisterGrpcServices(ctxFactory func(context.Context) (*sql
 		branchControl:        c.branchControlController,
 		branchControlFilesys: c.branchControlFilesys,
 		dropDatabase:         c.dropDatabase,
+		failover:             c.failover,
 		lgr:                  c.lgr.WithFields(logrus.Fields{}),
 	})
 }
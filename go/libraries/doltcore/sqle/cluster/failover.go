@@ -0,0 +1,339 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	replicationapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+// failover implements automatic failover for a cluster. It is only
+// instantiated when automatic_failover is enabled in the cluster config.
+//
+// While this server is a primary, failover sends a heartbeat to every standby
+// each heartbeat interval. Each heartbeat carries a sequence number, which
+// increases within the epoch of the primary, and whether every database was
+// replicated to that standby when the heartbeat was sent.
+//
+// While this server is a standby, failover waits for heartbeats from the
+// primary. If none arrives for the failover timeout, it asks every other
+// server in the cluster for its failover status and elects a new primary:
+// * If any server is a primary at our epoch or later, or has received a
+// heartbeat within the failover timeout, the primary is still alive and
+// nothing happens. Nothing happens either if any server does not have
+// automatic failover enabled.
+// * If fewer than a majority of the servers in the cluster answered, nothing
+// happens. This keeps a standby which is partitioned from the rest of the
+// cluster from promoting itself.
+// * Otherwise, the standby which was last reported caught up by the primary
+// wins, with ties broken by the server ids. If that is this server, it
+// becomes primary at an epoch one past the highest epoch it saw. If not,
+// this server waits another failover timeout for the winner's heartbeats.
+//
+// The new primary goes through Controller.setRoleAndEpoch, and the other
+// servers learn about it through the role and epoch headers on its
+// heartbeats, exactly as they would after a manual
+// dolt_assume_cluster_role().
+type failover struct {
+	lgr        *logrus.Entry
+	controller *Controller
+	clients    []*replicationServiceClient
+	serverID   string
+
+	heartbeatInterval time.Duration
+	failoverTimeout   time.Duration
+
+	mu       sync.Mutex
+	shutdown bool
+	done     chan struct{}
+
+	// The role this server had at the last tick.
+	role Role
+
+	// Heartbeats we send as a primary.
+	sequenceEpoch int
+	sequence      uint64
+
+	// Heartbeats we receive as a standby.
+	lastHeartbeat    time.Time
+	electionDeadline time.Time
+	caughtUpEpoch    int
+	caughtUpSequence uint64
+}
+
+func newFailover(lgr *logrus.Logger, c *Controller, cfg servercfg.ClusterAutomaticFailoverConfig) *failover {
+	return &failover{
+		lgr:               lgr.WithFields(logrus.Fields{}),
+		controller:        c,
+		clients:           c.replicationClients,
+		serverID:          uuid.NewString(),
+		heartbeatInterval: time.Duration(cfg.HeartbeatIntervalMillis()) * time.Millisecond,
+		failoverTimeout:   time.Duration(cfg.FailoverTimeoutMillis()) * time.Millisecond,
+		done:              make(chan struct{}),
+	}
+}
+
+func (f *failover) Run() {
+	f.lgr.Tracef("cluster/failover: running with heartbeat interval %v and failover timeout %v", f.heartbeatInterval, f.failoverTimeout)
+	ticker := time.NewTicker(f.heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-ticker.C:
+		}
+		f.tick()
+	}
+}
+
+func (f *failover) GracefulStop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.shutdown {
+		f.shutdown = true
+		close(f.done)
+	}
+}
+
+func (f *failover) tick() {
+	role, epoch := f.controller.roleAndEpoch()
+	f.mu.Lock()
+	if role != f.role {
+		// A server which just became a standby gives the primary a
+		// full failover timeout to start sending it heartbeats.
+		f.role = role
+		f.electionDeadline = time.Now().Add(f.failoverTimeout)
+	}
+	electionDue := time.Now().After(f.electionDeadline)
+	f.mu.Unlock()
+
+	if role == RolePrimary {
+		f.sendHeartbeats(epoch)
+	} else if role == RoleStandby && electionDue {
+		f.elect(epoch)
+	}
+}
+
+func (f *failover) sendHeartbeats(epoch int) {
+	f.mu.Lock()
+	if f.sequenceEpoch != epoch {
+		f.sequenceEpoch = epoch
+		f.sequence = 0
+	}
+	f.sequence += 1
+	sequence := f.sequence
+	f.mu.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(len(f.clients))
+	for _, client := range f.clients {
+		client := client
+		req := &replicationapi.HeartbeatRequest{
+			Sequence: sequence,
+			CaughtUp: f.controller.standbyCaughtUp(client.remote),
+		}
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), f.heartbeatInterval)
+			defer cancel()
+			_, err := client.client.Heartbeat(ctx, req)
+			if err != nil {
+				f.lgr.Tracef("cluster/failover: failed to send heartbeat %d to %s: %v", sequence, client.remote, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Called by the replication service when a primary at |epoch| sends us a
+// heartbeat.
+func (f *failover) recordHeartbeat(epoch int, req *replicationapi.HeartbeatRequest) {
+	_, ourEpoch := f.controller.roleAndEpoch()
+	if epoch < ourEpoch {
+		// A stale primary which does not know about the current
+		// epoch yet. It is not the primary we are waiting on.
+		f.lgr.Tracef("cluster/failover: ignoring heartbeat from a primary at epoch %d; this server is at epoch %d", epoch, ourEpoch)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	f.lastHeartbeat = now
+	f.electionDeadline = now.Add(f.failoverTimeout)
+	if req.CaughtUp {
+		f.caughtUpEpoch = epoch
+		f.caughtUpSequence = req.Sequence
+	}
+}
+
+func (f *failover) status() *replicationapi.GetFailoverStatusResponse {
+	role, epoch := f.controller.roleAndEpoch()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	millisSinceHeartbeat := int64(-1)
+	if f.lastHeartbeat != (time.Time{}) {
+		millisSinceHeartbeat = time.Since(f.lastHeartbeat).Milliseconds()
+	}
+	return &replicationapi.GetFailoverStatusResponse{
+		Role:                 string(role),
+		Epoch:                int64(epoch),
+		ServerId:             f.serverID,
+		MillisSinceHeartbeat: millisSinceHeartbeat,
+		CaughtUpEpoch:        int64(f.caughtUpEpoch),
+		CaughtUpSequence:     f.caughtUpSequence,
+	}
+}
+
+func (f *failover) postponeElection() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.electionDeadline = time.Now().Add(f.failoverTimeout)
+}
+
+// Fetches the failover status of every other server in the cluster. The
+// returned slices are parallel to f.clients. For each server which could not
+// be reached, the status is nil and the error is set.
+func (f *failover) peerStatuses() ([]*replicationapi.GetFailoverStatusResponse, []error) {
+	ret := make([]*replicationapi.GetFailoverStatusResponse, len(f.clients))
+	errs := make([]error, len(f.clients))
+	var wg sync.WaitGroup
+	wg.Add(len(f.clients))
+	for i, client := range f.clients {
+		i, client := i, client
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), f.failoverTimeout)
+			defer cancel()
+			resp, err := client.client.GetFailoverStatus(ctx, &replicationapi.GetFailoverStatusRequest{})
+			if err != nil {
+				f.lgr.Tracef("cluster/failover: failed to get failover status from %s: %v", client.remote, err)
+				errs[i] = err
+				return
+			}
+			ret[i] = resp
+		}()
+	}
+	wg.Wait()
+	return ret, errs
+}
+
+func (f *failover) elect(epoch int) {
+	f.lgr.Warnf("cluster/failover: no heartbeat from the primary in %v; checking whether to elect a new primary", f.failoverTimeout)
+	self := f.status()
+	statuses, errs := f.peerStatuses()
+
+	reachable := 1
+	highestEpoch := epoch
+	candidates := []*replicationapi.GetFailoverStatusResponse{self}
+	for i, s := range statuses {
+		remote := f.clients[i].remote
+		if status.Code(errs[i]) == codes.Unimplemented {
+			// The server is up, but it does not have
+			// automatic failover enabled. If it is the
+			// primary, it was never going to send us
+			// heartbeats.
+			f.lgr.Errorf("cluster/failover: %s does not have automatic_failover enabled; not electing a new primary", remote)
+			f.postponeElection()
+			return
+		}
+		if s == nil {
+			continue
+		}
+		reachable += 1
+		if int(s.Epoch) > highestEpoch {
+			highestEpoch = int(s.Epoch)
+		}
+		if Role(s.Role) == RolePrimary && int(s.Epoch) >= epoch {
+			f.lgr.Infof("cluster/failover: %s is primary at epoch %d; not electing a new primary", remote, s.Epoch)
+			f.postponeElection()
+			return
+		}
+		if s.MillisSinceHeartbeat >= 0 && s.MillisSinceHeartbeat < f.failoverTimeout.Milliseconds() {
+			f.lgr.Infof("cluster/failover: %s received a heartbeat from the primary %dms ago; not electing a new primary", remote, s.MillisSinceHeartbeat)
+			f.postponeElection()
+			return
+		}
+		if Role(s.Role) == RoleStandby {
+			candidates = append(candidates, s)
+		}
+	}
+
+	clusterSize := len(f.clients) + 1
+	if reachable*2 <= clusterSize {
+		f.lgr.Warnf("cluster/failover: could only reach %d of %d servers in the cluster; not electing a new primary without a majority", reachable, clusterSize)
+		f.postponeElection()
+		return
+	}
+
+	winner := candidates[0]
+	for _, c := range candidates[1:] {
+		if moreCaughtUp(c, winner) {
+			winner = c
+		}
+	}
+	if winner.ServerId != f.serverID {
+		f.lgr.Infof("cluster/failover: another standby is more caught up than this server; waiting for it to become primary")
+		f.postponeElection()
+		return
+	}
+
+	newEpoch := highestEpoch + 1
+	f.lgr.Warnf("cluster/failover: this server is the most caught up standby; becoming primary at epoch %d", newEpoch)
+	_, err := f.controller.setRoleAndEpoch(string(RolePrimary), newEpoch, roleTransitionOptions{
+		graceful: false,
+	})
+	if err != nil {
+		f.lgr.Errorf("cluster/failover: failed to become primary at epoch %d: %v", newEpoch, err)
+		f.postponeElection()
+	}
+}
+
+// Returns true if |a| was reported caught up by a primary more recently than
+// |b|. Standbys which were caught up at the same heartbeat are ordered by
+// their server ids, so that every standby picks the same winner.
+func moreCaughtUp(a, b *replicationapi.GetFailoverStatusResponse) bool {
+	if a.CaughtUpEpoch != b.CaughtUpEpoch {
+		return a.CaughtUpEpoch > b.CaughtUpEpoch
+	}
+	if a.CaughtUpSequence != b.CaughtUpSequence {
+		return a.CaughtUpSequence > b.CaughtUpSequence
+	}
+	return a.ServerId < b.ServerId
+}
+
+// Returns true if every database is currently replicated to the standby
+// |remote|. Called by a primary when it sends heartbeats.
+func (c *Controller) standbyCaughtUp(remote string) bool {
+	c.mu.Lock()
+	commithooks := make([]*commithook, len(c.commithooks))
+	copy(commithooks, c.commithooks)
+	c.mu.Unlock()
+	for _, h := range commithooks {
+		if h.remotename == remote && !h.caughtUp() {
+			return false
+		}
+	}
+	return true
+}
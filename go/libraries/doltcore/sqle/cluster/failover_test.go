@@ -0,0 +1,261 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gopkg.in/go-jose/go-jose.v2"
+
+	replicationapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/creds"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const testHeartbeatIntervalMillis = 50
+const testFailoverTimeoutMillis = 500
+
+type clusterKeyProvider struct {
+	mu   sync.Mutex
+	keys map[string]ed25519.PublicKey
+}
+
+func (p *clusterKeyProvider) GetKey(kid string) ([]jose.JSONWebKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pub, ok := p.keys[kid]; ok {
+		return []jose.JSONWebKey{{Key: pub, KeyID: kid}}, nil
+	}
+	return nil, fmt.Errorf("no key for kid %s", kid)
+}
+
+type testSysVars struct{}
+
+func (testSysVars) AddSystemVariables([]sql.SystemVariable) {
+}
+
+func (testSysVars) GetGlobal(string) (sql.SystemVariable, interface{}, bool) {
+	return nil, nil, false
+}
+
+type testClusterServer struct {
+	name       string
+	controller *Controller
+	srv        *grpc.Server
+	wg         sync.WaitGroup
+}
+
+func (s *testClusterServer) stop() {
+	s.controller.failover.GracefulStop()
+	s.srv.Stop()
+	s.wg.Wait()
+	for _, client := range s.controller.replicationClients {
+		client.closer()
+	}
+}
+
+// Starts a cluster of |n| in-process servers, where the first server is the
+// primary at epoch 1 and the rest are its standbys. Each server only runs the
+// parts of the Controller which automatic failover needs: its replication
+// service and its failover loop.
+func newTestCluster(t *testing.T, n int) []*testClusterServer {
+	listeners := make([]net.Listener, n)
+	for i := range listeners {
+		var err error
+		listeners[i], err = net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+	}
+
+	lgr := logrus.New()
+	lgr.SetLevel(logrus.WarnLevel)
+	keys := &clusterKeyProvider{keys: make(map[string]ed25519.PublicKey)}
+	servers := make([]*testClusterServer, n)
+	for i := range servers {
+		cfg := &servercfg.ClusterYAMLConfig{
+			BootstrapRole_:  string(RoleStandby),
+			BootstrapEpoch_: 1,
+			AutomaticFailover_: &servercfg.ClusterAutomaticFailoverYAMLConfig{
+				Enable_:                  ptr(true),
+				HeartbeatIntervalMillis_: ptr(uint64(testHeartbeatIntervalMillis)),
+				FailoverTimeoutMillis_:   ptr(uint64(testFailoverTimeoutMillis)),
+			},
+		}
+		if i == 0 {
+			cfg.BootstrapRole_ = string(RolePrimary)
+		}
+		for j := range listeners {
+			if j != i {
+				cfg.StandbyRemotes_ = append(cfg.StandbyRemotes_, servercfg.StandbyRemoteYAMLConfig{
+					Name_:              fmt.Sprintf("server%d", j),
+					RemoteURLTemplate_: fmt.Sprintf("http://%s/{database}", listeners[j].Addr().String()),
+				})
+			}
+		}
+		require.NoError(t, servercfg.ValidateClusterConfig(cfg))
+
+		c, err := NewController(lgr, cfg, config.NewMapConfig(make(map[string]string)))
+		require.NoError(t, err)
+		c.ManageSystemVariables(testSysVars{})
+		c.mysqlDbPersister = &replicatingMySQLDbPersister{}
+		c.bcReplication = &branchControlReplication{bcController: branch_control.CreateDefaultController(context.Background())}
+		c.sinterceptor.keyProvider = keys
+		keys.keys[creds.PubKeyToKIDStr(c.pub)] = c.pub
+
+		s := &testClusterServer{
+			name:       fmt.Sprintf("server%d", i),
+			controller: c,
+			srv:        grpc.NewServer(c.ServerOptions()...),
+		}
+		c.RegisterGrpcServices(nil, s.srv)
+		lis := listeners[i]
+		s.wg.Add(2)
+		go func() {
+			defer s.wg.Done()
+			s.srv.Serve(lis)
+		}()
+		go func() {
+			defer s.wg.Done()
+			c.failover.Run()
+		}()
+		servers[i] = s
+	}
+	t.Cleanup(func() {
+		for _, s := range servers {
+			s.stop()
+		}
+	})
+	return servers
+}
+
+func ptr[T any](t T) *T {
+	return &t
+}
+
+func waitForHeartbeats(t *testing.T, servers []*testClusterServer) {
+	require.Eventually(t, func() bool {
+		for _, s := range servers {
+			if s.controller.failover.status().MillisSinceHeartbeat == -1 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// Returns the single server among |servers| which is primary, or nil if none
+// or more than one of them is.
+func onlyPrimary(servers []*testClusterServer) *testClusterServer {
+	var primary *testClusterServer
+	for _, s := range servers {
+		if role, _ := s.controller.roleAndEpoch(); role == RolePrimary {
+			if primary != nil {
+				return nil
+			}
+			primary = s
+		}
+	}
+	return primary
+}
+
+func TestAutomaticFailover(t *testing.T) {
+	t.Run("HealthyPrimaryStaysPrimary", func(t *testing.T) {
+		servers := newTestCluster(t, 3)
+		waitForHeartbeats(t, servers[1:])
+		time.Sleep(3 * testFailoverTimeoutMillis * time.Millisecond)
+		for i, s := range servers {
+			role, epoch := s.controller.roleAndEpoch()
+			if i == 0 {
+				assert.Equal(t, RolePrimary, role)
+			} else {
+				assert.Equal(t, RoleStandby, role)
+			}
+			assert.Equal(t, 1, epoch)
+		}
+	})
+	t.Run("StandbyIsPromotedWhenPrimaryIsLost", func(t *testing.T) {
+		servers := newTestCluster(t, 3)
+		waitForHeartbeats(t, servers[1:])
+		servers[0].stop()
+		standbys := servers[1:]
+		require.Eventually(t, func() bool {
+			primary := onlyPrimary(standbys)
+			if primary == nil {
+				return false
+			}
+			// The other standby learns the new epoch from the
+			// heartbeats of the new primary.
+			for _, s := range standbys {
+				if _, epoch := s.controller.roleAndEpoch(); epoch != 2 {
+					return false
+				}
+			}
+			return true
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+	t.Run("MostCaughtUpStandbyIsPromoted", func(t *testing.T) {
+		servers := newTestCluster(t, 3)
+		// The primary never manages to replicate to server2.
+		hook := newCommitHook(logrus.New(), "server2", "", "db", RolePrimary, nil, nil, "")
+		hook.nextHead = hash.Of([]byte("next"))
+		servers[0].controller.registerCommitHook(hook)
+		require.Eventually(t, func() bool {
+			return servers[1].controller.failover.status().CaughtUpSequence > 0
+		}, 5*time.Second, 10*time.Millisecond)
+		waitForHeartbeats(t, servers[1:])
+		assert.Equal(t, uint64(0), servers[2].controller.failover.status().CaughtUpSequence)
+
+		servers[0].stop()
+		require.Eventually(t, func() bool {
+			return onlyPrimary(servers[1:]) == servers[1]
+		}, 10*time.Second, 10*time.Millisecond)
+		role, epoch := servers[1].controller.roleAndEpoch()
+		assert.Equal(t, RolePrimary, role)
+		assert.Equal(t, 2, epoch)
+	})
+	t.Run("MinorityDoesNotElect", func(t *testing.T) {
+		servers := newTestCluster(t, 3)
+		waitForHeartbeats(t, servers[1:])
+		servers[0].stop()
+		servers[1].stop()
+		time.Sleep(4 * testFailoverTimeoutMillis * time.Millisecond)
+		role, epoch := servers[2].controller.roleAndEpoch()
+		assert.Equal(t, RoleStandby, role)
+		assert.Equal(t, 1, epoch)
+	})
+}
+
+func TestMoreCaughtUp(t *testing.T) {
+	status := func(epoch int64, sequence uint64, id string) *replicationapi.GetFailoverStatusResponse {
+		return &replicationapi.GetFailoverStatusResponse{CaughtUpEpoch: epoch, CaughtUpSequence: sequence, ServerId: id}
+	}
+	assert.True(t, moreCaughtUp(status(2, 1, "b"), status(1, 10, "a")))
+	assert.True(t, moreCaughtUp(status(1, 10, "b"), status(1, 9, "a")))
+	assert.True(t, moreCaughtUp(status(1, 10, "a"), status(1, 10, "b")))
+	assert.False(t, moreCaughtUp(status(1, 10, "b"), status(1, 10, "a")))
+}
@@ -16,11 +16,13 @@ package cluster
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/mysql_db"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	replicationapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/replicationapi/v1alpha1"
@@ -44,6 +46,8 @@ type replicationServiceServer struct {
 	branchControlFilesys filesys.Filesys
 
 	dropDatabase func(*sql.Context, string) error
+
+	failover *failover
 }
 
 func (s *replicationServiceServer) UpdateUsersAndGrants(ctx context.Context, req *replicationapi.UpdateUsersAndGrantsRequest) (*replicationapi.UpdateUsersAndGrantsResponse, error) {
@@ -99,3 +103,30 @@ func (s *replicationServiceServer) DropDatabase(ctx context.Context, req *replic
 	}
 	return &replicationapi.DropDatabaseResponse{}, nil
 }
+
+func (s *replicationServiceServer) Heartbeat(ctx context.Context, req *replicationapi.HeartbeatRequest) (*replicationapi.HeartbeatResponse, error) {
+	if s.failover == nil {
+		return nil, status.Error(codes.Unimplemented, "unimplemented")
+	}
+
+	// The serverinterceptor has already checked the role and the epoch of
+	// the primary which sent the heartbeat against our own.
+	md, _ := metadata.FromIncomingContext(ctx)
+	epochs := md.Get(clusterRoleEpochHeader)
+	if len(epochs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "heartbeat was missing the role epoch of the primary")
+	}
+	epoch, err := strconv.Atoi(epochs[0])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "heartbeat had an invalid role epoch")
+	}
+	s.failover.recordHeartbeat(epoch, req)
+	return &replicationapi.HeartbeatResponse{}, nil
+}
+
+func (s *replicationServiceServer) GetFailoverStatus(ctx context.Context, req *replicationapi.GetFailoverStatusRequest) (*replicationapi.GetFailoverStatusResponse, error) {
+	if s.failover == nil {
+		return nil, status.Error(codes.Unimplemented, "unimplemented")
+	}
+	return s.failover.status(), nil
+}
@@ -386,6 +386,31 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		}
 		return dt, true, nil
 
+	case strings.HasPrefix(lwrName, doltdb.DoltBlameViewPrefix):
+		if head == nil {
+			var err error
+			head, err = ds.GetHeadCommit(ctx, db.RevisionQualifiedName())
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		baseTableName := tblName[len(doltdb.DoltBlameViewPrefix):]
+		tname := doltdb.TableName{Name: baseTableName, Schema: db.schemaName}
+		if resolve.UseSearchPath && db.schemaName == "" {
+			var err error
+			tname, _, _, err = resolve.Table(ctx, root, baseTableName)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		bt, err := dtables.NewBlameTable(ctx, tname, db.ddb, root, head)
+		if err != nil {
+			return nil, false, err
+		}
+		return bt, true, nil
+
 	case strings.HasPrefix(lwrName, doltdb.DoltCommitDiffTablePrefix):
 		baseTableName := tblName[len(doltdb.DoltCommitDiffTablePrefix):]
 		tname := doltdb.TableName{Name: baseTableName, Schema: db.schemaName}
@@ -691,6 +716,30 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
 			dt, found = dtables.NewTagsTable(ctx, lwrName, db.ddb), true
 		}
+	case doltdb.GetNotesTableName(), doltdb.NotesTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewNotesTable(ctx, lwrName, db.ddb), true
+		}
+	case doltdb.GetStorageUsageTableName(), doltdb.StorageUsageTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewStorageUsageTable(ctx, lwrName, db.ddb), true
+		}
+	case doltdb.GetBranchProtectionTableName(), doltdb.BranchProtectionTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewBranchProtectionTable(ctx, db.AliasedName(), lwrName, db.ddb), true
+		}
 	case dtables.AccessTableName:
 		basCtx := branch_control.GetBranchAwareSession(ctx)
 		if basCtx != nil {
@@ -803,6 +852,22 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
 			dt, found = dtables.NewBackupsTable(db, lwrName), true
 		}
+	case doltdb.GetCIRunsTableName(), doltdb.CIRunsTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewCIRunsTable(db.AliasedName(), lwrName, db.ddb), true
+		}
+	case doltdb.GetSlowQueriesTableName(), doltdb.SlowQueriesTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewSlowQueriesTable(db.AliasedName(), lwrName), true
+		}
 	}
 
 	if found {
@@ -927,6 +992,10 @@ func resolveAsOfCommitRef(ctx *sql.Context, db Database, head ref.DoltRef, commi
 		return cm, root, nil
 	}
 
+	if err := dsess.CheckRevisionReadAccess(ctx, db.Name(), ddb, commitRef); err != nil {
+		return nil, nil, err
+	}
+
 	cs, err := doltdb.NewCommitSpec(commitRef)
 
 	if err != nil {
@@ -975,6 +1044,10 @@ func (db Database) GetTableNamesAsOf(ctx *sql.Context, time interface{}) ([]stri
 	if err != nil {
 		return nil, err
 	}
+	tblNames, err = db.withoutSparseTables(ctx, root, tblNames)
+	if err != nil {
+		return nil, err
+	}
 
 	return filterDoltInternalTables(ctx, tblNames, db.schemaName), nil
 }
@@ -1184,7 +1257,15 @@ func (db Database) GetTableNames(ctx *sql.Context) ([]string, error) {
 	}
 
 	showSystemTables := showSystemTablesVar.(int8) == 1
-	tblNames, err := db.GetAllTableNames(ctx, showSystemTables)
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tblNames, err := db.getAllTableNames(ctx, root, showSystemTables)
+	if err != nil {
+		return nil, err
+	}
+	tblNames, err = db.withoutSparseTables(ctx, root, tblNames)
 	if err != nil {
 		return nil, err
 	}
@@ -1245,6 +1326,22 @@ func (db Database) getAllTableNames(ctx *sql.Context, root doltdb.RootValue, inc
 	return result, nil
 }
 
+// withoutSparseTables removes the placeholders of tables left out of a sparse clone from |tblNames|. The placeholders
+// can't be read, so they aren't listed, but reading one by name returns doltdb.ErrSparseTable.
+func (db Database) withoutSparseTables(ctx *sql.Context, root doltdb.RootValue, tblNames []string) ([]string, error) {
+	sparse, err := db.ddb.SparseTableNames(ctx, root, db.schemaName)
+	if err != nil || len(sparse) == 0 {
+		return tblNames, err
+	}
+	result := make([]string, 0, len(tblNames))
+	for _, name := range tblNames {
+		if _, ok := sparse[name]; !ok {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
 func filterDoltInternalTables(ctx *sql.Context, tblNames []string, schemaName string) []string {
 	result := []string{}
 
@@ -1858,18 +1955,6 @@ func (db Database) GetViewDefinition(ctx *sql.Context, viewName string) (sql.Vie
 		}
 	}
 
-	lwrViewName := strings.ToLower(viewName)
-	switch {
-	case strings.HasPrefix(lwrViewName, doltdb.DoltBlameViewPrefix):
-		tableName := lwrViewName[len(doltdb.DoltBlameViewPrefix):]
-
-		blameViewTextDef, err := dtables.NewBlameView(ctx, doltdb.TableName{Name: tableName, Schema: db.schemaName}, root)
-		if err != nil {
-			return sql.ViewDefinition{}, false, err
-		}
-		return sql.ViewDefinition{Name: viewName, TextDefinition: blameViewTextDef, CreateViewStatement: fmt.Sprintf("CREATE VIEW `%s` AS %s", viewName, blameViewTextDef)}, true, nil
-	}
-
 	schTblHash, ok, err := root.GetTableHash(ctx, doltdb.TableName{Name: doltdb.SchemasTableName, Schema: db.schemaName})
 	if err != nil {
 		return sql.ViewDefinition{}, false, err
@@ -26,6 +26,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
@@ -67,6 +68,8 @@ type DoltDatabaseProvider struct {
 
 	dbFactoryUrl string
 	isStandby    *bool
+	// runner is shared by copies of the provider, since it is set after the engine using the provider is created.
+	runner *sql.StatementRunner
 }
 
 var _ sql.DatabaseProvider = (*DoltDatabaseProvider)(nil)
@@ -159,6 +162,7 @@ func NewDoltDatabaseProviderWithDatabases(defaultBranch string, fs filesys.Files
 		defaultBranch:          defaultBranch,
 		dbFactoryUrl:           dbFactoryUrl,
 		isStandby:              new(bool),
+		runner:                 new(sql.StatementRunner),
 		droppedDatabaseManager: newDroppedDatabaseManager(fs),
 	}, nil
 }
@@ -231,6 +235,21 @@ func (p *DoltDatabaseProvider) SetIsStandby(standby bool) {
 	*p.isStandby = standby
 }
 
+// SetStatementRunner sets the engine which runs the queries of the sessions of this provider. It must be called once
+// the engine is created, before any procedure which runs queries of its own is called.
+func (p *DoltDatabaseProvider) SetStatementRunner(runner sql.StatementRunner) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	*p.runner = runner
+}
+
+// StatementRunner implements dsess.DoltDatabaseProvider.
+func (p *DoltDatabaseProvider) StatementRunner() sql.StatementRunner {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return *p.runner
+}
+
 // FileSystemForDatabase returns a filesystem, with the working directory set to the root directory
 // of the requested database. If the requested database isn't found, a database not found error
 // is returned.
@@ -411,8 +430,14 @@ func (p *DoltDatabaseProvider) allRevisionDbs(ctx *sql.Context, db dsess.SqlData
 		return nil, err
 	}
 
-	revDbs := make([]sql.Database, len(branches))
-	for i, branch := range branches {
+	revDbs := make([]sql.Database, 0, len(branches))
+	for _, branch := range branches {
+		// Branches which the user cannot read are not listed
+		if err = branch_control.CanReadBranch(ctx, db.Name(), branch.GetPath()); branch_control.ErrCannotReadBranch.Is(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		revisionQualifiedName := fmt.Sprintf("%s/%s", db.Name(), branch.GetPath())
 		revDb, ok, err := p.databaseForRevision(ctx, revisionQualifiedName, revisionQualifiedName)
 		if err != nil {
@@ -421,7 +446,7 @@ func (p *DoltDatabaseProvider) allRevisionDbs(ctx *sql.Context, db dsess.SqlData
 		if !ok {
 			return nil, fmt.Errorf("cannot get revision database for %s/%s", db.Name(), branch.GetPath())
 		}
-		revDbs[i] = revDb
+		revDbs = append(revDbs, revDb)
 	}
 
 	return revDbs, nil
@@ -747,7 +772,7 @@ func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 		return err
 	}
 
-	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, false, depth, dEnv)
+	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, false, depth, false, dEnv)
 	if err != nil {
 		return err
 	}
@@ -956,6 +981,18 @@ func (p *DoltDatabaseProvider) databaseForRevision(ctx *sql.Context, revisionQua
 	parts := strings.SplitN(revisionQualifiedName, dsess.DbRevisionDelimiter, 2)
 	baseName, rev := parts[0], parts[1]
 
+	p.mu.RLock()
+	srcDb, ok := p.databases[formatDbMapKeyName(baseName)]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	// Branch permissions can change during a session, so this is checked before the session cache is consulted
+	if err := dsess.CheckRevisionReadAccess(ctx, baseName, srcDb.DbData().Ddb, rev); err != nil {
+		return nil, false, err
+	}
+
 	// Look in the session cache for this DB before doing any IO to figure out what's being asked for
 	sess := dsess.DSessFromSess(ctx.Session)
 	dbCache := sess.DatabaseCache(ctx)
@@ -964,13 +1001,6 @@ func (p *DoltDatabaseProvider) databaseForRevision(ctx *sql.Context, revisionQua
 		return db, true, nil
 	}
 
-	p.mu.RLock()
-	srcDb, ok := p.databases[formatDbMapKeyName(baseName)]
-	p.mu.RUnlock()
-	if !ok {
-		return nil, false, nil
-	}
-
 	dbType, resolvedRevSpec, err := revisionDbType(ctx, srcDb, rev)
 	if err != nil {
 		return nil, false, err
@@ -0,0 +1,85 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// RunCIWorkflowFunc runs the Dolt CI workflow named |workflow| against |commit| of the database |dbName|, running its
+// queries with |queryF|, records the run in the CI run log of |ddb|, and returns whether the run passed.
+type RunCIWorkflowFunc func(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB, commit hash.Hash, workflow string, queryF func(*sql.Context, string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)) (bool, error)
+
+// RunCIWorkflow runs the workflows required to merge into a protected branch which have not passed yet. It is set by
+// the dolt_ci package, which depends on this one. When it is nil, such merges are rejected instead.
+var RunCIWorkflow RunCIWorkflowFunc
+
+// mergeWorkflowPassedFunc returns the doltdb.WorkflowPassedFunc used to check the workflows required to merge into a
+// protected branch of the database |dbName|. A workflow which has not passed on the merged commit yet is run in the
+// current session, with the privileges of its user, and its run is recorded.
+func mergeWorkflowPassedFunc(sqlCtx *sql.Context, dbName string, ddb *doltdb.DoltDB) doltdb.WorkflowPassedFunc {
+	return func(ctx context.Context, commit hash.Hash, workflow string) (bool, error) {
+		passed, err := ddb.CIWorkflowPassed(ctx, commit, workflow)
+		if err != nil || passed || RunCIWorkflow == nil {
+			return passed, err
+		}
+		return RunCIWorkflow(sqlCtx, dbName, ddb, commit, workflow, runSessionQuery)
+	}
+}
+
+// checkBranchUpdate returns an error if the protection rule of the branch named |branch|, if it has one, does not
+// allow the branch to be updated to |newHead|. A nil |newHead| deletes the branch. A branch which does not exist yet
+// may always be created.
+func checkBranchUpdate(ctx *sql.Context, ddb *doltdb.DoltDB, branch string, newHead *doltdb.Commit) error {
+	rule, err := ddb.GetBranchProtectionRule(ctx, branch)
+	if err != nil || rule == nil {
+		return err
+	}
+	oldHead, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef(branch))
+	if err == doltdb.ErrBranchNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return rule.CheckUpdate(ctx, oldHead, newHead, ddb.CIWorkflowPassed)
+}
+
+// checkBranchUpdateToSpec is like checkBranchUpdate, for an update of the branch to the commit that the commit spec
+// |cSpecStr| resolves to, relative to the head of |headRef|.
+func checkBranchUpdateToSpec(ctx *sql.Context, ddb *doltdb.DoltDB, branch string, cSpecStr string, headRef ref.DoltRef) error {
+	rule, err := ddb.GetBranchProtectionRule(ctx, branch)
+	if err != nil || rule == nil {
+		return err
+	}
+	cs, err := doltdb.NewCommitSpec(cSpecStr)
+	if err != nil {
+		return err
+	}
+	optCmt, err := ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return err
+	}
+	newHead, ok := optCmt.ToCommit()
+	if !ok {
+		return doltdb.ErrGhostCommitEncountered
+	}
+	return checkBranchUpdate(ctx, ddb, branch, newHead)
+}
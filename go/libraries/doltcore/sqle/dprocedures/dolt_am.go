@@ -0,0 +1,285 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/patchfile"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// ErrAmUncommittedChanges is returned when a patch is applied without a clean working set.
+var ErrAmUncommittedChanges = errors.New("cannot apply a patch with uncommitted changes")
+
+var doltAmSchema = []*sql.Column{
+	{
+		Name:     "hash",
+		Type:     gmstypes.LongText,
+		Nullable: true,
+	},
+	{
+		Name:     "applied",
+		Type:     gmstypes.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "data_conflicts",
+		Type:     gmstypes.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "schema_conflicts",
+		Type:     gmstypes.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "constraint_violations",
+		Type:     gmstypes.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "message",
+		Type:     gmstypes.LongText,
+		Nullable: true,
+	},
+}
+
+// doltAm is the stored procedure version for the CLI command `dolt am`. It applies each commit of a patch written
+// by dolt_format_patch as a new commit on the current branch, merging its changes with a three-way merge. If a
+// commit does not apply cleanly, its changes are left in the working set with their conflicts and the procedure
+// stops; once they are resolved and committed, the rest of the patch is applied by calling it again with --skip.
+func doltAm(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("error: empty database name")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return nil, err
+	}
+
+	apr, err := cli.CreateAmArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if apr.NArg() != 1 {
+		return nil, fmt.Errorf("error: a patch is required")
+	}
+	skip := apr.GetIntOrDefault(cli.SkipParam, 0)
+	if skip < 0 {
+		return nil, fmt.Errorf("error: --%s must not be negative", cli.SkipParam)
+	}
+
+	p, err := patchfile.Read(strings.NewReader(apr.Arg(0)))
+	if err != nil {
+		return nil, err
+	}
+	if skip > len(p.Commits) {
+		return nil, fmt.Errorf("error: the patch has only %d commits", len(p.Commits))
+	}
+
+	var head string
+	applied := 0
+	for i := skip; i < len(p.Commits); i++ {
+		h, result, err := applyPatchCommit(ctx, dbName, p.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("patch %d of %d (%s): %w", i+1, len(p.Commits), p.Commits[i].Hash, err)
+		}
+		if result != nil {
+			msg := fmt.Sprintf("patch %d of %d (%s) did not apply cleanly; resolve the conflicts and commit, then continue with --%s %d",
+				i+1, len(p.Commits), p.Commits[i].Hash, cli.SkipParam, i+1)
+			return rowToIter(nil, int64(applied),
+				int64(result.CountOfTablesWithDataConflicts()),
+				int64(result.CountOfTablesWithSchemaConflicts()),
+				int64(result.CountOfTablesWithConstraintViolations()),
+				msg), nil
+		}
+		if h != "" {
+			head = h
+			// committing ends the transaction, so start a new one for the next commit to see this one
+			dSess := dsess.DSessFromSess(ctx.Session)
+			tx, err := dSess.StartTransaction(ctx, sql.ReadWrite)
+			if err != nil {
+				return nil, err
+			}
+			ctx.SetTransaction(tx)
+		}
+		applied++
+	}
+
+	var headVal interface{}
+	if head != "" {
+		headVal = head
+	}
+	return rowToIter(headVal, int64(applied), int64(0), int64(0), int64(0), nil), nil
+}
+
+// applyPatchCommit applies |c| to the working set of |dbName| and commits it. If the changes do not apply cleanly,
+// they are left in the working set, and the result of merging them is returned instead. The returned hash is empty
+// if the changes were already in the working set, so that there was nothing to commit.
+func applyPatchCommit(ctx *sql.Context, dbName string, c patchfile.Commit) (string, *merge.Result, error) {
+	dSess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return "", nil, fmt.Errorf("failed to get roots for current session")
+	}
+
+	wsOnlyHasIgnoredTables, err := diff.WorkingSetContainsOnlyIgnoredTables(ctx, roots)
+	if err != nil {
+		return "", nil, err
+	}
+	if !wsOnlyHasIgnoredTables {
+		return "", nil, ErrAmUncommittedChanges
+	}
+
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return "", nil, err
+	} else if !ok {
+		return "", nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return "", nil, fmt.Errorf("failed to get doltDB")
+	}
+
+	baseRoot, theirRoot, err := c.Roots(ctx, roots.Working)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// conflicts reference the commits they were merged from, so the patch's roots are written as dangling commits
+	head, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return "", nil, err
+	}
+	meta, err := datas.NewCommitMetaWithUserTS(c.Author, c.Email, c.Message, c.Date)
+	if err != nil {
+		return "", nil, err
+	}
+	baseCommit, err := commitDanglingRoot(ctx, ddb, baseRoot, head, meta)
+	if err != nil {
+		return "", nil, err
+	}
+	theirCommit, err := commitDanglingRoot(ctx, ddb, theirRoot, baseCommit, meta)
+	if err != nil {
+		return "", nil, err
+	}
+
+	mo := merge.MergeOpts{IsCherryPick: true}
+	result, err := merge.MergeRoots(ctx, roots.Working, theirRoot, baseRoot, theirCommit, baseCommit, dbState.EditOpts(), mo)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, schConflict := range result.SchemaConflicts {
+		if schConflict.ModifyDeleteConflict {
+			return "", nil, schConflict
+		}
+	}
+
+	if err = dSess.SetWorkingRoot(ctx, dbName, result.Root); err != nil {
+		return "", nil, err
+	}
+	if err = stagePatchedTables(ctx, dbName, result.Stats); err != nil {
+		return "", nil, err
+	}
+	if result.HasMergeArtifacts() {
+		// record the patch as a cherry-pick, so that it can be committed or aborted once the conflicts are resolved
+		ws, err := dSess.WorkingSet(ctx, dbName)
+		if err != nil {
+			return "", nil, err
+		}
+		if err = dSess.SetWorkingSet(ctx, dbName, ws.StartCherryPick(theirCommit, c.Hash)); err != nil {
+			return "", nil, err
+		}
+		return "", result, nil
+	}
+
+	roots, ok = dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return "", nil, fmt.Errorf("failed to get roots for current session")
+	}
+	pendingCommit, err := dSess.NewPendingCommit(ctx, dbName, roots, actions.CommitStagedProps{
+		Message:    c.Message,
+		Date:       c.Date,
+		Name:       c.Author,
+		Email:      c.Email,
+		AllowEmpty: len(c.Tables) == 0,
+		SkipEmpty:  len(c.Tables) > 0,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if pendingCommit == nil {
+		return "", nil, nil
+	}
+	newCommit, err := dSess.DoltCommit(ctx, dbName, dSess.GetTransaction(), pendingCommit)
+	if err != nil {
+		return "", nil, err
+	}
+	h, err := newCommit.HashOf()
+	if err != nil {
+		return "", nil, err
+	}
+	return h.String(), nil, nil
+}
+
+// stagePatchedTables stages the tables from |mergeStats| that don't have any merge artifacts.
+func stagePatchedTables(ctx *sql.Context, dbName string, mergeStats map[doltdb.TableName]*merge.MergeStats) error {
+	tablesToAdd := make([]doltdb.TableName, 0, len(mergeStats))
+	for tableName, stats := range mergeStats {
+		if stats.HasArtifacts() {
+			continue
+		}
+		// stage removed tables first, so that renamed tables keep their foreign keys
+		if stats.Operation == merge.TableRemoved {
+			tablesToAdd = append([]doltdb.TableName{tableName}, tablesToAdd...)
+		} else {
+			tablesToAdd = append(tablesToAdd, tableName)
+		}
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("unable to get roots for database '%s' from session", dbName)
+	}
+	roots, err := actions.StageTables(ctx, roots, tablesToAdd, true)
+	if err != nil {
+		return err
+	}
+	return dSess.SetRoots(ctx, dbName, roots)
+}
+
+// commitDanglingRoot writes |root| as a commit with parent |parent| which is not referenced by any branch.
+func commitDanglingRoot(ctx *sql.Context, ddb *doltdb.DoltDB, root doltdb.RootValue, parent *doltdb.Commit, meta *datas.CommitMeta) (*doltdb.Commit, error) {
+	_, h, err := ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	return ddb.CommitDanglingWithParentCommits(ctx, h, []*doltdb.Commit{parent}, meta)
+}
@@ -17,6 +17,7 @@ package dprocedures
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
@@ -65,7 +67,7 @@ func doDoltBackup(ctx *sql.Context, args []string) (int, error) {
 		return statusErr, err
 	}
 
-	invalidParams := []string{dbfactory.AWSCredsFileParam, dbfactory.AWSCredsProfile, dbfactory.AWSCredsTypeParam, dbfactory.AWSRegionParam}
+	invalidParams := []string{dbfactory.AWSCredsFileParam, dbfactory.AWSCredsProfile, dbfactory.AWSCredsTypeParam, dbfactory.AWSRegionParam, cli.ContinuousFlag, cli.IntervalParam}
 	for _, param := range invalidParams {
 		if apr.Contains(param) {
 			return statusErr, fmt.Errorf("parameter '%s' is not supported when running this command via SQL", param)
@@ -167,6 +169,15 @@ func restoreBackup(ctx *sql.Context, _ env.DbData[*sql.Context], apr *argparser.
 	dbName := strings.TrimSpace(apr.Arg(2))
 	force := apr.Contains(cli.ForceFlag)
 
+	var at *time.Time
+	if atStr, ok := apr.GetValue(cli.AtParam); ok {
+		t, err := dconfig.ParseDate(atStr)
+		if err != nil {
+			return err
+		}
+		at = &t
+	}
+
 	sess := dsess.DSessFromSess(ctx.Session)
 
 	params, err := loadAwsParams(ctx, sess, apr, backupUrl, "restore")
@@ -187,7 +198,7 @@ func restoreBackup(ctx *sql.Context, _ env.DbData[*sql.Context], apr *argparser.
 				"A database with that name already exists. Did you mean to supply --force?", dbName)
 		}
 
-		return syncRootsFromBackup(ctx, existingDbData, sess, r)
+		return syncRootsFromBackup(ctx, existingDbData, sess, r, at)
 	} else {
 		// Track whether the db directory existed before we tried to create it, so we can clean up on errors
 		userDirExisted, _ := sess.Provider().FileSystem().Exists(dbName)
@@ -205,7 +216,7 @@ func restoreBackup(ctx *sql.Context, _ env.DbData[*sql.Context], apr *argparser.
 			return err
 		}
 
-		if err = syncRootsFromBackup(ctx, clonedEnv.DbData(ctx), sess, r); err != nil {
+		if err = syncRootsFromBackup(ctx, clonedEnv.DbData(ctx), sess, r, at); err != nil {
 			// If we're cloning into a directory that already exists do not erase it.
 			// Otherwise, make a best effort to delete any directory we created.
 			if userDirExisted {
@@ -324,7 +335,7 @@ func syncRootsToBackup(ctx *sql.Context, dbData env.DbData[*sql.Context], sess *
 		return err
 	}
 
-	err = actions.SyncRoots(ctx, dbData.Ddb, destDb, tmpDir, runProgFuncs, stopProgFuncs)
+	err = actions.SyncRootsWithHistory(ctx, dbData.Ddb, destDb, tmpDir, runProgFuncs, stopProgFuncs)
 	if err != nil && err != pull.ErrDBUpToDate {
 		return fmt.Errorf("error syncing backup: %w", err)
 	}
@@ -332,8 +343,9 @@ func syncRootsToBackup(ctx *sql.Context, dbData env.DbData[*sql.Context], sess *
 	return nil
 }
 
-// syncRootsFromBackup syncs the roots from the backup specified by |backup| to |dbData|.
-func syncRootsFromBackup[C doltdb.Context](ctx *sql.Context, dbData env.DbData[C], sess *dsess.DoltSession, backup env.Remote) error {
+// syncRootsFromBackup syncs the roots from the backup specified by |backup| to |dbData|. If |at| is not nil, the
+// roots the backup had at that time are restored.
+func syncRootsFromBackup[C doltdb.Context](ctx *sql.Context, dbData env.DbData[C], sess *dsess.DoltSession, backup env.Remote, at *time.Time) error {
 	destDb, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), backup, true)
 	if err != nil {
 		return fmt.Errorf("error loading backup destination: %w", err)
@@ -344,7 +356,7 @@ func syncRootsFromBackup[C doltdb.Context](ctx *sql.Context, dbData env.DbData[C
 		return err
 	}
 
-	err = actions.SyncRoots(ctx, destDb, dbData.Ddb, tmpDir, runProgFuncs, stopProgFuncs)
+	err = actions.RestoreBackup(ctx, destDb, dbData.Ddb, at, tmpDir, runProgFuncs, stopProgFuncs)
 	if err != nil && err != pull.ErrDBUpToDate {
 		return fmt.Errorf("error syncing backup: %w", err)
 	}
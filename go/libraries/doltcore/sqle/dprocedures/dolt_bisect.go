@@ -0,0 +1,381 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bisect"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var doltBisectProcedureSchema = []*sql.Column{
+	{
+		Name:     "status",
+		Type:     types.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "message",
+		Type:     types.LongText,
+		Nullable: true,
+	},
+}
+
+const (
+	bisectStartCmd = "start"
+	bisectBadCmd   = "bad"
+	bisectGoodCmd  = "good"
+	bisectSkipCmd  = "skip"
+	bisectResetCmd = "reset"
+	bisectRunCmd   = "run"
+)
+
+// ErrNoBisectInProgress is returned when a bisect subcommand other than start is used outside a bisect session.
+var ErrNoBisectInProgress = errors.New("no bisect in progress; start one with dolt_bisect('start')")
+
+// BisectWaitingMessage is returned while the bad commit or all the good commits have not been given yet.
+var BisectWaitingMessage = "status: waiting for both good and bad commits"
+
+// BisectResetMessage is returned when a bisect session is ended.
+var BisectResetMessage = "bisect session ended"
+
+func doltBisect(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, message, err := doDoltBisect(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res), message), nil
+}
+
+func doDoltBisect(ctx *sql.Context, args []string) (int, string, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if dbName == "" {
+		return 1, "", sql.ErrNoDatabaseSelected.New()
+	}
+
+	apr, err := cli.CreateBisectArgParser().Parse(args)
+	if err != nil {
+		return 1, "", err
+	}
+	if apr.NArg() == 0 {
+		return 1, "", fmt.Errorf("expected one of: start, bad, good, skip, reset, run")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return 1, "", fmt.Errorf("could not load database %s", dbName)
+	}
+
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return 1, "", err
+	}
+
+	subcommand, revs := strings.ToLower(apr.Arg(0)), apr.Args[1:]
+	if subcommand == bisectStartCmd {
+		state := &doltdb.BisectState{}
+		if len(revs) > 0 {
+			state.Bad, err = resolveBisectRev(ctx, dbData, revs[0])
+			if err != nil {
+				return 1, "", err
+			}
+			for _, rev := range revs[1:] {
+				h, err := resolveBisectRev(ctx, dbData, rev)
+				if err != nil {
+					return 1, "", err
+				}
+				state.Good = append(state.Good, h)
+			}
+		}
+		return bisectNext(ctx, dSess, dbName, ws, dbData, state)
+	}
+
+	if ws.BisectState() == nil {
+		return 1, "", ErrNoBisectInProgress
+	}
+	// the state is updated in place, so work on a copy of the working set's
+	state := *ws.BisectState()
+	state.Good = append([]hash.Hash(nil), state.Good...)
+	state.Skip = append([]hash.Hash(nil), state.Skip...)
+
+	switch subcommand {
+	case bisectResetCmd:
+		if len(revs) > 0 {
+			return 1, "", fmt.Errorf("reset takes no arguments")
+		}
+		if err = dSess.SetWorkingSet(ctx, dbName, ws.WithBisectState(nil)); err != nil {
+			return 1, "", err
+		}
+		return 0, BisectResetMessage, nil
+
+	case bisectBadCmd:
+		if len(revs) > 1 {
+			return 1, "", fmt.Errorf("only one commit can be marked bad")
+		}
+		hashes, err := resolveBisectRevs(ctx, dbData, &state, revs)
+		if err != nil {
+			return 1, "", err
+		}
+		state.Bad = hashes[0]
+		return bisectNext(ctx, dSess, dbName, ws, dbData, &state)
+
+	case bisectGoodCmd:
+		hashes, err := resolveBisectRevs(ctx, dbData, &state, revs)
+		if err != nil {
+			return 1, "", err
+		}
+		state.Good = append(state.Good, hashes...)
+		return bisectNext(ctx, dSess, dbName, ws, dbData, &state)
+
+	case bisectSkipCmd:
+		hashes, err := resolveBisectRevs(ctx, dbData, &state, revs)
+		if err != nil {
+			return 1, "", err
+		}
+		state.Skip = append(state.Skip, hashes...)
+		return bisectNext(ctx, dSess, dbName, ws, dbData, &state)
+
+	case bisectRunCmd:
+		if len(revs) != 1 {
+			return 1, "", fmt.Errorf("run takes exactly one argument, the query to run")
+		}
+		return bisectRun(ctx, dSess, dbName, ws, dbData, &state, revs[0])
+
+	default:
+		return 1, "", fmt.Errorf("unknown bisect subcommand %s; expected one of: start, bad, good, skip, reset, run", apr.Arg(0))
+	}
+}
+
+// resolveBisectRevs resolves |revs| to commit hashes. If no revision is given, the commit currently being tested is
+// used, or the head of the current branch if there is none.
+func resolveBisectRevs(ctx *sql.Context, dbData env.DbData[*sql.Context], state *doltdb.BisectState, revs []string) ([]hash.Hash, error) {
+	if len(revs) == 0 {
+		if !state.Current.IsEmpty() {
+			return []hash.Hash{state.Current}, nil
+		}
+		revs = []string{"HEAD"}
+	}
+	hashes := make([]hash.Hash, len(revs))
+	for i, rev := range revs {
+		h, err := resolveBisectRev(ctx, dbData, rev)
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = h
+	}
+	return hashes, nil
+}
+
+func resolveBisectRev(ctx *sql.Context, dbData env.DbData[*sql.Context], rev string) (hash.Hash, error) {
+	cm, err := resolveBisectCommit(ctx, dbData, rev)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return cm.HashOf()
+}
+
+func resolveBisectCommit(ctx *sql.Context, dbData env.DbData[*sql.Context], rev string) (*doltdb.Commit, error) {
+	spec, err := doltdb.NewCommitSpec(rev)
+	if err != nil {
+		return nil, err
+	}
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	optCmt, err := dbData.Ddb.Resolve(ctx, spec, headRef)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+// bisectStep finds the next commit to test for |state|, if the bad commit and at least one good commit are known, and
+// records it as the current commit of |state|. The returned result is nil if they are not.
+func bisectStep(ctx *sql.Context, dbData env.DbData[*sql.Context], state *doltdb.BisectState) (*bisect.Result, error) {
+	var res *bisect.Result
+	state.Current = hash.Hash{}
+	if !state.Bad.IsEmpty() && len(state.Good) > 0 {
+		var err error
+		res, err = bisect.Bisect(ctx, dbData.Ddb, state.Bad, state.Good, state.Skip)
+		if err != nil {
+			return nil, err
+		}
+		if res.Next != nil {
+			state.Current, err = res.Next.HashOf()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// bisectNext takes a bisect step for |state|, records it on the working set |ws| of the database |dbName|, and
+// describes the result.
+func bisectNext(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, ws *doltdb.WorkingSet, dbData env.DbData[*sql.Context], state *doltdb.BisectState) (int, string, error) {
+	res, err := bisectStep(ctx, dbData, state)
+	if err != nil {
+		return 1, "", err
+	}
+	if err = dSess.SetWorkingSet(ctx, dbName, ws.WithBisectState(state)); err != nil {
+		return 1, "", err
+	}
+	if res == nil {
+		return 0, BisectWaitingMessage, nil
+	}
+	message, err := describeBisectResult(ctx, res)
+	if err != nil {
+		return 1, "", err
+	}
+	return 0, message, nil
+}
+
+// bisectRun tests commits with |query| until the first bad commit is found. A commit is bad if the query returns any
+// rows when run against that commit, and good if it returns none. A commit at which the query errors is skipped, as
+// with |dolt bisect skip|. The query is run by the session's engine, with the privileges of the session's user.
+func bisectRun(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, ws *doltdb.WorkingSet, dbData env.DbData[*sql.Context], state *doltdb.BisectState, query string) (int, string, error) {
+	if state.Bad.IsEmpty() || len(state.Good) == 0 {
+		return 1, "", fmt.Errorf("run requires a bad commit and at least one good commit")
+	}
+
+	baseName, _ := dsess.SplitRevisionDbName(dbName)
+	defer ctx.SetCurrentDatabase(dbName)
+
+	sb := strings.Builder{}
+	for {
+		res, err := bisectStep(ctx, dbData, state)
+		if err != nil {
+			return 1, "", err
+		}
+		if res.Next == nil {
+			if err = dSess.SetWorkingSet(ctx, dbName, ws.WithBisectState(state)); err != nil {
+				return 1, "", err
+			}
+			message, err := describeBisectResult(ctx, res)
+			if err != nil {
+				return 1, "", err
+			}
+			sb.WriteString(message)
+			return 0, sb.String(), nil
+		}
+
+		ctx.SetCurrentDatabase(baseName + dsess.DbRevisionDelimiter + state.Current.String())
+		bad, err := bisectQueryHasRows(ctx, query)
+		ctx.SetCurrentDatabase(dbName)
+		if err != nil {
+			sb.WriteString(fmt.Sprintf("%s skipped: %s\n", state.Current.String(), err.Error()))
+			state.Skip = append(state.Skip, state.Current)
+			continue
+		}
+		if bad {
+			sb.WriteString(fmt.Sprintf("%s is bad\n", state.Current.String()))
+			state.Bad = state.Current
+		} else {
+			sb.WriteString(fmt.Sprintf("%s is good\n", state.Current.String()))
+			state.Good = append(state.Good, state.Current)
+		}
+	}
+}
+
+func bisectQueryHasRows(ctx *sql.Context, query string) (bool, error) {
+	_, iter, _, err := runSessionQuery(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	_, err = iter.Next(ctx)
+	if err != nil && err != io.EOF {
+		iter.Close(ctx)
+		return false, err
+	}
+	hasRows := err == nil
+	if err = iter.Close(ctx); err != nil {
+		return false, err
+	}
+	return hasRows, nil
+}
+
+func describeBisectResult(ctx *sql.Context, res *bisect.Result) (string, error) {
+	switch {
+	case res.FirstBad != nil:
+		summary, err := bisectCommitSummary(ctx, res.FirstBad)
+		if err != nil {
+			return "", err
+		}
+		h, err := res.FirstBad.HashOf()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s is the first bad commit\n%s", h.String(), summary), nil
+
+	case res.Next != nil:
+		summary, err := bisectCommitSummary(ctx, res.Next)
+		if err != nil {
+			return "", err
+		}
+		revisions, steps := "revisions", "steps"
+		if res.Remaining == 1 {
+			revisions = "revision"
+		}
+		if res.Steps == 1 {
+			steps = "step"
+		}
+		return fmt.Sprintf("Bisecting: %d %s left to test after this (roughly %d %s)\n%s",
+			res.Remaining, revisions, res.Steps, steps, summary), nil
+
+	default:
+		sb := strings.Builder{}
+		sb.WriteString("There are only 'skip'ped commits left to test.\nThe first bad commit could be any of:\n")
+		for _, cm := range res.Candidates {
+			h, err := cm.HashOf()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(h.String())
+			sb.WriteString("\n")
+		}
+		sb.WriteString("We cannot bisect more!")
+		return sb.String(), nil
+	}
+}
+
+func bisectCommitSummary(ctx *sql.Context, cm *doltdb.Commit) (string, error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return "", err
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return "", err
+	}
+	description, _, _ := strings.Cut(meta.Description, "\n")
+	return fmt.Sprintf("[%s] %s", h.String(), description), nil
+}
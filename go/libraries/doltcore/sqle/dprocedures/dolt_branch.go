@@ -70,6 +70,13 @@ func doDoltBranch(ctx *sql.Context, args []string) (int, error) {
 		return 1, fmt.Errorf("Could not load database %s", dbName)
 	}
 
+	// Hold the worktrees lock while checking that branches aren't checked out in other worktrees and updating them.
+	unlock, err := lockWorktrees(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+	defer unlock()
+
 	var rsc doltdb.ReplicationStatusController
 
 	switch {
@@ -152,6 +159,21 @@ func renameBranch(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argpar
 		return err
 	}
 
+	if err := checkBranchUpdate(ctx, dbData.Ddb, oldBranchName, nil); err != nil {
+		return err
+	}
+	if err := checkBranchNotInOtherWorktree(ctx, dbName, oldBranchName); err != nil {
+		return err
+	}
+	if force {
+		if err := checkBranchUpdateToSpec(ctx, dbData.Ddb, newBranchName, oldBranchName, nil); err != nil {
+			return err
+		}
+		if err := checkBranchNotInOtherWorktree(ctx, dbName, newBranchName); err != nil {
+			return err
+		}
+	}
+
 	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
 	if err != nil {
 		return err
@@ -237,6 +259,14 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argp
 		if err = branch_control.CanDeleteBranch(ctx, branchName); err != nil {
 			return err
 		}
+		if !apr.Contains(cli.RemoteParam) {
+			if err = checkBranchUpdate(ctx, dbData.Ddb, branchName, nil); err != nil {
+				return err
+			}
+			if err = checkBranchNotInOtherWorktree(ctx, dbName, branchName); err != nil {
+				return fmt.Errorf("Cannot delete branch '%s': %w", branchName, err)
+			}
+		}
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
@@ -420,6 +450,21 @@ func createNewBranch(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *arg
 		return err
 	}
 
+	if apr.Contains(cli.ForceFlag) {
+		headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+		if err != nil {
+			return err
+		}
+		err = checkBranchUpdateToSpec(ctx, dbData.Ddb, branchName, startPt, headRef)
+		if err != nil {
+			return err
+		}
+		err = checkBranchNotInOtherWorktree(ctx, ctx.GetCurrentDatabase(), branchName)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, startPt, apr.Contains(cli.ForceFlag), rsc)
 	if err != nil {
 		return err
@@ -465,6 +510,14 @@ func copyABranch(ctx *sql.Context, dbData env.DbData[*sql.Context], srcBr string
 		if err := branch_control.CanDeleteBranch(ctx, destBr); err != nil {
 			return err
 		}
+		// a missing source branch is reported below
+		err := checkBranchUpdateToSpec(ctx, dbData.Ddb, destBr, srcBr, nil)
+		if err != nil && !errors.Is(err, doltdb.ErrBranchNotFound) {
+			return err
+		}
+		if err = checkBranchNotInOtherWorktree(ctx, ctx.GetCurrentDatabase(), destBr); err != nil {
+			return err
+		}
 	}
 	err := actions.CopyBranchOnDB(ctx, dbData.Ddb, srcBr, destBr, force, rsc)
 	if err != nil {
@@ -104,6 +104,13 @@ func doDoltCheckout(ctx *sql.Context, args []string) (statusCode int, successMes
 
 	updateHead := apr.Contains(cli.MoveFlag)
 
+	// Hold the worktrees lock while checking that the branch isn't checked out in another worktree and checking it out.
+	unlock, err := lockWorktrees(ctx, currentDbName)
+	if err != nil {
+		return 1, "", err
+	}
+	defer unlock()
+
 	var rsc doltdb.ReplicationStatusController
 	// If we're switching branches, then we need to clear any Doltgres session objects since they're temporary
 	dSess.DoltgresSessObj = nil
@@ -438,6 +445,9 @@ func checkoutNewBranch(
 		newBranchName = optionBBranch
 	}
 
+	if err = checkBranchNotInOtherWorktree(ctx, dbName, newBranchName); err != nil {
+		return "", "", err
+	}
 	err = actions.CreateBranchWithStartPt(ctx, dbData, newBranchName, startPt, createBranchForcibly, rsc)
 	if err != nil {
 		return "", "", err
@@ -496,6 +506,9 @@ func checkoutExistingBranch(
 	branchName string,
 	apr *argparser.ArgParseResults,
 ) error {
+	if err := checkBranchNotInOtherWorktree(ctx, dbName, branchName); err != nil {
+		return err
+	}
 	wsRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef(branchName))
 	if err != nil {
 		return err
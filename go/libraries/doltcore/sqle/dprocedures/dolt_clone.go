@@ -61,6 +61,12 @@ func doltClone(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	if !ok {
 		depth = -1
 	}
+	if apr.Contains(cli.LazyFlag) {
+		return nil, errhand.BuildDError("error: lazy clones are only supported by the dolt clone command").Build()
+	}
+	if apr.Contains(cli.TablesFlag) {
+		return nil, errhand.BuildDError("error: sparse clones are only supported by the dolt clone command").Build()
+	}
 
 	err = sess.Provider().CloneDatabaseFromRemote(ctx, dir, branch, remoteName, remoteUrl, depth, remoteParms)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/utils/gpg"
@@ -183,6 +184,18 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 		return "", false, errors.New("nothing to commit")
 	}
 
+	noVerify := apr.Contains(cli.NoVerifyFlag)
+	if noVerify {
+		if err = checkNoVerifyPrivs(ctx); err != nil {
+			return "", false, err
+		}
+	} else {
+		err = runPreHook(ctx, dSess, dbName, env.PreCommitHook, pendingCommit.Roots.Staged, hookVars(ctx, dbName))
+		if err != nil {
+			return "", false, err
+		}
+	}
+
 	if apr.Contains(cli.SignFlag) || shouldSign {
 		keyId := apr.GetValueOrDefault(cli.SignFlag, "")
 
@@ -218,6 +231,12 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 		return "", false, err
 	}
 
+	if !noVerify {
+		vars := hookVars(ctx, dbName)
+		vars["DOLT_COMMIT"] = h.String()
+		runPostHook(ctx, dSess, dbName, env.PostCommitHook, vars)
+	}
+
 	return h.String(), false, nil
 }
 
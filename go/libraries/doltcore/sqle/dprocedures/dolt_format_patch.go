@@ -0,0 +1,101 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/patchfile"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltFormatPatch is the stored procedure version for the CLI command `dolt format-patch`. It returns the patch
+// for a range of commits as a single JSON document.
+func doltFormatPatch(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("error: empty database name")
+	}
+
+	apr, err := cli.CreateFormatPatchArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if apr.NArg() != 1 {
+		return nil, fmt.Errorf("error: a revision range such as <from>..<to> is required")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	from, to, err := resolvePatchRange(ctx, dbData, apr.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+	p, err := patchfile.Format(ctx, dbData.Ddb, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err = p.Write(&buf); err != nil {
+		return nil, err
+	}
+	return rowToIter(buf.String()), nil
+}
+
+// resolvePatchRange resolves a revision range of the form <from>..<to>. A single revision <from> is the range
+// <from>..HEAD.
+func resolvePatchRange(ctx *sql.Context, dbData env.DbData[*sql.Context], revRange string) (from, to *doltdb.Commit, err error) {
+	fromSpec, toSpec, ok := strings.Cut(revRange, "..")
+	if !ok || toSpec == "" {
+		toSpec = "HEAD"
+	}
+	if strings.HasPrefix(toSpec, ".") {
+		return nil, nil, fmt.Errorf("error: invalid revision range '%s'", revRange)
+	}
+
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	cms := make([]*doltdb.Commit, 2)
+	for i, spec := range []string{fromSpec, toSpec} {
+		cs, err := doltdb.NewCommitSpec(spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		optCmt, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, nil, doltdb.ErrGhostCommitEncountered
+		}
+		cms[i] = cm
+	}
+	return cms[0], cms[1], nil
+}
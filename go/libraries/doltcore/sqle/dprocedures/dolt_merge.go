@@ -225,7 +225,40 @@ func performMerge(
 		}
 	}
 
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+	rule, err := dbData.Ddb.GetBranchProtectionRule(ctx, headRef.GetPath())
+	if err != nil {
+		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+	if rule != nil {
+		mergeHash, err := spec.MergeC.HashOf()
+		if err != nil {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		if err = rule.CheckMergedCommit(ctx, mergeHash, mergeWorkflowPassedFunc(ctx, dbName, dbData.Ddb)); err != nil {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		// A branch which only accepts merges is never fast-forwarded, so that its head is always a merge commit
+		if rule.RequireMerge && !spec.Squash {
+			spec.NoFF = true
+		}
+	}
+
 	if canFF {
+		if !spec.NoVerify {
+			mergeRoot, err := spec.MergeC.GetRootValue(ctx)
+			if err != nil {
+				return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+			}
+			err = runPreHook(ctx, sess, dbName, env.PreMergeHook, mergeRoot, preMergeHookVars(ctx, dbName, spec))
+			if err != nil {
+				return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+			}
+		}
+
 		if spec.NoFF {
 			var commit *doltdb.Commit
 			ws, commit, err = executeNoFFMerge(ctx, sess, spec, msg, dbName, ws, noCommit)
@@ -271,6 +304,7 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", sql.ErrDatabaseNotFound.New(dbName)
 	}
 
+	preMergeWs := ws
 	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
 	if err == doltdb.ErrUnresolvedConflictsOrViolations {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
@@ -286,6 +320,17 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
 	}
 
+	if !spec.NoVerify {
+		err = runPreHook(ctx, sess, dbName, env.PreMergeHook, ws.StagedRoot(), preMergeHookVars(ctx, dbName, spec))
+		if err != nil {
+			// the merge has already been written to the session's working set, so it is undone here
+			if wsErr := sess.SetWorkingSet(ctx, dbName, preMergeWs); wsErr != nil {
+				return ws, "", noConflictsOrViolations, threeWayMerge, "", wsErr
+			}
+			return preMergeWs, "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+	}
+
 	err = sess.SetWorkingSet(ctx, dbName, ws)
 	if err != nil {
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
@@ -298,6 +343,9 @@ func performMerge(
 		if spec.Force {
 			args = append(args, "--force")
 		}
+		if spec.NoVerify {
+			args = append(args, "--"+cli.NoVerifyFlag)
+		}
 		commit, _, err = doDoltCommit(ctx, args)
 		if err != nil {
 			return ws, commit, noConflictsOrViolations, threeWayMerge, "", err
@@ -452,11 +500,24 @@ func executeNoFFMerge(
 	return ws, commit, nil
 }
 
+// preMergeHookVars returns the environment variables given to pre-merge hook executables.
+func preMergeHookVars(ctx *sql.Context, dbName string, spec *merge.MergeSpec) map[string]string {
+	vars := hookVars(ctx, dbName)
+	vars["DOLT_MERGE_HEAD"] = spec.MergeH.String()
+	return vars
+}
+
 func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, apr *argparser.ArgParseResults, commitSpecStr string) (*merge.MergeSpec, error) {
 	ddb, ok := sess.GetDoltDB(ctx, dbName)
 
 	dbData, ok := sess.GetDbData(ctx, dbName)
 
+	if apr.Contains(cli.NoVerifyFlag) {
+		if err := checkNoVerifyPrivs(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	name, email, err := getNameAndEmail(ctx, apr)
 	if err != nil {
 		return nil, err
@@ -492,6 +553,7 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 		merge.WithForce(apr.Contains(cli.ForceFlag)),
 		merge.WithNoCommit(apr.Contains(cli.NoCommitFlag)),
 		merge.WithNoEdit(apr.Contains(cli.NoEditFlag)),
+		merge.WithNoVerify(apr.Contains(cli.NoVerifyFlag)),
 	)
 }
 
@@ -0,0 +1,220 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+var doltNotesProcedureSchema = []*sql.Column{
+	{
+		Name:     "status",
+		Type:     types.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "message",
+		Type:     types.LongText,
+		Nullable: true,
+	},
+}
+
+const (
+	notesAddCmd    = "add"
+	notesRemoveCmd = "remove"
+	notesPushCmd   = "push"
+	notesFetchCmd  = "fetch"
+)
+
+// doltNotes is the stored procedure version for the CLI command `dolt notes`.
+func doltNotes(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, message, err := doDoltNotes(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res), message), nil
+}
+
+// doDoltNotes adds and removes notes, and copies them to and from remotes. To read notes, the dolt_notes system
+// table is used.
+func doDoltNotes(ctx *sql.Context, args []string) (int, string, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return cmdFailure, "", fmt.Errorf("Empty database name.")
+	}
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return cmdFailure, "", fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	apr, err := cli.CreateNotesArgParser().Parse(args)
+	if err != nil {
+		return cmdFailure, "", err
+	}
+	if apr.NArg() == 0 {
+		return cmdFailure, "", fmt.Errorf("error: missing subcommand, use 'dolt_notes' system table to list notes")
+	}
+	if apr.NArg() > 2 {
+		return cmdFailure, "", fmt.Errorf("error: too many arguments for '%s'", apr.Arg(0))
+	}
+	target := ""
+	if apr.NArg() > 1 {
+		target = apr.Arg(1)
+	}
+
+	switch apr.Arg(0) {
+	case notesAddCmd:
+		return addNote(ctx, dSess, dbData, apr, target)
+	case notesRemoveCmd:
+		return removeNote(ctx, dbData, target)
+	case notesPushCmd, notesFetchCmd:
+		if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+			return cmdFailure, "", err
+		}
+		return syncNotes(ctx, dSess, dbData, apr.Arg(0), target)
+	default:
+		return cmdFailure, "", fmt.Errorf("error: unknown subcommand '%s'", apr.Arg(0))
+	}
+}
+
+func resolveNoteCommit(ctx *sql.Context, dbData env.DbData[*sql.Context], spec string) (*doltdb.Commit, error) {
+	if spec == "" {
+		spec = "HEAD"
+	}
+	cs, err := doltdb.NewCommitSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	optCmt, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+func addNote(ctx *sql.Context, dSess *dsess.DoltSession, dbData env.DbData[*sql.Context], apr *argparser.ArgParseResults, spec string) (int, string, error) {
+	msg, ok := apr.GetValue(cli.MessageArg)
+	if !ok {
+		return cmdFailure, "", fmt.Errorf("error: a note message must be given with -m")
+	}
+
+	var name, email string
+	var err error
+	if authorStr, ok := apr.GetValue(cli.AuthorParam); ok {
+		name, email, err = cli.ParseAuthor(authorStr)
+		if err != nil {
+			return cmdFailure, "", err
+		}
+	} else {
+		name = dSess.Username()
+		email = dSess.Email()
+	}
+
+	cm, err := resolveNoteCommit(ctx, dbData, spec)
+	if err != nil {
+		return cmdFailure, "", err
+	}
+
+	meta := datas.NewTagMeta(name, email, msg)
+	err = dbData.Ddb.SetNote(ctx, cm, meta, apr.Contains(cli.ForceFlag))
+	if err != nil {
+		return cmdFailure, "", err
+	}
+	return cmdSuccess, "", nil
+}
+
+func removeNote(ctx *sql.Context, dbData env.DbData[*sql.Context], spec string) (int, string, error) {
+	cm, err := resolveNoteCommit(ctx, dbData, spec)
+	if err != nil {
+		return cmdFailure, "", err
+	}
+	h, err := cm.HashOf()
+	if err != nil {
+		return cmdFailure, "", err
+	}
+	if err = dbData.Ddb.DeleteNote(ctx, h); err != nil {
+		return cmdFailure, "", err
+	}
+	return cmdSuccess, "", nil
+}
+
+func syncNotes(ctx *sql.Context, dSess *dsess.DoltSession, dbData env.DbData[*sql.Context], subcommand, remoteName string) (int, string, error) {
+	var remoteArgs []string
+	if remoteName != "" {
+		remoteArgs = []string{remoteName}
+	}
+	remote, _, err := env.RemoteForFetchArgs(remoteArgs, dbData.Rsr)
+	if err != nil {
+		return cmdFailure, "", err
+	}
+
+	push := subcommand == notesPushCmd
+	remoteDB, err := dSess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote, push)
+	if err != nil {
+		return cmdFailure, "", actions.HandleInitRemoteStorageClientErr(remote.Name, remote.Url, err)
+	}
+	if err = remoteDB.Rebase(ctx); err != nil {
+		return cmdFailure, "", fmt.Errorf("failed to read latest version of remote database %s@%s: %w", remote.Name, remote.Url, err)
+	}
+
+	tmpDir, err := dbData.Rsw.TempTableFilesDir()
+	if err != nil {
+		return cmdFailure, "", err
+	}
+
+	var n int
+	if push {
+		n, err = actions.PushNotes(ctx, tmpDir, dbData.Ddb, remoteDB, runProgFuncs, stopProgFuncs)
+	} else {
+		n, err = actions.FetchNotes(ctx, tmpDir, remoteDB, dbData.Ddb, runProgFuncs, stopProgFuncs)
+	}
+	if err != nil {
+		return cmdFailure, "", fmt.Errorf("notes %s failed: %w", subcommand, err)
+	}
+
+	if n == 0 {
+		return cmdSuccess, "Everything up-to-date", nil
+	}
+	verb := "Pushed"
+	if !push {
+		verb = "Fetched"
+	}
+	noun := "notes"
+	if n == 1 {
+		noun = "note"
+	}
+	return cmdSuccess, fmt.Sprintf("%s %d %s", verb, n, noun), nil
+}
@@ -134,6 +134,9 @@ func resetSoftToRef(
 	dSess *dsess.DoltSession,
 	dbName string,
 ) error {
+	if err := checkResetBranchUpdate(ctx, dbData, dbName, firstArg); err != nil {
+		return err
+	}
 	roots, err := actions.ResetSoftToRef(ctx, dbData, firstArg)
 	if err != nil {
 		return err
@@ -215,6 +218,9 @@ func resetSoft(
 
 	// If ref is "" that means HEAD, which makes reset --soft a no-op
 	if arg != "" {
+		if err := checkResetBranchUpdate(ctx, dbData, dbName, arg); err != nil {
+			return err
+		}
 		roots, err := actions.ResetSoftToRef(ctx, dbData, arg)
 		if err != nil {
 			return err
@@ -249,6 +255,12 @@ func resetHard(
 		arg = apr.Arg(0)
 	}
 
+	if arg != "" {
+		if err := checkResetBranchUpdate(ctx, dbData, dbName, arg); err != nil {
+			return err
+		}
+	}
+
 	var newHead *doltdb.Commit
 	newHead, roots, err := actions.ResetHardTables(ctx, dbData, arg, roots)
 
@@ -283,3 +295,13 @@ func resetHard(
 
 	return nil
 }
+
+// checkResetBranchUpdate returns an error if resetting the current branch to |cSpecStr| is not allowed by the branch's
+// protection rule.
+func checkResetBranchUpdate(ctx *sql.Context, dbData env.DbData[*sql.Context], dbName string, cSpecStr string) error {
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return err
+	}
+	return checkBranchUpdateToSpec(ctx, dbData.Ddb, headRef.GetPath(), cSpecStr, headRef)
+}
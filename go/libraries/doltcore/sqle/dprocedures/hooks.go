@@ -0,0 +1,171 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// DoltHookWarningCode is the code of the warning issued when a post-commit hook fails.
+const DoltHookWarningCode int = 1105 // Since this our own custom warning we'll use 1105, the code for an unknown error
+
+// maxHookRows is the number of rows returned by a failing hook query which are included in the error.
+const maxHookRows = 10
+
+// loadRepoHook returns the hook of the type given configured for the database |dbName|. Databases which aren't backed
+// by a directory, such as in-memory databases, have no hooks.
+func loadRepoHook(dSess *dsess.DoltSession, dbName string, hookType env.HookType) (env.RepoHook, string, error) {
+	fs, err := dSess.Provider().FileSystemForDatabase(dbName)
+	if err != nil || fs == nil {
+		return env.RepoHook{Type: hookType}, "", nil
+	}
+	hook, err := env.LoadRepoHook(fs, hookType)
+	if err != nil {
+		return hook, "", err
+	}
+	dir, err := fs.Abs("")
+	if err != nil {
+		return hook, "", err
+	}
+	return hook, dir, nil
+}
+
+// hookVars returns the environment variables given to hook executables which describe the database being changed.
+func hookVars(ctx *sql.Context, dbName string) map[string]string {
+	baseName, _ := dsess.SplitRevisionDbName(dbName)
+	vars := map[string]string{"DOLT_DATABASE": baseName}
+	if branch, err := dsess.DSessFromSess(ctx.Session).GetBranch(ctx); err == nil && branch != "" {
+		vars["DOLT_BRANCH"] = branch
+	}
+	return vars
+}
+
+// runPreHook runs the hook of the type given, returning an error if it fails. While the hook's query runs, the working
+// root of the session is |root|, the root about to be committed or merged. The session's roots are restored
+// afterwards.
+func runPreHook(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, hookType env.HookType, root doltdb.RootValue, vars map[string]string) error {
+	hook, dir, err := loadRepoHook(dSess, dbName, hookType)
+	if err != nil || hook.IsEmpty() {
+		return err
+	}
+
+	if hook.Sql != "" {
+		roots, ok := dSess.GetRoots(ctx, dbName)
+		if !ok {
+			return fmt.Errorf("Could not load database %s", dbName)
+		}
+		hookRoots := roots
+		hookRoots.Working = root
+		if err = dSess.SetRoots(ctx, dbName, hookRoots); err != nil {
+			return err
+		}
+		hookErr := runHookQuery(ctx, dSess, hook)
+		if err = dSess.SetRoots(ctx, dbName, roots); err != nil {
+			return err
+		}
+		if hookErr != nil {
+			return hookErr
+		}
+	}
+
+	return hook.RunExec(ctx, dir, vars)
+}
+
+// runPostHook runs the hook of the type given. Its failure is reported as a warning, since the operation it follows
+// has already completed.
+func runPostHook(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, hookType env.HookType, vars map[string]string) {
+	hook, dir, err := loadRepoHook(dSess, dbName, hookType)
+	if err == nil && !hook.IsEmpty() {
+		err = runHookQuery(ctx, dSess, hook)
+		if err == nil {
+			err = hook.RunExec(ctx, dir, vars)
+		}
+	}
+	if err != nil {
+		ctx.Warn(DoltHookWarningCode, "%s", err.Error())
+	}
+}
+
+// checkNoVerifyPrivs returns an error if the current user may not bypass the hooks of a repository with --no-verify.
+// Hooks are how a repository enforces its rules on commits and merges, so skipping them is restricted to admins, like
+// other operations which bypass the normal rules of a database.
+func checkNoVerifyPrivs(ctx *sql.Context) error {
+	isSuper, err := UserHasSuperAccess(ctx)
+	if err != nil {
+		return err
+	}
+	if !isSuper {
+		return fmt.Errorf("--%s requires the SUPER privilege: %w", cli.NoVerifyFlag, sql.ErrPrivilegeCheckFailed.New(ctx.Session.Client().User))
+	}
+	return nil
+}
+
+// runHookQuery runs the query of |hook| in the current session, with the privileges of the current user, returning an
+// error including the rows returned, if any.
+func runHookQuery(ctx *sql.Context, dSess *dsess.DoltSession, hook env.RepoHook) error {
+	if hook.Sql == "" {
+		return nil
+	}
+
+	_, iter, _, err := runSessionQuery(ctx, hook.Sql)
+	if err != nil {
+		return fmt.Errorf("%s hook failed: error running hook query: %w", hook.Type, err)
+	}
+
+	var rows []string
+	truncated := false
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			iter.Close(ctx)
+			return fmt.Errorf("%s hook failed: error running hook query: %w", hook.Type, err)
+		}
+		if len(rows) == maxHookRows {
+			truncated = true
+			break
+		}
+		vals := make([]string, len(row))
+		for i, v := range row {
+			if v == nil {
+				vals[i] = "NULL"
+			} else {
+				vals[i] = fmt.Sprint(v)
+			}
+		}
+		rows = append(rows, strings.Join(vals, ", "))
+	}
+	if err = iter.Close(ctx); err != nil {
+		return err
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+	if truncated {
+		rows = append(rows, "...")
+	}
+	return fmt.Errorf("%s hook failed: hook query returned rows:\n%s", hook.Type, strings.Join(rows, "\n"))
+}
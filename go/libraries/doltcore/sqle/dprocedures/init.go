@@ -15,12 +15,18 @@
 package dprocedures
 
 import (
+	"fmt"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
 var DoltProcedures = []sql.ExternalStoredProcedureDetails{
 	{Name: "dolt_add", Schema: int64Schema("status"), Function: doltAdd},
+	{Name: "dolt_am", Schema: doltAmSchema, Function: doltAm},
+	{Name: "dolt_bisect", Schema: doltBisectProcedureSchema, Function: doltBisect},
 	{Name: "dolt_backup", Schema: int64Schema("status"), Function: doltBackup, ReadOnly: true, AdminOnly: true},
 	{Name: "dolt_branch", Schema: int64Schema("status"), Function: doltBranch},
 	{Name: "dolt_checkout", Schema: doltCheckoutSchema, Function: doltCheckout, ReadOnly: true},
@@ -31,6 +37,7 @@ var DoltProcedures = []sql.ExternalStoredProcedureDetails{
 	{Name: "dolt_commit_hash_out", Schema: stringSchema("hash"), Function: doltCommitHashOut},
 	{Name: "dolt_conflicts_resolve", Schema: int64Schema("status"), Function: doltConflictsResolve},
 	{Name: "dolt_count_commits", Schema: int64Schema("ahead", "behind"), Function: doltCountCommits, ReadOnly: true},
+	{Name: "dolt_format_patch", Schema: stringSchema("patch"), Function: doltFormatPatch, ReadOnly: true},
 	{Name: "dolt_fetch", Schema: int64Schema("status"), Function: doltFetch, AdminOnly: true},
 	{Name: "dolt_undrop", Schema: int64Schema("status"), Function: doltUndrop, AdminOnly: true},
 	{Name: "dolt_update_column_tag", Schema: int64Schema("status"), Function: doltUpdateColumnTag, AdminOnly: true},
@@ -49,6 +56,7 @@ var DoltProcedures = []sql.ExternalStoredProcedureDetails{
 	{Name: "dolt_revert", Schema: int64Schema("status"), Function: doltRevert},
 	{Name: "dolt_stash", Schema: int64Schema("status"), Function: doltStash},
 	{Name: "dolt_tag", Schema: int64Schema("status"), Function: doltTag},
+	{Name: "dolt_notes", Schema: doltNotesProcedureSchema, Function: doltNotes},
 	{Name: "dolt_verify_constraints", Schema: int64Schema("violations"), Function: doltVerifyConstraints},
 
 	{Name: "dolt_stats_restart", Schema: statsFuncSchema, Function: statsFunc(statsRestart)},
@@ -96,3 +104,25 @@ func rowToIter(vals ...interface{}) sql.RowIter {
 	}
 	return sql.RowsToRowIter(row)
 }
+
+// runSessionQuery runs |query| with the engine of the current session as part of the calling statement, so that it
+// runs in the statement's transaction, without committing it, and with the privileges of the session's user.
+func runSessionQuery(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
+	runner := dsess.DSessFromSess(ctx.Session).Provider().StatementRunner()
+	if runner == nil {
+		return nil, nil, nil, fmt.Errorf("unable to run query: no engine is available to this session")
+	}
+	// The query is not a process of its own: finishing it must not end the calling statement's process, which would
+	// cancel the statement's context.
+	ctx = ctx.WithQuery(query)
+	ctx.ApplyOpts(sql.WithProcessList(sql.EmptyProcessList{}), sql.WithRootSpan(nil))
+	var sch sql.Schema
+	var qFlags *sql.QueryFlags
+	iter, err := sql.RunInterpreted(ctx, func(ctx *sql.Context) (sql.RowIter, error) {
+		var iter sql.RowIter
+		var err error
+		sch, iter, qFlags, err = runner.QueryWithBindings(ctx, query, nil, nil, nil)
+		return iter, err
+	})
+	return sch, iter, qFlags, err
+}
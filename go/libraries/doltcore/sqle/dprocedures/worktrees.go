@@ -0,0 +1,43 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// checkBranchNotInOtherWorktree returns an error if |branch| is checked out in a worktree of the repository of the
+// database |dbName| other than the one the database was loaded from. Worktrees which check out the same branch would
+// share its working set. Databases which aren't backed by a directory have no worktrees.
+func checkBranchNotInOtherWorktree(ctx *sql.Context, dbName string, branch string) error {
+	fs, err := dsess.DSessFromSess(ctx.Session).Provider().FileSystemForDatabase(dbName)
+	if err != nil || fs == nil {
+		return nil
+	}
+	return env.CheckBranchNotInOtherWorktree(fs, branch)
+}
+
+// lockWorktrees takes the worktrees lock of the repository of the database |dbName|, see env.LockWorktrees. The
+// returned function releases the lock.
+func lockWorktrees(ctx *sql.Context, dbName string) (func(), error) {
+	fs, err := dsess.DSessFromSess(ctx.Session).Provider().FileSystemForDatabase(dbName)
+	if err != nil || fs == nil {
+		return func() {}, nil
+	}
+	return env.LockWorktrees(fs)
+}
@@ -16,8 +16,14 @@ package dsess
 
 import (
 	"context"
+	"io"
+	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 // CheckAccessForDb checks whether the current user has the given permissions for the given database.
@@ -52,8 +58,133 @@ func CheckAccessForDb(ctx context.Context, db SqlDatabase, flags branch_control.
 	// Get the permissions for the branch, user, and host combination
 	_, perms := controller.Access.Match(dbName, branch, user, host)
 	// If either the flags match or the user is an admin for this branch, then we allow access
-	if (perms&flags == flags) || (perms&branch_control.Permissions_Admin == branch_control.Permissions_Admin) {
+	if perms.Allows(flags) {
 		return nil
 	}
 	return branch_control.ErrIncorrectPermissions.New(user, host, branch)
 }
+
+// CheckRevisionReadAccess checks whether the current user may read the revision spec in the given database, such as
+// the branch in `db/branch`, `AS OF 'branch~1'`, or the arguments to dolt_diff(). A commit hash may be read unless only
+// branches that the user cannot read reach the commit. Other revision specs which do not name a branch, such as HEAD,
+// are always allowed.
+func CheckRevisionReadAccess(ctx context.Context, dbName string, ddb *doltdb.DoltDB, revSpec string) error {
+	name, _, err := doltdb.SplitAncestorSpec(strings.TrimSpace(revSpec))
+	if err != nil {
+		// Let the resolution of the revision spec report the error
+		return nil
+	}
+	if strings.EqualFold(name, "HEAD") || strings.EqualFold(name, doltdb.Working) || strings.EqualFold(name, doltdb.Staged) {
+		return nil
+	}
+	if doltdb.IsValidCommitHash(name) {
+		return checkCommitReadAccess(ctx, dbName, ddb, hash.Parse(name))
+	}
+	name = strings.TrimPrefix(name, "refs/")
+	name = strings.TrimPrefix(name, "heads/")
+	return branch_control.CanReadBranch(ctx, dbName, name)
+}
+
+// checkCommitReadAccess returns an error if the commit |h| is hidden from the current user.
+func checkCommitReadAccess(ctx context.Context, dbName string, ddb *doltdb.DoltDB, h hash.Hash) error {
+	hidden, err := HiddenCommits(ctx, dbName, ddb)
+	if err != nil {
+		return err
+	}
+	if !hidden.Has(h) {
+		return nil
+	}
+	branchAwareSession := branch_control.GetBranchAwareSession(ctx)
+	return branch_control.ErrCannotReadCommit.New(branchAwareSession.GetUser(), branchAwareSession.GetHost(), h.String())
+}
+
+// ReadableRefHeads returns the commits at the heads of the branches, remote branches and tags of the database which
+// the current user may read, and of the branches and remote branches which the user may not read.
+func ReadableRefHeads(ctx context.Context, dbName string, ddb *doltdb.DoltDB) (readable []hash.Hash, hidden []hash.Hash, err error) {
+	addRef := func(r ref.DoltRef, h hash.Hash) error {
+		err := branch_control.CanReadBranch(ctx, dbName, r.GetPath())
+		if branch_control.ErrCannotReadBranch.Is(err) {
+			hidden = append(hidden, h)
+			return nil
+		} else if err != nil {
+			return err
+		}
+		readable = append(readable, h)
+		return nil
+	}
+
+	branches, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, b := range branches {
+		if err = addRef(b.Ref, b.Hash); err != nil {
+			return nil, nil, err
+		}
+	}
+	remotes, err := ddb.GetRemotesWithHashes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range remotes {
+		if err = addRef(r.Ref, r.Hash); err != nil {
+			return nil, nil, err
+		}
+	}
+	tags, err := ddb.GetTagsWithHashes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, t := range tags {
+		readable = append(readable, t.Hash)
+	}
+	return readable, hidden, nil
+}
+
+// HiddenCommits returns the commits of the database which are hidden from the current user: those which only branches
+// that the user cannot read reach. Returns an empty set if the user can read every branch.
+func HiddenCommits(ctx context.Context, dbName string, ddb *doltdb.DoltDB) (hash.HashSet, error) {
+	readable, hidden, err := ReadableRefHeads(ctx, dbName, ddb)
+	if err != nil || len(hidden) == 0 {
+		return hash.NewHashSet(), err
+	}
+	itr, err := commitwalk.GetDotDotRevisionsIterator[context.Context](ctx, ddb, hidden, ddb, readable, nil)
+	if err != nil {
+		return nil, err
+	}
+	hiddenCommits := hash.NewHashSet()
+	for {
+		h, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			return hiddenCommits, nil
+		} else if err != nil {
+			return nil, err
+		}
+		hiddenCommits.Insert(h)
+	}
+}
+
+// CommitItrForReadableBranches is like doltdb.CommitItrForAllBranches, but only iterates over the commits of the
+// branches of the database that the current user may read.
+func CommitItrForReadableBranches[C doltdb.Context](ctx context.Context, dbName string, ddb *doltdb.DoltDB) (doltdb.CommitItr[C], error) {
+	branchRefs, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCommits := make([]*doltdb.Commit, 0, len(branchRefs))
+	for _, branchRef := range branchRefs {
+		if err = branch_control.CanReadBranch(ctx, dbName, branchRef.GetPath()); branch_control.ErrCannotReadBranch.Is(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		cm, err := ddb.ResolveCommitRef(ctx, branchRef)
+		if err != nil {
+			return nil, err
+		}
+		rootCommits = append(rootCommits, cm)
+	}
+
+	return doltdb.CommitItrForRoots[C](ddb, rootCommits...), nil
+}
@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -70,6 +71,10 @@ type DoltSession struct {
 	// If non-nil, this will be returned from ValidateSession.
 	// Used by sqle/cluster to put a session into a terminal err state.
 	validateErr error
+
+	// If non-nil, counts the rows read from tables by this session's queries.
+	// See EnableRowsExaminedCounter.
+	rowsExamined *atomic.Int64
 }
 
 var _ sql.Session = (*DoltSession)(nil)
@@ -321,6 +326,19 @@ func (d *DoltSession) SetValidateErr(err error) {
 	d.validateErr = err
 }
 
+// EnableRowsExaminedCounter turns on counting of the rows read from tables by this session's queries. Counting adds a
+// little overhead to every table read, so it's off unless something, like the sql-server slow query log, needs it.
+// Must be called before the session runs any queries.
+func (d *DoltSession) EnableRowsExaminedCounter() {
+	d.rowsExamined = &atomic.Int64{}
+}
+
+// RowsExaminedCounter returns the running count of rows read from tables by this session's queries, or nil if
+// counting was not enabled with EnableRowsExaminedCounter.
+func (d *DoltSession) RowsExaminedCounter() *atomic.Int64 {
+	return d.rowsExamined
+}
+
 // ValidateSession validates a working set if there are a valid sessionState with non-nil working set.
 // If there is no sessionState or its current working set not defined, then no need for validation,
 // so no error is returned.
@@ -666,6 +684,10 @@ func (d *DoltSession) commitBranchState(
 		return nil, fmt.Errorf("expected a DoltTransaction")
 	}
 
+	if err := d.checkBranchNotInOtherWorktree(branchState); err != nil {
+		return nil, err
+	}
+
 	_, newCommit, err := commitFunc(ctx, dtx, branchState.WorkingSet())
 	if err != nil {
 		return nil, err
@@ -679,6 +701,23 @@ func (d *DoltSession) commitBranchState(
 	return newCommit, nil
 }
 
+// checkBranchNotInOtherWorktree returns an error if the branch of |branchState| is checked out in a worktree other
+// than the one its database was loaded from. The working set of the branch belongs to that worktree, so it can't be
+// written here, for example by a session which selected the branch with a revision database or USE.
+func (d *DoltSession) checkBranchNotInOtherWorktree(branchState *branchState) error {
+	if branchState.revisionType != RevisionTypeBranch {
+		return nil
+	}
+	fs, err := d.provider.FileSystemForDatabase(branchState.dbState.dbName)
+	if err != nil || fs == nil {
+		return nil
+	}
+	if err = env.CheckBranchNotInOtherWorktree(fs, branchState.head); err != nil {
+		return fmt.Errorf("cannot write to branch '%s': %w", branchState.head, err)
+	}
+	return nil
+}
+
 // commitCurrentHead commits the current HEAD for the database given, using the doCommitFunc provided
 func (d *DoltSession) commitCurrentHead(ctx *sql.Context, dbName string, tx sql.Transaction, commitFunc doCommitFunc) (*doltdb.Commit, error) {
 	branchState, ok, err := d.lookupDbState(ctx, dbName)
@@ -1016,6 +1055,10 @@ func (d *DoltSession) ResolveRootForRef(ctx *sql.Context, dbName, refStr string)
 		return nil, nil, "", sql.ErrDatabaseNotFound.New(dbName)
 	}
 
+	if err := CheckRevisionReadAccess(ctx, dbName, dbData.Ddb, refStr); err != nil {
+		return nil, nil, "", err
+	}
+
 	headRef, err := d.CWBHeadRef(ctx, dbName)
 	if err == doltdb.ErrOperationNotSupportedInDetachedHead {
 		// leave head ref nil, we may not need it (commit hash)
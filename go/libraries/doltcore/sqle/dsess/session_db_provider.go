@@ -111,6 +111,10 @@ type DoltDatabaseProvider interface {
 	// PurgeDroppedDatabases permanently deletes any dropped databases that are being held in temporary storage
 	// in case they need to be restored. This operation is not reversible, so use with caution!
 	PurgeDroppedDatabases(ctx *sql.Context) error
+	// StatementRunner returns the engine which runs the queries of sessions using this provider, or nil if it has not
+	// been set. Procedures which run queries of their own, such as hook queries, run them with it, so that they are
+	// subject to the privileges of the session's user.
+	StatementRunner() sql.StatementRunner
 }
 
 type SessionDatabaseBranchSpec struct {
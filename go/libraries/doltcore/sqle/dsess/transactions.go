@@ -262,6 +262,21 @@ func doltCommit(ctx *sql.Context,
 		}
 	}
 
+	rule, err := doltDb.GetBranchProtectionRule(ctx, headRef.GetPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	if rule != nil {
+		// Unless amending, the branch head is added as the first parent when the commit is written
+		numParents := len(pending.CommitOptions.Parents)
+		if !pending.CommitOptions.Amend {
+			numParents++
+		}
+		if err = rule.CheckCommit(numParents, pending.CommitOptions.Amend); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	workingSet = workingSet.ClearMerge()
 
 	var rsc doltdb.ReplicationStatusController
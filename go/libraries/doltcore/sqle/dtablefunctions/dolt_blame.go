@@ -0,0 +1,282 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/blame"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+const blameTableDefaultRowCount = 1000
+
+var _ sql.TableFunction = (*BlameTableFunction)(nil)
+var _ sql.ExecSourceRel = (*BlameTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*BlameTableFunction)(nil)
+
+// BlameTableFunction implements the dolt_blame table function, which attributes the rows of a table, or with
+// --columns its cells, to the commits that last changed them:
+//
+//	dolt_blame([<rev>,] <table> [, '--since', <rev>] [, '--until', <rev>] [, '--columns'])
+type BlameTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	argExprs []sql.Expression
+
+	tableName string
+	until     string
+	since     string
+	cells     bool
+	sqlSch    sql.Schema
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (btf *BlameTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &BlameTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (btf *BlameTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(btf.Schema())
+	numRows, _, err := btf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (btf *BlameTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return blameTableDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (btf *BlameTableFunction) Database() sql.Database {
+	return btf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (btf *BlameTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nbtf := *btf
+	nbtf.database = database
+	return &nbtf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (btf *BlameTableFunction) Name() string {
+	return "dolt_blame"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (btf *BlameTableFunction) Resolved() bool {
+	for _, expr := range btf.argExprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (btf *BlameTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (btf *BlameTableFunction) String() string {
+	var args []string
+	for _, expr := range btf.argExprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_BLAME(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (btf *BlameTableFunction) Schema() sql.Schema {
+	return btf.sqlSch
+}
+
+// Children implements the sql.Node interface.
+func (btf *BlameTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (btf *BlameTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return btf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (btf *BlameTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: btf.database.Name(), Table: btf.tableName}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (btf *BlameTableFunction) Expressions() []sql.Expression {
+	return btf.argExprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (btf *BlameTableFunction) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New(btf.Name(), "1 or more", 0)
+	}
+	for _, expr := range exprs {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(btf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(btf.Name(), expr.String())
+		}
+	}
+
+	newBtf := *btf
+	newBtf.argExprs = exprs
+	if err := newBtf.evalArguments(); err != nil {
+		return nil, err
+	}
+
+	_, target, err := newBtf.blameTarget(btf.ctx)
+	if err != nil {
+		return nil, err
+	}
+	newBtf.sqlSch = dtables.BlameSchema(newBtf.Name(), target.sch, newBtf.cells)
+
+	return &newBtf, nil
+}
+
+func (btf *BlameTableFunction) evalArguments() error {
+	args, err := getDoltArgs(btf.ctx, btf.argExprs, btf.Name())
+	if err != nil {
+		return err
+	}
+
+	apr, err := cli.CreateBlameArgParser().Parse(args)
+	if err != nil {
+		return sql.ErrInvalidArgumentDetails.New(btf.Name(), err.Error())
+	}
+
+	switch apr.NArg() {
+	case 1:
+		btf.tableName = apr.Arg(0)
+		btf.until = apr.GetValueOrDefault(cli.UntilParam, "")
+	case 2:
+		if apr.Contains(cli.UntilParam) {
+			return sql.ErrInvalidArgumentDetails.New(btf.Name(), "a revision cannot be given together with --until")
+		}
+		btf.until, btf.tableName = apr.Arg(0), apr.Arg(1)
+	default:
+		return sql.ErrInvalidArgumentDetails.New(btf.Name(), "a table name must be given")
+	}
+	btf.since = apr.GetValueOrDefault(cli.SinceParam, "")
+	btf.cells = apr.Contains(cli.ColumnsFlag)
+	return nil
+}
+
+// blameTarget is the table being blamed and the commit it is blamed at.
+type blameTarget struct {
+	name doltdb.TableName
+	sch  schema.Schema
+}
+
+// blameTarget resolves the commit to blame at and finds the blamed table in it.
+func (btf *BlameTableFunction) blameTarget(ctx *sql.Context) (*doltdb.Commit, blameTarget, error) {
+	sqledb, ok := btf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, blameTarget{}, fmt.Errorf("unexpected database type: %T", btf.database)
+	}
+
+	cm, err := btf.resolveCommit(ctx, sqledb, btf.until)
+	if err != nil {
+		return nil, blameTarget{}, err
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, blameTarget{}, err
+	}
+
+	tbl, name, ok, err := doltdb.GetTableInsensitive(ctx, root, doltdb.TableName{Name: btf.tableName})
+	if err != nil {
+		return nil, blameTarget{}, err
+	}
+	if !ok {
+		return nil, blameTarget{}, sql.ErrTableNotFound.New(btf.tableName)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, blameTarget{}, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, blameTarget{}, blame.ErrKeylessTable
+	}
+
+	return cm, blameTarget{name: doltdb.TableName{Name: name}, sch: sch}, nil
+}
+
+// resolveCommit resolves |rev|, or the session's head if |rev| is empty.
+func (btf *BlameTableFunction) resolveCommit(ctx *sql.Context, sqledb dsess.SqlDatabase, rev string) (*doltdb.Commit, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	if rev == "" {
+		return sess.GetHeadCommit(ctx, sqledb.RevisionQualifiedName())
+	}
+	headRef, err := sess.CWBHeadRef(ctx, sqledb.RevisionQualifiedName())
+	if err != nil {
+		return nil, err
+	}
+	return resolveCommit(ctx, sqledb, headRef, rev)
+}
+
+// RowIter implements the sql.Node interface
+func (btf *BlameTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	cm, target, err := btf.blameTarget(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqledb := btf.database.(dsess.SqlDatabase)
+	opts := blame.Options{Cells: btf.cells}
+	if btf.since != "" {
+		opts.Since, err = btf.resolveCommit(ctx, sqledb, btf.since)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	it, err := blame.Table(ctx, sqledb.DbData().Ddb, cm, target.name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return dtables.NewBlameRowIter(it, btf.cells), nil
+}
@@ -143,6 +143,9 @@ func (b *BranchStatusTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.
 
 	commits := make([]*doltdb.Commit, len(specs))
 	for i, spec := range specs {
+		if cErr := dsess.CheckRevisionReadAccess(ctx, sqlDb.Name(), sqlDb.DbData().Ddb, spec); cErr != nil {
+			return nil, cErr
+		}
 		cs, cErr := doltdb.NewCommitSpec(spec)
 		if cErr != nil {
 			return nil, cErr
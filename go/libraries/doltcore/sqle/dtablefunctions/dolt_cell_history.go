@@ -0,0 +1,625 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const cellHistoryDefaultRowCount = 100
+
+var _ sql.TableFunction = (*CellHistoryTableFunction)(nil)
+var _ sql.ExecSourceRel = (*CellHistoryTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*CellHistoryTableFunction)(nil)
+
+// CellHistoryTableFunction implements the dolt_cell_history table function, which returns every commit reachable
+// from HEAD that changed a single cell of a table, identified by its primary key values and column name:
+//
+//	dolt_cell_history(<table>, <pk>..., <column>)
+//
+// Each commit is compared to its parents by diffing only the range of the row's key, so the cost of the walk does
+// not depend on the size of the table. Merge commits are returned only when the cell differs from all of their
+// parents, that is when the merge itself changed it.
+type CellHistoryTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	argExprs []sql.Expression
+
+	tableName  string
+	columnName string
+	pkValues   []interface{}
+
+	// tag and sqlType are those of the column in the table at HEAD
+	tag     uint64
+	sqlType sql.Type
+	pkTags  []uint64
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (chtf *CellHistoryTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &CellHistoryTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (chtf *CellHistoryTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(chtf.Schema())
+	numRows, _, err := chtf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (chtf *CellHistoryTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return cellHistoryDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (chtf *CellHistoryTableFunction) Database() sql.Database {
+	return chtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (chtf *CellHistoryTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nchtf := *chtf
+	nchtf.database = database
+	return &nchtf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (chtf *CellHistoryTableFunction) Name() string {
+	return "dolt_cell_history"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (chtf *CellHistoryTableFunction) Resolved() bool {
+	for _, expr := range chtf.argExprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (chtf *CellHistoryTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (chtf *CellHistoryTableFunction) String() string {
+	var args []string
+	for _, expr := range chtf.argExprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_CELL_HISTORY(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (chtf *CellHistoryTableFunction) Schema() sql.Schema {
+	if !chtf.Resolved() {
+		return nil
+	}
+	return sql.Schema{
+		&sql.Column{Name: "commit_hash", Type: types.Text},
+		&sql.Column{Name: "committer", Type: types.Text},
+		&sql.Column{Name: "email", Type: types.Text},
+		&sql.Column{Name: "date", Type: types.Datetime},
+		&sql.Column{Name: "message", Type: types.Text},
+		&sql.Column{Name: "diff_type", Type: types.Text},
+		&sql.Column{Name: "old_value", Type: chtf.sqlType, Nullable: true},
+		&sql.Column{Name: "new_value", Type: chtf.sqlType, Nullable: true},
+	}
+}
+
+// Children implements the sql.Node interface.
+func (chtf *CellHistoryTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (chtf *CellHistoryTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return chtf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (chtf *CellHistoryTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: chtf.database.Name(), Table: chtf.tableName}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (chtf *CellHistoryTableFunction) Expressions() []sql.Expression {
+	return chtf.argExprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (chtf *CellHistoryTableFunction) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) < 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(chtf.Name(), "3 or more", len(exprs))
+	}
+	for _, expr := range exprs {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(chtf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(chtf.Name(), expr.String())
+		}
+	}
+
+	newChtf := *chtf
+	newChtf.argExprs = exprs
+	if err := newChtf.evalArguments(); err != nil {
+		return nil, err
+	}
+	if err := newChtf.resolveColumn(); err != nil {
+		return nil, err
+	}
+
+	return &newChtf, nil
+}
+
+// evalArguments evaluates the table name, primary key values and column name arguments.
+func (chtf *CellHistoryTableFunction) evalArguments() error {
+	var names []string
+	for _, i := range []int{0, len(chtf.argExprs) - 1} {
+		expr := chtf.argExprs[i]
+		if !types.IsText(expr.Type()) {
+			return sql.ErrInvalidArgumentDetails.New(chtf.Name(), expr.String())
+		}
+		v, err := expr.Eval(chtf.ctx, nil)
+		if err != nil {
+			return err
+		}
+		s, _, err := types.Text.Convert(chtf.ctx, v)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return sql.ErrInvalidArgumentDetails.New(chtf.Name(), expr.String())
+		}
+		names = append(names, s.(string))
+	}
+	chtf.tableName, chtf.columnName = names[0], names[1]
+
+	chtf.pkValues = nil
+	for _, expr := range chtf.argExprs[1 : len(chtf.argExprs)-1] {
+		v, err := expr.Eval(chtf.ctx, nil)
+		if err != nil {
+			return err
+		}
+		chtf.pkValues = append(chtf.pkValues, v)
+	}
+	return nil
+}
+
+// resolveColumn finds the table and column in HEAD and checks that a value was given for each primary key column.
+func (chtf *CellHistoryTableFunction) resolveColumn() error {
+	_, head, err := chtf.headCommit(chtf.ctx)
+	if err != nil {
+		return err
+	}
+	root, err := head.GetRootValue(chtf.ctx)
+	if err != nil {
+		return err
+	}
+	tbl, _, ok, err := doltdb.GetTableInsensitive(chtf.ctx, root, doltdb.TableName{Name: chtf.tableName})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sql.ErrTableNotFound.New(chtf.tableName)
+	}
+	sch, err := tbl.GetSchema(chtf.ctx)
+	if err != nil {
+		return err
+	}
+	if schema.IsKeyless(sch) {
+		return sql.ErrInvalidArgumentDetails.New(chtf.Name(), fmt.Sprintf("table %s has no primary key", chtf.tableName))
+	}
+
+	pkCols := sch.GetPKCols()
+	if pkCols.Size() != len(chtf.pkValues) {
+		return sql.ErrInvalidArgumentDetails.New(chtf.Name(),
+			fmt.Sprintf("table %s has %d primary key columns, but %d values were given", chtf.tableName, pkCols.Size(), len(chtf.pkValues)))
+	}
+	chtf.pkTags = pkCols.Tags
+
+	col, ok := sch.GetAllCols().GetByNameCaseInsensitive(chtf.columnName)
+	if !ok {
+		return sql.ErrTableColumnNotFound.New(chtf.tableName, chtf.columnName)
+	}
+	chtf.tag = col.Tag
+	chtf.sqlType = col.TypeInfo.ToSqlType()
+	return nil
+}
+
+// headCommit returns the commit the history starts from, which is HEAD of the function's database.
+func (chtf *CellHistoryTableFunction) headCommit(ctx *sql.Context) (dsess.SqlDatabase, *doltdb.Commit, error) {
+	sqledb, ok := chtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected database type: %T", chtf.database)
+	}
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, sqledb.RevisionQualifiedName())
+	if err != nil {
+		return nil, nil, err
+	}
+	return sqledb, head, nil
+}
+
+// RowIter implements the sql.Node interface
+func (chtf *CellHistoryTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	sqledb, head, err := chtf.headCommit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ddb := sqledb.DbData().Ddb
+	h, err := head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	itr, err := commitwalk.GetTopologicalOrderIterator[*sql.Context](ctx, ddb, []hash.Hash{h}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &cellWalker{chtf: chtf, states: make(map[hash.Hash]*cellState), schemas: make(map[hash.Hash]*cellSchema)}
+	var rows []sql.Row
+	for {
+		cmHash, optCmt, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+
+		row, err := w.changeAt(ctx, cmHash, cm)
+		if err != nil {
+			return nil, err
+		}
+		if row != nil {
+			rows = append(rows, row)
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// cellSchema is the layout of the tracked cell in a version of the table's schema.
+type cellSchema struct {
+	kd, vd val.TupleDesc
+	// keyIdx is the index in the function's primary key values of each key column, or nil if the primary key
+	// columns have changed. keyTypes are the types of the key columns.
+	keyIdx   []int
+	keyTypes []sql.Type
+	// inKey is true if the column is a primary key column, and idx is the column's position in the key or value
+	// tuple. idx is -1 if the column is not in the schema.
+	inKey bool
+	idx   int
+	typ   sql.Type
+}
+
+// cellState is the tracked row of the table at a commit.
+type cellState struct {
+	exists   bool
+	schHash  hash.Hash
+	rowsHash hash.Hash
+	sch      *cellSchema
+	rows     prolly.Map
+	key      val.Tuple
+}
+
+// cellWalker compares the tracked cell across commits, caching the table state at each commit it has seen.
+type cellWalker struct {
+	chtf    *CellHistoryTableFunction
+	states  map[hash.Hash]*cellState
+	schemas map[hash.Hash]*cellSchema
+}
+
+// changeAt returns the row describing the change to the cell made by |cm|, or nil if |cm| did not change it.
+func (w *cellWalker) changeAt(ctx *sql.Context, cmHash hash.Hash, cm *doltdb.Commit) (sql.Row, error) {
+	cur, err := w.state(ctx, cmHash, cm)
+	if err != nil {
+		return nil, err
+	}
+	// the commit's state is no longer needed once its children have been visited, which happens before in
+	// topological order
+	delete(w.states, cmHash)
+
+	var first cellChange
+	if cm.NumParents() == 0 {
+		first, err = w.compare(ctx, &cellState{}, cur)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i < cm.NumParents(); i++ {
+		optCmt, err := cm.GetParent(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		parent, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		parentHash, err := parent.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		prev, err := w.state(ctx, parentHash, parent)
+		if err != nil {
+			return nil, err
+		}
+		change, err := w.compare(ctx, prev, cur)
+		if err != nil {
+			return nil, err
+		}
+		if !change.changed {
+			return nil, nil
+		}
+		if i == 0 {
+			first = change
+		}
+	}
+	if !first.changed {
+		return nil, nil
+	}
+
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Row{cmHash.String(), meta.Name, meta.Email, meta.Time(), meta.Description, first.diffType, first.from, first.to}, nil
+}
+
+// state loads the table at |cm| and builds the key of the tracked row in it.
+func (w *cellWalker) state(ctx *sql.Context, cmHash hash.Hash, cm *doltdb.Commit) (*cellState, error) {
+	if st, ok := w.states[cmHash]; ok {
+		return st, nil
+	}
+
+	st := &cellState{}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, root, doltdb.TableName{Name: w.chtf.tableName})
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if st.schHash, err = tbl.GetSchemaHash(ctx); err != nil {
+			return nil, err
+		}
+		if st.sch, err = w.schema(ctx, st.schHash, tbl); err != nil {
+			return nil, err
+		}
+		if st.sch.keyIdx != nil {
+			st.exists = true
+			idx, err := tbl.GetRowData(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if st.rowsHash, err = idx.HashOf(); err != nil {
+				return nil, err
+			}
+			if st.rows, err = durable.ProllyMapFromIndex(idx); err != nil {
+				return nil, err
+			}
+			if st.key, err = w.key(ctx, st.sch, st.rows.NodeStore()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	w.states[cmHash] = st
+	return st, nil
+}
+
+// schema returns the layout of the tracked cell in the schema of |tbl|.
+func (w *cellWalker) schema(ctx context.Context, schHash hash.Hash, tbl *doltdb.Table) (*cellSchema, error) {
+	if cs, ok := w.schemas[schHash]; ok {
+		return cs, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cs := &cellSchema{kd: sch.GetKeyDescriptor(tbl.NodeStore()), vd: sch.GetValueDescriptor(tbl.NodeStore()), idx: -1}
+
+	pkCols := sch.GetPKCols()
+	if !schema.IsKeyless(sch) && pkCols.Size() == len(w.chtf.pkTags) {
+		cs.keyIdx = make([]int, len(w.chtf.pkTags))
+		for i, tag := range w.chtf.pkTags {
+			idx, ok := pkCols.TagToIdx[tag]
+			if !ok {
+				cs.keyIdx = nil
+				break
+			}
+			cs.keyIdx[idx] = i
+		}
+		for _, col := range pkCols.GetColumns() {
+			cs.keyTypes = append(cs.keyTypes, col.TypeInfo.ToSqlType())
+		}
+	}
+
+	if col, ok := sch.GetAllCols().GetByTag(w.chtf.tag); ok {
+		cs.typ = col.TypeInfo.ToSqlType()
+		if idx, ok := pkCols.TagToIdx[col.Tag]; ok {
+			cs.inKey, cs.idx = true, idx
+		} else if idx, ok := sch.GetNonPKCols().StoredIndexByTag(col.Tag); ok {
+			cs.idx = idx
+		}
+	}
+
+	w.schemas[schHash] = cs
+	return cs, nil
+}
+
+// key builds the key of the tracked row from the primary key values, converted to the types of the key columns.
+func (w *cellWalker) key(ctx *sql.Context, cs *cellSchema, ns tree.NodeStore) (val.Tuple, error) {
+	kb := val.NewTupleBuilder(cs.kd, ns)
+	for i, pkIdx := range cs.keyIdx {
+		v, _, err := cs.keyTypes[i].Convert(ctx, w.chtf.pkValues[pkIdx])
+		if err != nil {
+			return nil, err
+		}
+		if err = tree.PutField(ctx, ns, kb, i, v); err != nil {
+			return nil, err
+		}
+	}
+	return kb.Build(ns.Pool())
+}
+
+// cellChange is the difference in the tracked cell between two commits.
+type cellChange struct {
+	changed  bool
+	diffType string
+	from, to interface{}
+}
+
+// compare returns the change to the tracked cell from |prev| to |cur|. Unchanged tables are skipped by hash. When
+// the layout of the rows is the same, only the key of the tracked row is diffed; otherwise the row is looked up in
+// both versions of the table.
+func (w *cellWalker) compare(ctx *sql.Context, prev, cur *cellState) (cellChange, error) {
+	if !prev.exists && !cur.exists {
+		return cellChange{}, nil
+	}
+	if prev.exists && cur.exists && prev.schHash == cur.schHash && prev.rowsHash == cur.rowsHash {
+		return cellChange{}, nil
+	}
+
+	var fromKey, fromVal, toKey, toVal val.Tuple
+	if prev.exists && cur.exists && prev.sch.kd.Equals(cur.sch.kd) && prev.sch.vd.Equals(cur.sch.vd) {
+		rng := prolly.PrefixRange(ctx, cur.key, cur.sch.kd)
+		err := prolly.RangeDiffMaps(ctx, prev.rows, cur.rows, rng, func(_ context.Context, d tree.Diff) error {
+			if d.From != nil {
+				fromKey, fromVal = val.Tuple(d.Key), val.Tuple(d.From)
+			}
+			if d.To != nil {
+				toKey, toVal = val.Tuple(d.Key), val.Tuple(d.To)
+			}
+			return nil
+		})
+		if err != nil && err != io.EOF {
+			return cellChange{}, err
+		}
+	} else {
+		var err error
+		if fromKey, fromVal, err = lookupRow(ctx, prev); err != nil {
+			return cellChange{}, err
+		}
+		if toKey, toVal, err = lookupRow(ctx, cur); err != nil {
+			return cellChange{}, err
+		}
+	}
+
+	if fromKey == nil && toKey == nil {
+		return cellChange{}, nil
+	}
+	from, err := w.value(ctx, prev, fromKey, fromVal)
+	if err != nil {
+		return cellChange{}, err
+	}
+	to, err := w.value(ctx, cur, toKey, toVal)
+	if err != nil {
+		return cellChange{}, err
+	}
+
+	change := cellChange{changed: true, diffType: "modified", from: from, to: to}
+	switch {
+	case fromKey == nil:
+		change.diffType = "added"
+	case toKey == nil:
+		change.diffType = "removed"
+	default:
+		cmp, err := w.chtf.sqlType.Compare(ctx, from, to)
+		if err != nil {
+			return cellChange{}, err
+		}
+		change.changed = cmp != 0
+	}
+	return change, nil
+}
+
+// lookupRow returns the tracked row in |st|, or nil if it does not exist.
+func lookupRow(ctx context.Context, st *cellState) (k, v val.Tuple, err error) {
+	if !st.exists {
+		return nil, nil, nil
+	}
+	err = st.rows.Get(ctx, st.key, func(key, value val.Tuple) error {
+		k, v = key, value
+		return nil
+	})
+	return k, v, err
+}
+
+// value returns the tracked cell of the row |k|, |v| of |st|, converted to the type of the column at HEAD. Missing
+// rows and columns are NULL.
+func (w *cellWalker) value(ctx *sql.Context, st *cellState, k, v val.Tuple) (interface{}, error) {
+	if k == nil || st.sch.idx < 0 {
+		return nil, nil
+	}
+
+	var field interface{}
+	var err error
+	if st.sch.inKey {
+		field, err = tree.GetField(ctx, st.sch.kd, st.sch.idx, k, st.rows.NodeStore())
+	} else {
+		field, err = tree.GetField(ctx, st.sch.vd, st.sch.idx, v, st.rows.NodeStore())
+	}
+	if err != nil || field == nil {
+		return nil, err
+	}
+
+	converted, _, err := w.chtf.sqlType.Convert(ctx, field)
+	if err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
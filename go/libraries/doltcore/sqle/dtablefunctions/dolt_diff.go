@@ -262,12 +262,12 @@ func resolveCommitStrings(ctx *sql.Context, fromRef, toRef, dotRef interface{},
 				return "", "", err
 			}
 
-			rightCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, refs[0])
+			rightCm, err := resolveCommit(ctx, db, headRef, refs[0])
 			if err != nil {
 				return "", "", err
 			}
 
-			leftCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, refs[1])
+			leftCm, err := resolveCommit(ctx, db, headRef, refs[1])
 			if err != nil {
 				return "", "", err
 			}
@@ -330,13 +330,17 @@ func resolveRoot(ctx *sql.Context, sess *dsess.DoltSession, dbName, hashStr stri
 	return &refDetails{root, hashStr, commitTime}, nil
 }
 
-func resolveCommit(ctx *sql.Context, ddb *doltdb.DoltDB, headRef ref.DoltRef, cSpecStr string) (*doltdb.Commit, error) {
+func resolveCommit(ctx *sql.Context, db dsess.SqlDatabase, headRef ref.DoltRef, cSpecStr string) (*doltdb.Commit, error) {
+	if err := dsess.CheckRevisionReadAccess(ctx, db.Name(), db.DbData().Ddb, cSpecStr); err != nil {
+		return nil, err
+	}
+
 	cs, err := doltdb.NewCommitSpec(cSpecStr)
 	if err != nil {
 		return nil, err
 	}
 
-	optCmt, err := ddb.Resolve(ctx, cs, headRef)
+	optCmt, err := db.DbData().Ddb.Resolve(ctx, cs, headRef)
 	if err != nil {
 		return nil, err
 	}
@@ -23,6 +23,7 @@ import (
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
@@ -47,6 +48,7 @@ type LogTableFunction struct {
 	tableNames       []string
 
 	minParents    int
+	showAll       bool
 	showParents   bool
 	showSignature bool
 	decoration    string
@@ -142,6 +144,10 @@ func (ltf *LogTableFunction) getOptionsString() string {
 		options = append(options, fmt.Sprintf("--%s %d", cli.MinParentsFlag, ltf.minParents))
 	}
 
+	if ltf.showAll {
+		options = append(options, fmt.Sprintf("--%s", cli.AllFlag))
+	}
+
 	if ltf.showParents {
 		options = append(options, fmt.Sprintf("--%s", cli.ParentsFlag))
 	}
@@ -265,6 +271,7 @@ func (ltf *LogTableFunction) addOptions(expression []sql.Expression) error {
 	}
 
 	ltf.minParents = minParents
+	ltf.showAll = apr.Contains(cli.AllFlag)
 	ltf.showParents = apr.Contains(cli.ParentsFlag)
 	ltf.showSignature = apr.Contains(cli.ShowSignatureFlag)
 
@@ -424,7 +431,7 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 		return commit.NumParents() >= ltf.minParents, nil
 	}
 
-	cHashToRefs, err := getCommitHashToRefs(ctx, sqledb.DbData().Ddb, ltf.decoration)
+	cHashToRefs, err := getCommitHashToRefs(ctx, sqledb.Name(), sqledb.DbData().Ddb, ltf.decoration)
 	if err != nil {
 		return nil, err
 	}
@@ -446,6 +453,9 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 	}
 
 	for _, revisionStr := range revisionValStrs {
+		if err = dsess.CheckRevisionReadAccess(ctx, sqledb.Name(), sqledb.DbData().Ddb, revisionStr); err != nil {
+			return nil, err
+		}
 		cs, err := doltdb.NewCommitSpec(revisionStr)
 		if err != nil {
 			return nil, err
@@ -463,8 +473,26 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 		commits = append(commits, commit)
 	}
 
+	if ltf.showAll {
+		// Every ref the user can read is included, but not the branches hidden from them
+		readableHeads, _, err := dsess.ReadableRefHeads(ctx, sqledb.Name(), sqledb.DbData().Ddb)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range readableHeads {
+			commit, err := doltdb.HashToCommit(ctx, sqledb.DbData().Ddb.ValueReadWriter(), sqledb.DbData().Ddb.NodeStore(), h)
+			if err != nil {
+				return nil, err
+			}
+			commits = append(commits, commit)
+		}
+	}
+
 	var notCommits []*doltdb.Commit
 	for _, notRevisionStr := range notRevisionValStrs {
+		if err = dsess.CheckRevisionReadAccess(ctx, sqledb.Name(), sqledb.DbData().Ddb, notRevisionStr); err != nil {
+			return nil, err
+		}
 		cs, err := doltdb.NewCommitSpec(notRevisionStr)
 		if err != nil {
 			return nil, err
@@ -508,7 +536,7 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 		return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, ltf.tableNames)
 	}
 
-	if len(revisionValStrs) <= 1 && len(notRevisionValStrs) == 0 {
+	if len(commits) == 1 && len(notRevisionValStrs) == 0 {
 		return ltf.NewLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits[0], matchFunc, cHashToRefs, ltf.tableNames)
 	}
 
@@ -553,15 +581,20 @@ func (ltf *LogTableFunction) evaluateArguments() (revisionValStrs []string, notR
 	return revisionValStrs, notRevisionValStrs, false, nil
 }
 
-func getCommitHashToRefs(ctx *sql.Context, ddb *doltdb.DoltDB, decoration string) (map[hash.Hash][]string, error) {
+func getCommitHashToRefs(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB, decoration string) (map[hash.Hash][]string, error) {
 	cHashToRefs := map[hash.Hash][]string{}
 
-	// Get all branches
+	// Get all branches, except those hidden from the user
 	branches, err := ddb.GetBranchesWithHashes(ctx)
 	if err != nil {
 		return nil, err
 	}
 	for _, b := range branches {
+		if err = branch_control.CanReadBranch(ctx, dbName, b.Ref.GetPath()); branch_control.ErrCannotReadBranch.Is(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		refName := b.Ref.String()
 		if decoration != "full" {
 			refName = b.Ref.GetPath() // trim out "refs/heads/"
@@ -575,6 +608,11 @@ func getCommitHashToRefs(ctx *sql.Context, ddb *doltdb.DoltDB, decoration string
 		return nil, err
 	}
 	for _, r := range remotes {
+		if err = branch_control.CanReadBranch(ctx, dbName, r.Ref.GetPath()); branch_control.ErrCannotReadBranch.Is(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		refName := r.Ref.String()
 		if decoration != "full" {
 			refName = r.Ref.GetPath() // trim out "refs/remotes/"
@@ -296,12 +296,12 @@ func resolveBranchesToRoots(ctx *sql.Context, db dsess.SqlDatabase, leftBranch,
 		return rootInfo{}, err
 	}
 
-	leftCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, leftBranch)
+	leftCm, err := resolveCommit(ctx, db, headRef, leftBranch)
 	if err != nil {
 		return rootInfo{}, err
 	}
 
-	rightCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, rightBranch)
+	rightCm, err := resolveCommit(ctx, db, headRef, rightBranch)
 	if err != nil {
 		return rootInfo{}, err
 	}
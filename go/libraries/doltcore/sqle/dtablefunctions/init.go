@@ -20,7 +20,9 @@ var DoltTableFunctions = []sql.TableFunction{
 	&DiffTableFunction{},
 	&DiffStatTableFunction{},
 	&DiffSummaryTableFunction{},
+	&BlameTableFunction{},
 	&BranchStatusTableFunction{},
+	&CellHistoryTableFunction{},
 	&LogTableFunction{},
 	&PatchTableFunction{},
 	&PreviewMergeConflictsSummaryTableFunction{},
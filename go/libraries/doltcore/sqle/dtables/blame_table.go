@@ -0,0 +1,207 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/blame"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+var errUnblameableTable = errors.New("unable to generate blame view for table without primary key")
+
+const blameDefaultRowCount = 1000
+
+var _ sql.Table = (*BlameTable)(nil)
+var _ sql.StatisticsTable = (*BlameTable)(nil)
+
+// BlameTable is a sql.Table implementation of the DOLT_BLAME system table, which shows the latest commit that changed
+// each row of a table. Rows are followed through table renames and primary key changes, see the blame package.
+type BlameTable struct {
+	tableName doltdb.TableName
+	blamed    doltdb.TableName
+	ddb       *doltdb.DoltDB
+	head      *doltdb.Commit
+	sqlSch    sql.Schema
+}
+
+// NewBlameTable returns the DOLT_BLAME system table for the table |tblName| of |root|. Rows are blamed as of the
+// commit |head|.
+func NewBlameTable(ctx *sql.Context, tblName doltdb.TableName, ddb *doltdb.DoltDB, root doltdb.RootValue, head *doltdb.Commit) (sql.Table, error) {
+	table, tblName, err := getTableInsensitiveOrError(ctx, root, tblName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the schema comes from the blamed commit, unless the table doesn't exist there yet
+	headRoot, err := head.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	headTable, _, ok, err := doltdb.GetTableInsensitive(ctx, headRoot, tblName)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		table = headTable
+	}
+
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, errUnblameableTable
+	}
+
+	name := doltdb.TableName{Name: doltdb.DoltBlameViewPrefix + tblName.Name, Schema: tblName.Schema}
+	return &BlameTable{
+		tableName: name,
+		blamed:    tblName,
+		ddb:       ddb,
+		head:      head,
+		sqlSch:    BlameSchema(name.Name, sch, false),
+	}, nil
+}
+
+// BlameSchema returns the schema of the blame of a table with schema |sch|: the primary key columns of the table
+// followed by the commit that last changed the row. When blaming |cells|, there is a row per cell, and the name
+// of the cell's column follows the primary key columns.
+func BlameSchema(tableName string, sch schema.Schema, cells bool) sql.Schema {
+	var sqlSch sql.Schema
+	for _, col := range sch.GetPKCols().GetColumns() {
+		sqlSch = append(sqlSch, &sql.Column{Name: col.Name, Type: col.TypeInfo.ToSqlType(), Nullable: true, Source: tableName})
+	}
+	if cells {
+		sqlSch = append(sqlSch, &sql.Column{Name: "column_name", Type: types.Text, Source: tableName})
+	}
+	return append(sqlSch,
+		&sql.Column{Name: "commit", Type: typeinfo.StringDefaultType.ToSqlType(), Nullable: true, Source: tableName},
+		&sql.Column{Name: "commit_date", Type: typeinfo.DatetimeType.ToSqlType(), Nullable: true, Source: tableName},
+		&sql.Column{Name: "committer", Type: types.Text, Source: tableName},
+		&sql.Column{Name: "email", Type: types.Text, Source: tableName},
+		&sql.Column{Name: "message", Type: types.Text, Source: tableName},
+	)
+}
+
+// NewBlameRowIter returns the rows of the blame |it|, with the schema returned by BlameSchema. Rows are blamed as
+// they are read.
+func NewBlameRowIter(it *blame.Iter, cells bool) sql.RowIter {
+	return &blameRowIter{it: it, cells: cells}
+}
+
+type blameRowIter struct {
+	it    *blame.Iter
+	cells bool
+	// rows are the rows for the cells of the last blamed row which are still to be returned.
+	rows []sql.Row
+}
+
+var _ sql.RowIter = (*blameRowIter)(nil)
+
+func (bi *blameRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	// when blaming cells, a table with only primary key columns has no rows
+	for len(bi.rows) == 0 {
+		r, err := bi.it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var key sql.Row
+		for i := range bi.it.KeyDesc.Types {
+			v, err := tree.GetField(ctx, bi.it.KeyDesc, i, r.Key, bi.it.NS)
+			if err != nil {
+				return nil, err
+			}
+			key = append(key, v)
+		}
+
+		if !bi.cells {
+			return append(key, blameOriginValues(r.Origin)...), nil
+		}
+		for i, col := range bi.it.Columns {
+			row := append(append(sql.Row{}, key...), col.Name)
+			bi.rows = append(bi.rows, append(row, blameOriginValues(r.Cells[i])...))
+		}
+	}
+	row := bi.rows[0]
+	bi.rows = bi.rows[1:]
+	return row, nil
+}
+
+func (bi *blameRowIter) Close(*sql.Context) error {
+	return nil
+}
+
+func blameOriginValues(o *blame.Origin) sql.Row {
+	return sql.Row{o.Hash.String(), o.Meta.Time(), o.Meta.Name, o.Meta.Email, o.Meta.Description}
+}
+
+func (bt *BlameTable) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(bt.Schema())
+	numRows, _, err := bt.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (bt *BlameTable) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return blameDefaultRowCount, false, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table.
+func (bt *BlameTable) Name() string {
+	return bt.tableName.Name
+}
+
+// String is a sql.Table interface function which returns the name of the table.
+func (bt *BlameTable) String() string {
+	return bt.tableName.Name
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the blame system table.
+func (bt *BlameTable) Schema() sql.Schema {
+	return bt.sqlSch
+}
+
+// Collation implements the sql.Table interface.
+func (bt *BlameTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently, the data is unpartitioned.
+func (bt *BlameTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (bt *BlameTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	it, err := blame.Table(ctx, bt.ddb, bt.head, bt.blamed, blame.Options{})
+	if errors.Is(err, doltdb.ErrTableNotFound) {
+		// the table was created after the blamed commit
+		return sql.RowsToRowIter(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	return NewBlameRowIter(it, false), nil
+}
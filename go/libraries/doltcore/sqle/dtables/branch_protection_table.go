@@ -0,0 +1,268 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// BranchProtectionTable is a sql.Table implementation that exposes the branch protection rules of a database, and
+// allows them to be changed. Rules are not versioned, so changes take effect as soon as the statement making them
+// completes, regardless of the transaction it is part of.
+type BranchProtectionTable struct {
+	dbName    string
+	tableName string
+	ddb       *doltdb.DoltDB
+}
+
+var _ sql.Table = (*BranchProtectionTable)(nil)
+var _ sql.InsertableTable = (*BranchProtectionTable)(nil)
+var _ sql.ReplaceableTable = (*BranchProtectionTable)(nil)
+var _ sql.UpdatableTable = (*BranchProtectionTable)(nil)
+var _ sql.DeletableTable = (*BranchProtectionTable)(nil)
+
+// NewBranchProtectionTable creates a BranchProtectionTable
+func NewBranchProtectionTable(_ *sql.Context, dbName, tableName string, ddb *doltdb.DoltDB) sql.Table {
+	return &BranchProtectionTable{dbName: dbName, tableName: tableName, ddb: ddb}
+}
+
+// Name is a sql.Table interface function which returns the name of the table.
+func (bt *BranchProtectionTable) Name() string {
+	return bt.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table.
+func (bt *BranchProtectionTable) String() string {
+	return bt.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the branch protection system table.
+func (bt *BranchProtectionTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "branch", Type: types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_ai_ci), Source: bt.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: bt.dbName},
+		{Name: "require_merge", Type: types.Boolean, Source: bt.tableName, PrimaryKey: false, Nullable: false, Default: falseDefault(), DatabaseSource: bt.dbName},
+		{Name: "block_force_updates", Type: types.Boolean, Source: bt.tableName, PrimaryKey: false, Nullable: false, Default: falseDefault(), DatabaseSource: bt.dbName},
+		{Name: "block_deletion", Type: types.Boolean, Source: bt.tableName, PrimaryKey: false, Nullable: false, Default: falseDefault(), DatabaseSource: bt.dbName},
+		{Name: "required_workflows", Type: types.Text, Source: bt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: bt.dbName},
+	}
+}
+
+func falseDefault() *sql.ColumnDefaultValue {
+	return sql.NewUnresolvedColumnDefaultValue("false")
+}
+
+// Collation implements the sql.Table interface.
+func (bt *BranchProtectionTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (bt *BranchProtectionTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition.
+func (bt *BranchProtectionTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	rules, err := bt.ddb.GetBranchProtectionRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sql.Row, 0, len(rules))
+	for _, rule := range rules {
+		rows = append(rows, branchProtectionRuleToRow(rule))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Inserter implements the sql.InsertableTable interface.
+func (bt *BranchProtectionTable) Inserter(*sql.Context) sql.RowInserter {
+	return bt.newEditor()
+}
+
+// Replacer implements the sql.ReplaceableTable interface.
+func (bt *BranchProtectionTable) Replacer(*sql.Context) sql.RowReplacer {
+	return bt.newEditor()
+}
+
+// Updater implements the sql.UpdatableTable interface.
+func (bt *BranchProtectionTable) Updater(*sql.Context) sql.RowUpdater {
+	return bt.newEditor()
+}
+
+// Deleter implements the sql.DeletableTable interface.
+func (bt *BranchProtectionTable) Deleter(*sql.Context) sql.RowDeleter {
+	return bt.newEditor()
+}
+
+func (bt *BranchProtectionTable) newEditor() *branchProtectionEditor {
+	return &branchProtectionEditor{table: bt}
+}
+
+func branchProtectionRuleToRow(rule doltdb.BranchProtectionRule) sql.Row {
+	var workflows interface{}
+	if len(rule.RequiredWorkflows) > 0 {
+		workflows = strings.Join(rule.RequiredWorkflows, ",")
+	}
+	return sql.NewRow(rule.Branch, boolToInt8(rule.RequireMerge), boolToInt8(rule.BlockForceUpdates), boolToInt8(rule.BlockDeletion), workflows)
+}
+
+func branchProtectionRuleFromRow(row sql.Row) doltdb.BranchProtectionRule {
+	rule := doltdb.BranchProtectionRule{
+		Branch:            row[0].(string),
+		RequireMerge:      isTrue(row[1]),
+		BlockForceUpdates: isTrue(row[2]),
+		BlockDeletion:     isTrue(row[3]),
+	}
+	if workflows, ok := row[4].(string); ok {
+		for _, workflow := range strings.Split(workflows, ",") {
+			if workflow = strings.TrimSpace(workflow); workflow != "" {
+				rule.RequiredWorkflows = append(rule.RequiredWorkflows, workflow)
+			}
+		}
+	}
+	return rule
+}
+
+func boolToInt8(b bool) int8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// branchProtectionEditor edits the branch protection rules of a database. Edits are made to a copy of the rules, which
+// is written to the database when the statement completes.
+type branchProtectionEditor struct {
+	table *BranchProtectionTable
+	rules []doltdb.BranchProtectionRule
+}
+
+var _ sql.RowInserter = (*branchProtectionEditor)(nil)
+var _ sql.RowReplacer = (*branchProtectionEditor)(nil)
+var _ sql.RowUpdater = (*branchProtectionEditor)(nil)
+var _ sql.RowDeleter = (*branchProtectionEditor)(nil)
+
+// StatementBegin implements the interface sql.TableEditor.
+func (be *branchProtectionEditor) StatementBegin(*sql.Context) {
+}
+
+// DiscardChanges implements the interface sql.TableEditor.
+func (be *branchProtectionEditor) DiscardChanges(*sql.Context, error) error {
+	be.rules = nil
+	return nil
+}
+
+// StatementComplete implements the interface sql.TableEditor.
+func (be *branchProtectionEditor) StatementComplete(ctx *sql.Context) error {
+	if be.rules == nil {
+		return nil
+	}
+	err := be.table.ddb.SetBranchProtectionRules(ctx, be.rules)
+	be.rules = nil
+	return err
+}
+
+// loadRules loads the current rules of the database, if this statement has not loaded them yet.
+func (be *branchProtectionEditor) loadRules(ctx *sql.Context) error {
+	if be.rules != nil {
+		return nil
+	}
+	rules, err := be.table.ddb.GetBranchProtectionRules(ctx)
+	if err != nil {
+		return err
+	}
+	be.rules = append(make([]doltdb.BranchProtectionRule, 0, len(rules)), rules...)
+	return nil
+}
+
+func (be *branchProtectionEditor) indexOf(branch string) int {
+	for i := range be.rules {
+		if strings.EqualFold(be.rules[i].Branch, branch) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Insert implements the interface sql.RowInserter.
+func (be *branchProtectionEditor) Insert(ctx *sql.Context, row sql.Row) error {
+	rule := branchProtectionRuleFromRow(row)
+	if err := branch_control.CanProtectBranch(ctx, be.table.dbName, rule.Branch); err != nil {
+		return err
+	}
+	if err := be.loadRules(ctx); err != nil {
+		return err
+	}
+	if i := be.indexOf(rule.Branch); i >= 0 {
+		existing := branchProtectionRuleToRow(be.rules[i])
+		return sql.NewUniqueKeyErr(fmt.Sprintf("[%q]", rule.Branch), true, existing)
+	}
+	be.rules = append(be.rules, rule)
+	return nil
+}
+
+// Update implements the interface sql.RowUpdater.
+func (be *branchProtectionEditor) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	oldRule := branchProtectionRuleFromRow(old)
+	newRule := branchProtectionRuleFromRow(new)
+	if err := branch_control.CanProtectBranch(ctx, be.table.dbName, oldRule.Branch); err != nil {
+		return err
+	}
+	if err := branch_control.CanProtectBranch(ctx, be.table.dbName, newRule.Branch); err != nil {
+		return err
+	}
+	if err := be.loadRules(ctx); err != nil {
+		return err
+	}
+	if !strings.EqualFold(oldRule.Branch, newRule.Branch) {
+		if i := be.indexOf(newRule.Branch); i >= 0 {
+			existing := branchProtectionRuleToRow(be.rules[i])
+			return sql.NewUniqueKeyErr(fmt.Sprintf("[%q]", newRule.Branch), true, existing)
+		}
+	}
+	if i := be.indexOf(oldRule.Branch); i >= 0 {
+		be.rules[i] = newRule
+	}
+	return nil
+}
+
+// Delete implements the interface sql.RowDeleter.
+func (be *branchProtectionEditor) Delete(ctx *sql.Context, row sql.Row) error {
+	rule := branchProtectionRuleFromRow(row)
+	if err := branch_control.CanProtectBranch(ctx, be.table.dbName, rule.Branch); err != nil {
+		return err
+	}
+	if err := be.loadRules(ctx); err != nil {
+		return err
+	}
+	if i := be.indexOf(rule.Branch); i >= 0 {
+		be.rules = append(be.rules[:i], be.rules[i+1:]...)
+	}
+	return nil
+}
+
+// Close implements the interface sql.Closer.
+func (be *branchProtectionEditor) Close(*sql.Context) error {
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
@@ -147,10 +148,19 @@ func NewBranchItr(ctx *sql.Context, table *BranchesTable) (*BranchItr, error) {
 		}
 	}
 
-	branchNames := make([]string, len(branchRefs))
-	commits := make([]*doltdb.Commit, len(branchRefs))
-	dirtyBits := make([]bool, len(branchRefs))
-	for i, branch := range branchRefs {
+	branchNames := make([]string, 0, len(branchRefs))
+	commits := make([]*doltdb.Commit, 0, len(branchRefs))
+	dirtyBits := make([]bool, 0, len(branchRefs))
+	for _, branch := range branchRefs {
+		// Branches which the user cannot read are hidden
+		if !remote {
+			if err = branch_control.CanReadBranch(ctx, db.Name(), branch.GetPath()); branch_control.ErrCannotReadBranch.Is(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
 		commit, err := ddb.ResolveCommitRefAtRoot(ctx, branch, txRoot)
 		if err != nil {
 			return nil, err
@@ -165,13 +175,13 @@ func NewBranchItr(ctx *sql.Context, table *BranchesTable) (*BranchItr, error) {
 		}
 
 		if branch.GetType() == ref.RemoteRefType {
-			branchNames[i] = "remotes/" + branch.GetPath()
+			branchNames = append(branchNames, "remotes/"+branch.GetPath())
 		} else {
-			branchNames[i] = branch.GetPath()
+			branchNames = append(branchNames, branch.GetPath())
 		}
 
-		dirtyBits[i] = dirty
-		commits[i] = commit
+		dirtyBits = append(dirtyBits, dirty)
+		commits = append(commits, commit)
 	}
 
 	return &BranchItr{
@@ -0,0 +1,132 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// CIRunsTable is a sql.Table implementation that exposes the CI run log of a database: the results of the workflows
+// run by sql-server when a branch head advances, and of the workflows run to merge into a protected branch. There is
+// one row per workflow step, identified by the run and the index of the step in the run. A job without steps has a
+// single row with an empty step name, and a workflow without steps, or one that could not be run, has a single row
+// with empty job and step names. The log is persisted in the database, and only its most recent doltdb.MaxCIRuns runs
+// are kept.
+type CIRunsTable struct {
+	dbName    string
+	tableName string
+	ddb       *doltdb.DoltDB
+}
+
+var _ sql.Table = (*CIRunsTable)(nil)
+
+// NewCIRunsTable creates a CIRunsTable
+func NewCIRunsTable(dbName, tableName string, ddb *doltdb.DoltDB) *CIRunsTable {
+	return &CIRunsTable{dbName: dbName, tableName: tableName, ddb: ddb}
+}
+
+func (ct *CIRunsTable) Name() string {
+	return ct.tableName
+}
+
+func (ct *CIRunsTable) String() string {
+	return ct.tableName
+}
+
+func (ct *CIRunsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "run_id", Type: types.Uint64, Source: ct.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "workflow_name", Type: types.Text, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "branch", Type: types.Text, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "commit_hash", Type: types.Text, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "step_index", Type: types.Uint32, Source: ct.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "job_name", Type: types.Text, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "step_name", Type: types.Text, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "status", Type: types.Text, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "message", Type: types.Text, Source: ct.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: ct.dbName},
+		{Name: "started_at", Type: types.DatetimeMaxPrecision, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "finished_at", Type: types.DatetimeMaxPrecision, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "duration_ms", Type: types.Int64, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+	}
+}
+
+func (ct *CIRunsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (ct *CIRunsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (ct *CIRunsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	runs, err := ct.ddb.GetCIRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rows []sql.Row
+	for _, run := range runs {
+		rows = append(rows, ciRunRows(run)...)
+	}
+	return &ciRunsItr{rows: rows}, nil
+}
+
+// ciRunRows returns the rows of |run| in the dolt_ci_runs table.
+func ciRunRows(run doltdb.CIRun) []sql.Row {
+	if run.Error != "" || len(run.Steps) == 0 {
+		status := doltdb.CIRunStatusPassed
+		var message interface{}
+		if run.Error != "" {
+			status = doltdb.CIRunStatusError
+			message = run.Error
+		}
+		duration := run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+		return []sql.Row{sql.NewRow(run.ID, run.Workflow, run.Branch, run.CommitHash, uint32(0), "", "", status, message, run.StartedAt, run.FinishedAt, duration)}
+	}
+	rows := make([]sql.Row, len(run.Steps))
+	for i, step := range run.Steps {
+		var message interface{}
+		if step.Message != "" {
+			message = step.Message
+		}
+		duration := step.FinishedAt.Sub(step.StartedAt).Milliseconds()
+		rows[i] = sql.NewRow(run.ID, run.Workflow, run.Branch, run.CommitHash, uint32(i), step.Job, step.Step, step.Status, message, step.StartedAt, step.FinishedAt, duration)
+	}
+	return rows
+}
+
+type ciRunsItr struct {
+	rows []sql.Row
+	idx  int
+}
+
+var _ sql.RowIter = (*ciRunsItr)(nil)
+
+func (itr *ciRunsItr) Next(*sql.Context) (sql.Row, error) {
+	if itr.idx >= len(itr.rows) {
+		return nil, io.EOF
+	}
+	itr.idx++
+	return itr.rows[itr.idx-1], nil
+}
+
+func (itr *ciRunsItr) Close(*sql.Context) error {
+	return nil
+}
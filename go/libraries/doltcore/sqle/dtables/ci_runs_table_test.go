@@ -0,0 +1,66 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+func TestCIRunRows(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Second)
+	step := func(job, name, status, message string) doltdb.CIStepRun {
+		return doltdb.CIStepRun{Job: job, Step: name, Status: status, Message: message, StartedAt: start, FinishedAt: end}
+	}
+	run := func(err string, steps ...doltdb.CIStepRun) doltdb.CIRun {
+		return doltdb.CIRun{ID: 7, Workflow: "wf", Branch: "main", CommitHash: "abc", Error: err, Steps: steps, StartedAt: start, FinishedAt: end}
+	}
+
+	t.Run("steps with the same names", func(t *testing.T) {
+		rows := ciRunRows(run("", step("job", "step", doltdb.CIRunStatusPassed, ""), step("job", "step", doltdb.CIRunStatusFailed, "bad")))
+		assert.Equal(t, []sql.Row{
+			{uint64(7), "wf", "main", "abc", uint32(0), "job", "step", doltdb.CIRunStatusPassed, nil, start, end, int64(1000)},
+			{uint64(7), "wf", "main", "abc", uint32(1), "job", "step", doltdb.CIRunStatusFailed, "bad", start, end, int64(1000)},
+		}, rows)
+	})
+
+	t.Run("job without steps", func(t *testing.T) {
+		rows := ciRunRows(run("", step("empty", "", doltdb.CIRunStatusPassed, ""), step("job", "step", doltdb.CIRunStatusPassed, "")))
+		assert.Equal(t, []sql.Row{
+			{uint64(7), "wf", "main", "abc", uint32(0), "empty", "", doltdb.CIRunStatusPassed, nil, start, end, int64(1000)},
+			{uint64(7), "wf", "main", "abc", uint32(1), "job", "step", doltdb.CIRunStatusPassed, nil, start, end, int64(1000)},
+		}, rows)
+	})
+
+	t.Run("workflow without steps", func(t *testing.T) {
+		rows := ciRunRows(run(""))
+		assert.Equal(t, []sql.Row{
+			{uint64(7), "wf", "main", "abc", uint32(0), "", "", doltdb.CIRunStatusPassed, nil, start, end, int64(1000)},
+		}, rows)
+	})
+
+	t.Run("workflow which could not be run", func(t *testing.T) {
+		rows := ciRunRows(run("no such workflow"))
+		assert.Equal(t, []sql.Row{
+			{uint64(7), "wf", "main", "abc", uint32(0), "", "", doltdb.CIRunStatusError, "no such workflow", start, end, int64(1000)},
+		}, rows)
+	})
+}
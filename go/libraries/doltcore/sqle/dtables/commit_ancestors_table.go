@@ -22,6 +22,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 )
 
@@ -96,7 +97,7 @@ func (ct *CommitAncestorsTable) PartitionRows(ctx *sql.Context, p sql.Partition)
 			ddb: ct.ddb,
 		}, nil
 	default:
-		return NewCommitAncestorsRowItr(ctx, ct.ddb)
+		return NewCommitAncestorsRowItr(ctx, ct.dbName, ct.ddb)
 	}
 }
 
@@ -124,6 +125,10 @@ func (ct *CommitAncestorsTable) LookupPartitions(ctx *sql.Context, lookup sql.In
 		}
 
 		hashes, commits, metas := index.HashesToCommits(ctx, ct.ddb, hs, nil, false)
+		hashes, commits, metas, err := readableCommits(ctx, ct.dbName, ct.ddb, hashes, commits, metas)
+		if err != nil {
+			return nil, err
+		}
 		if len(hashes) == 0 {
 			return sql.PartitionsToPartitionIter(), nil
 		}
@@ -142,9 +147,10 @@ type CommitAncestorsRowItr struct {
 	cache []sql.Row
 }
 
-// NewCommitAncestorsRowItr creates a CommitAncestorsRowItr from the current environment.
-func NewCommitAncestorsRowItr(sqlCtx *sql.Context, ddb *doltdb.DoltDB) (*CommitAncestorsRowItr, error) {
-	itr, err := doltdb.CommitItrForAllBranches[*sql.Context](sqlCtx, ddb)
+// NewCommitAncestorsRowItr creates a CommitAncestorsRowItr from the current environment. Only the commits of the
+// branches that the current user may read are included.
+func NewCommitAncestorsRowItr(sqlCtx *sql.Context, dbName string, ddb *doltdb.DoltDB) (*CommitAncestorsRowItr, error) {
+	itr, err := dsess.CommitItrForReadableBranches[*sql.Context](sqlCtx, dbName, ddb)
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +26,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/types"
@@ -275,6 +276,10 @@ func (dt *CommitDiffTable) rootValForHash(ctx *sql.Context, hashStr string) (dol
 	} else if strings.EqualFold(hashStr, doltdb.Staged) {
 		root = dt.stagedRoot
 	} else {
+		if err := dsess.CheckRevisionReadAccess(ctx, dt.dbName, dt.ddb, hashStr); err != nil {
+			return nil, "", nil, err
+		}
+
 		cs, err := doltdb.NewCommitSpec(hashStr)
 		if err != nil {
 			return nil, "", nil, err
@@ -23,6 +23,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -98,7 +99,7 @@ func (ct *CommitsTable) PartitionRows(ctx *sql.Context, p sql.Partition) (sql.Ro
 	case *doltdb.CommitPart:
 		return sql.RowsToRowIter(formatCommitTableRow(p.Hash(), p.Meta())), nil
 	default:
-		return NewCommitsRowItr(ctx, ct.ddb)
+		return NewCommitsRowItr(ctx, ct.dbName, ct.ddb)
 	}
 }
 
@@ -124,6 +125,10 @@ func (ct *CommitsTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLooku
 			return nil, fmt.Errorf("failed to parse commit lookup ranges: %s", sql.DebugString(lookup.Ranges))
 		}
 		hashes, commits, metas := index.HashesToCommits(ctx, ct.ddb, hashStrs, nil, false)
+		hashes, commits, metas, err := readableCommits(ctx, ct.dbName, ct.ddb, hashes, commits, metas)
+		if err != nil {
+			return nil, err
+		}
 		if len(hashes) == 0 {
 			return sql.PartitionsToPartitionIter(), nil
 		}
@@ -139,9 +144,10 @@ type CommitsRowItr struct {
 	itr doltdb.CommitItr[*sql.Context]
 }
 
-// NewCommitsRowItr creates a CommitsRowItr from the current environment.
-func NewCommitsRowItr(ctx *sql.Context, ddb *doltdb.DoltDB) (CommitsRowItr, error) {
-	itr, err := doltdb.CommitItrForAllBranches[*sql.Context](ctx, ddb)
+// NewCommitsRowItr creates a CommitsRowItr from the current environment. Only the commits of the branches that the
+// current user may read are included.
+func NewCommitsRowItr(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB) (CommitsRowItr, error) {
+	itr, err := dsess.CommitItrForReadableBranches[*sql.Context](ctx, dbName, ddb)
 	if err != nil {
 		return CommitsRowItr{}, err
 	}
@@ -174,6 +180,29 @@ func (itr CommitsRowItr) Close(*sql.Context) error {
 	return nil
 }
 
+// readableCommits filters the results of index.HashesToCommits down to the commits that the current user may read.
+func readableCommits(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB, hashes []hash.Hash, commits []*doltdb.Commit, metas []*datas.CommitMeta) ([]hash.Hash, []*doltdb.Commit, []*datas.CommitMeta, error) {
+	if len(hashes) == 0 {
+		return hashes, commits, metas, nil
+	}
+	hidden, err := dsess.HiddenCommits(ctx, dbName, ddb)
+	if err != nil || hidden.Size() == 0 {
+		return hashes, commits, metas, err
+	}
+	var readableHashes []hash.Hash
+	var readableCommits []*doltdb.Commit
+	var readableMetas []*datas.CommitMeta
+	for i, h := range hashes {
+		if hidden.Has(h) {
+			continue
+		}
+		readableHashes = append(readableHashes, h)
+		readableCommits = append(readableCommits, commits[i])
+		readableMetas = append(readableMetas, metas[i])
+	}
+	return readableHashes, readableCommits, readableMetas, nil
+}
+
 func formatCommitTableRow(h hash.Hash, meta *datas.CommitMeta) sql.Row {
 	return sql.NewRow(h.String(), meta.Name, meta.Email, meta.Time(), meta.Description)
 }
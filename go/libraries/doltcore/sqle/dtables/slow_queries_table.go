@@ -0,0 +1,177 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+const (
+	// SlowQueryCommitted is the commit outcome of a query whose transaction was committed when it finished.
+	SlowQueryCommitted = "committed"
+	// SlowQueryUncommitted is the commit outcome of a query which ran in an explicit transaction, or with autocommit
+	// off, and whose transaction was still open when it finished.
+	SlowQueryUncommitted = "uncommitted"
+	// SlowQueryFailed is the commit outcome of a query which failed, or whose autocommit transaction could not be
+	// committed.
+	SlowQueryFailed = "failed"
+
+	// maxSlowQueriesPerDatabase is the number of slow queries retained for each database. Older queries are discarded.
+	maxSlowQueriesPerDatabase = 256
+)
+
+// SlowQuery is a query run by sql-server which took longer than the slow query log's threshold.
+type SlowQuery struct {
+	Query string
+	User  string
+	Host  string
+	// Database is the name of the database the query ran against, without any revision.
+	Database string
+	// Branch is the branch or other revision of Database the query ran against.
+	Branch       string
+	StartedAt    time.Time
+	Duration     time.Duration
+	RowsExamined int64
+	RowsReturned int64
+	// CommitOutcome is one of SlowQueryCommitted, SlowQueryUncommitted or SlowQueryFailed.
+	CommitOutcome string
+	// Plan is the query's plan, as shown by EXPLAIN PLAN. It is empty if the query failed before it was planned.
+	Plan string
+}
+
+type slowQueryLogEntry struct {
+	id    uint64
+	query SlowQuery
+}
+
+// slowQueryLog holds the most recent slow queries of each database. It lives in memory only, so slow queries are not
+// visible to other processes and do not survive a restart.
+type slowQueryLog struct {
+	mu      sync.Mutex
+	nextID  uint64
+	queries map[string][]slowQueryLogEntry
+}
+
+var slowQueries = &slowQueryLog{queries: make(map[string][]slowQueryLogEntry)}
+
+// RecordSlowQuery records |query| against its database and returns the id it was assigned.
+func RecordSlowQuery(query SlowQuery) uint64 {
+	slowQueries.mu.Lock()
+	defer slowQueries.mu.Unlock()
+	slowQueries.nextID++
+	key := strings.ToLower(query.Database)
+	entries := append(slowQueries.queries[key], slowQueryLogEntry{id: slowQueries.nextID, query: query})
+	if len(entries) > maxSlowQueriesPerDatabase {
+		entries = entries[len(entries)-maxSlowQueriesPerDatabase:]
+	}
+	slowQueries.queries[key] = entries
+	return slowQueries.nextID
+}
+
+func getSlowQueries(dbName string) []slowQueryLogEntry {
+	slowQueries.mu.Lock()
+	defer slowQueries.mu.Unlock()
+	entries := slowQueries.queries[strings.ToLower(dbName)]
+	return append([]slowQueryLogEntry(nil), entries...)
+}
+
+// SlowQueriesTable is a sql.Table implementation that exposes the queries against a database which sql-server's slow
+// query log recorded.
+type SlowQueriesTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*SlowQueriesTable)(nil)
+
+// NewSlowQueriesTable creates a SlowQueriesTable
+func NewSlowQueriesTable(dbName, tableName string) *SlowQueriesTable {
+	return &SlowQueriesTable{dbName: dbName, tableName: tableName}
+}
+
+func (st *SlowQueriesTable) Name() string {
+	return st.tableName
+}
+
+func (st *SlowQueriesTable) String() string {
+	return st.tableName
+}
+
+func (st *SlowQueriesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "query_id", Type: types.Uint64, Source: st.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "query", Type: types.LongText, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "user", Type: types.Text, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "host", Type: types.Text, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "branch", Type: types.Text, Source: st.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: st.dbName},
+		{Name: "started_at", Type: types.DatetimeMaxPrecision, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "duration_ms", Type: types.Int64, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "rows_examined", Type: types.Int64, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "rows_returned", Type: types.Int64, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "commit_outcome", Type: types.Text, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.dbName},
+		{Name: "query_plan", Type: types.LongText, Source: st.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: st.dbName},
+	}
+}
+
+func (st *SlowQueriesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (st *SlowQueriesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (st *SlowQueriesTable) PartitionRows(_ *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	var rows []sql.Row
+	for _, entry := range getSlowQueries(st.dbName) {
+		q := entry.query
+		var branch, plan interface{}
+		if q.Branch != "" {
+			branch = q.Branch
+		}
+		if q.Plan != "" {
+			plan = q.Plan
+		}
+		rows = append(rows, sql.NewRow(entry.id, q.Query, q.User, q.Host, branch, q.StartedAt, q.Duration.Milliseconds(), q.RowsExamined, q.RowsReturned, q.CommitOutcome, plan))
+	}
+	return &slowQueriesItr{rows: rows}, nil
+}
+
+type slowQueriesItr struct {
+	rows []sql.Row
+	idx  int
+}
+
+var _ sql.RowIter = (*slowQueriesItr)(nil)
+
+func (itr *slowQueriesItr) Next(*sql.Context) (sql.Row, error) {
+	if itr.idx >= len(itr.rows) {
+		return nil, io.EOF
+	}
+	itr.idx++
+	return itr.rows[itr.idx-1], nil
+}
+
+func (itr *slowQueriesItr) Close(*sql.Context) error {
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+const storageUsageDefaultRowCount = 10
+
+var _ sql.Table = (*StorageUsageTable)(nil)
+var _ sql.StatisticsTable = (*StorageUsageTable)(nil)
+
+// StorageUsageTable is a sql.Table implementation that implements a system table which shows what the storage of the
+// database is used by. Each row is the number of chunks, and their size on disk, used by one kind of data. Every chunk
+// of the database is read to build the table, so reading it can take a while for large databases.
+type StorageUsageTable struct {
+	tableName string
+	ddb       *doltdb.DoltDB
+}
+
+// NewStorageUsageTable creates a StorageUsageTable
+func NewStorageUsageTable(_ *sql.Context, tableName string, ddb *doltdb.DoltDB) sql.Table {
+	return &StorageUsageTable{tableName: tableName, ddb: ddb}
+}
+
+func (st *StorageUsageTable) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(st.Schema())
+	numRows, _, err := st.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (st *StorageUsageTable) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return storageUsageDefaultRowCount, false, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table.
+func (st *StorageUsageTable) Name() string {
+	return st.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table.
+func (st *StorageUsageTable) String() string {
+	return st.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the storage usage system table.
+func (st *StorageUsageTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "category", Type: types.Text, Source: st.tableName, PrimaryKey: true},
+		{Name: "table_name", Type: types.Text, Source: st.tableName, PrimaryKey: true},
+		{Name: "index_name", Type: types.Text, Source: st.tableName, PrimaryKey: true},
+		{Name: "chunks", Type: types.Uint64, Source: st.tableName, PrimaryKey: false},
+		{Name: "bytes", Type: types.Uint64, Source: st.tableName, PrimaryKey: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (st *StorageUsageTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently, the data is unpartitioned.
+func (st *StorageUsageTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *StorageUsageTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	return NewStorageUsageItr(ctx, st.ddb)
+}
+
+// StorageUsageItr is a sql.RowItr implementation which iterates over the storage used by each kind of data.
+type StorageUsageItr struct {
+	usage []doltdb.StorageUsage
+	idx   int
+}
+
+// NewStorageUsageItr creates a StorageUsageItr from the database's storage.
+func NewStorageUsageItr(ctx *sql.Context, ddb *doltdb.DoltDB) (*StorageUsageItr, error) {
+	usage, err := ddb.GetStorageUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageUsageItr{usage, 0}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *StorageUsageItr) Next(ctx *sql.Context) (sql.Row, error) {
+	if itr.idx >= len(itr.usage) {
+		return nil, io.EOF
+	}
+
+	defer func() {
+		itr.idx++
+	}()
+
+	u := itr.usage[itr.idx]
+	return sql.NewRow(string(u.Category), u.Table, u.Index, u.Chunks, u.Bytes), nil
+}
+
+// Close closes the iterator.
+func (itr *StorageUsageItr) Close(*sql.Context) error {
+	return nil
+}
@@ -560,6 +560,9 @@ func getCommitsFromCommitHashEquality(ctx *sql.Context, ddb *doltdb.DoltDB, filt
 }
 
 func getCommitFromHash(ctx *sql.Context, ddb *doltdb.DoltDB, val string) *doltdb.Commit {
+	if dsess.CheckRevisionReadAccess(ctx, ctx.GetCurrentDatabase(), ddb, val) != nil {
+		return nil
+	}
 	cmSpec, err := doltdb.NewCommitSpec(val)
 	if err != nil {
 		return nil
@@ -1361,6 +1361,218 @@ var BranchControlTests = []BranchControlTest{
 			},
 		},
 	},
+	{
+		Name: "Deny hides branches",
+		SetUpScript: []string{
+			"DELETE FROM dolt_branch_control WHERE user = '%';",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"CREATE USER testuser@localhost;",
+			"GRANT ALL ON *.* TO testuser@localhost;",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'hidden%', 'testuser', 'localhost', 'deny');",
+			"CREATE TABLE test (pk BIGINT PRIMARY KEY);",
+			"INSERT INTO test VALUES (1);",
+			"CALL DOLT_COMMIT('-Am', 'setup commit');",
+			"CALL DOLT_BRANCH('hidden');",
+			"CALL DOLT_BRANCH('visible');",
+			"CALL DOLT_CHECKOUT('hidden');",
+			"INSERT INTO test VALUES (5);",
+			"CALL DOLT_COMMIT('-am', 'hidden commit');",
+			"CALL DOLT_CHECKOUT('main');",
+			"SET @hidden_commit = HASHOF('hidden');",
+			"SET @main_commit = HASHOF('main');",
+		},
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT name FROM dolt_branches ORDER BY name;",
+				Expected: []sql.Row{{"hidden"}, {"main"}, {"visible"}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT name FROM dolt_branches ORDER BY name;",
+				Expected: []sql.Row{{"main"}, {"visible"}},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM `mydb/hidden`.test;",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "USE `mydb/hidden`;",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM test AS OF 'hidden';",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM test AS OF 'hidden~1';",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT * FROM test AS OF 'visible';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT * FROM `mydb/visible`.test;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff('main', 'hidden', 'test');",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_log('hidden');",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_commit_diff_test WHERE to_commit = 'hidden' AND from_commit = 'main';",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "INSERT INTO `mydb/hidden`.test VALUES (2);",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{ // Commits which only hidden branches reach are not listed, and can not be read by their hash
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_commits WHERE message = 'hidden commit';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_commits WHERE message = 'hidden commit';",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_commits WHERE commit_hash = @hidden_commit;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_commits WHERE commit_hash = @main_commit;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_commit_ancestors WHERE commit_hash = @hidden_commit;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_commit_ancestors WHERE parent_hash = @main_commit;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT message FROM dolt_log('--all') WHERE message = 'hidden commit';",
+				Expected: []sql.Row{{"hidden commit"}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_log('--all') WHERE message = 'hidden commit';",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_log('--all', '--decorate', 'short') WHERE refs LIKE '%hidden%';",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM test AS OF @hidden_commit;",
+				ExpectedErr: branch_control.ErrCannotReadCommit,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_log(@hidden_commit);",
+				ExpectedErr: branch_control.ErrCannotReadCommit,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff(@main_commit, @hidden_commit, 'test');",
+				ExpectedErr: branch_control.ErrCannotReadCommit,
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT * FROM test AS OF @main_commit;",
+				Expected: []sql.Row{{1}},
+			},
+			{ // A deny is not a subset of an existing write, but it is a subset of an existing deny
+				User:        "root",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'hidden2', 'testuser', 'localhost', 'deny');",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "CALL DOLT_CHECKOUT('hidden');",
+				ExpectedErr: branch_control.ErrCannotReadBranch,
+			},
+			{ // A longer match makes the branch readable again
+				User:  "root",
+				Host:  "localhost",
+				Query: "INSERT INTO dolt_branch_control VALUES ('mydb', 'hidden', 'testuser', 'localhost', 'write');",
+				Expected: []sql.Row{
+					{types.NewOkResult(1)},
+				},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT * FROM `mydb/hidden`.test;",
+				Expected: []sql.Row{{1}, {5}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT name FROM dolt_branches ORDER BY name;",
+				Expected: []sql.Row{{"hidden"}, {"main"}, {"visible"}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_commits WHERE commit_hash = @hidden_commit;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
 }
 
 func TestBranchControl(t *testing.T) {
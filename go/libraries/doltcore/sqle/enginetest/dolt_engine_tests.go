@@ -1468,13 +1468,6 @@ func RunSystemTableIndexesTests(t *testing.T, harness DoltEnginetestHarness) {
 			t.Run(c, func(t *testing.T) {
 				e.EngineAnalyzer().Coster = biasedCosters[i]
 				for _, tt := range stt.queries {
-					if tt.query == "select count(*) from dolt_blame_xy" && c == "inner" {
-						// todo we either need join hints to work inside the blame view
-						// and force the window relation to be primary, or we need the
-						// blame view's timestamp columns to be specific enough to not
-						// overlap during testing.
-						t.Skip("the blame table is unstable as secondary table in join with exchange node")
-					}
 					t.Run(fmt.Sprintf("%s(%s): %s", stt.name, c, tt.query), func(t *testing.T) {
 						if tt.skip {
 							t.Skip()
@@ -273,6 +273,7 @@ func (d *DoltHarness) NewEngine(t *testing.T) (enginetest.QueryEngine, error) {
 			return nil, err
 		}
 		e.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(kvexec.Builder{})
+		doltProvider.SetStatementRunner(e)
 		d.engine = e
 
 		sqlCtx := enginetest.NewContext(d)
@@ -475,6 +476,7 @@ func (d *DoltHarness) NewDatabases(names ...string) []sql.Database {
 
 	e := enginetest.NewEngineWithProvider(d.t, d, d.provider)
 	require.NoError(d.t, err)
+	doltProvider.SetStatementRunner(e)
 	d.engine = e
 
 	for _, name := range names {
@@ -527,7 +529,9 @@ func (d *DoltHarness) NewReadOnlyEngine(provider sql.DatabaseProvider) (enginete
 	d.session, err = dsess.NewDoltSession(enginetest.NewBaseSession(), readOnlyProvider, d.multiRepoEnv.Config(), d.branchControl, d.statsPro, writer.NewWriteSession, d.gcSafepointController)
 	require.NoError(d.t, err)
 
-	return enginetest.NewEngineWithProvider(nil, d, readOnlyProvider), nil
+	e := enginetest.NewEngineWithProvider(nil, d, readOnlyProvider)
+	readOnlyProvider.SetStatementRunner(e)
+	return e, nil
 }
 
 func (d *DoltHarness) NewDatabaseProvider() sql.MutableDatabaseProvider {
@@ -1336,6 +1336,159 @@ var DoltScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "blame: follows table renames and primary key changes",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c1 varchar(20) not null, c2 int);",
+			"INSERT INTO t VALUES (1, 'one', 10), (2, 'two', 20);",
+			"CALL dolt_commit('-Am', 'create t');",
+			"UPDATE t SET c2 = 21 WHERE pk = 2;",
+			"CALL dolt_commit('-am', 'update two');",
+			"RENAME TABLE t TO t2;",
+			"CALL dolt_commit('-Am', 'rename t');",
+			"ALTER TABLE t2 RENAME COLUMN c2 TO c3;",
+			"CALL dolt_commit('-am', 'rename c2');",
+			"ALTER TABLE t2 DROP PRIMARY KEY, ADD PRIMARY KEY (pk, c1);",
+			"CALL dolt_commit('-am', 'change primary key');",
+			"INSERT INTO t2 VALUES (3, 'three', 30);",
+			"CALL dolt_commit('-am', 'add three');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT pk, c1, message FROM dolt_blame_t2",
+				Expected: []sql.Row{
+					{1, "one", "create t"},
+					{2, "two", "update two"},
+					{3, "three", "add three"},
+				},
+			},
+			{
+				Query: "SELECT pk, c1, message FROM dolt_blame('t2')",
+				Expected: []sql.Row{
+					{1, "one", "create t"},
+					{2, "two", "update two"},
+					{3, "three", "add three"},
+				},
+			},
+			{
+				Query: "SELECT pk, message FROM dolt_blame('HEAD~2', 't2')",
+				Expected: []sql.Row{
+					{1, "create t"},
+					{2, "update two"},
+				},
+			},
+			{
+				Query: "SELECT pk, message FROM dolt_blame('t2', '--since', 'HEAD~4')",
+				Expected: []sql.Row{
+					{1, "update two"},
+					{2, "update two"},
+					{3, "add three"},
+				},
+			},
+			{
+				Query:          "SELECT * FROM dolt_blame('HEAD~4', 't2')",
+				ExpectedErrStr: "table not found: t2",
+			},
+			{
+				Query:          "SELECT * FROM dolt_blame('HEAD', 't2', '--until', 'HEAD')",
+				ExpectedErrStr: "Invalid argument to dolt_blame: a revision cannot be given together with --until",
+			},
+		},
+	},
+	{
+		Name: "blame: --columns blames each cell",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c1 int, c2 int);",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 2, 2);",
+			"CALL dolt_commit('-Am', 'create t');",
+			"UPDATE t SET c2 = 3 WHERE pk = 2;",
+			"CALL dolt_commit('-am', 'update c2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT pk, column_name, message FROM dolt_blame('t', '--columns')",
+				Expected: []sql.Row{
+					{1, "c1", "create t"},
+					{1, "c2", "create t"},
+					{2, "c1", "create t"},
+					{2, "c2", "update c2"},
+				},
+			},
+		},
+	},
+	{
+		Name: "dolt_cell_history",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk1 int, pk2 varchar(10), c1 int, c2 varchar(20), primary key (pk1, pk2));",
+			"INSERT INTO t VALUES (1, 'a', 1, 'one'), (2, 'b', 2, 'two');",
+			"CALL dolt_commit('-Am', 'create t');",
+			"UPDATE t SET c1 = 10 WHERE pk1 = 1;",
+			"CALL dolt_commit('-am', 'update c1');",
+			"UPDATE t SET c2 = 'uno' WHERE pk1 = 1;",
+			"CALL dolt_commit('-am', 'update c2');",
+			"CALL dolt_checkout('-b', 'branch1');",
+			"UPDATE t SET c1 = 11 WHERE pk1 = 1;",
+			"CALL dolt_commit('-am', 'update c1 on branch1');",
+			"CALL dolt_checkout('main');",
+			"INSERT INTO t VALUES (3, 'c', 3, 'three');",
+			"CALL dolt_commit('-am', 'add row 3');",
+			"CALL dolt_merge('branch1', '-m', 'merge branch1');",
+			"ALTER TABLE t MODIFY c1 bigint;",
+			"CALL dolt_commit('-am', 'widen c1');",
+			"DELETE FROM t WHERE pk1 = 2;",
+			"CALL dolt_commit('-am', 'delete row 2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT message, diff_type, old_value, new_value FROM dolt_cell_history('t', 1, 'a', 'c1');",
+				Expected: []sql.Row{
+					{"update c1 on branch1", "modified", int64(10), int64(11)},
+					{"update c1", "modified", int64(1), int64(10)},
+					{"create t", "added", nil, int64(1)},
+				},
+			},
+			{
+				Query: "SELECT message, diff_type, old_value, new_value FROM dolt_cell_history('t', 1, 'a', 'c2');",
+				Expected: []sql.Row{
+					{"update c2", "modified", "one", "uno"},
+					{"create t", "added", nil, "one"},
+				},
+			},
+			{
+				Query: "SELECT message, diff_type, old_value, new_value FROM dolt_cell_history('t', 2, 'b', 'c2');",
+				Expected: []sql.Row{
+					{"delete row 2", "removed", "two", nil},
+					{"create t", "added", nil, "two"},
+				},
+			},
+			{
+				Query: "SELECT message FROM dolt_cell_history('t', '1', 'a', 'C1') WHERE commit_hash = hashof('HEAD~2^2');",
+				Expected: []sql.Row{
+					{"update c1 on branch1"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_cell_history('t', 4, 'd', 'c1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "SELECT * FROM dolt_cell_history('t', 1, 'c1');",
+				ExpectedErrStr: "Invalid argument to dolt_cell_history: table t has 2 primary key columns, but 1 values were given",
+			},
+			{
+				Query:       "SELECT * FROM dolt_cell_history('t', 1, 'a', 'c3');",
+				ExpectedErr: sql.ErrTableColumnNotFound,
+			},
+			{
+				Query:       "SELECT * FROM dolt_cell_history('t2', 1, 'a', 'c1');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "SELECT * FROM dolt_cell_history('t', 'c1');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+		},
+	},
 	{
 		Name: "dolt_docs panic",
 		SetUpScript: []string{
@@ -3979,6 +4132,157 @@ var DoltBranchScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_branch_protection: require merge",
+		SetUpScript: []string{
+			"create table t (i int primary key);",
+			"call dolt_commit('-Am', 'add t');",
+			"call dolt_branch('feature');",
+			"insert into dolt_branch_protection (branch, require_merge) values ('main', true);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_branch_protection;",
+				Expected: []sql.Row{{"main", 1, 0, 0, nil}},
+			},
+			{
+				Query:    "insert into t values (1);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "call dolt_commit('-am', 'direct commit');",
+				ExpectedErrStr: "branch is protected: 'main' only accepts merges",
+			},
+			{
+				Query:    "call dolt_reset('--hard');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_checkout('feature');",
+				Expected: []sql.Row{{0, "Switched to branch 'feature'"}},
+			},
+			{
+				Query:    "insert into t values (2);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'commit on feature');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "call dolt_checkout('main');",
+				Expected: []sql.Row{{0, "Switched to branch 'main'"}},
+			},
+			{
+				// A fast-forward merge would make the head a commit made directly on the branch, so the merge is
+				// always made with a merge commit
+				Query:    "call dolt_merge('feature');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select count(*) from dolt_log;",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "call dolt_commit('--amend', '-m', 'amended merge');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+		},
+	},
+	{
+		Name: "dolt_branch_protection: block force updates and deletion",
+		SetUpScript: []string{
+			"create table t (i int primary key);",
+			"call dolt_commit('-Am', 'add t');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"call dolt_branch('other', 'HEAD~1');",
+			"call dolt_branch('protected');",
+			"insert into dolt_branch_protection values ('protected', false, true, true, null);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_branch('-D', 'protected');",
+				ExpectedErrStr: "branch is protected: cannot delete 'protected'",
+			},
+			{
+				Query:          "call dolt_branch('-m', 'protected', 'renamed');",
+				ExpectedErrStr: "branch is protected: cannot delete 'protected'",
+			},
+			{
+				Query:          "call dolt_branch('-f', 'protected', 'other');",
+				ExpectedErrStr: "branch is protected: cannot make a non-fast-forward update of 'protected'",
+			},
+			{
+				Query:          "call dolt_branch('-f', '-c', 'other', 'protected');",
+				ExpectedErrStr: "branch is protected: cannot make a non-fast-forward update of 'protected'",
+			},
+			{
+				Query:    "call dolt_checkout('protected');",
+				Expected: []sql.Row{{0, "Switched to branch 'protected'"}},
+			},
+			{
+				Query:          "call dolt_reset('--hard', 'HEAD~1');",
+				ExpectedErrStr: "branch is protected: cannot make a non-fast-forward update of 'protected'",
+			},
+			{
+				Query:          "call dolt_reset('--soft', 'HEAD~1');",
+				ExpectedErrStr: "branch is protected: cannot make a non-fast-forward update of 'protected'",
+			},
+			{
+				Query:          "call dolt_commit('--amend', '-m', 'amended');",
+				ExpectedErrStr: "branch is protected: cannot amend the head of 'protected'",
+			},
+			{
+				Query:    "insert into t values (2);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'insert 2');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "call dolt_checkout('main');",
+				Expected: []sql.Row{{0, "Switched to branch 'main'"}},
+			},
+			{
+				Query:    "update dolt_branch_protection set block_deletion = false where branch = 'PROTECTED';",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "call dolt_branch('-D', 'protected');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "delete from dolt_branch_protection;",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "select * from dolt_branch_protection;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "dolt_branch_protection: required workflows",
+		SetUpScript: []string{
+			"insert into dolt_branch_protection (branch, required_workflows) values ('main', 'tests, lint');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select branch, required_workflows from dolt_branch_protection;",
+				Expected: []sql.Row{{"main", "tests,lint"}},
+			},
+			{
+				Query:          "insert into dolt_branch_protection (branch) values ('MAIN');",
+				ExpectedErrStr: "duplicate primary key given: [\"MAIN\"]",
+			},
+		},
+	},
 }
 
 var DoltResetTestScripts = []queries.ScriptTest{
@@ -8097,7 +8401,9 @@ var DoltSystemVariables = []queries.ScriptTest{
 				Query: "SHOW TABLES;",
 				Expected: []sql.Row{
 					{"dolt_backups"},
+					{"dolt_branch_protection"},
 					{"dolt_branches"},
+					{"dolt_ci_runs"},
 					{"dolt_commit_ancestors"},
 					{"dolt_commit_diff_test"},
 					{"dolt_commits"},
@@ -8109,10 +8415,13 @@ var DoltSystemVariables = []queries.ScriptTest{
 					{"dolt_help"},
 					{"dolt_history_test"},
 					{"dolt_log"},
+					{"dolt_notes"},
 					{"dolt_remote_branches"},
 					{"dolt_remotes"},
+					{"dolt_slow_queries"},
 					{"dolt_stashes"},
 					{"dolt_status"},
+					{"dolt_storage_usage"},
 					{"dolt_workspace_test"},
 					{"test"},
 				},
@@ -59,6 +59,10 @@ var DoltHelpScripts = []queries.ScriptTest{
 			{"dolt_gc"},
 			{"dolt_stash"},
 			{"dolt_rebase"},
+			{"dolt_format_patch"},
+			{"dolt_am"},
+			{"dolt_bisect"},
+			{"dolt_notes"},
 		},
 	},
 	{
@@ -86,7 +86,11 @@ func (idt *IndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Partition)
 		}
 	}
 
-	return idt.lb.NewPartitionRowIter(ctx, part)
+	iter, err := idt.lb.NewPartitionRowIter(ctx, part)
+	if err != nil {
+		return nil, err
+	}
+	return countRowsExamined(ctx, iter), nil
 }
 
 func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
@@ -103,7 +107,11 @@ func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition
 		}
 	}
 
-	return idt.lb.NewPartitionRowIter(ctx, part)
+	iter, err := idt.lb.NewPartitionRowIter(ctx, part)
+	if err != nil {
+		return nil, err
+	}
+	return countRowsExamined(ctx, iter), nil
 }
 
 var _ sql.IndexedTable = (*WritableIndexedDoltTable)(nil)
@@ -172,7 +180,11 @@ func (t *WritableIndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Part
 		}
 	}
 
-	return t.lb.NewPartitionRowIter(ctx, part)
+	iter, err := t.lb.NewPartitionRowIter(ctx, part)
+	if err != nil {
+		return nil, err
+	}
+	return countRowsExamined(ctx, iter), nil
 }
 
 // WithProjections implements sql.ProjectedTable
@@ -21,16 +21,22 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 type remotesrvStore struct {
-	ctxFactory func(context.Context) (*sql.Context, error)
-	createDBs  bool
+	ctxFactory           func(context.Context) (*sql.Context, error)
+	createDBs            bool
+	checkBranchReads     bool
+	userBranchProtection bool
 }
 
 var _ remotesrv.DBCache = remotesrvStore{}
@@ -61,13 +67,44 @@ func (s remotesrvStore) Get(ctx context.Context, path, _ string) (remotesrv.Remo
 	if !ok {
 		return nil, remotesrv.ErrUnimplemented
 	}
+	if s.checkBranchReads {
+		err = checkAllBranchesReadable(sqlCtx, sdb)
+		if err != nil {
+			return nil, err
+		}
+	}
 	datasdb := doltdb.HackDatasDatabaseFromDoltDB(sdb.DbData().Ddb)
 	cs := datas.ChunkStoreFromDatabase(datasdb)
 	rss, ok := cs.(remotesrv.RemoteSrvStore)
 	if !ok {
 		return nil, remotesrv.ErrUnimplemented
 	}
-	return rss, nil
+	store := branchProtectedStore{RemoteSrvStore: rss, ddb: sdb.DbData().Ddb}
+	if s.userBranchProtection {
+		store.passed = sdb.DbData().Ddb.CIWorkflowPassed
+	}
+	return store, nil
+}
+
+// branchProtectedStore is a remotesrv.RemoteSrvStore which rejects root
+// updates that update a branch in a way its branch protection rule does not
+// allow.
+type branchProtectedStore struct {
+	remotesrv.RemoteSrvStore
+	ddb *doltdb.DoltDB
+	// passed is nil for cluster replication, which does not check workflow
+	// runs, since they are only recorded on the primary.
+	passed doltdb.WorkflowPassedFunc
+}
+
+func (s branchProtectedStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
+	err := s.ddb.CheckRootUpdate(ctx, last, current, s.passed == nil, s.passed)
+	if errors.Is(err, doltdb.ErrBranchProtected) {
+		return false, status.Error(codes.PermissionDenied, err.Error())
+	} else if err != nil {
+		return false, err
+	}
+	return s.RemoteSrvStore.Commit(ctx, current, last)
 }
 
 // In the SQL context, the database provider that we use to expose the
@@ -83,14 +120,56 @@ type CreateUnknownDatabasesSetting bool
 const CreateUnknownDatabases CreateUnknownDatabasesSetting = true
 const DoNotCreateUnknownDatabases CreateUnknownDatabasesSetting = false
 
+// A remotesapi client reads and writes the chunk store of a database
+// directly, which includes every branch of that database. When the
+// remotesapi endpoint is exposed to users, a user who has been denied
+// reading any branch of a database through branch_control therefore cannot
+// access that database at all. Cluster replication, which is not done on
+// behalf of a user, ignores branch permissions.
+
+type BranchPermissionsSetting bool
+
+const CheckBranchPermissions BranchPermissionsSetting = true
+const IgnoreBranchPermissions BranchPermissionsSetting = false
+
+// Writes through a remotesapi endpoint update branches directly, so they are
+// checked against the branch protection rules of the database. When the
+// endpoint is exposed to users, a write may not change the rules themselves,
+// and the CI workflows a rule requires must have passed. Cluster replication
+// copies the rules along with the branches, so on a standby only the
+// restrictions in effect both before and after a write are enforced.
+
+type BranchProtectionSetting bool
+
+const UserBranchProtection BranchProtectionSetting = true
+const ReplicatedBranchProtection BranchProtectionSetting = false
+
 // Returns a remotesrv.DBCache instance which will use the *sql.Context
 // returned from |ctxFactory| to access a database in the session
 // DatabaseProvider.
-func RemoteSrvDBCache(ctxFactory func(context.Context) (*sql.Context, error), createSetting CreateUnknownDatabasesSetting) (remotesrv.DBCache, error) {
-	dbcache := remotesrvStore{ctxFactory, bool(createSetting)}
+func RemoteSrvDBCache(ctxFactory func(context.Context) (*sql.Context, error), createSetting CreateUnknownDatabasesSetting, branchPermissionsSetting BranchPermissionsSetting, branchProtectionSetting BranchProtectionSetting) (remotesrv.DBCache, error) {
+	dbcache := remotesrvStore{ctxFactory, bool(createSetting), bool(branchPermissionsSetting), bool(branchProtectionSetting)}
 	return dbcache, nil
 }
 
+// checkAllBranchesReadable returns a PermissionDenied error if the user of
+// |ctx| cannot read every branch of |db|.
+func checkAllBranchesReadable(ctx *sql.Context, db dsess.SqlDatabase) error {
+	branches, err := db.DbData().Ddb.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+	for _, branch := range branches {
+		err = branch_control.CanReadBranch(ctx, db.Name(), branch.GetPath())
+		if branch_control.ErrCannotReadBranch.Is(err) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func WithUserPasswordAuth(args remotesrv.ServerArgs, authnz remotesrv.AccessControl) remotesrv.ServerArgs {
 	si := remotesrv.ServerInterceptor{
 		Lgr:              args.Logger,
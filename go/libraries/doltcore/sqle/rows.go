@@ -16,12 +16,14 @@ package sqle
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
@@ -177,6 +179,30 @@ func (itr *doltTableRowIter) Close(*sql.Context) error {
 	return nil
 }
 
+// rowsExaminedIter adds the rows returned by a table's row iterator to a session's count of rows examined.
+type rowsExaminedIter struct {
+	sql.RowIter
+	counter *atomic.Int64
+}
+
+// countRowsExamined wraps |iter| so that the rows it returns are counted, if the session is counting the rows its
+// queries read from tables. See dsess.DoltSession.EnableRowsExaminedCounter.
+func countRowsExamined(ctx *sql.Context, iter sql.RowIter) sql.RowIter {
+	sess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok || sess.RowsExaminedCounter() == nil {
+		return iter
+	}
+	return &rowsExaminedIter{RowIter: iter, counter: sess.RowsExaminedCounter()}
+}
+
+func (itr *rowsExaminedIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := itr.RowIter.Next(ctx)
+	if err == nil {
+		itr.counter.Add(1)
+	}
+	return r, err
+}
+
 func ProllyRowIterFromPartition(
 	ctx context.Context,
 	sch schema.Schema,
@@ -629,6 +629,7 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 	if err != nil {
 		return originalRowIter, err
 	}
+	originalRowIter = countRowsExamined(ctx, originalRowIter)
 
 	if t.overriddenSchema != nil {
 		return newMappingRowIter(ctx, t, originalRowIter)
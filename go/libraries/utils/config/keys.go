@@ -31,6 +31,13 @@ var ConfigOptions = map[string]struct{}{
 	PushAutoSetupRemote:   {},
 	ProfileKey:            {},
 	VersionCheckDisabled:  {},
+	PreCommitHookSqlKey:   {},
+	PreCommitHookExecKey:  {},
+	PreMergeHookSqlKey:    {},
+	PreMergeHookExecKey:   {},
+	PostCommitHookSqlKey:  {},
+	PostCommitHookExecKey: {},
+	LazyCloneRemoteKey:    {},
 }
 
 const UserEmailKey = "user.email"
@@ -68,3 +75,17 @@ const VersionCheckDisabled = "versioncheck.disabled"
 const SignCommitsKey = "commit.gpgsign"
 
 const GPGSigningKeyKey = "user.signingkey"
+
+const PreCommitHookSqlKey = "hooks.pre-commit.sql"
+
+const PreCommitHookExecKey = "hooks.pre-commit.exec"
+
+const PreMergeHookSqlKey = "hooks.pre-merge.sql"
+
+const PreMergeHookExecKey = "hooks.pre-merge.exec"
+
+const PostCommitHookSqlKey = "hooks.post-commit.sql"
+
+const PostCommitHookExecKey = "hooks.post-commit.exec"
+
+const LazyCloneRemoteKey = "clone.lazyremote"
@@ -0,0 +1,353 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package streammux multiplexes streams over a single connection, such as the stdin and stdout of a process. The
+// client side of a Session opens streams, and the server side accepts them. Each stream is a net.Conn.
+//
+// Every frame on the connection has a 9 byte header: a frame type, a big-endian uint32 stream id and a big-endian
+// uint32 payload length. Only data frames have a payload. Streams are not flow controlled, so data received for a
+// stream is buffered until it is read.
+package streammux
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	frameOpen  byte = 1
+	frameData  byte = 2
+	frameClose byte = 3
+
+	frameHeaderSize = 9
+	maxPayloadSize  = 64 * 1024
+)
+
+// ErrSessionClosed is returned when opening or accepting streams on a closed Session.
+var ErrSessionClosed = errors.New("streammux: session closed")
+
+// Session multiplexes streams over a connection.
+type Session struct {
+	conn     io.ReadWriteCloser
+	isClient bool
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	streams map[uint32]*stream
+	nextID  uint32
+	closed  bool
+	accept  chan *stream
+	done    chan struct{}
+}
+
+// Client returns a Session which opens streams over |conn|.
+func Client(conn io.ReadWriteCloser) *Session {
+	return newSession(conn, true)
+}
+
+// Server returns a Session which accepts streams opened over |conn|.
+func Server(conn io.ReadWriteCloser) *Session {
+	return newSession(conn, false)
+}
+
+func newSession(conn io.ReadWriteCloser, isClient bool) *Session {
+	s := &Session{
+		conn:     conn,
+		isClient: isClient,
+		streams:  make(map[uint32]*stream),
+		nextID:   1,
+		accept:   make(chan *stream, 16),
+		done:     make(chan struct{}),
+	}
+	go s.readLoop()
+	return s
+}
+
+// Open opens a new stream. It may only be called on client Sessions.
+func (s *Session) Open() (net.Conn, error) {
+	if !s.isClient {
+		return nil, errors.New("streammux: only clients can open streams")
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, ErrSessionClosed
+	}
+	st := s.newStream(s.nextID)
+	s.nextID++
+	s.mu.Unlock()
+
+	if err := s.writeFrame(frameOpen, st.id, nil); err != nil {
+		st.Close()
+		return nil, err
+	}
+	return st, nil
+}
+
+// Accept waits for the client to open a stream. It may only be called on server Sessions. Session implements
+// net.Listener, so that servers can accept streams like connections.
+func (s *Session) Accept() (net.Conn, error) {
+	select {
+	case st := <-s.accept:
+		return st, nil
+	case <-s.done:
+		return nil, ErrSessionClosed
+	}
+}
+
+// Addr implements net.Listener.
+func (s *Session) Addr() net.Addr {
+	return muxAddr{}
+}
+
+// Close closes the Session, its streams and the underlying connection.
+func (s *Session) Close() error {
+	s.shutdown()
+	return s.conn.Close()
+}
+
+// Done returns a channel which is closed when the Session is closed, either by Close or because the underlying
+// connection was closed by the other side.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Session) shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	for _, st := range s.streams {
+		st.closeRead()
+	}
+	close(s.done)
+}
+
+// newStream must be called with |s.mu| held.
+func (s *Session) newStream(id uint32) *stream {
+	st := &stream{session: s, id: id}
+	st.cond = sync.NewCond(&st.mu)
+	s.streams[id] = st
+	return st
+}
+
+func (s *Session) readLoop() {
+	var hdr [frameHeaderSize]byte
+	for {
+		if _, err := io.ReadFull(s.conn, hdr[:]); err != nil {
+			s.shutdown()
+			return
+		}
+		typ := hdr[0]
+		id := binary.BigEndian.Uint32(hdr[1:5])
+		n := binary.BigEndian.Uint32(hdr[5:9])
+		if n > maxPayloadSize {
+			s.shutdown()
+			return
+		}
+		var payload []byte
+		if n > 0 {
+			payload = make([]byte, n)
+			if _, err := io.ReadFull(s.conn, payload); err != nil {
+				s.shutdown()
+				return
+			}
+		}
+
+		s.mu.Lock()
+		st := s.streams[id]
+		if typ == frameOpen && st == nil && !s.isClient && !s.closed {
+			st = s.newStream(id)
+			s.mu.Unlock()
+			select {
+			case s.accept <- st:
+			case <-s.done:
+			}
+			continue
+		}
+		s.mu.Unlock()
+
+		// frames for streams which were closed locally are dropped
+		if st == nil {
+			continue
+		}
+		switch typ {
+		case frameData:
+			st.push(payload)
+		case frameClose:
+			st.closeRead()
+		}
+	}
+}
+
+func (s *Session) writeFrame(typ byte, id uint32, payload []byte) error {
+	var hdr [frameHeaderSize]byte
+	hdr[0] = typ
+	binary.BigEndian.PutUint32(hdr[1:5], id)
+	binary.BigEndian.PutUint32(hdr[5:9], uint32(len(payload)))
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	select {
+	case <-s.done:
+		return ErrSessionClosed
+	default:
+	}
+	if _, err := s.conn.Write(hdr[:]); err != nil {
+		return err
+	}
+	if len(payload) > 0 {
+		if _, err := s.conn.Write(payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Session) removeStream(id uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.streams, id)
+}
+
+// stream is a net.Conn for one stream of a Session. Deadlines are not supported.
+type stream struct {
+	session *Session
+	id      uint32
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	buf      [][]byte
+	readEOF  bool
+	closed   bool
+	closeErr error
+	once     sync.Once
+}
+
+var _ net.Conn = (*stream)(nil)
+
+func (st *stream) push(p []byte) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if !st.closed {
+		st.buf = append(st.buf, p)
+		st.cond.Broadcast()
+	}
+}
+
+func (st *stream) closeRead() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.readEOF = true
+	st.cond.Broadcast()
+}
+
+func (st *stream) Read(p []byte) (int, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for len(st.buf) == 0 && !st.readEOF && !st.closed {
+		st.cond.Wait()
+	}
+	if st.closed {
+		return 0, net.ErrClosed
+	}
+	if len(st.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, st.buf[0])
+	if n == len(st.buf[0]) {
+		st.buf[0] = nil
+		st.buf = st.buf[1:]
+	} else {
+		st.buf[0] = st.buf[0][n:]
+	}
+	return n, nil
+}
+
+func (st *stream) Write(p []byte) (int, error) {
+	st.mu.Lock()
+	closed := st.closed
+	st.mu.Unlock()
+	if closed {
+		return 0, net.ErrClosed
+	}
+
+	var written int
+	for len(p) > 0 {
+		n := len(p)
+		if n > maxPayloadSize {
+			n = maxPayloadSize
+		}
+		if err := st.session.writeFrame(frameData, st.id, p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func (st *stream) Close() error {
+	st.once.Do(func() {
+		st.mu.Lock()
+		st.closed = true
+		st.buf = nil
+		st.cond.Broadcast()
+		st.mu.Unlock()
+
+		st.session.removeStream(st.id)
+		err := st.session.writeFrame(frameClose, st.id, nil)
+		if err != nil && !errors.Is(err, ErrSessionClosed) {
+			st.closeErr = err
+		}
+	})
+	return st.closeErr
+}
+
+func (st *stream) LocalAddr() net.Addr {
+	return muxAddr{}
+}
+
+func (st *stream) RemoteAddr() net.Addr {
+	return muxAddr{}
+}
+
+func (st *stream) SetDeadline(t time.Time) error {
+	return nil
+}
+
+func (st *stream) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func (st *stream) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+type muxAddr struct{}
+
+func (muxAddr) Network() string {
+	return "streammux"
+}
+
+func (muxAddr) String() string {
+	return "streammux"
+}
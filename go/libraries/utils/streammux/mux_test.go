@@ -0,0 +1,126 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streammux
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSessions(t *testing.T) (*Session, *Session) {
+	c, s := net.Pipe()
+	client, server := Client(c), Server(s)
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return client, server
+}
+
+// echo accepts streams on |server| and echoes everything written to them until they are closed.
+func echo(server *Session) {
+	for {
+		conn, err := server.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			_, _ = io.Copy(conn, conn)
+		}()
+	}
+}
+
+func TestStreams(t *testing.T) {
+	client, server := newTestSessions(t)
+	go echo(server)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := client.Open()
+			require.NoError(t, err)
+
+			// larger than a single frame
+			data := make([]byte, 3*maxPayloadSize+17)
+			_, err = rand.Read(data)
+			require.NoError(t, err)
+			go func() {
+				_, _ = conn.Write(data)
+			}()
+
+			actual := make([]byte, len(data))
+			_, err = io.ReadFull(conn, actual)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(data, actual))
+			require.NoError(t, conn.Close())
+
+			_, err = conn.Write([]byte("closed"))
+			assert.ErrorIs(t, err, net.ErrClosed)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStreamCloseIsEOF(t *testing.T) {
+	client, server := newTestSessions(t)
+
+	conn, err := client.Open()
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	accepted, err := server.Accept()
+	require.NoError(t, err)
+	data, err := io.ReadAll(accepted)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	require.NoError(t, accepted.Close())
+}
+
+func TestSessionClose(t *testing.T) {
+	client, server := newTestSessions(t)
+
+	conn, err := client.Open()
+	require.NoError(t, err)
+	accepted, err := server.Accept()
+	require.NoError(t, err)
+
+	// closing the client closes the server, and the streams of both
+	require.NoError(t, client.Close())
+	<-server.Done()
+	_, err = io.ReadAll(accepted)
+	assert.NoError(t, err)
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+
+	_, err = client.Open()
+	assert.ErrorIs(t, err, ErrSessionClosed)
+	_, err = server.Accept()
+	assert.ErrorIs(t, err, ErrSessionClosed)
+
+	_, err = server.Open()
+	assert.Error(t, err)
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// azureUploadBlockSize is the size of the blocks staged when uploading a blob
+	azureUploadBlockSize = 8 * 1024 * 1024
+
+	// azureCopyBlockSize is the largest range of a source blob which is staged as a single block by Concatenate
+	azureCopyBlockSize = 100 * 1024 * 1024
+
+	// azureCopySASExpiry is how long the urls used to read the sources of Concatenate are valid for
+	azureCopySASExpiry = time.Hour
+
+	// azureStorageScope is the scope of the Microsoft Entra ID tokens which authorize requests to Azure Storage
+	azureStorageScope = "https://storage.azure.com/.default"
+)
+
+// AzureBlobstore provides an Azure Blob Storage implementation of the Blobstore interface. Blobs are stored as block
+// blobs, and their ETags are used as their versions.
+type AzureBlobstore struct {
+	client        *container.Client
+	cred          azcore.TokenCredential
+	containerName string
+	prefix        string
+}
+
+var _ Blobstore = &AzureBlobstore{}
+
+// NewAzureBlobstore creates a new instance of an AzureBlobstore
+func NewAzureBlobstore(client *container.Client, containerName, prefix string) *AzureBlobstore {
+	for len(prefix) > 0 && prefix[0] == '/' {
+		prefix = prefix[1:]
+	}
+
+	return &AzureBlobstore{client, nil, containerName, prefix}
+}
+
+// NewAzureBlobstoreWithTokenCredential creates a new instance of an AzureBlobstore whose |client| is authorized with
+// the Microsoft Entra ID token credential |cred|. |cred| also authorizes the service to read the sources of
+// Concatenate, which it can not do with the client's credential.
+func NewAzureBlobstoreWithTokenCredential(client *container.Client, cred azcore.TokenCredential, containerName, prefix string) *AzureBlobstore {
+	bs := NewAzureBlobstore(client, containerName, prefix)
+	bs.cred = cred
+	return bs
+}
+
+func (bs *AzureBlobstore) Path() string {
+	return path.Join(bs.containerName, bs.prefix)
+}
+
+func (bs *AzureBlobstore) blobClient(key string) *blockblob.Client {
+	return bs.client.NewBlockBlobClient(path.Join(bs.prefix, key))
+}
+
+func (bs *AzureBlobstore) absKey(key string) string {
+	return "az://" + path.Join(bs.containerName, bs.prefix, key)
+}
+
+// Exists returns true if a blob exists for the given key, and false if it does not.
+func (bs *AzureBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := bs.blobClient(key).GetProperties(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Get retrieves an io.reader for the portion of a blob specified by br along with
+// its version
+func (bs *AzureBlobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	bc := bs.blobClient(key)
+	opts := &blob.DownloadStreamOptions{}
+	if !br.isAllRange() {
+		if br.offset < 0 {
+			// Azure does not support suffix ranges, so the size of the blob is needed to
+			// find the offset, and the read is made conditional on the blob not changing.
+			props, err := bc.GetProperties(ctx, nil)
+			if bloberror.HasCode(err, bloberror.BlobNotFound) {
+				return nil, "", NotFound{bs.absKey(key)}
+			} else if err != nil {
+				return nil, "", err
+			}
+			br = br.positiveRange(*props.ContentLength)
+			opts.AccessConditions = &blob.AccessConditions{
+				ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfMatch: props.ETag},
+			}
+		}
+		opts.Range = blob.HTTPRange{Offset: br.offset, Count: br.length}
+	}
+
+	resp, err := bc.DownloadStream(ctx, opts)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, "", NotFound{bs.absKey(key)}
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	return resp.Body, fmtETag(resp.ETag), nil
+}
+
+// Put sets the blob and the version for a key
+func (bs *AzureBlobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	return bs.upload(ctx, key, reader, nil)
+}
+
+// CheckAndPut will check the current version of a blob against an expectedVersion, and if the
+// versions match it will update the data and version associated with the key
+func (bs *AzureBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	conds := &blob.ModifiedAccessConditions{}
+	if expectedVersion != "" {
+		conds.IfMatch = to.Ptr(azcore.ETag(expectedVersion))
+	} else {
+		conds.IfNoneMatch = to.Ptr(azcore.ETagAny)
+	}
+
+	ver, err := bs.upload(ctx, key, reader, &blob.AccessConditions{ModifiedAccessConditions: conds})
+	if bloberror.HasCode(err, bloberror.ConditionNotMet, bloberror.BlobAlreadyExists, bloberror.BlobNotFound) {
+		return "", CheckAndPutError{key, expectedVersion, "unknown (Not supported in Azure implementation)"}
+	}
+
+	return ver, err
+}
+
+func (bs *AzureBlobstore) upload(ctx context.Context, key string, reader io.Reader, conds *blob.AccessConditions) (string, error) {
+	resp, err := bs.blobClient(key).UploadStream(ctx, reader, &blockblob.UploadStreamOptions{
+		BlockSize:        azureUploadBlockSize,
+		AccessConditions: conds,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmtETag(resp.ETag), nil
+}
+
+// Concatenate creates a new blob named |key| from the contents of |sources|. Each source is copied into a block
+// of the new blob on the server, and the blocks are then committed together as the blob's block list.
+func (bs *AzureBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	type blockRange struct {
+		source string
+		rng    blob.HTTPRange
+	}
+
+	// find the ranges of each source to copy, as a single block can only be copied from a limited range
+	sizes := make([]int64, len(sources))
+	eg, ectx := errgroup.WithContext(ctx)
+	for i := range sources {
+		idx := i
+		eg.Go(func() error {
+			props, err := bs.blobClient(sources[idx]).GetProperties(ectx, nil)
+			if bloberror.HasCode(err, bloberror.BlobNotFound) {
+				return NotFound{bs.absKey(sources[idx])}
+			} else if err != nil {
+				return err
+			}
+			sizes[idx] = *props.ContentLength
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return "", err
+	}
+
+	var ranges []blockRange
+	for i, src := range sources {
+		for off := int64(0); off < sizes[i]; off += azureCopyBlockSize {
+			ranges = append(ranges, blockRange{src, blob.HTTPRange{Offset: off, Count: min(azureCopyBlockSize, sizes[i]-off)}})
+		}
+	}
+
+	// block ids must all have the same length, and be unique among the blob's uncommitted blocks
+	blockPrefix := uuid.New().String()
+	ids := make([]string, len(ranges))
+	for i := range ranges {
+		ids[i] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s-%08d", blockPrefix, i)))
+	}
+
+	dest := bs.blobClient(key)
+	eg, ectx = errgroup.WithContext(ctx)
+	for i := range ranges {
+		idx := i
+		eg.Go(func() error {
+			srcURL, srcAuth, err := bs.copySource(ectx, ranges[idx].source)
+			if err != nil {
+				return err
+			}
+			_, err = dest.StageBlockFromURL(ectx, ids[idx], srcURL, &blockblob.StageBlockFromURLOptions{
+				Range:                   ranges[idx].rng,
+				CopySourceAuthorization: srcAuth,
+			})
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return "", err
+	}
+
+	resp, err := dest.CommitBlockList(ctx, ids, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return fmtETag(resp.ETag), nil
+}
+
+// copySource returns a url that the service can read the blob |key| from when copying it, and the authorization
+// for the read, if any. If the client is authorized with a shared key, the url is signed with a short-lived SAS
+// token. If it is authorized with a token credential, the read is authorized with a bearer token. Otherwise, the
+// client's url is used, which carries the SAS token the client was created with, if any.
+func (bs *AzureBlobstore) copySource(ctx context.Context, key string) (string, *string, error) {
+	bc := bs.blobClient(key)
+	srcURL, err := bc.GetSASURL(sas.BlobPermissions{Read: true}, time.Now().Add(azureCopySASExpiry), nil)
+	if err != bloberror.MissingSharedKeyCredential {
+		return srcURL, nil, err
+	}
+	if bs.cred == nil {
+		return bc.URL(), nil, nil
+	}
+
+	tok, err := bs.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{azureStorageScope}})
+	if err != nil {
+		return "", nil, err
+	}
+	return bc.URL(), to.Ptr("Bearer " + tok.Token), nil
+}
+
+func fmtETag(etag *azcore.ETag) string {
+	if etag == nil {
+		return ""
+	}
+
+	return string(*etag)
+}
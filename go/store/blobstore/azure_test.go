@@ -0,0 +1,368 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeAzureAccount   = "devstoreaccount1"
+	fakeAzureContainer = "dolt"
+)
+
+// fakeAzureServer is an in-process fake of the parts of the Azure Blob Storage REST API used by AzureBlobstore:
+// Get Blob, Get Blob Properties, Put Blob, Put Block, Put Block From URL and Put Block List. Requests are not
+// authenticated, but the reads of Put Block From URL can be required to be authorized.
+type fakeAzureServer struct {
+	mu          sync.Mutex
+	blobs       map[string]*fakeAzureBlob
+	uncommitted map[string]map[string][]byte
+	etags       int
+
+	// copySourceAuth, if set, is the authorization Put Block From URL requires to read sources which are not
+	// signed with a SAS token.
+	copySourceAuth string
+}
+
+type fakeAzureBlob struct {
+	data   []byte
+	etag   string
+	blocks map[string][]byte
+}
+
+func newFakeAzureServer() *fakeAzureServer {
+	return &fakeAzureServer{
+		blobs:       make(map[string]*fakeAzureBlob),
+		uncommitted: make(map[string]map[string][]byte),
+	}
+}
+
+// newFakeAzureBlobstore starts a fakeAzureServer and returns an AzureBlobstore using it.
+func newFakeAzureBlobstore(t *testing.T, prefix string) *AzureBlobstore {
+	srv := httptest.NewServer(newFakeAzureServer())
+	if t != nil {
+		t.Cleanup(srv.Close)
+	}
+
+	cred, err := container.NewSharedKeyCredential(fakeAzureAccount, base64.StdEncoding.EncodeToString([]byte("fake account key")))
+	if err != nil {
+		panic(err)
+	}
+	client, err := container.NewClientWithSharedKeyCredential(srv.URL+"/"+fakeAzureAccount+"/"+fakeAzureContainer, cred, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewAzureBlobstore(client, fakeAzureContainer, prefix)
+}
+
+func (s *fakeAzureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.URL.Path
+	switch {
+	case r.Method == http.MethodHead:
+		s.getBlob(w, r, key, false)
+	case r.Method == http.MethodGet:
+		s.getBlob(w, r, key, true)
+	case r.Method == http.MethodPut && r.URL.Query().Get("comp") == "block":
+		s.putBlock(w, r, key)
+	case r.Method == http.MethodPut && r.URL.Query().Get("comp") == "blocklist":
+		s.putBlockList(w, r, key)
+	case r.Method == http.MethodPut && r.URL.Query().Get("comp") == "":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+		s.commit(w, r, key, body, nil)
+	default:
+		writeFakeAzureError(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+	}
+}
+
+func (s *fakeAzureServer) getBlob(w http.ResponseWriter, r *http.Request, key string, withBody bool) {
+	b, ok := s.blobs[key]
+	if !ok {
+		writeFakeAzureError(w, http.StatusNotFound, "BlobNotFound")
+		return
+	}
+	if m := r.Header.Get("If-Match"); m != "" && m != b.etag {
+		writeFakeAzureError(w, http.StatusPreconditionFailed, "ConditionNotMet")
+		return
+	}
+
+	data := b.data
+	status := http.StatusOK
+	rng := r.Header.Get("x-ms-range")
+	if rng == "" {
+		rng = r.Header.Get("Range")
+	}
+	if rng != "" {
+		start, end, ok := parseFakeAzureRange(rng, int64(len(data)))
+		if !ok {
+			writeFakeAzureError(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, len(data)))
+		data = data[start:end]
+		status = http.StatusPartialContent
+	}
+
+	w.Header().Set("ETag", b.etag)
+	w.Header().Set("x-ms-blob-type", "BlockBlob")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(status)
+	if withBody {
+		w.Write(data)
+	}
+}
+
+func (s *fakeAzureServer) putBlock(w http.ResponseWriter, r *http.Request, key string) {
+	id := r.URL.Query().Get("blockid")
+	var data []byte
+	if src := r.Header.Get("x-ms-copy-source"); src != "" {
+		srcURL, err := url.Parse(src)
+		if err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidHeaderValue")
+			return
+		}
+		if s.copySourceAuth != "" && srcURL.Query().Get("sig") == "" && r.Header.Get("x-ms-copy-source-authorization") != s.copySourceAuth {
+			writeFakeAzureError(w, http.StatusUnauthorized, "CannotVerifyCopySource")
+			return
+		}
+		b, ok := s.blobs[srcURL.Path]
+		if !ok {
+			writeFakeAzureError(w, http.StatusNotFound, "CannotVerifyCopySource")
+			return
+		}
+		data = b.data
+		if rng := r.Header.Get("x-ms-source-range"); rng != "" {
+			start, end, ok := parseFakeAzureRange(rng, int64(len(data)))
+			if !ok {
+				writeFakeAzureError(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+				return
+			}
+			data = data[start:end]
+		}
+	} else {
+		var err error
+		if data, err = io.ReadAll(r.Body); err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+	}
+
+	if s.uncommitted[key] == nil {
+		s.uncommitted[key] = make(map[string][]byte)
+	}
+	s.uncommitted[key][id] = bytes.Clone(data)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *fakeAzureServer) putBlockList(w http.ResponseWriter, r *http.Request, key string) {
+	var committed map[string][]byte
+	if b, ok := s.blobs[key]; ok {
+		committed = b.blocks
+	}
+	uncommitted := s.uncommitted[key]
+
+	// the order of the block list is the order of the blob's blocks, whichever kind of element lists them
+	dec := xml.NewDecoder(r.Body)
+	var data []byte
+	blocks := make(map[string][]byte)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidXmlDocument")
+			return
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local == "BlockList" {
+			continue
+		}
+		var id string
+		if err = dec.DecodeElement(&id, &start); err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidXmlDocument")
+			return
+		}
+
+		var block []byte
+		switch start.Name.Local {
+		case "Committed":
+			block, ok = committed[id]
+		case "Uncommitted":
+			block, ok = uncommitted[id]
+		default:
+			if block, ok = uncommitted[id]; !ok {
+				block, ok = committed[id]
+			}
+		}
+		if !ok {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidBlockList")
+			return
+		}
+		blocks[id] = block
+		data = append(data, block...)
+	}
+
+	if s.commit(w, r, key, data, blocks) {
+		delete(s.uncommitted, key)
+	}
+}
+
+// commit replaces the blob |key| with |data| if the request's conditions are met.
+func (s *fakeAzureServer) commit(w http.ResponseWriter, r *http.Request, key string, data []byte, blocks map[string][]byte) bool {
+	b, exists := s.blobs[key]
+	if m := r.Header.Get("If-Match"); m != "" && (!exists || (m != "*" && m != b.etag)) {
+		writeFakeAzureError(w, http.StatusPreconditionFailed, "ConditionNotMet")
+		return false
+	}
+	if m := r.Header.Get("If-None-Match"); m != "" && exists && (m == "*" || m == b.etag) {
+		writeFakeAzureError(w, http.StatusConflict, "BlobAlreadyExists")
+		return false
+	}
+
+	s.etags++
+	etag := fmt.Sprintf("\"0x%X\"", s.etags)
+	s.blobs[key] = &fakeAzureBlob{data: data, etag: etag, blocks: blocks}
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusCreated)
+	return true
+}
+
+// parseFakeAzureRange parses a range of the form bytes=start-[end] into the offsets [start, end) of a blob of |size|.
+func parseFakeAzureRange(rng string, size int64) (int64, int64, bool) {
+	startStr, endStr, ok := strings.Cut(strings.TrimPrefix(rng, "bytes="), "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start >= size {
+		return 0, 0, false
+	}
+	end := size
+	if endStr != "" {
+		last, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || last < start {
+			return 0, 0, false
+		}
+		end = min(last+1, size)
+	}
+	return start, end, true
+}
+
+func writeFakeAzureError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"utf-8\"?><Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func TestAzureBlobstorePath(t *testing.T) {
+	bs := newFakeAzureBlobstore(t, "/some/prefix")
+	assert.Equal(t, "dolt/some/prefix", bs.Path())
+
+	_, _, err := bs.Get(context.Background(), "missing", AllRange)
+	require.True(t, IsNotFoundError(err))
+	assert.Equal(t, "az://dolt/some/prefix/missing", err.(NotFound).Key)
+}
+
+func TestAzureBlobstoreConcatenateMultipleBlocks(t *testing.T) {
+	ctx := context.Background()
+	bs := newFakeAzureBlobstore(t, "")
+
+	// blobs larger than the upload block size are uploaded as several blocks
+	large := randBytes(azureUploadBlockSize + 1024)
+	_, err := PutBytes(ctx, bs, "large", large)
+	require.NoError(t, err)
+	small := randBytes(64)
+	_, err = PutBytes(ctx, bs, "small", small)
+	require.NoError(t, err)
+
+	ver, err := bs.Concatenate(ctx, "composite", []string{"small", "large", "small"})
+	require.NoError(t, err)
+
+	data, getVer, err := GetBytes(ctx, bs, "composite", AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, ver, getVer)
+	assert.Equal(t, append(append(bytes.Clone(small), large...), small...), data)
+
+	_, err = bs.Concatenate(ctx, "composite", []string{"small", "missing"})
+	assert.True(t, IsNotFoundError(err))
+}
+
+type fakeAzureTokenCredential string
+
+func (c fakeAzureTokenCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: string(c), ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestAzureBlobstoreConcatenateWithTokenCredential(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeAzureServer()
+	fake.copySourceAuth = "Bearer fake-token"
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	cred := fakeAzureTokenCredential("fake-token")
+	client, err := container.NewClient(srv.URL+"/"+fakeAzureAccount+"/"+fakeAzureContainer, cred, &container.ClientOptions{
+		ClientOptions: azcore.ClientOptions{InsecureAllowCredentialWithHTTP: true},
+	})
+	require.NoError(t, err)
+
+	for _, bs := range []*AzureBlobstore{
+		NewAzureBlobstore(client, fakeAzureContainer, "without"),
+		NewAzureBlobstoreWithTokenCredential(client, cred, fakeAzureContainer, "with"),
+	} {
+		_, err = PutBytes(ctx, bs, "a", []byte("abc"))
+		require.NoError(t, err)
+		_, err = PutBytes(ctx, bs, "b", []byte("def"))
+		require.NoError(t, err)
+		_, err = bs.Concatenate(ctx, "composite", []string{"a", "b"})
+		if bs.cred == nil {
+			// the service can not read the sources with the client's credential alone
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		data, _, err := GetBytes(ctx, bs, "composite", AllRange)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("abcdef"), data)
+	}
+}
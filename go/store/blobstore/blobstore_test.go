@@ -20,6 +20,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"hash/maphash"
+	"io"
 	"log"
 	"math/rand"
 	"os"
@@ -113,12 +114,17 @@ func appendLocalTest(tests []BlobstoreTest) []BlobstoreTest {
 	return append(tests, BlobstoreTest{"local", NewLocalBlobstore(dir), 10, 20})
 }
 
+func appendAzureTest(tests []BlobstoreTest) []BlobstoreTest {
+	return append(tests, BlobstoreTest{"azure", newFakeAzureBlobstore(nil, uuid.New().String()+"/"), 10, 20})
+}
+
 func newBlobStoreTests() []BlobstoreTest {
 	var tests []BlobstoreTest
 	tests = append(tests, BlobstoreTest{"inmem", NewInMemoryBlobstore(""), 10, 20})
 	tests = appendLocalTest(tests)
 	tests = appendGCSTest(tests)
 	tests = appendOCITest(tests)
+	tests = appendAzureTest(tests)
 
 	return tests
 }
@@ -460,7 +466,7 @@ func testConcatenate(t *testing.T, bs Blobstore, cnt int) {
 		assert.NoError(t, err)
 
 		act := make([]byte, length)
-		n, err := rdr.Read(act)
+		n, err := io.ReadFull(rdr, act)
 		assert.NoError(t, err)
 		assert.Equal(t, int(length), n)
 		assert.Equal(t, blobs[i].data, act)
@@ -0,0 +1,367 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// s3MinPartSize is the smallest part, other than the last one, that S3 accepts in a multipart upload
+	s3MinPartSize = 5 * 1024 * 1024
+
+	// s3MaxPartSize is the largest part that S3 accepts in a multipart upload
+	s3MaxPartSize = 5 * 1024 * 1024 * 1024
+
+	// s3ConcatenateConcurrency is the number of parts that Concatenate uploads at a time
+	s3ConcatenateConcurrency = 8
+)
+
+// S3API is the subset of the S3 client used by S3Blobstore
+type S3API interface {
+	manager.UploadAPIClient
+	GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(context.Context, *s3.HeadObjectInput, ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	UploadPartCopy(context.Context, *s3.UploadPartCopyInput, ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error)
+}
+
+var _ S3API = (*s3.Client)(nil)
+
+// S3Blobstore provides an S3 implementation of the Blobstore interface. It only relies on features which are
+// also provided by other S3 compatible object stores, and uses the ETags of objects as their versions, with
+// conditional writes for CheckAndPut.
+type S3Blobstore struct {
+	client S3API
+	bucket string
+	prefix string
+}
+
+var _ Blobstore = &S3Blobstore{}
+
+// NewS3Blobstore creates a new instance of an S3Blobstore
+func NewS3Blobstore(client S3API, bucket, prefix string) *S3Blobstore {
+	for len(prefix) > 0 && prefix[0] == '/' {
+		prefix = prefix[1:]
+	}
+
+	return &S3Blobstore{client, bucket, prefix}
+}
+
+func (bs *S3Blobstore) Path() string {
+	return path.Join(bs.bucket, bs.prefix)
+}
+
+func (bs *S3Blobstore) key(key string) string {
+	return path.Join(bs.prefix, key)
+}
+
+func (bs *S3Blobstore) absKey(key string) string {
+	return "s3://" + path.Join(bs.bucket, bs.prefix, key)
+}
+
+// Exists returns true if a blob exists for the given key, and false if it does not.
+func (bs *S3Blobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := bs.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(bs.key(key)),
+	})
+	if isS3NotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Get retrieves an io.reader for the portion of a blob specified by br along with
+// its version
+func (bs *S3Blobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(bs.key(key)),
+	}
+	if !br.isAllRange() {
+		if br.offset < 0 && br.length > 0 {
+			// a suffix range can't be limited in length, so the size of the object is needed to find
+			// the offset, and the read is made conditional on the object not changing.
+			head, err := bs.client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: aws.String(bs.bucket),
+				Key:    aws.String(bs.key(key)),
+			})
+			if isS3NotFound(err) {
+				return nil, "", NotFound{bs.absKey(key)}
+			} else if err != nil {
+				return nil, "", err
+			}
+			br = br.positiveRange(aws.ToInt64(head.ContentLength))
+			input.IfMatch = head.ETag
+		}
+		input.Range = aws.String(s3RangeHeader(br))
+	}
+
+	out, err := bs.client.GetObject(ctx, input)
+	if isS3NotFound(err) {
+		return nil, "", NotFound{bs.absKey(key)}
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	return out.Body, aws.ToString(out.ETag), nil
+}
+
+// Put sets the blob and the version for a key
+func (bs *S3Blobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	uploader := manager.NewUploader(bs.client, func(u *manager.Uploader) {
+		u.PartSize = s3MinPartSize
+	})
+	out, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(bs.key(key)),
+		Body:   reader,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.ToString(out.ETag), nil
+}
+
+// CheckAndPut will check the current version of a blob against an expectedVersion, and if the
+// versions match it will update the data and version associated with the key
+func (bs *S3Blobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	// conditional writes are only supported for single part uploads, so the blob is read into memory
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(bs.bucket),
+		Key:           aws.String(bs.key(key)),
+		Body:          bytes.NewReader(data),
+		ContentLength: aws.Int64(int64(len(data))),
+	}
+	if expectedVersion != "" {
+		input.IfMatch = aws.String(expectedVersion)
+	} else {
+		input.IfNoneMatch = aws.String("*")
+	}
+
+	out, err := bs.client.PutObject(ctx, input)
+	if code := s3ErrorCode(err); code == "PreconditionFailed" || code == "ConditionalRequestConflict" || isS3NotFound(err) {
+		return "", CheckAndPutError{key, expectedVersion, "unknown (Not supported in S3 implementation)"}
+	} else if err != nil {
+		return "", err
+	}
+
+	return aws.ToString(out.ETag), nil
+}
+
+// Concatenate creates a new blob named |key| from the contents of |sources| with a multipart upload. Sources which
+// are large enough to be parts of their own are copied on the server, and smaller ones are downloaded and uploaded
+// together as a single part.
+func (bs *S3Blobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	sizes := make([]int64, len(sources))
+	eg, ectx := errgroup.WithContext(ctx)
+	for i := range sources {
+		idx := i
+		eg.Go(func() error {
+			head, err := bs.client.HeadObject(ectx, &s3.HeadObjectInput{
+				Bucket: aws.String(bs.bucket),
+				Key:    aws.String(bs.key(sources[idx])),
+			})
+			if isS3NotFound(err) {
+				return NotFound{bs.absKey(sources[idx])}
+			} else if err != nil {
+				return err
+			}
+			sizes[idx] = aws.ToInt64(head.ContentLength)
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return "", err
+	}
+
+	start, err := bs.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bs.bucket),
+		Key:    aws.String(bs.key(key)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	parts, err := bs.uploadConcatenatedParts(ctx, key, aws.ToString(start.UploadId), sources, sizes)
+	if err != nil {
+		_, abortErr := bs.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bs.bucket),
+			Key:      aws.String(bs.key(key)),
+			UploadId: start.UploadId,
+		})
+		return "", errors.Join(err, abortErr)
+	}
+
+	out, err := bs.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bs.bucket),
+		Key:             aws.String(bs.key(key)),
+		UploadId:        start.UploadId,
+		MultipartUpload: &s3types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.ToString(out.ETag), nil
+}
+
+// uploadConcatenatedParts uploads the parts of the concatenation of |sources| to the multipart upload |uploadID|.
+// Every part but the last must be at least s3MinPartSize, so the ranges of sources which are too small to be copied
+// are buffered until they fill a part.
+func (bs *S3Blobstore) uploadConcatenatedParts(ctx context.Context, key, uploadID string, sources []string, sizes []int64) ([]s3types.CompletedPart, error) {
+	var parts []*s3types.CompletedPart
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.SetLimit(s3ConcatenateConcurrency)
+
+	addPart := func(upload func(partNum int32) (*string, error)) {
+		part := &s3types.CompletedPart{PartNumber: aws.Int32(int32(len(parts) + 1))}
+		parts = append(parts, part)
+		eg.Go(func() (err error) {
+			part.ETag, err = upload(*part.PartNumber)
+			return err
+		})
+	}
+	addBufferedPart := func(buf []byte) {
+		addPart(func(partNum int32) (*string, error) {
+			out, err := bs.client.UploadPart(ectx, &s3.UploadPartInput{
+				Bucket:        aws.String(bs.bucket),
+				Key:           aws.String(bs.key(key)),
+				UploadId:      aws.String(uploadID),
+				PartNumber:    aws.Int32(partNum),
+				Body:          bytes.NewReader(buf),
+				ContentLength: aws.Int64(int64(len(buf))),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return out.ETag, nil
+		})
+	}
+
+	var buf []byte
+	for i, src := range sources {
+		size, off := sizes[i], int64(0)
+		if len(buf) > 0 {
+			// fill the buffered part from the start of this source
+			n := min(s3MinPartSize-int64(len(buf)), size)
+			data, err := bs.readRange(ctx, src, 0, n)
+			if err != nil {
+				return nil, errors.Join(err, eg.Wait())
+			}
+			buf, off = append(buf, data...), n
+			if len(buf) >= s3MinPartSize {
+				addBufferedPart(buf)
+				buf = nil
+			}
+		}
+
+		for size-off >= s3MinPartSize {
+			copyStart, copyEnd := off, off+min(s3MaxPartSize, size-off)
+			copySource := url.PathEscape(bs.bucket + "/" + bs.key(src))
+			addPart(func(partNum int32) (*string, error) {
+				out, err := bs.client.UploadPartCopy(ectx, &s3.UploadPartCopyInput{
+					Bucket:          aws.String(bs.bucket),
+					Key:             aws.String(bs.key(key)),
+					UploadId:        aws.String(uploadID),
+					PartNumber:      aws.Int32(partNum),
+					CopySource:      aws.String(copySource),
+					CopySourceRange: aws.String(s3RangeHeader(BlobRange{copyStart, copyEnd - copyStart})),
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.CopyPartResult.ETag, nil
+			})
+			off = copyEnd
+		}
+
+		if off < size {
+			data, err := bs.readRange(ctx, src, off, size-off)
+			if err != nil {
+				return nil, errors.Join(err, eg.Wait())
+			}
+			buf = append(buf, data...)
+		}
+	}
+	if len(buf) > 0 || len(parts) == 0 {
+		addBufferedPart(buf)
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	completed := make([]s3types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completed[i] = *part
+	}
+	return completed, nil
+}
+
+func (bs *S3Blobstore) readRange(ctx context.Context, key string, offset, length int64) ([]byte, error) {
+	if length == 0 {
+		return nil, nil
+	}
+	rc, _, err := bs.Get(ctx, key, NewBlobRange(offset, length))
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// s3RangeHeader returns the Range header for |br|, which must not be a negative offset with a length.
+func s3RangeHeader(br BlobRange) string {
+	if br.offset < 0 {
+		return fmt.Sprintf("bytes=%d", br.offset)
+	} else if br.length == 0 {
+		return fmt.Sprintf("bytes=%d-", br.offset)
+	}
+	return fmt.Sprintf("bytes=%d-%d", br.offset, br.offset+br.length-1)
+}
+
+func s3ErrorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return ""
+}
+
+func isS3NotFound(err error) bool {
+	code := s3ErrorCode(err)
+	return code == "NoSuchKey" || code == "NotFound"
+}
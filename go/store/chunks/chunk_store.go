@@ -126,7 +126,8 @@ type ChunkStore interface {
 	// PersistGhostHashes is used to persist a set of addresses that are known to exist, but
 	// are not currently stored here. Only the GenerationalChunkStore implementation allows use of this method, as
 	// shallow clones are only allowed in local copies currently. Note that at the application level, the only
-	// hashes which can be ghosted are commit ids, but the chunk store doesn't know what those are.
+	// hashes which can be ghosted are commit ids and the tables left out of a sparse clone, but the chunk store
+	// doesn't know what those are. The hashes are added to the ones already persisted.
 	PersistGhostHashes(ctx context.Context, refs hash.HashSet) error
 
 	// Close tears down any resources in use by the implementation. After
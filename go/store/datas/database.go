@@ -201,15 +201,19 @@ type GarbageCollector interface {
 	GC(ctx context.Context, mode types.GCMode, cmp chunks.GCArchiveLevel, oldGenRefs, newGenRefs hash.HashSet, safepointController types.GCSafepointController) error
 }
 
-// CanUsePuller returns true if a datas.Puller can be used to pull data from one Database into another.  Not all
-// Databases support this yet.
-func CanUsePuller(db Database) bool {
-	cs := db.chunkStore()
-	if tfs, ok := cs.(chunks.TableFileStore); ok {
-		ops := tfs.SupportedOperations()
-		return ops.CanRead && ops.CanWrite
+// CanUsePuller returns true if a datas.Puller can be used to pull data from |src| into |sink|.  Not all
+// Databases support this yet. Table files are only read from |src|, so it may be read only.
+func CanUsePuller(src, sink Database) bool {
+	srcTFS, ok := src.chunkStore().(chunks.TableFileStore)
+	if !ok || !srcTFS.SupportedOperations().CanRead {
+		return false
+	}
+	sinkTFS, ok := sink.chunkStore().(chunks.TableFileStore)
+	if !ok {
+		return false
 	}
-	return false
+	ops := sinkTFS.SupportedOperations()
+	return ops.CanRead && ops.CanWrite
 }
 
 func GetCSStatSummaryForDB(db Database) string {
@@ -159,6 +159,7 @@ type WorkingSetHead struct {
 	StagedAddr  *hash.Hash
 	MergeState  *MergeState
 	RebaseState *RebaseState
+	BisectState *BisectState
 }
 
 type RebaseState struct {
@@ -206,6 +207,40 @@ func (rs *RebaseState) EmptyCommitHandling(_ context.Context) uint8 {
 	return rs.emptyCommitHandling
 }
 
+// BisectState is the state of a bisect session. Commits are recorded by their addresses, and an empty address is one
+// that hasn't been given or determined yet.
+type BisectState struct {
+	badCommitAddr     hash.Hash
+	goodCommitAddrs   []hash.Hash
+	skipCommitAddrs   []hash.Hash
+	currentCommitAddr hash.Hash
+}
+
+func NewBisectState(bad hash.Hash, good, skip []hash.Hash, current hash.Hash) *BisectState {
+	return &BisectState{
+		badCommitAddr:     bad,
+		goodCommitAddrs:   good,
+		skipCommitAddrs:   skip,
+		currentCommitAddr: current,
+	}
+}
+
+func (bs *BisectState) BadCommitAddr() hash.Hash {
+	return bs.badCommitAddr
+}
+
+func (bs *BisectState) GoodCommitAddrs() []hash.Hash {
+	return bs.goodCommitAddrs
+}
+
+func (bs *BisectState) SkipCommitAddrs() []hash.Hash {
+	return bs.skipCommitAddrs
+}
+
+func (bs *BisectState) CurrentCommitAddr() hash.Hash {
+	return bs.currentCommitAddr
+}
+
 type MergeState struct {
 	preMergeWorkingAddr *hash.Hash
 	fromCommitAddr      *hash.Hash
@@ -461,6 +496,19 @@ func (h serialWorkingSetHead) HeadWorkingSet() (*WorkingSetHead, error) {
 		)
 	}
 
+	bisectState, err := h.msg.TryBisectState(nil)
+	if err != nil {
+		return nil, err
+	}
+	if bisectState != nil {
+		ret.BisectState = NewBisectState(
+			hash.New(bisectState.BadCommitAddrBytes()),
+			hashesFromBytes(bisectState.GoodCommitAddrsBytes()),
+			hashesFromBytes(bisectState.SkipCommitAddrsBytes()),
+			hash.New(bisectState.CurrentCommitAddrBytes()),
+		)
+	}
+
 	return &ret, nil
 }
 
@@ -15,11 +15,13 @@
 package datas
 
 import (
+	"context"
 	"fmt"
 
 	flatbuffers "github.com/dolthub/flatbuffers/v23/go"
 
 	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
@@ -34,6 +36,33 @@ func storeroot_flatbuffer(am prolly.AddressMap) serial.Message {
 	return serial.FinishMessage(builder, serial.StoreRootEnd(builder), []byte(serial.StoreRootFileID))
 }
 
+// WriteStoreRoot writes a store root whose datasets are |datasets| to |vrw| and |ns|, and returns its address. It is
+// used to create a root for a subset of the datasets of a database, and is only supported for the __DOLT__ format.
+func WriteStoreRoot(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeStore, datasets map[string]hash.Hash) (hash.Hash, error) {
+	if !vrw.Format().UsesFlatbuffers() {
+		return hash.Hash{}, fmt.Errorf("store roots cannot be written for format %s", vrw.Format().VersionString())
+	}
+	am, err := prolly.NewEmptyAddressMap(ns)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	ae := am.Editor()
+	for name, addr := range datasets {
+		if err := ae.Add(ctx, name, addr); err != nil {
+			return hash.Hash{}, err
+		}
+	}
+	am, err = ae.Flush(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	r, err := vrw.WriteValue(ctx, types.SerialMessage(storeroot_flatbuffer(am)))
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return r.TargetHash(), nil
+}
+
 func parse_storeroot(bs []byte, ns tree.NodeStore) (prolly.AddressMap, error) {
 	if serial.GetFileID(bs) != serial.StoreRootFileID {
 		panic("expected store root file id, got: " + serial.GetFileID(bs))
@@ -87,6 +87,7 @@ type WorkingSetSpec struct {
 	StagedRoot  types.Ref
 	MergeState  *MergeState
 	RebaseState *RebaseState
+	BisectState *BisectState
 }
 
 // newWorkingSet creates a new working set object.
@@ -112,10 +113,11 @@ func newWorkingSet(ctx context.Context, db *database, workingSetSpec WorkingSetS
 	stagedRef := workingSetSpec.StagedRoot
 	mergeState := workingSetSpec.MergeState
 	rebaseState := workingSetSpec.RebaseState
+	bisectState := workingSetSpec.BisectState
 
 	if db.Format().UsesFlatbuffers() {
 		stagedAddr := stagedRef.TargetHash()
-		data := workingset_flatbuffer(workingRef.TargetHash(), &stagedAddr, mergeState, rebaseState, meta)
+		data := workingset_flatbuffer(workingRef.TargetHash(), &stagedAddr, mergeState, rebaseState, bisectState, meta)
 
 		r, err := db.WriteValue(ctx, types.SerialMessage(data))
 		if err != nil {
@@ -163,10 +165,10 @@ func newWorkingSet(ctx context.Context, db *database, workingSetSpec WorkingSetS
 }
 
 // workingset_flatbuffer creates a flatbuffer message for working set metadata.
-func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *MergeState, rebaseState *RebaseState, meta *WorkingSetMeta) serial.Message {
+func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *MergeState, rebaseState *RebaseState, bisectState *BisectState, meta *WorkingSetMeta) serial.Message {
 	builder := flatbuffers.NewBuilder(1024)
 	workingoff := builder.CreateByteVector(working[:])
-	var stagedOff, mergeStateOff, rebaseStateOffset flatbuffers.UOffsetT
+	var stagedOff, mergeStateOff, rebaseStateOffset, bisectStateOffset flatbuffers.UOffsetT
 	if staged != nil {
 		stagedOff = builder.CreateByteVector((*staged)[:])
 	}
@@ -199,6 +201,19 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 		rebaseStateOffset = serial.RebaseStateEnd(builder)
 	}
 
+	if bisectState != nil {
+		badAddrOffset := builder.CreateByteVector(bisectState.badCommitAddr[:])
+		goodAddrsOffset := builder.CreateByteVector(hashesToBytes(bisectState.goodCommitAddrs))
+		skipAddrsOffset := builder.CreateByteVector(hashesToBytes(bisectState.skipCommitAddrs))
+		currentAddrOffset := builder.CreateByteVector(bisectState.currentCommitAddr[:])
+		serial.BisectStateStart(builder)
+		serial.BisectStateAddBadCommitAddr(builder, badAddrOffset)
+		serial.BisectStateAddGoodCommitAddrs(builder, goodAddrsOffset)
+		serial.BisectStateAddSkipCommitAddrs(builder, skipAddrsOffset)
+		serial.BisectStateAddCurrentCommitAddr(builder, currentAddrOffset)
+		bisectStateOffset = serial.BisectStateEnd(builder)
+	}
+
 	var nameOff, emailOff, descOff flatbuffers.UOffsetT
 	if meta != nil {
 		nameOff = builder.CreateString(meta.Name)
@@ -217,6 +232,9 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 	if rebaseStateOffset != 0 {
 		serial.WorkingSetAddRebaseState(builder, rebaseStateOffset)
 	}
+	if bisectStateOffset != 0 {
+		serial.WorkingSetAddBisectState(builder, bisectStateOffset)
+	}
 
 	if meta != nil {
 		serial.WorkingSetAddName(builder, nameOff)
@@ -276,6 +294,24 @@ func NewRebaseState(preRebaseWorkingRoot hash.Hash, commitAddr hash.Hash, branch
 	}
 }
 
+// hashesToBytes concatenates |hashes|.
+func hashesToBytes(hashes []hash.Hash) []byte {
+	bs := make([]byte, 0, len(hashes)*hash.ByteLen)
+	for _, h := range hashes {
+		bs = append(bs, h[:]...)
+	}
+	return bs
+}
+
+// hashesFromBytes splits |bs|, concatenated hashes, into hashes.
+func hashesFromBytes(bs []byte) []hash.Hash {
+	hashes := make([]hash.Hash, 0, len(bs)/hash.ByteLen)
+	for ; len(bs) >= hash.ByteLen; bs = bs[hash.ByteLen:] {
+		hashes = append(hashes, hash.New(bs[:hash.ByteLen]))
+	}
+	return hashes
+}
+
 func IsWorkingSet(v types.Value) (bool, error) {
 	if s, ok := v.(types.Struct); ok {
 		// We're being more lenient here than in other checks, to make it more likely we can release changes to the
@@ -46,6 +46,10 @@ var errNotEnoughChunks = errors.New("Not enough samples to build default diction
 
 func UnArchive(ctx context.Context, cs chunks.ChunkStore, smd StorageMetadata, progress chan interface{}) (err error) {
 	if gs, ok := cs.(*GenerationalNBS); ok {
+		if gs.newGen.encrypted() || gs.oldGen.encrypted() {
+			return errEncryptedStore
+		}
+
 		err = unArchiveSingleBlockStore(ctx, gs.newGen, smd, progress)
 		if err != nil {
 			return err
@@ -149,6 +153,10 @@ func unArchiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore,
 
 func BuildArchive(ctx context.Context, cs chunks.ChunkStore, dagGroups *ChunkRelations, purge bool, progress chan interface{}) (err error) {
 	if gs, ok := cs.(*GenerationalNBS); ok {
+		if gs.newGen.encrypted() || gs.oldGen.encrypted() {
+			return errEncryptedStore
+		}
+
 		err = archiveSingleBlockStore(ctx, gs.newGen, dagGroups, purge, progress)
 		if err != nil {
 			return err
@@ -354,6 +362,9 @@ func indexFinalize(arcW *archiveWriter, originTableFile hash.Hash) error {
 	if !originTableFile.IsEmpty() {
 		meta[amdkOriginTableFile] = originTableFile.String()
 	}
+	for k, v := range arcW.metadata {
+		meta[k] = v
+	}
 
 	jsonData, err := json.Marshal(meta)
 	if err != nil {
@@ -595,7 +606,7 @@ func gatherAllChunks(ctx context.Context, cs chunkSource, idx tableIndex, stats
 }
 
 func verifyAllChunks(ctx context.Context, idx tableIndex, archiveFile string, progress chan interface{}, stats *Stats) error {
-	fra, err := newFileReaderAt(archiveFile)
+	fra, err := newFileReaderAt(archiveFile, nil)
 	if err != nil {
 		return err
 	}
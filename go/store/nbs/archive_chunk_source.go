@@ -34,10 +34,10 @@ type archiveChunkSource struct {
 
 var _ chunkSource = &archiveChunkSource{}
 
-func newArchiveChunkSource(ctx context.Context, dir string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, stats *Stats) (archiveChunkSource, error) {
+func newArchiveChunkSource(ctx context.Context, dir string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, enc *chunkCipher, stats *Stats) (archiveChunkSource, error) {
 	archiveFile := filepath.Join(dir, h.String()+ArchiveFileSuffix)
 
-	fra, err := newFileReaderAt(archiveFile)
+	fra, err := newFileReaderAt(archiveFile, enc)
 	if err != nil {
 		return archiveChunkSource{}, err
 	}
@@ -234,6 +234,12 @@ func (acs archiveChunkSource) getManyCompressed(ctx context.Context, eg *errgrou
 }
 
 func (acs archiveChunkSource) iterateAllChunks(ctx context.Context, cb func(chunks.Chunk), stats *Stats) error {
+	return acs.scanAllChunks(ctx, cb, func(_ hash.Hash, err error) error {
+		return err
+	}, stats)
+}
+
+func (acs archiveChunkSource) scanAllChunks(ctx context.Context, cb func(chunks.Chunk), bad func(hash.Hash, error) error, stats *Stats) error {
 	addrCount := uint32(len(acs.aRdr.prefixes))
 	for i := uint32(0); i < addrCount; i++ {
 		var h hash.Hash
@@ -249,7 +255,10 @@ func (acs archiveChunkSource) iterateAllChunks(ctx context.Context, cb func(chun
 
 		data, err := acs.aRdr.get(ctx, h, stats)
 		if err != nil {
-			return err
+			if err = bad(h, err); err != nil {
+				return err
+			}
+			continue
 		}
 
 		cb(chunks.NewChunkWithHash(h, data))
@@ -70,6 +70,8 @@ type archiveWriter struct {
 	workflowStage    stage
 	finalPath        string
 	chunkDataLength  uint64
+	// metadata holds additional entries for the metadata of the archive.
+	metadata map[string]string
 }
 
 /*
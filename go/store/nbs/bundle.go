@@ -0,0 +1,285 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// A bundle is a single archive file which holds a root chunk and the chunks reachable from it, so that a database can
+// be moved between machines without a network connection. The root and the other fields of the bundle are stored in
+// the metadata of the archive. A bundle can be opened as a read only NomsBlockStore whose only table file is the
+// bundle itself.
+//
+// An incremental bundle omits the chunks which are reachable from its prerequisite commits. It can only be read by
+// databases which already have them.
+
+const ( // amdk = Archive Metadata Data Key
+	// The root hash of the bundle.
+	amdkBundleRoot = "bundle_root"
+	// The noms binary format of the bundled chunks.
+	amdkBundleFormat = "bundle_format"
+	// The comma separated hashes of the commits an incremental bundle depends on.
+	amdkBundlePrerequisites = "bundle_prerequisites"
+)
+
+var ErrNotABundle = errors.New("not a dolt bundle")
+
+// BundleWriter writes a bundle file. Chunks are added with AddChunk, and the bundle is written by Finish.
+type BundleWriter struct {
+	asw   *ArchiveStreamWriter
+	count int
+}
+
+// NewBundleWriter returns a BundleWriter which stages its output in |tmpDir|.
+func NewBundleWriter(tmpDir string) (*BundleWriter, error) {
+	asw, err := NewArchiveStreamWriter(tmpDir)
+	if err != nil {
+		return nil, err
+	}
+	return &BundleWriter{asw: asw}, nil
+}
+
+// AddChunk adds |c| to the bundle. Each chunk may only be added once.
+func (bw *BundleWriter) AddChunk(c chunks.Chunk) error {
+	if _, err := bw.asw.AddChunk(ChunkToCompressedChunk(c)); err != nil {
+		return err
+	}
+	bw.count++
+	return nil
+}
+
+// ChunkCount returns the number of chunks added to the bundle.
+func (bw *BundleWriter) ChunkCount() int {
+	return bw.count
+}
+
+// Finish writes the bundle to |path|. |root| must be one of the chunks added to the bundle, and |prerequisites| are
+// the commits whose chunks were left out of it.
+func (bw *BundleWriter) Finish(path, nbfVerStr string, root hash.Hash, prerequisites []hash.Hash) error {
+	prereqs := make([]string, len(prerequisites))
+	for i, h := range prerequisites {
+		prereqs[i] = h.String()
+	}
+	bw.asw.writer.metadata = map[string]string{
+		amdkBundleRoot:          root.String(),
+		amdkBundleFormat:        nbfVerStr,
+		amdkBundlePrerequisites: strings.Join(prereqs, ","),
+	}
+	if _, _, err := bw.asw.Finish(); err != nil {
+		return err
+	}
+	if err := bw.asw.writer.flushToFile(path); err != nil {
+		return err
+	}
+	// the bundle is staged in a temp file, which is only readable by its owner
+	return os.Chmod(path, 0644)
+}
+
+// Cancel discards a bundle which has not been finished.
+func (bw *BundleWriter) Cancel() error {
+	rdr, err := bw.asw.writer.output.Reader()
+	if err != nil {
+		return err
+	}
+	return rdr.Close()
+}
+
+// BundleInfo describes the contents of a bundle file.
+type BundleInfo struct {
+	Root          hash.Hash
+	Format        string
+	Prerequisites []hash.Hash
+	ChunkCount    uint32
+
+	name hash.Hash
+}
+
+// ReadBundleInfo reads the metadata of the bundle at |path|. It returns an error wrapping ErrNotABundle if the file
+// is not a bundle.
+func ReadBundleInfo(ctx context.Context, path string) (BundleInfo, error) {
+	acs, err := openBundleArchive(ctx, path, &Stats{})
+	if err != nil {
+		return BundleInfo{}, err
+	}
+	defer acs.close()
+
+	data, err := acs.aRdr.getMetadata(ctx, &Stats{})
+	if err != nil {
+		return BundleInfo{}, err
+	}
+	var meta map[string]string
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return BundleInfo{}, fmt.Errorf("%w: %s", ErrNotABundle, path)
+	}
+	root, ok := hash.MaybeParse(meta[amdkBundleRoot])
+	if !ok {
+		return BundleInfo{}, fmt.Errorf("%w: %s", ErrNotABundle, path)
+	}
+
+	info := BundleInfo{
+		Root:       root,
+		Format:     meta[amdkBundleFormat],
+		ChunkCount: acs.aRdr.count(),
+		name:       acs.hash(),
+	}
+	if prereqs := meta[amdkBundlePrerequisites]; prereqs != "" {
+		for _, s := range strings.Split(prereqs, ",") {
+			h, ok := hash.MaybeParse(s)
+			if !ok {
+				return BundleInfo{}, fmt.Errorf("bundle %s has an invalid prerequisite: %s", path, s)
+			}
+			info.Prerequisites = append(info.Prerequisites, h)
+		}
+	}
+	return info, nil
+}
+
+func openBundleArchive(ctx context.Context, path string, stats *Stats) (archiveChunkSource, error) {
+	fra, err := newFileReaderAt(path, nil)
+	if err != nil {
+		return archiveChunkSource{}, err
+	}
+	if fra.sz < int64(archiveFooterSize) {
+		fra.Close()
+		return archiveChunkSource{}, fmt.Errorf("%w: %s", ErrNotABundle, path)
+	}
+	aRdr, err := newArchiveReader(ctx, fra, uint64(fra.sz), stats)
+	if err != nil {
+		fra.Close()
+		if errors.Is(err, ErrInvalidFileSignature) {
+			return archiveChunkSource{}, fmt.Errorf("%w: %s", ErrNotABundle, path)
+		}
+		return archiveChunkSource{}, err
+	}
+	return archiveChunkSource{path, aRdr}, nil
+}
+
+// NewBundleStore opens the bundle at |path| as a read only store.
+func NewBundleStore(ctx context.Context, nbfVerStr, path string, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	cacheOnce.Do(makeGlobalCaches)
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := ReadBundleInfo(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Format != nbfVerStr {
+		return nil, fmt.Errorf("bundle %s has format %s, which does not match the format %s", path, info.Format, nbfVerStr)
+	}
+
+	specs := []tableSpec{{name: info.name, chunkCount: info.ChunkCount}}
+	m := bundleManifest{
+		path: path,
+		contents: manifestContents{
+			manifestVers: StorageVersion,
+			nbfVers:      info.Format,
+			lock:         generateLockHash(info.Root, specs, nil, nil),
+			root:         info.Root,
+			specs:        specs,
+		},
+	}
+	p := &bundlePersister{path: path, info: info}
+	return newNomsBlockStore(ctx, nbfVerStr, makeManifestManager(m), p, q, inlineConjoiner{defaultMaxTables}, 0)
+}
+
+// BundlePrerequisites returns the prerequisite commits of |cs| if it is a bundle store.
+func BundlePrerequisites(cs chunks.ChunkStore) ([]hash.Hash, bool) {
+	nbs, ok := cs.(*NomsBlockStore)
+	if !ok {
+		return nil, false
+	}
+	p, ok := nbs.persister.(*bundlePersister)
+	if !ok {
+		return nil, false
+	}
+	return p.info.Prerequisites, true
+}
+
+// bundleManifest is the manifest of a bundle store, which never changes.
+type bundleManifest struct {
+	path     string
+	contents manifestContents
+}
+
+var _ manifest = bundleManifest{}
+
+func (m bundleManifest) Name() string {
+	return m.path
+}
+
+func (m bundleManifest) ParseIfExists(ctx context.Context, stats *Stats, readHook func() error) (bool, manifestContents, error) {
+	if readHook != nil {
+		if err := readHook(); err != nil {
+			return false, manifestContents{}, err
+		}
+	}
+	return true, m.contents, nil
+}
+
+func (m bundleManifest) Update(ctx context.Context, lastLock hash.Hash, newContents manifestContents, stats *Stats, writeHook func() error) (manifestContents, error) {
+	return m.contents, errReadOnlyManifest
+}
+
+// bundlePersister opens the bundle file as the only table file of a bundle store.
+type bundlePersister struct {
+	path string
+	info BundleInfo
+}
+
+var _ tablePersister = (*bundlePersister)(nil)
+
+func (p *bundlePersister) Persist(ctx context.Context, mt *memTable, haver chunkReader, keeper keeperF, stats *Stats) (chunkSource, gcBehavior, error) {
+	return nil, gcBehavior_Continue, errReadOnlyManifest
+}
+
+func (p *bundlePersister) ConjoinAll(ctx context.Context, sources chunkSources, stats *Stats) (chunkSource, cleanupFunc, error) {
+	return nil, nil, errReadOnlyManifest
+}
+
+func (p *bundlePersister) Open(ctx context.Context, name hash.Hash, chunkCount uint32, stats *Stats) (chunkSource, error) {
+	if name != p.info.name {
+		return nil, fmt.Errorf("error opening table file: %w: %s", ErrTableFileNotFound, name.String())
+	}
+	return openBundleArchive(ctx, p.path, stats)
+}
+
+func (p *bundlePersister) Exists(ctx context.Context, name string, chunkCount uint32, stats *Stats) (bool, error) {
+	return name == p.info.name.String(), nil
+}
+
+func (p *bundlePersister) PruneTableFiles(ctx context.Context, keeper func() []hash.Hash, mtime time.Time) error {
+	return nil
+}
+
+func (p *bundlePersister) AccessMode() chunks.ExclusiveAccessMode {
+	return chunks.ExclusiveAccessMode_ReadOnly
+}
+
+func (p *bundlePersister) Close() error {
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/constants"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestBundleStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.bundle")
+
+	bw, err := NewBundleWriter(dir)
+	require.NoError(t, err)
+	var bundled []chunks.Chunk
+	for _, s := range []string{"one", "two", "three"} {
+		c := chunks.NewChunk([]byte(s))
+		require.NoError(t, bw.AddChunk(c))
+		bundled = append(bundled, c)
+	}
+	assert.Equal(t, 3, bw.ChunkCount())
+	root := bundled[0].Hash()
+	prereq := hash.Of([]byte("prerequisite"))
+	require.NoError(t, bw.Finish(path, constants.FormatDoltString, root, []hash.Hash{prereq}))
+
+	info, err := ReadBundleInfo(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, root, info.Root)
+	assert.Equal(t, constants.FormatDoltString, info.Format)
+	assert.Equal(t, []hash.Hash{prereq}, info.Prerequisites)
+	assert.Equal(t, uint32(3), info.ChunkCount)
+
+	store, err := NewBundleStore(ctx, constants.FormatDoltString, path, &UnlimitedQuotaProvider{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	actualRoot, err := store.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, actualRoot)
+	for _, c := range bundled {
+		actual, err := store.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c.Data(), actual.Data())
+	}
+	ok, err := store.Has(ctx, hash.Of([]byte("four")))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	prereqs, ok := BundlePrerequisites(store)
+	assert.True(t, ok)
+	assert.Equal(t, []hash.Hash{prereq}, prereqs)
+
+	_, sources, _, err := store.Sources(ctx)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, info.name.String(), sources[0].FileID())
+
+	// bundle stores are read only
+	c := chunks.NewChunk([]byte("four"))
+	err = store.Put(ctx, c, noopGetAddrs)
+	if err == nil {
+		_, err = store.Commit(ctx, c.Hash(), root)
+	}
+	assert.Error(t, err)
+
+	_, err = NewBundleStore(ctx, constants.FormatLD1String, path, &UnlimitedQuotaProvider{})
+	assert.Error(t, err)
+}
+
+func TestReadBundleInfoNotABundle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "short")
+	require.NoError(t, os.WriteFile(path, []byte("not a bundle"), 0644))
+	_, err := ReadBundleInfo(ctx, path)
+	assert.ErrorIs(t, err, ErrNotABundle)
+
+	path = filepath.Join(dir, "long")
+	require.NoError(t, os.WriteFile(path, make([]byte, 4096), 0644))
+	_, err = ReadBundleInfo(ctx, path)
+	assert.ErrorIs(t, err, ErrNotABundle)
+}
@@ -0,0 +1,811 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+)
+
+// Chunk stores in a local directory can be encrypted at rest. Table files and archives are encrypted in their entirety
+// with AES-256 in GCM mode, behind a header which records the id of the key and a random nonce prefix:
+//
+// +------------------+-------------+-----------------+-----------+-----------+-----+
+// | magic (8 bytes)  | key id (8)  | nonce prefix (8)| segment 0 | segment 1 | ... |
+// +------------------+-------------+-----------------+-----------+-----------+-----+
+//
+// The file content is split into segments of encryptedFileSegmentSize bytes, each sealed separately so that readers
+// can decrypt and authenticate any range of the file without reading all of it. The nonce of a segment is the nonce
+// prefix followed by the big-endian uint32 index of the segment, and its additional data is the header followed by
+// a byte which is 1 for the last segment and 0 otherwise, so segments can not be reordered, dropped or moved between
+// files without failing authentication. Chunk records in the chunk journal have their payloads encrypted
+// individually, see journalRec.
+//
+// Chunk addresses and table file names are always computed over plaintext, so an encrypted store exchanges the same
+// chunks and table files with remotes as an unencrypted one. Data read from an encrypted store for a push is
+// decrypted, and data written to it by a pull or clone is encrypted.
+
+const (
+	// EncryptionConfigFileName is the name of the file in the directory of a chunk store which records the key its
+	// chunk data is encrypted with. Stores without this file are not encrypted.
+	EncryptionConfigFileName = "encryption.json"
+
+	encryptionCipherName = "aes-256-gcm"
+
+	encryptionKeySize   = 32
+	encryptionKeyIDSize = 8
+	encryptionNonceSize = 12
+	encryptionTagSize   = 16
+
+	encryptedFileMagic           = "DOLTENC2"
+	encryptedFileNonceSize       = 8
+	encryptedFileHeaderSize      = len(encryptedFileMagic) + encryptionKeyIDSize + encryptedFileNonceSize
+	encryptedFileSegmentSize     = 16 * 1024
+	encryptedFileSegmentSealedSz = encryptedFileSegmentSize + encryptionTagSize
+)
+
+var ErrNoEncryptionKey = fmt.Errorf("no encryption key found: set %s, %s or %s", dconfig.EnvEncryptionKey, dconfig.EnvEncryptionKeyFile, dconfig.EnvEncryptionKeyCommand)
+
+var errEncryptedStore = errors.New("operation is not supported for encrypted databases")
+
+// ErrDecryptionFailed is returned when encrypted chunk data does not authenticate under the key it names.
+var ErrDecryptionFailed = errors.New("encrypted data failed authentication: it is corrupt or has been modified")
+
+// EncryptionKeyID identifies an EncryptionKey without revealing it.
+type EncryptionKeyID [encryptionKeyIDSize]byte
+
+func (id EncryptionKeyID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// ParseEncryptionKeyID parses the hex encoded key id |s|.
+func ParseEncryptionKeyID(s string) (EncryptionKeyID, error) {
+	var id EncryptionKeyID
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != encryptionKeyIDSize {
+		return id, fmt.Errorf("invalid encryption key id: %s", s)
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+// EncryptionKey is an AES-256 key used to encrypt chunk data at rest.
+type EncryptionKey struct {
+	id   EncryptionKeyID
+	aead cipher.AEAD
+}
+
+// ID returns the id of the key, which is derived from the key material.
+func (k EncryptionKey) ID() EncryptionKeyID {
+	return k.id
+}
+
+// ParseEncryptionKey parses a 32 byte key encoded as hex or base64.
+func ParseEncryptionKey(s string) (EncryptionKey, error) {
+	s = strings.TrimSpace(s)
+	var key []byte
+	if b, err := hex.DecodeString(s); err == nil && len(b) == encryptionKeySize {
+		key = b
+	} else if b, err := base64.StdEncoding.DecodeString(s); err == nil && len(b) == encryptionKeySize {
+		key = b
+	} else {
+		return EncryptionKey{}, fmt.Errorf("invalid encryption key: expected %d bytes encoded as hex or base64", encryptionKeySize)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return EncryptionKey{}, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return EncryptionKey{}, err
+	}
+	h := sha256.New()
+	h.Write([]byte("dolt encryption key id"))
+	h.Write(key)
+	var id EncryptionKeyID
+	copy(id[:], h.Sum(nil))
+	return EncryptionKey{id: id, aead: aead}, nil
+}
+
+// EncryptionKeys are the encryption keys available to this process. Keys come from, in order:
+//   - DOLT_ENCRYPTION_KEY, a comma separated list of keys.
+//   - DOLT_ENCRYPTION_KEY_FILE, the path of a file with one key on each line.
+//   - DOLT_ENCRYPTION_KEY_COMMAND, a key management plugin. The command is run with the id of the key it should write
+//     to stdout, or with no arguments for the key new databases should be encrypted with.
+//
+// The first key found is the primary key, which encrypts new databases and which rotations rotate to.
+type EncryptionKeys struct {
+	mu      sync.Mutex
+	keys    []EncryptionKey
+	command []string
+}
+
+// LoadEncryptionKeys loads the encryption keys configured in the environment.
+func LoadEncryptionKeys() (*EncryptionKeys, error) {
+	ks := &EncryptionKeys{}
+	if val := os.Getenv(dconfig.EnvEncryptionKey); val != "" {
+		for _, s := range strings.Split(val, ",") {
+			if err := ks.add(s); err != nil {
+				return nil, fmt.Errorf("error reading %s: %w", dconfig.EnvEncryptionKey, err)
+			}
+		}
+	}
+
+	if path := os.Getenv(dconfig.EnvEncryptionKeyFile); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", dconfig.EnvEncryptionKeyFile, err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if err = ks.add(line); err != nil {
+				return nil, fmt.Errorf("error reading %s: %w", path, err)
+			}
+		}
+	}
+
+	if cmd := os.Getenv(dconfig.EnvEncryptionKeyCommand); cmd != "" {
+		ks.command = strings.Fields(cmd)
+	}
+	return ks, nil
+}
+
+// NewEncryptionKeys returns EncryptionKeys holding |keys|, the first of which is the primary key.
+func NewEncryptionKeys(keys ...EncryptionKey) *EncryptionKeys {
+	return &EncryptionKeys{keys: keys}
+}
+
+func (ks *EncryptionKeys) add(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	k, err := ParseEncryptionKey(s)
+	if err != nil {
+		return err
+	}
+	ks.keys = append(ks.keys, k)
+	return nil
+}
+
+// Primary returns the primary key.
+func (ks *EncryptionKeys) Primary(ctx context.Context) (EncryptionKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if len(ks.keys) > 0 {
+		return ks.keys[0], nil
+	}
+	if len(ks.command) == 0 {
+		return EncryptionKey{}, ErrNoEncryptionKey
+	}
+	k, err := ks.runCommand(ctx, "")
+	if err != nil {
+		return EncryptionKey{}, err
+	}
+	ks.keys = append(ks.keys, k)
+	return k, nil
+}
+
+// Get returns the key with id |id|.
+func (ks *EncryptionKeys) Get(ctx context.Context, id EncryptionKeyID) (EncryptionKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	for _, k := range ks.keys {
+		if k.id == id {
+			return k, nil
+		}
+	}
+	if len(ks.command) == 0 {
+		return EncryptionKey{}, fmt.Errorf("encryption key %s is not available: %w", id, ErrNoEncryptionKey)
+	}
+	k, err := ks.runCommand(ctx, id.String())
+	if err != nil {
+		return EncryptionKey{}, err
+	} else if k.id != id {
+		return EncryptionKey{}, fmt.Errorf("%s returned key %s when asked for key %s", dconfig.EnvEncryptionKeyCommand, k.id, id)
+	}
+	ks.keys = append(ks.keys, k)
+	return k, nil
+}
+
+func (ks *EncryptionKeys) runCommand(ctx context.Context, id string) (EncryptionKey, error) {
+	args := ks.command[1:]
+	if id != "" {
+		args = append(args[:len(args):len(args)], id)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ks.command[0], args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return EncryptionKey{}, fmt.Errorf("error running %s: %w: %s", dconfig.EnvEncryptionKeyCommand, err, strings.TrimSpace(stderr.String()))
+	}
+	return ParseEncryptionKey(string(out))
+}
+
+type encryptionConfig struct {
+	Cipher string `json:"cipher"`
+	KeyID  string `json:"key_id"`
+}
+
+// IsEncrypted returns whether the chunk store in |dir| is encrypted.
+func IsEncrypted(dir string) (bool, error) {
+	return fileExists(filepath.Join(dir, EncryptionConfigFileName))
+}
+
+// InitEncryption configures the chunk store in |dir|, which must not have been created yet, to encrypt its chunk data
+// with the primary key of |keys|. It returns the id of the key.
+func InitEncryption(ctx context.Context, dir string, keys *EncryptionKeys) (EncryptionKeyID, error) {
+	k, err := keys.Primary(ctx)
+	if err != nil {
+		return EncryptionKeyID{}, err
+	}
+	if ok, err := fileExists(filepath.Join(dir, manifestFileName)); err != nil {
+		return EncryptionKeyID{}, err
+	} else if ok {
+		return EncryptionKeyID{}, fmt.Errorf("cannot encrypt existing database in %s", dir)
+	}
+	return k.id, writeEncryptionConfig(dir, k.id)
+}
+
+// InheritEncryption configures the chunk store in |dir| to be encrypted like the store in |from|, if |from| is
+// encrypted and |dir| is not yet configured.
+func InheritEncryption(from, dir string) error {
+	data, err := os.ReadFile(filepath.Join(from, EncryptionConfigFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if ok, err := IsEncrypted(dir); err != nil || ok {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, EncryptionConfigFileName), data)
+}
+
+func readEncryptionConfig(dir string) (cfg encryptionConfig, ok bool, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, EncryptionConfigFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, false, nil
+	} else if err != nil {
+		return cfg, false, err
+	}
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, false, fmt.Errorf("invalid %s in %s: %w", EncryptionConfigFileName, dir, err)
+	}
+	if cfg.Cipher != encryptionCipherName {
+		return cfg, false, fmt.Errorf("unsupported cipher in %s: %s", filepath.Join(dir, EncryptionConfigFileName), cfg.Cipher)
+	}
+	return cfg, true, nil
+}
+
+func writeEncryptionConfig(dir string, id EncryptionKeyID) error {
+	data, err := json.MarshalIndent(encryptionConfig{Cipher: encryptionCipherName, KeyID: id.String()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, EncryptionConfigFileName), data)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return file.Rename(tmp, path)
+}
+
+// chunkCipher encrypts and decrypts the chunk data of an encrypted store. New data is encrypted with the store's
+// current key. Existing data is decrypted with whichever key it was encrypted with.
+type chunkCipher struct {
+	dir  string
+	keys *EncryptionKeys
+
+	mu      sync.RWMutex
+	current EncryptionKey
+}
+
+// loadChunkCipher returns the chunkCipher for the chunk store in |dir|, or nil if the store is not encrypted.
+func loadChunkCipher(ctx context.Context, dir string) (*chunkCipher, error) {
+	cfg, ok, err := readEncryptionConfig(dir)
+	if err != nil || !ok {
+		return nil, err
+	}
+	id, err := ParseEncryptionKeyID(cfg.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := LoadEncryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+	current, err := keys.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open encrypted database in %s: %w", dir, err)
+	}
+	return &chunkCipher{dir: dir, keys: keys, current: current}, nil
+}
+
+func (c *chunkCipher) currentKey() EncryptionKey {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current
+}
+
+func (c *chunkCipher) key(id EncryptionKeyID) (EncryptionKey, error) {
+	if k := c.currentKey(); k.id == id {
+		return k, nil
+	}
+	return c.keys.Get(context.Background(), id)
+}
+
+// setCurrentKey makes |k| the key new data is encrypted with and records it in the store's encryption config.
+func (c *chunkCipher) setCurrentKey(k EncryptionKey) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := writeEncryptionConfig(c.dir, k.id); err != nil {
+		return err
+	}
+	c.current = k
+	return nil
+}
+
+// encryptingWriter writes an encrypted file header to |w| and returns a writer which encrypts the file content.
+// The writer must be closed to seal the last segment of the file.
+func (c *chunkCipher) encryptingWriter(w io.Writer) (io.WriteCloser, error) {
+	fc := fileCipher{key: c.currentKey()}
+	copy(fc.hdr[:], encryptedFileMagic)
+	copy(fc.hdr[len(encryptedFileMagic):], fc.key.id[:])
+	if _, err := rand.Read(fc.hdr[len(encryptedFileMagic)+encryptionKeyIDSize:]); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(fc.hdr[:]); err != nil {
+		return nil, err
+	}
+	return &encryptingFileWriter{w: w, fc: fc, buf: make([]byte, 0, encryptedFileSegmentSize)}, nil
+}
+
+// encryptingFileWriter seals the content of an encrypted file one segment at a time. A full segment is only sealed
+// once more content is written, so that Close can seal the last segment as the last one.
+type encryptingFileWriter struct {
+	w      io.Writer
+	fc     fileCipher
+	seg    int64
+	buf    []byte
+	sealed []byte
+	closed bool
+}
+
+func (ew *encryptingFileWriter) Write(p []byte) (int, error) {
+	if ew.closed {
+		return 0, os.ErrClosed
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if len(ew.buf) == encryptedFileSegmentSize {
+			if err := ew.seal(false); err != nil {
+				return n - len(p), err
+			}
+		}
+		c := copy(ew.buf[len(ew.buf):encryptedFileSegmentSize], p)
+		ew.buf = ew.buf[:len(ew.buf)+c]
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// Close seals the last segment. It does not close the underlying writer.
+func (ew *encryptingFileWriter) Close() error {
+	if ew.closed {
+		return nil
+	}
+	ew.closed = true
+	return ew.seal(true)
+}
+
+func (ew *encryptingFileWriter) seal(last bool) error {
+	ew.sealed = ew.fc.seal(ew.sealed[:0], ew.buf, ew.seg, last)
+	ew.seg++
+	ew.buf = ew.buf[:0]
+	_, err := ew.w.Write(ew.sealed)
+	return err
+}
+
+// fileCipher encrypts and decrypts the segments of an encrypted table file or archive.
+type fileCipher struct {
+	key EncryptionKey
+	hdr [encryptedFileHeaderSize]byte
+}
+
+// readFileHeader reads the encryption header of the file |f|.
+func (c *chunkCipher) readFileHeader(f io.ReaderAt, path string) (fileCipher, error) {
+	var fc fileCipher
+	if _, err := f.ReadAt(fc.hdr[:], 0); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fileCipher{}, fmt.Errorf("file %s is not encrypted", path)
+		}
+		return fileCipher{}, err
+	}
+	if string(fc.hdr[:len(encryptedFileMagic)]) != encryptedFileMagic {
+		return fileCipher{}, fmt.Errorf("file %s is not encrypted", path)
+	}
+
+	var id EncryptionKeyID
+	copy(id[:], fc.hdr[len(encryptedFileMagic):])
+	k, err := c.key(id)
+	if err != nil {
+		return fileCipher{}, fmt.Errorf("cannot decrypt %s: %w", path, err)
+	}
+	fc.key = k
+	return fc, nil
+}
+
+func (fc *fileCipher) nonce(seg int64) []byte {
+	nonce := make([]byte, encryptionNonceSize)
+	copy(nonce, fc.hdr[len(encryptedFileMagic)+encryptionKeyIDSize:])
+	binary.BigEndian.PutUint32(nonce[encryptedFileNonceSize:], uint32(seg))
+	return nonce
+}
+
+func (fc *fileCipher) additionalData(last bool) []byte {
+	ad := make([]byte, encryptedFileHeaderSize+1)
+	copy(ad, fc.hdr[:])
+	if last {
+		ad[encryptedFileHeaderSize] = 1
+	}
+	return ad
+}
+
+// seal appends segment |seg| of the file content, |plaintext|, to |dst| encrypted and authenticated.
+func (fc *fileCipher) seal(dst, plaintext []byte, seg int64, last bool) []byte {
+	return fc.key.aead.Seal(dst, fc.nonce(seg), plaintext, fc.additionalData(last))
+}
+
+// open appends sealed segment |seg| of the file content to |dst| decrypted.
+func (fc *fileCipher) open(dst, sealed []byte, seg int64, last bool) ([]byte, error) {
+	p, err := fc.key.aead.Open(dst, fc.nonce(seg), sealed, fc.additionalData(last))
+	if err != nil {
+		return nil, fmt.Errorf("segment %d: %w", seg, ErrDecryptionFailed)
+	}
+	return p, nil
+}
+
+// encryptedContentSize returns the size of the plaintext content of an encrypted file whose content, the file
+// without its header, is |sz| bytes.
+func encryptedContentSize(sz int64) (int64, error) {
+	// every file has at least one segment, the last of which may be empty
+	segs := (sz + encryptedFileSegmentSealedSz - 1) / encryptedFileSegmentSealedSz
+	if segs == 0 || sz-(segs-1)*encryptedFileSegmentSealedSz < encryptionTagSize {
+		return 0, fmt.Errorf("encrypted file is truncated: %w", ErrDecryptionFailed)
+	}
+	return sz - segs*encryptionTagSize, nil
+}
+
+// encryptedSegments returns the number of segments of encrypted content whose plaintext is |sz| bytes.
+func encryptedSegments(sz int64) int64 {
+	if sz == 0 {
+		return 1
+	}
+	return (sz + encryptedFileSegmentSize - 1) / encryptedFileSegmentSize
+}
+
+// readAt reads the plaintext content at |off| of the encrypted file |r|, whose plaintext content is |sz| bytes,
+// into |p|. Every segment |p| overlaps is decrypted and authenticated.
+func (fc *fileCipher) readAt(r io.ReaderAt, p []byte, off, sz int64) (n int, err error) {
+	if off >= sz {
+		if len(p) == 0 {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+	if off+int64(len(p)) > sz {
+		p = p[:sz-off]
+		err = io.EOF
+	}
+
+	last := encryptedSegments(sz) - 1
+	sealed := make([]byte, encryptedFileSegmentSealedSz)
+	plain := make([]byte, 0, encryptedFileSegmentSize)
+	for n < len(p) {
+		pos := off + int64(n)
+		seg := pos / encryptedFileSegmentSize
+		segSz := min(encryptedFileSegmentSize, sz-seg*encryptedFileSegmentSize)
+		buf := sealed[:segSz+encryptionTagSize]
+		if _, rerr := r.ReadAt(buf, int64(encryptedFileHeaderSize)+seg*encryptedFileSegmentSealedSz); rerr != nil {
+			return n, rerr
+		}
+		var oerr error
+		if plain, oerr = fc.open(plain[:0], buf, seg, seg == last); oerr != nil {
+			return n, oerr
+		}
+		n += copy(p[n:], plain[pos-seg*encryptedFileSegmentSize:])
+	}
+	return n, err
+}
+
+// decryptingReader reads the plaintext content of an encrypted file sequentially, one segment at a time.
+type decryptingReader struct {
+	r     io.Reader
+	fc    *fileCipher
+	seg   int64
+	last  int64
+	sz    int64
+	plain []byte
+	buf   []byte
+}
+
+// reader returns a reader of the plaintext content of the encrypted file |r|, which is positioned after the header
+// of the file, whose plaintext content is |sz| bytes.
+func (fc *fileCipher) reader(r io.Reader, sz int64) io.Reader {
+	return &decryptingReader{
+		r:    r,
+		fc:   fc,
+		last: encryptedSegments(sz) - 1,
+		sz:   sz,
+		buf:  make([]byte, encryptedFileSegmentSealedSz),
+	}
+}
+
+func (dr *decryptingReader) Read(p []byte) (int, error) {
+	for len(dr.plain) == 0 {
+		if dr.seg > dr.last {
+			return 0, io.EOF
+		}
+		segSz := min(encryptedFileSegmentSize, dr.sz-dr.seg*encryptedFileSegmentSize)
+		buf := dr.buf[:segSz+encryptionTagSize]
+		if _, err := io.ReadFull(dr.r, buf); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		plain, err := dr.fc.open(buf[:0], buf, dr.seg, dr.seg == dr.last)
+		if err != nil {
+			return 0, err
+		}
+		dr.plain = plain
+		dr.seg++
+	}
+	n := copy(p, dr.plain)
+	dr.plain = dr.plain[n:]
+	return n, nil
+}
+
+// encryptedFileKeyID returns the id of the key the table file or archive at |path| is encrypted with.
+func encryptedFileKeyID(path string) (EncryptionKeyID, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return EncryptionKeyID{}, err
+	}
+	defer f.Close()
+	hdr := make([]byte, encryptedFileHeaderSize)
+	if _, err = io.ReadFull(f, hdr); err != nil || string(hdr[:len(encryptedFileMagic)]) != encryptedFileMagic {
+		return EncryptionKeyID{}, fmt.Errorf("file %s is not encrypted", path)
+	}
+	var id EncryptionKeyID
+	copy(id[:], hdr[len(encryptedFileMagic):])
+	return id, nil
+}
+
+// chunkCipher returns the chunkCipher of |nbs|, or nil if |nbs| is not encrypted.
+func (nbs *NomsBlockStore) chunkCipher() *chunkCipher {
+	switch p := nbs.persister.(type) {
+	case *fsTablePersister:
+		return p.enc
+	case *ChunkJournal:
+		return p.persister.enc
+	}
+	return nil
+}
+
+func (nbs *NomsBlockStore) encrypted() bool {
+	return nbs.chunkCipher() != nil
+}
+
+// localBlockStores returns the NomsBlockStores which make up |cs|.
+func localBlockStores(cs chunks.ChunkStore) []*NomsBlockStore {
+	switch s := cs.(type) {
+	case *GenerationalNBS:
+		return []*NomsBlockStore{s.newGen, s.oldGen}
+	case *NomsBlockStore:
+		return []*NomsBlockStore{s}
+	case NBSMetricWrapper:
+		return []*NomsBlockStore{s.nbs}
+	}
+	return nil
+}
+
+// EncryptionKeyIDOf returns the id of the key new chunk data written to |cs| is encrypted with, and false if |cs| is
+// not encrypted.
+func EncryptionKeyIDOf(cs chunks.ChunkStore) (EncryptionKeyID, bool) {
+	for _, st := range localBlockStores(cs) {
+		if enc := st.chunkCipher(); enc != nil {
+			return enc.currentKey().id, true
+		}
+	}
+	return EncryptionKeyID{}, false
+}
+
+// RotateEncryptionKey makes |key| the key new chunk data written to |cs| is encrypted with. Existing data stays
+// encrypted with the keys it was written with until it is rewritten, either by a full garbage collection or by
+// ReencryptTableFiles.
+func RotateEncryptionKey(cs chunks.ChunkStore, key EncryptionKey) error {
+	var encrypted bool
+	for _, st := range localBlockStores(cs) {
+		if enc := st.chunkCipher(); enc != nil {
+			encrypted = true
+			if err := enc.setCurrentKey(key); err != nil {
+				return err
+			}
+		}
+	}
+	if !encrypted {
+		return errors.New("database is not encrypted")
+	}
+	return nil
+}
+
+// ReencryptTableFiles re-encrypts the table files, archives and chunk journal records of |cs| which are not
+// encrypted with its current key. It returns the number of files and journal records which were re-encrypted.
+func ReencryptTableFiles(ctx context.Context, cs chunks.ChunkStore) (int, error) {
+	var cnt int
+	for _, st := range localBlockStores(cs) {
+		var ftp *fsTablePersister
+		var wr *journalWriter
+		st.mu.RLock()
+		switch p := st.persister.(type) {
+		case *fsTablePersister:
+			ftp = p
+		case *ChunkJournal:
+			ftp, wr = p.persister, p.wr
+		}
+		st.mu.RUnlock()
+		if ftp == nil || ftp.enc == nil {
+			continue
+		}
+
+		n, err := ftp.reencryptTableFiles(ctx)
+		cnt += n
+		if err != nil {
+			return cnt, err
+		}
+		if wr != nil {
+			n, err = wr.reencrypt(ctx)
+			cnt += n
+			if err != nil {
+				return cnt, err
+			}
+		}
+	}
+	return cnt, nil
+}
+
+// reencryptTableFiles re-encrypts the table files and archives in |ftp.dir| which are not encrypted with the
+// current key.
+func (ftp *fsTablePersister) reencryptTableFiles(ctx context.Context) (int, error) {
+	current := ftp.enc.currentKey()
+	entries, err := os.ReadDir(ftp.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	var cnt int
+	for _, e := range entries {
+		name := e.Name()
+		base := strings.TrimSuffix(name, ArchiveFileSuffix)
+		if e.IsDir() || len(base) != hash.StringLen || base == chunkJournalAddr {
+			continue
+		} else if _, ok := hash.MaybeParse(base); !ok {
+			continue
+		}
+
+		path := filepath.Join(ftp.dir, name)
+		id, err := encryptedFileKeyID(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // removed by a concurrent prune
+		} else if err != nil {
+			return cnt, err
+		} else if id == current.id {
+			continue
+		}
+
+		if err = ftp.reencryptFile(ctx, path); err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+	return cnt, nil
+}
+
+// reencryptFile replaces the table file or archive at |path| with a copy encrypted with the current key.
+func (ftp *fsTablePersister) reencryptFile(ctx context.Context, path string) (err error) {
+	fra, err := newFileReaderAt(path, ftp.enc)
+	if err != nil {
+		return err
+	}
+	defer fra.Close()
+	r, err := fra.Reader(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	ftp.removeMu.Lock()
+	temp, err := tempfiles.MovableTempFileProvider.NewFile(ftp.dir, tempTablePrefix)
+	if err != nil {
+		ftp.removeMu.Unlock()
+		return err
+	}
+	tempName := filepath.Clean(temp.Name())
+	ftp.curTmps[tempName] = struct{}{}
+	ftp.removeMu.Unlock()
+	defer func() {
+		ftp.removeMu.Lock()
+		delete(ftp.curTmps, tempName)
+		ftp.removeMu.Unlock()
+	}()
+
+	w, err := ftp.fileWriter(temp)
+	if err == nil {
+		_, err = io.Copy(w, r)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err == nil {
+		err = temp.Sync()
+	}
+	if cerr := temp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = file.Remove(tempName)
+		return err
+	}
+
+	ftp.removeMu.Lock()
+	defer ftp.removeMu.Unlock()
+	if ftp.toKeep != nil {
+		ftp.toKeep[filepath.Clean(path)] = struct{}{}
+	}
+	return file.Rename(tempName, path)
+}
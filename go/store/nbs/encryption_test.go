@@ -0,0 +1,385 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/constants"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func makeTestEncryptionKey(t *testing.T) string {
+	var key [encryptionKeySize]byte
+	_, err := rand.Read(key[:])
+	require.NoError(t, err)
+	return hex.EncodeToString(key[:])
+}
+
+func initTestEncryption(ctx context.Context, dir string) error {
+	if ok, err := IsEncrypted(dir); err != nil || ok {
+		return err
+	}
+	keys, err := LoadEncryptionKeys()
+	if err != nil {
+		return err
+	}
+	_, err = InitEncryption(ctx, dir, keys)
+	return err
+}
+
+func TestEncryptedLocalStoreSuite(t *testing.T) {
+	t.Setenv(dconfig.EnvEncryptionKey, makeTestEncryptionKey(t))
+	fn := func(ctx context.Context, dir string) (*NomsBlockStore, error) {
+		if err := initTestEncryption(ctx, dir); err != nil {
+			return nil, err
+		}
+		return NewLocalStore(ctx, constants.FormatDefaultString, dir, testMemTableSize, NewUnlimitedMemQuotaProvider())
+	}
+	suite.Run(t, &BlockStoreSuite{factory: fn})
+}
+
+func TestEncryptedJournalingStoreSuite(t *testing.T) {
+	cacheOnce.Do(makeGlobalCaches)
+	t.Setenv(dconfig.EnvEncryptionKey, makeTestEncryptionKey(t))
+	fn := func(ctx context.Context, dir string) (*NomsBlockStore, error) {
+		if err := initTestEncryption(ctx, dir); err != nil {
+			return nil, err
+		}
+		return NewLocalJournalingStore(ctx, types.Format_Default.VersionString(), dir, NewUnlimitedMemQuotaProvider())
+	}
+	suite.Run(t, &BlockStoreSuite{
+		factory:        fn,
+		skipInterloper: true,
+	})
+}
+
+func makeTestChunkCipher(t *testing.T) *chunkCipher {
+	k, err := ParseEncryptionKey(makeTestEncryptionKey(t))
+	require.NoError(t, err)
+	return &chunkCipher{keys: NewEncryptionKeys(k), current: k}
+}
+
+func TestEncryptedFileSegments(t *testing.T) {
+	enc := makeTestChunkCipher(t)
+	const seg = encryptedFileSegmentSize
+	for _, sz := range []int{0, 1, seg - 1, seg, seg + 1, 3 * seg, 3*seg + 5} {
+		plaintext := make([]byte, sz)
+		_, err := rand.Read(plaintext)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		w, err := enc.encryptingWriter(&buf)
+		require.NoError(t, err)
+		// write in pieces which do not line up with segments
+		for p := plaintext; len(p) > 0; {
+			n := min(len(p), 1000)
+			_, err = w.Write(p[:n])
+			require.NoError(t, err)
+			p = p[n:]
+		}
+		require.NoError(t, w.Close())
+		file := buf.Bytes()
+
+		contentSz, err := encryptedContentSize(int64(len(file) - encryptedFileHeaderSize))
+		require.NoError(t, err)
+		require.Equal(t, int64(sz), contentSz)
+		fc, err := enc.readFileHeader(bytes.NewReader(file), "test")
+		require.NoError(t, err)
+
+		for _, off := range []int{0, 1, seg - 1, seg, seg + 1, 2*seg + 7, sz} {
+			if off > sz {
+				continue
+			}
+			for _, l := range []int{1, 100, seg, 2*seg + 3} {
+				p := make([]byte, l)
+				n, err := fc.readAt(bytes.NewReader(file), p, int64(off), contentSz)
+				end := min(off+l, sz)
+				if end < off+l {
+					assert.ErrorIs(t, err, io.EOF)
+				} else {
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, plaintext[off:end], p[:n], "size %d offset %d length %d", sz, off, l)
+			}
+		}
+
+		r := fc.reader(bytes.NewReader(file[encryptedFileHeaderSize:]), contentSz)
+		actual, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, actual)
+	}
+}
+
+func TestEncryptedFileTampering(t *testing.T) {
+	enc := makeTestChunkCipher(t)
+	plaintext := make([]byte, 3*encryptedFileSegmentSize+5)
+	_, err := rand.Read(plaintext)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := enc.encryptingWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(plaintext)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	file := buf.Bytes()
+
+	read := func(file []byte) error {
+		fc, err := enc.readFileHeader(bytes.NewReader(file), "test")
+		if err != nil {
+			return err
+		}
+		sz, err := encryptedContentSize(int64(len(file) - encryptedFileHeaderSize))
+		if err != nil {
+			return err
+		}
+		_, err = fc.readAt(bytes.NewReader(file), make([]byte, sz), 0, sz)
+		return err
+	}
+	require.NoError(t, read(file))
+
+	// flipping any bit of the nonce or of a segment fails authentication
+	for _, off := range []int{encryptedFileHeaderSize - 1, encryptedFileHeaderSize, encryptedFileHeaderSize + encryptedFileSegmentSealedSz + 10, len(file) - 1} {
+		tampered := bytes.Clone(file)
+		tampered[off] ^= 1
+		assert.ErrorIs(t, read(tampered), ErrDecryptionFailed, "offset %d", off)
+	}
+
+	// segments can not be dropped or reordered
+	truncated := file[:encryptedFileHeaderSize+3*encryptedFileSegmentSealedSz]
+	assert.ErrorIs(t, read(truncated), ErrDecryptionFailed)
+	swapped := bytes.Clone(file)
+	s0 := swapped[encryptedFileHeaderSize:][:encryptedFileSegmentSealedSz]
+	s1 := swapped[encryptedFileHeaderSize+encryptedFileSegmentSealedSz:][:encryptedFileSegmentSealedSz]
+	tmp := bytes.Clone(s0)
+	copy(s0, s1)
+	copy(s1, tmp)
+	assert.ErrorIs(t, read(swapped), ErrDecryptionFailed)
+}
+
+func TestEncryptedChunkRecordTampering(t *testing.T) {
+	enc := makeTestChunkCipher(t)
+	data := []byte("encrypted chunk record payload")
+	cc := ChunkToCompressedChunk(chunks.NewChunk(data))
+	var nonce [encryptionNonceSize]byte
+	_, err := rand.Read(nonce[:])
+	require.NoError(t, err)
+
+	l, _ := encryptedChunkRecordSize(cc)
+	buf := make([]byte, l)
+	writeEncryptedChunkRecord(buf, cc, enc.currentKey(), nonce)
+	rec, err := readJournalRecord(buf)
+	require.NoError(t, err)
+	payload, err := rec.decryptPayload(enc)
+	require.NoError(t, err)
+	assert.Equal(t, cc.FullCompressedChunk, payload)
+	sz, err := rec.uncompressedPayloadSize(enc)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(data)), sz)
+
+	rec.payload[0] ^= 1
+	_, err = rec.decryptPayload(enc)
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+	rec.payload[0] ^= 1
+
+	// the payload is bound to the address of its chunk
+	rec.address[0] ^= 1
+	_, err = rec.decryptPayload(enc)
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	key := make([]byte, encryptionKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	fromHex, err := ParseEncryptionKey(hex.EncodeToString(key))
+	require.NoError(t, err)
+	fromB64, err := ParseEncryptionKey(" " + "\n" + base64.StdEncoding.EncodeToString(key) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, fromHex.ID(), fromB64.ID())
+
+	_, err = ParseEncryptionKey(hex.EncodeToString(key[:16]))
+	assert.Error(t, err)
+	_, err = ParseEncryptionKey("not a key")
+	assert.Error(t, err)
+}
+
+func TestEncryptionKeyFile(t *testing.T) {
+	k1, k2 := makeTestEncryptionKey(t), makeTestEncryptionKey(t)
+	path := filepath.Join(t.TempDir(), "keys")
+	require.NoError(t, os.WriteFile(path, []byte("# primary\n"+k1+"\n\n"+k2+"\n"), 0600))
+	t.Setenv(dconfig.EnvEncryptionKey, "")
+	t.Setenv(dconfig.EnvEncryptionKeyFile, path)
+
+	keys, err := LoadEncryptionKeys()
+	require.NoError(t, err)
+	primary, err := keys.Primary(context.Background())
+	require.NoError(t, err)
+	expected, err := ParseEncryptionKey(k1)
+	require.NoError(t, err)
+	assert.Equal(t, expected.ID(), primary.ID())
+
+	second, err := ParseEncryptionKey(k2)
+	require.NoError(t, err)
+	_, err = keys.Get(context.Background(), second.ID())
+	assert.NoError(t, err)
+	_, err = keys.Get(context.Background(), EncryptionKeyID{})
+	assert.ErrorIs(t, err, ErrNoEncryptionKey)
+}
+
+func TestEncryptedStoreRoundTrip(t *testing.T) {
+	cacheOnce.Do(makeGlobalCaches)
+	t.Run("TableFiles", func(t *testing.T) {
+		testEncryptedStoreRoundTrip(t, func(ctx context.Context, dir string) (*NomsBlockStore, error) {
+			return NewLocalStore(ctx, constants.FormatDefaultString, dir, testMemTableSize, NewUnlimitedMemQuotaProvider())
+		})
+	})
+	t.Run("Journal", func(t *testing.T) {
+		testEncryptedStoreRoundTrip(t, func(ctx context.Context, dir string) (*NomsBlockStore, error) {
+			return NewLocalJournalingStore(ctx, types.Format_Default.VersionString(), dir, NewUnlimitedMemQuotaProvider())
+		})
+	})
+}
+
+func testEncryptedStoreRoundTrip(t *testing.T, factory func(ctx context.Context, dir string) (*NomsBlockStore, error)) {
+	ctx := context.Background()
+	k1, k2 := makeTestEncryptionKey(t), makeTestEncryptionKey(t)
+	t.Setenv(dconfig.EnvEncryptionKey, k1)
+	dir := t.TempDir()
+	require.NoError(t, initTestEncryption(ctx, dir))
+
+	open := func() *NomsBlockStore {
+		st, err := factory(ctx, dir)
+		require.NoError(t, err)
+		return st
+	}
+
+	marker := []byte("this-chunk-data-must-not-be-stored-in-plaintext")
+	var inserted []chunks.Chunk
+	put := func(st *NomsBlockStore) {
+		for i := 0; i < 16; i++ {
+			c := chunks.NewChunk(append(append([]byte{}, marker...), byte(len(inserted))))
+			require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+			inserted = append(inserted, c)
+		}
+		root, err := st.Root(ctx)
+		require.NoError(t, err)
+		ok, err := st.Commit(ctx, inserted[len(inserted)-1].Hash(), root)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	verify := func(st *NomsBlockStore) {
+		for _, c := range inserted {
+			actual, err := st.Get(ctx, c.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, c.Data(), actual.Data())
+		}
+	}
+	assertNoPlaintext := func() {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		for _, e := range entries {
+			data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+			require.NoError(t, err)
+			assert.False(t, bytes.Contains(data, marker), "plaintext found in %s", e.Name())
+		}
+	}
+
+	st := open()
+	put(st)
+	verify(st)
+	require.NoError(t, st.Close())
+	assertNoPlaintext()
+
+	// table files are read back as plaintext, which is what is pushed to remotes
+	st = open()
+	verify(st)
+	put(st)
+	_, sources, _, err := st.Sources(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, sources)
+	for _, tf := range sources {
+		rd, sz, err := tf.Open(ctx)
+		require.NoError(t, err)
+		data, err := io.ReadAll(rd)
+		require.NoError(t, err)
+		require.NoError(t, rd.Close())
+		assert.Equal(t, sz, uint64(len(data)))
+		if tf.FileID() == chunkJournalAddr {
+			continue
+		}
+		idx, err := parseTableIndexByCopy(ctx, data, &UnlimitedQuotaProvider{})
+		require.NoError(t, err)
+		assert.Equal(t, tf.NumChunks(), int(idx.chunkCount()))
+		idx.Close()
+	}
+	require.NoError(t, st.Close())
+	assertNoPlaintext()
+
+	// the store cannot be opened without its key
+	t.Setenv(dconfig.EnvEncryptionKey, k2)
+	_, err = factory(ctx, dir)
+	assert.ErrorIs(t, err, ErrNoEncryptionKey)
+
+	// rotate to |k2|, after which |k1| is no longer needed
+	t.Setenv(dconfig.EnvEncryptionKey, k2+","+k1)
+	st = open()
+	newKey, err := ParseEncryptionKey(k2)
+	require.NoError(t, err)
+	require.NoError(t, RotateEncryptionKey(st, newKey))
+	n, err := ReencryptTableFiles(ctx, st)
+	require.NoError(t, err)
+	assert.Greater(t, n, 0)
+	verify(st)
+	require.NoError(t, st.Close())
+
+	t.Setenv(dconfig.EnvEncryptionKey, k2)
+	st = open()
+	id, ok := EncryptionKeyIDOf(st)
+	assert.True(t, ok)
+	assert.Equal(t, newKey.ID(), id)
+	verify(st)
+	require.NoError(t, st.Close())
+	assertNoPlaintext()
+}
+
+func TestInitEncryptionExistingStore(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(dconfig.EnvEncryptionKey, makeTestEncryptionKey(t))
+	st, dir, _ := makeTestLocalStore(t, defaultMaxTables)
+	defer st.Close()
+	keys, err := LoadEncryptionKeys()
+	require.NoError(t, err)
+	_, err = InitEncryption(ctx, dir, keys)
+	assert.Error(t, err)
+	_, ok := EncryptionKeyIDOf(st)
+	assert.False(t, ok)
+	assert.Error(t, RotateEncryptionKey(st, EncryptionKey{}))
+}
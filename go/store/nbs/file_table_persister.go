@@ -43,13 +43,15 @@ import (
 
 const tempTablePrefix = "nbs_table_"
 
-func newFSTablePersister(dir string, q MemoryQuotaProvider) tablePersister {
-	return &fsTablePersister{dir, q, sync.Mutex{}, nil, make(map[string]struct{})}
+func newFSTablePersister(dir string, q MemoryQuotaProvider, enc *chunkCipher) tablePersister {
+	return &fsTablePersister{dir, q, enc, sync.Mutex{}, nil, make(map[string]struct{})}
 }
 
 type fsTablePersister struct {
 	dir string
 	q   MemoryQuotaProvider
+	// enc encrypts and decrypts table files and archives. It is nil if the store is not encrypted.
+	enc *chunkCipher
 
 	// Protects the following two maps.
 	removeMu sync.Mutex
@@ -70,7 +72,7 @@ var _ tablePersister = &fsTablePersister{}
 var _ tableFilePersister = &fsTablePersister{}
 
 func (ftp *fsTablePersister) Open(ctx context.Context, name hash.Hash, chunkCount uint32, stats *Stats) (chunkSource, error) {
-	return newFileTableReader(ctx, ftp.dir, name, chunkCount, ftp.q, stats)
+	return newFileTableReader(ctx, ftp.dir, name, chunkCount, ftp.q, ftp.enc, stats)
 }
 
 func (ftp *fsTablePersister) Exists(ctx context.Context, name string, chunkCount uint32, stats *Stats) (bool, error) {
@@ -115,6 +117,10 @@ func (ftp *fsTablePersister) Path() string {
 }
 
 func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, fileId string, fileSz uint64, chunkCount uint32) error {
+	if ftp.enc != nil && fileId == chunkJournalAddr {
+		return fmt.Errorf("cannot copy a chunk journal into an encrypted database: %w", errEncryptedStore)
+	}
+
 	tn, f, err := func() (n string, cleanup func(), err error) {
 		ftp.removeMu.Lock()
 		var temp *os.File
@@ -139,7 +145,17 @@ func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, fil
 			}
 		}()
 
-		_, err = io.Copy(temp, r)
+		w, err := ftp.fileWriter(temp)
+		if err != nil {
+			return "", cleanup, err
+		}
+
+		_, err = io.Copy(w, r)
+		if err != nil {
+			return "", cleanup, err
+		}
+
+		err = w.Close()
 		if err != nil {
 			return "", cleanup, err
 		}
@@ -166,6 +182,10 @@ func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, fil
 }
 
 func (ftp *fsTablePersister) TryMoveCmpChunkTableWriter(ctx context.Context, filename string, w *CmpChunkTableWriter) error {
+	if ftp.enc != nil {
+		// the writer's file is not encrypted, the caller must copy it with CopyTableFile instead
+		return errEncryptedStore
+	}
 	path := filepath.Join(ftp.dir, filename)
 	ftp.removeMu.Lock()
 	if ftp.toKeep != nil {
@@ -204,7 +224,18 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, d
 			}
 		}()
 
-		_, ferr = io.Copy(temp, bytes.NewReader(data))
+		var w io.WriteCloser
+		w, ferr = ftp.fileWriter(temp)
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
+
+		_, ferr = io.Copy(w, bytes.NewReader(data))
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
+
+		ferr = w.Close()
 		if ferr != nil {
 			return "", cleanup, ferr
 		}
@@ -270,6 +301,12 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 			}
 		}()
 
+		var w io.WriteCloser
+		w, ferr = ftp.fileWriter(temp)
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
+
 		for _, sws := range plan.sources.sws {
 			var r io.ReadCloser
 			r, _, ferr = sws.source.reader(ctx)
@@ -277,7 +314,7 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 				return "", cleanup, ferr
 			}
 
-			n, ferr := io.CopyN(temp, r, int64(sws.dataLen))
+			n, ferr := io.CopyN(w, r, int64(sws.dataLen))
 			if ferr != nil {
 				r.Close()
 				return "", cleanup, ferr
@@ -294,8 +331,13 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 			}
 		}
 
-		_, ferr = temp.Write(plan.mergedIndex)
+		_, ferr = w.Write(plan.mergedIndex)
+
+		if ferr != nil {
+			return "", cleanup, ferr
+		}
 
+		ferr = w.Close()
 		if ferr != nil {
 			return "", cleanup, ferr
 		}
@@ -433,6 +475,23 @@ func (ftp *fsTablePersister) PruneTableFiles(ctx context.Context, keeper func()
 	return nil
 }
 
+// fileWriter returns a writer for the content of a new table file or archive written to |f|, which encrypts the
+// content if the store is encrypted. Closing the writer finishes the content but does not close |f|.
+func (ftp *fsTablePersister) fileWriter(f *os.File) (io.WriteCloser, error) {
+	if ftp.enc == nil {
+		return nopWriteCloser{f}, nil
+	}
+	return ftp.enc.encryptingWriter(f)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
 func (ftp *fsTablePersister) Close() error {
 	return nil
 }
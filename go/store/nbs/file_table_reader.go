@@ -78,51 +78,71 @@ func archiveFileExists(ctx context.Context, dir string, name string) (bool, erro
 	return err == nil, err
 }
 
-func newFileTableReader(ctx context.Context, dir string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, stats *Stats) (cs chunkSource, err error) {
+func newFileTableReader(ctx context.Context, dir string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, enc *chunkCipher, stats *Stats) (cs chunkSource, err error) {
 	// we either have a table file or an archive file
 	tfExists, err := tableFileExists(ctx, dir, h)
 	if err != nil {
 		return nil, err
 	} else if tfExists {
-		return nomsFileTableReader(ctx, filepath.Join(dir, h.String()), h, chunkCount, q)
+		return nomsFileTableReader(ctx, filepath.Join(dir, h.String()), h, chunkCount, q, enc)
 	}
 
 	afExists, err := archiveFileExists(ctx, dir, h.String())
 	if err != nil {
 		return nil, err
 	} else if afExists {
-		return newArchiveChunkSource(ctx, dir, h, chunkCount, q, stats)
+		return newArchiveChunkSource(ctx, dir, h, chunkCount, q, enc, stats)
 	}
 	return nil, fmt.Errorf("error opening table file: %w: %s/%s", ErrTableFileNotFound, dir, h.String())
 }
 
-func newFileReaderAt(path string) (*fileReaderAt, error) {
+// newFileReaderAt opens the file at |path|. If |enc| is non-nil, the file is encrypted, and the fileReaderAt reads
+// its decrypted content.
+func newFileReaderAt(path string, enc *chunkCipher) (*fileReaderAt, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 	fi, err := f.Stat()
 	if err != nil {
+		f.Close()
 		return nil, err
 	}
 	if fi.Size() < 0 {
 		// Size returns the number of bytes for regular files and is system dependent for others (Some of which can be negative).
+		f.Close()
 		return nil, fmt.Errorf("%s has invalid size: %d", path, fi.Size())
 	}
+	sz := fi.Size()
+
+	var fc *fileCipher
+	if enc != nil {
+		c, err := enc.readFileHeader(f, path)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		fc = &c
+		if sz, err = encryptedContentSize(sz - int64(encryptedFileHeaderSize)); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("cannot decrypt %s: %w", path, err)
+		}
+	}
+
 	cnt := new(int32)
 	*cnt = 1
-	return &fileReaderAt{f, path, fi.Size(), cnt}, nil
+	return &fileReaderAt{f, path, sz, enc, fc, cnt}, nil
 }
 
-func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider) (cs chunkSource, err error) {
-	fra, err := newFileReaderAt(path)
+func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, enc *chunkCipher) (cs chunkSource, err error) {
+	fra, err := newFileReaderAt(path, enc)
 	if err != nil {
 		return nil, err
 	}
 
 	idxSz := int64(indexSize(chunkCount) + footerSize)
 	indexOffset := fra.sz - idxSz
-	r := io.NewSectionReader(fra.f, indexOffset, idxSz)
+	r := io.NewSectionReader(fra, indexOffset, idxSz)
 	if int64(int(idxSz)) != idxSz {
 		err = fmt.Errorf("table file %s is too large to read on this platform. index size %d > max int.", path, idxSz)
 		return
@@ -190,7 +210,11 @@ func (ftr *fileTableReader) clone() (chunkSource, error) {
 type fileReaderAt struct {
 	f    *os.File
 	path string
-	sz   int64
+	// sz is the size of the file content, which excludes the header of encrypted files.
+	sz int64
+	// enc and fc are non-nil for encrypted files. |fc| decrypts the content of this file.
+	enc *chunkCipher
+	fc  *fileCipher
 	// refcnt, clone() increments and Close() decrements. The *os.File is closed when it reaches 0.
 	cnt *int32
 }
@@ -199,12 +223,14 @@ func (fra *fileReaderAt) clone() (tableReaderAt, error) {
 	if dynassert.Assert(atomic.AddInt32(fra.cnt, 1) > 1, "attempt to clone a closed fileReaderAt") {
 		// Restore previous refcnt, even know we're in a weird state...
 		atomic.AddInt32(fra.cnt, -1)
-		return newFileReaderAt(fra.path)
+		return newFileReaderAt(fra.path, fra.enc)
 	}
 	return &fileReaderAt{
 		fra.f,
 		fra.path,
 		fra.sz,
+		fra.enc,
+		fra.fc,
 		fra.cnt,
 	}, nil
 }
@@ -219,7 +245,30 @@ func (fra *fileReaderAt) Close() error {
 }
 
 func (fra *fileReaderAt) Reader(ctx context.Context) (io.ReadCloser, error) {
-	return os.Open(fra.path)
+	f, err := os.Open(fra.path)
+	if err != nil || fra.fc == nil {
+		return f, err
+	}
+	if _, err = f.Seek(int64(encryptedFileHeaderSize), io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{fra.fc.reader(f, fra.sz), f}, nil
+}
+
+// ReadAt reads the file content at |off|, decrypting it if the file is encrypted.
+func (fra *fileReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
+	if fra.fc == nil {
+		return fra.f.ReadAt(p, off)
+	}
+	n, err = fra.fc.readAt(fra.f, p, off, fra.sz)
+	if errors.Is(err, ErrDecryptionFailed) {
+		err = fmt.Errorf("cannot decrypt %s: %w", fra.path, err)
+	}
+	return n, err
 }
 
 func (fra *fileReaderAt) ReadAtWithStats(ctx context.Context, p []byte, off int64, stats *Stats) (n int, err error) {
@@ -228,11 +277,11 @@ func (fra *fileReaderAt) ReadAtWithStats(ctx context.Context, p []byte, off int6
 		stats.FileBytesPerRead.Sample(uint64(len(p)))
 		stats.FileReadLatency.SampleTimeSince(t1)
 	}()
-	return fra.f.ReadAt(p, off)
+	return fra.ReadAt(p, off)
 }
 
-func newTableFileMetadata(path string, chunkCount uint32) (*TableFileMetadata, error) {
-	fra, err := newFileReaderAt(path)
+func newTableFileMetadata(path string, chunkCount uint32, enc *chunkCipher) (*TableFileMetadata, error) {
+	fra, err := newFileReaderAt(path, enc)
 	if err != nil {
 		return nil, err
 	}
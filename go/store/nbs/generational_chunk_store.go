@@ -40,6 +40,7 @@ type GenerationalNBS struct {
 	oldGen   *NomsBlockStore
 	newGen   *NomsBlockStore
 	ghostGen *GhostBlockStore
+	lazyGen  *LazyBlockStore
 }
 
 var ErrGhostChunkRequested = errors.New("requested chunk which is expected to be a ghost chunk")
@@ -55,6 +56,61 @@ func (gcs *GenerationalNBS) GhostGen() chunks.ChunkStore {
 	return gcs.ghostGen
 }
 
+// LoadLazyGen makes this store a lazy clone if the database in its directory was cloned lazily.
+func (gcs *GenerationalNBS) LoadLazyGen() error {
+	path, ok := gcs.newGen.Path()
+	if !ok {
+		return nil
+	}
+	lazyGen, err := newLazyBlockStore(path, gcs.oldGen)
+	if err != nil {
+		return err
+	}
+	gcs.lazyGen = lazyGen
+	return nil
+}
+
+// PersistLazyHashes makes this store a lazy clone, which is missing the commits |refs| and the chunks they reference.
+// Missing chunks are fetched from the source given to SetLazySource when they are read.
+func (gcs *GenerationalNBS) PersistLazyHashes(ctx context.Context, refs hash.HashSet) error {
+	path, ok := gcs.newGen.Path()
+	if !ok {
+		return fmt.Errorf("runtime error. lazy clones require a store on disk")
+	}
+	lazyGen, err := persistLazyHashes(path, gcs.oldGen, refs)
+	if err != nil {
+		return err
+	}
+	gcs.lazyGen = lazyGen
+	return nil
+}
+
+// IsLazy returns true if this store is a lazy clone.
+func (gcs *GenerationalNBS) IsLazy() bool {
+	return gcs.lazyGen != nil
+}
+
+// SetLazySource sets the source which a lazy clone fetches missing chunks from. It does nothing if this store is not
+// a lazy clone.
+func (gcs *GenerationalNBS) SetLazySource(open LazySource) {
+	if gcs.lazyGen != nil {
+		gcs.lazyGen.SetSource(open)
+	}
+}
+
+// CompleteLazyClone makes a lazy clone complete. |fetch| is called with the commits the clone is missing, and must
+// fetch them and all the chunks they reference into this store.
+func (gcs *GenerationalNBS) CompleteLazyClone(ctx context.Context, fetch func(ctx context.Context, lazyRefs hash.HashSet) error) error {
+	if gcs.lazyGen == nil {
+		return nil
+	}
+	if err := gcs.lazyGen.complete(ctx, fetch); err != nil {
+		return err
+	}
+	gcs.lazyGen = nil
+	return nil
+}
+
 func NewGenerationalCS(oldGen, newGen *NomsBlockStore, ghostGen *GhostBlockStore) *GenerationalNBS {
 	if oldGen.Version() != "" && oldGen.Version() != newGen.Version() {
 		panic("oldgen and newgen chunkstore versions vary")
@@ -105,6 +161,15 @@ func (gcs *GenerationalNBS) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		}
 	}
 
+	if c.IsEmpty() && gcs.lazyGen != nil {
+		err = gcs.lazyGen.getMany(ctx, hash.NewHashSet(h), func(_ context.Context, chunk *chunks.Chunk) {
+			c = *chunk
+		})
+		if err != nil {
+			return chunks.EmptyChunk, err
+		}
+	}
+
 	return c, nil
 }
 
@@ -149,10 +214,28 @@ func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, fo
 
 	// Last ditch effort to see if the requested objects are commits we've decided to ignore. Note the function spec
 	// considers non-present chunks to be silently ignored, so we don't need to return an error here
-	if gcs.ghostGen == nil {
+	if gcs.ghostGen != nil {
+		hashes = notFound
+		notFound = hashes.Copy()
+		err = gcs.ghostGen.GetMany(ctx, hashes, func(ctx context.Context, chunk *chunks.Chunk) {
+			func() {
+				mu.Lock()
+				defer mu.Unlock()
+				delete(notFound, chunk.Hash())
+			}()
+
+			found(ctx, chunk)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// A lazy clone fetches the rest from its remote.
+	if gcs.lazyGen == nil || len(notFound) == 0 {
 		return nil
 	}
-	return gcs.ghostGen.GetMany(ctx, notFound, found)
+	return gcs.lazyGen.getMany(ctx, notFound, found)
 }
 
 func (gcs *GenerationalNBS) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, ToChunker)) error {
@@ -191,9 +274,26 @@ func (gcs *GenerationalNBS) getManyCompressed(ctx context.Context, hashes hash.H
 
 	// The missing chunks may be ghost chunks.
 	if gcs.ghostGen != nil {
-		return gcs.ghostGen.getManyCompressed(ctx, notFound, found, gcDepMode)
+		notGhost := notFound.Copy()
+		err = gcs.ghostGen.getManyCompressed(ctx, notFound, func(ctx context.Context, chunk ToChunker) {
+			mu.Lock()
+			delete(notGhost, chunk.Hash())
+			mu.Unlock()
+			found(ctx, chunk)
+		}, gcDepMode)
+		if err != nil {
+			return err
+		}
+		notFound = notGhost
 	}
-	return nil
+
+	// A lazy clone fetches the rest from its remote.
+	if gcs.lazyGen == nil || len(notFound) == 0 {
+		return nil
+	}
+	return gcs.lazyGen.getMany(ctx, notFound, func(ctx context.Context, chunk *chunks.Chunk) {
+		found(ctx, ChunkToCompressedChunk(*chunk))
+	})
 }
 
 // Has returns true iff the value at the address |h| is contained in the store
@@ -211,10 +311,15 @@ func (gcs *GenerationalNBS) Has(ctx context.Context, h hash.Hash) (bool, error)
 	// Possibly a truncated commit.
 	if gcs.ghostGen != nil {
 		has, err = gcs.ghostGen.Has(ctx, h)
-		if err != nil {
+		if err != nil || has {
 			return has, err
 		}
 	}
+
+	// Possibly a commit which a lazy clone has not fetched.
+	if gcs.lazyGen != nil {
+		has = gcs.lazyGen.has(h)
+	}
 	return has, nil
 }
 
@@ -232,11 +337,21 @@ func (gcs *GenerationalNBS) HasMany(ctx context.Context, hashes hash.HashSet) (h
 	if err != nil {
 		return nil, err
 	}
-	if len(absent) == 0 || gcs.ghostGen == nil {
+	if len(absent) == 0 {
 		return nil, err
 	}
 
-	return gcs.ghostGen.HasMany(ctx, absent)
+	if gcs.ghostGen != nil {
+		absent, err = gcs.ghostGen.HasMany(ctx, absent)
+		if err != nil || len(absent) == 0 {
+			return absent, err
+		}
+	}
+
+	if gcs.lazyGen != nil {
+		absent = gcs.lazyGen.hasMany(absent)
+	}
+	return absent, nil
 }
 
 // |refCheck| is called from write processes in newGen, so it is called with
@@ -257,11 +372,21 @@ func (gcs *GenerationalNBS) refCheck(recs []hasRecord) (hash.HashSet, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(absent) == 0 || gcs.ghostGen == nil {
+	if len(absent) == 0 {
 		return absent, nil
 	}
 
-	return gcs.ghostGen.refCheck(recs)
+	if gcs.ghostGen != nil {
+		absent, err = gcs.ghostGen.refCheck(recs)
+		if err != nil || len(absent) == 0 {
+			return absent, err
+		}
+	}
+
+	if gcs.lazyGen != nil {
+		return gcs.lazyGen.refCheck(context.Background(), recs)
+	}
+	return absent, nil
 }
 
 // Put caches c in the ChunkSource. Upon return, c must be visible to
@@ -333,9 +458,17 @@ func (gcs *GenerationalNBS) StatsSummary() string {
 // Close() concurrently with any other ChunkStore method; behavior is
 // undefined and probably crashy.
 func (gcs *GenerationalNBS) Close() error {
+	// A lazy clone writes the chunks it has fetched, and not cached yet, to the old gen before it is closed.
+	var lErr error
+	if gcs.lazyGen != nil {
+		lErr = gcs.lazyGen.flush(context.Background())
+	}
 	oErr := gcs.oldGen.Close()
 	nErr := gcs.newGen.Close()
 
+	if lErr != nil {
+		return lErr
+	}
 	if oErr != nil {
 		return oErr
 	}
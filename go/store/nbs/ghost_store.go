@@ -108,26 +108,35 @@ func (g *GhostBlockStore) PersistGhostHashes(ctx context.Context, hashes hash.Ha
 		return fmt.Errorf("runtime error. PersistGhostHashes called with empty hash set")
 	}
 
+	// Ghost hashes are added to the ones already persisted. A sparse clone adds placeholders for the tables it leaves
+	// out each time it fetches.
+	skippedRefs := hash.HashSet{}
+	if g.skippedRefs != nil {
+		skippedRefs = g.skippedRefs.Copy()
+	}
+	skippedRefs.InsertAll(hashes)
+
 	f, err := os.OpenFile(g.ghostObjectsFile, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	for h := range hashes {
+	for h := range skippedRefs {
 		if _, err := f.WriteString(h.String() + "\n"); err != nil {
 			return err
 		}
 	}
 
-	g.skippedRefs = &hash.HashSet{}
-	for h := range hashes {
-		g.skippedRefs.Insert(h)
-	}
-
+	g.skippedRefs = &skippedRefs
 	return nil
 }
 
+// IsEmpty returns true if there are no ghost hashes.
+func (g *GhostBlockStore) IsEmpty() bool {
+	return g == nil || g.skippedRefs.Size() == 0
+}
+
 func (g GhostBlockStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
 	if g.skippedRefs.Has(h) {
 		return true, nil
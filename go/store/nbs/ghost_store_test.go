@@ -83,4 +83,13 @@ func TestGhostBlockStore(t *testing.T) {
 		require.True(t, got[0].IsGhost())
 		require.Equal(t, ghost, got[0].Hash())
 	})
+	t.Run("PersistMore", func(t *testing.T) {
+		another := hash.Parse("8ltbqnrfbv4uc5mbgfcs6rmn9k9dpm93")
+		require.NoError(t, bs.PersistGhostHashes(ctx, hash.NewHashSet(another)))
+		reopened, err := NewGhostBlockStore(path)
+		require.NoError(t, err)
+		a, err := reopened.HasMany(ctx, hash.NewHashSet(absent, ghost, another))
+		require.NoError(t, err)
+		require.Equal(t, hash.NewHashSet(absent), a)
+	})
 }
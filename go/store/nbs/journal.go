@@ -147,6 +147,7 @@ func (j *ChunkJournal) bootstrapJournalWriter(ctx context.Context) (err error) {
 		if err != nil {
 			return err
 		}
+		j.wr.enc = j.persister.enc
 
 		_, err = j.wr.bootstrapJournal(ctx, j.reflogRingBuffer)
 		if err != nil {
@@ -174,6 +175,7 @@ func (j *ChunkJournal) bootstrapJournalWriter(ctx context.Context) (err error) {
 	} else if !ok {
 		return errors.New("missing chunk journal " + j.path)
 	}
+	j.wr.enc = j.persister.enc
 
 	// parse existing journal file
 	root, err := j.wr.bootstrapJournal(ctx, j.reflogRingBuffer)
@@ -245,6 +247,17 @@ func (j *ChunkJournal) IterateRoots(f func(root string, timestamp *time.Time) er
 	})
 }
 
+// IterateJournalRoots reads the root hash records of the chunk journal file, from oldest to newest, and passes each
+// root and the time it was written to |f|. Unlike IterateRoots, it is not limited to the most recent roots, and it
+// returns every root written since the journal was created. Roots written by older versions of Dolt have no
+// timestamp, and are passed with a zero time. If |f| returns an error, iteration is stopped and the error is returned.
+func (j *ChunkJournal) IterateJournalRoots(ctx context.Context, f func(root hash.Hash, timestamp time.Time) error) error {
+	if j.wr == nil {
+		return nil
+	}
+	return j.wr.iterateRoots(ctx, f)
+}
+
 // Persist implements tablePersister.
 func (j *ChunkJournal) Persist(ctx context.Context, mt *memTable, haver chunkReader, keeper keeperF, stats *Stats) (chunkSource, gcBehavior, error) {
 	if j.backing.readOnly() {
@@ -47,6 +47,11 @@ import (
 // followed only by the fixed-width record checksum. This allows the payload
 // to be extracted from the journalRec using only the record length and payload
 // offset. See recLookup for more detail.
+//
+// In the journals of encrypted stores, chunk records have an encryption field
+// immediately before the payload, holding the id of the key and the nonce the
+// payload is encrypted with. The payload is encrypted with AES-256 in GCM mode,
+// with the chunk address as additional data, and is followed by the GCM tag.
 type journalRec struct {
 	length    uint32
 	kind      journalRecKind
@@ -54,6 +59,10 @@ type journalRec struct {
 	payload   []byte
 	timestamp time.Time
 	checksum  uint32
+
+	encrypted bool
+	keyID     EncryptionKeyID
+	nonce     [encryptionNonceSize]byte
 }
 
 // payloadOffset returns the journalOffset of the payload within the record
@@ -62,14 +71,37 @@ func (r journalRec) payloadOffset() uint32 {
 	return r.length - uint32(len(r.payload)+journalRecChecksumSz)
 }
 
-// uncompressedPayloadSize returns the uncompressed size of the payload.
-func (r journalRec) uncompressedPayloadSize() (sz uint64) {
+// uncompressedPayloadSize returns the uncompressed size of the payload. |enc|
+// decrypts the payload of encrypted records.
+func (r journalRec) uncompressedPayloadSize(enc *chunkCipher) (sz uint64, err error) {
+	payload := r.payload
+	if r.encrypted {
+		if payload, err = r.decryptPayload(enc); err != nil {
+			return 0, err
+		}
+	}
 	// |r.payload| is snappy-encoded and starts with
 	// the uvarint-encoded uncompressed data size
-	sz, _ = binary.Uvarint(r.payload)
+	sz, _ = binary.Uvarint(payload)
 	return
 }
 
+// decryptPayload returns the decrypted payload of |r|, after authenticating it.
+func (r journalRec) decryptPayload(enc *chunkCipher) ([]byte, error) {
+	if enc == nil {
+		return nil, fmt.Errorf("cannot read encrypted chunk record %s: database is not encrypted", r.address)
+	}
+	k, err := enc.key(r.keyID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := k.aead.Open(nil, r.nonce[:], r.payload, r.address[:])
+	if err != nil {
+		return nil, fmt.Errorf("cannot read encrypted chunk record %s: %w", r.address, ErrDecryptionFailed)
+	}
+	return payload, nil
+}
+
 type journalRecKind uint8
 
 const (
@@ -81,20 +113,22 @@ const (
 type journalRecTag uint8
 
 const (
-	unknownJournalRecTag   journalRecTag = 0
-	kindJournalRecTag      journalRecTag = 1
-	addrJournalRecTag      journalRecTag = 2
-	payloadJournalRecTag   journalRecTag = 3
-	timestampJournalRecTag journalRecTag = 4
+	unknownJournalRecTag    journalRecTag = 0
+	kindJournalRecTag       journalRecTag = 1
+	addrJournalRecTag       journalRecTag = 2
+	payloadJournalRecTag    journalRecTag = 3
+	timestampJournalRecTag  journalRecTag = 4
+	encryptionJournalRecTag journalRecTag = 5
 )
 
 const (
-	journalRecTagSz       = 1
-	journalRecLenSz       = 4
-	journalRecKindSz      = 1
-	journalRecAddrSz      = 20
-	journalRecChecksumSz  = 4
-	journalRecTimestampSz = 8
+	journalRecTagSz        = 1
+	journalRecLenSz        = 4
+	journalRecKindSz       = 1
+	journalRecAddrSz       = 20
+	journalRecChecksumSz   = 4
+	journalRecTimestampSz  = 8
+	journalRecEncryptionSz = encryptionKeyIDSize + encryptionNonceSize
 )
 
 // journalRecordTimestampGenerator returns the current time in Unix epoch seconds. This function is stored in a
@@ -123,6 +157,15 @@ func chunkRecordSize(c CompressedChunk) (recordSz, payloadOff uint32) {
 	return recordSz, payloadOff
 }
 
+// encryptedChunkRecordSize returns the record size and payload offset of
+// a chunk record for |c| with an encrypted payload.
+func encryptedChunkRecordSize(c CompressedChunk) (recordSz, payloadOff uint32) {
+	recordSz, payloadOff = chunkRecordSize(c)
+	recordSz += journalRecTagSz + journalRecEncryptionSz + encryptionTagSize
+	payloadOff += journalRecTagSz + journalRecEncryptionSz
+	return
+}
+
 func rootHashRecordSize() (recordSz int) {
 	recordSz += journalRecLenSz
 	recordSz += journalRecTagSz + journalRecKindSz
@@ -161,6 +204,41 @@ func writeChunkRecord(buf []byte, c CompressedChunk) (n uint32) {
 	return
 }
 
+// writeEncryptedChunkRecord writes a chunk record for |c| to |buf|, with
+// its payload encrypted with |key| and |nonce|.
+func writeEncryptedChunkRecord(buf []byte, c CompressedChunk, key EncryptionKey, nonce [encryptionNonceSize]byte) (n uint32) {
+	l, _ := encryptedChunkRecordSize(c)
+	writeUint32(buf[:journalRecLenSz], l)
+	n += journalRecLenSz
+	// kind
+	buf[n] = byte(kindJournalRecTag)
+	n += journalRecTagSz
+	buf[n] = byte(chunkJournalRecKind)
+	n += journalRecKindSz
+	// address
+	buf[n] = byte(addrJournalRecTag)
+	n += journalRecTagSz
+	copy(buf[n:], c.H[:])
+	n += journalRecAddrSz
+	// encryption
+	buf[n] = byte(encryptionJournalRecTag)
+	n += journalRecTagSz
+	copy(buf[n:], key.id[:])
+	n += encryptionKeyIDSize
+	copy(buf[n:], nonce[:])
+	n += encryptionNonceSize
+	// payload
+	buf[n] = byte(payloadJournalRecTag)
+	n += journalRecTagSz
+	sealed := key.aead.Seal(buf[n:n], nonce[:], c.FullCompressedChunk, c.H[:])
+	n += uint32(len(sealed))
+	// checksum
+	writeUint32(buf[n:], crc(buf[:n]))
+	n += journalRecChecksumSz
+	d.PanicIfFalse(l == n)
+	return
+}
+
 func writeRootHashRecord(buf []byte, root hash.Hash) (n uint32) {
 	// length
 	l := rootHashRecordSize()
@@ -210,6 +288,11 @@ func readJournalRecord(buf []byte) (rec journalRec, err error) {
 			unixSeconds := readUint64(buf)
 			rec.timestamp = time.Unix(int64(unixSeconds), 0)
 			buf = buf[journalRecTimestampSz:]
+		case encryptionJournalRecTag:
+			rec.encrypted = true
+			copy(rec.keyID[:], buf)
+			copy(rec.nonce[:], buf[encryptionKeyIDSize:])
+			buf = buf[journalRecEncryptionSz:]
 		case payloadJournalRecTag:
 			sz := len(buf) - journalRecChecksumSz
 			rec.payload = buf[:sz]
@@ -17,6 +17,7 @@ package nbs
 import (
 	"bufio"
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"hash/crc32"
@@ -25,10 +26,12 @@ import (
 	"path/filepath"
 	"runtime/trace"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/dolthub/dolt/go/libraries/utils/file"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
 )
@@ -177,6 +180,9 @@ type journalWriter struct {
 	batchCrc    uint32
 	maxNovel    int
 
+	// enc encrypts and decrypts chunk record payloads. It is nil if the store is not encrypted.
+	enc *chunkCipher
+
 	lock sync.RWMutex
 }
 
@@ -312,7 +318,11 @@ func (wr *journalWriter) bootstrapJournal(ctx context.Context, reflogRingBuffer
 				Length: uint32(len(r.payload)),
 			}
 			wr.ranges.put(r.address, rng)
-			wr.uncmpSz += r.uncompressedPayloadSize()
+			sz, err := r.uncompressedPayloadSize(wr.enc)
+			if err != nil {
+				return err
+			}
+			wr.uncmpSz += sz
 
 			a := toAddr16(r.address)
 			if err := writeIndexLookup(wr.indexWriter, lookup{a: a, r: rng}); err != nil {
@@ -390,8 +400,8 @@ func (wr *journalWriter) getCompressedChunk(h hash.Hash) (CompressedChunk, error
 	if !ok {
 		return CompressedChunk{}, nil
 	}
-	buf := make([]byte, r.Length)
-	if _, err := wr.readAt(buf, int64(r.Offset)); err != nil {
+	buf, err := wr.readPayload(r, h)
+	if err != nil {
 		return CompressedChunk{}, err
 	}
 	return NewCompressedChunk(hash.Hash(h), buf)
@@ -399,13 +409,39 @@ func (wr *journalWriter) getCompressedChunk(h hash.Hash) (CompressedChunk, error
 
 // getCompressedChunk reads the CompressedChunks with addr |h|.
 func (wr *journalWriter) getCompressedChunkAtRange(r Range, h hash.Hash) (CompressedChunk, error) {
-	buf := make([]byte, r.Length)
-	if _, err := wr.readAt(buf, int64(r.Offset)); err != nil {
+	buf, err := wr.readPayload(r, h)
+	if err != nil {
 		return CompressedChunk{}, err
 	}
 	return NewCompressedChunk(hash.Hash(h), buf)
 }
 
+// readPayload reads the payload of the chunk record for |h| at |r|, decrypting it if the journal is encrypted.
+func (wr *journalWriter) readPayload(r Range, h hash.Hash) ([]byte, error) {
+	if wr.enc == nil {
+		buf := make([]byte, r.Length)
+		if _, err := wr.readAt(buf, int64(r.Offset)); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+
+	// the encryption field of an encrypted chunk record immediately precedes the payload field
+	const prefixSz = journalRecTagSz + journalRecEncryptionSz + journalRecTagSz
+	buf := make([]byte, prefixSz+int(r.Length))
+	if _, err := wr.readAt(buf, int64(r.Offset)-prefixSz); err != nil {
+		return nil, err
+	}
+	if journalRecTag(buf[0]) != encryptionJournalRecTag || journalRecTag(buf[prefixSz-1]) != payloadJournalRecTag {
+		return nil, fmt.Errorf("chunk record at offset %d of encrypted journal %s is not encrypted", r.Offset, wr.path)
+	}
+	rec := journalRec{encrypted: true, payload: buf[prefixSz:]}
+	copy(rec.keyID[:], buf[journalRecTagSz:])
+	copy(rec.nonce[:], buf[journalRecTagSz+encryptionKeyIDSize:])
+	rec.address = h
+	return rec.decryptPayload(wr.enc)
+}
+
 // getRange returns a Range for the chunk with addr |h|.
 func (wr *journalWriter) getRange(ctx context.Context, h hash.Hash) (rng Range, ok bool, err error) {
 	// callers will use |rng| to read directly from the
@@ -421,19 +457,33 @@ func (wr *journalWriter) getRange(ctx context.Context, h hash.Hash) (rng Range,
 
 // writeCompressedChunk writes |cc| to the journal.
 func (wr *journalWriter) writeCompressedChunk(ctx context.Context, cc CompressedChunk) error {
+	var nonce [encryptionNonceSize]byte
+	if wr.enc != nil {
+		if _, err := rand.Read(nonce[:]); err != nil {
+			return err
+		}
+	}
+
 	wr.lock.Lock()
 	defer wr.lock.Unlock()
 	recordLen, payloadOff := chunkRecordSize(cc)
+	if wr.enc != nil {
+		recordLen, payloadOff = encryptedChunkRecordSize(cc)
+	}
 	rng := Range{
 		Offset: uint64(wr.offset()) + uint64(payloadOff),
-		Length: uint32(len(cc.FullCompressedChunk)),
+		Length: recordLen - payloadOff - journalRecChecksumSz,
 	}
 	buf, err := wr.getBytes(ctx, int(recordLen))
 	if err != nil {
 		return err
 	}
 	wr.unsyncd += uint64(recordLen)
-	_ = writeChunkRecord(buf, cc)
+	if wr.enc != nil {
+		_ = writeEncryptedChunkRecord(buf, cc, wr.enc.currentKey(), nonce)
+	} else {
+		_ = writeChunkRecord(buf, cc)
+	}
 	wr.ranges.put(cc.H, rng)
 
 	a := toAddr16(cc.H)
@@ -611,6 +661,9 @@ func (wr *journalWriter) snapshot(ctx context.Context) (io.ReadCloser, int64, er
 	if err != nil {
 		return nil, 0, err
 	}
+	if wr.enc != nil {
+		return decryptedJournalSnapshot(ctx, f, wr.off, wr.enc)
+	}
 	return journalWriterSnapshot{
 		io.LimitReader(f, wr.off),
 		func() error {
@@ -619,6 +672,147 @@ func (wr *journalWriter) snapshot(ctx context.Context) (io.ReadCloser, int64, er
 	}, wr.off, nil
 }
 
+// iterateRoots calls |f| with the address and timestamp of each root hash record in the journal, from oldest to
+// newest. The journal is read through a new file descriptor, so writes to the journal are not blocked while it is read.
+func (wr *journalWriter) iterateRoots(ctx context.Context, f func(hash.Hash, time.Time) error) error {
+	wr.lock.Lock()
+	err := wr.flush(ctx)
+	sz := wr.off
+	wr.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	rd, err := os.Open(wr.path)
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+
+	_, err = processJournalRecords(ctx, io.NewSectionReader(rd, 0, sz), 0, func(_ int64, r journalRec) error {
+		if r.kind != rootHashJournalRecKind {
+			return nil
+		}
+		return f(r.address, r.timestamp)
+	})
+	return err
+}
+
+// decryptedJournalSnapshot returns the first |sz| bytes of the encrypted journal |f| as an unencrypted journal,
+// along with its size. Chunk records are decrypted and lose their encryption fields, so the unencrypted journal is
+// smaller than |sz|.
+func decryptedJournalSnapshot(ctx context.Context, f *os.File, sz int64, enc *chunkCipher) (io.ReadCloser, int64, error) {
+	var plainSz int64
+	_, err := processJournalRecords(ctx, io.NewSectionReader(f, 0, sz), 0, func(o int64, r journalRec) error {
+		plainSz += int64(r.length)
+		if r.encrypted {
+			plainSz -= journalRecTagSz + journalRecEncryptionSz + encryptionTagSize
+		}
+		return nil
+	})
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := processJournalRecords(ctx, io.NewSectionReader(f, 0, sz), 0, func(o int64, r journalRec) error {
+			if !r.encrypted {
+				_, err := io.Copy(pw, io.NewSectionReader(f, o, int64(r.length)))
+				return err
+			}
+			payload, err := r.decryptPayload(enc)
+			if err != nil {
+				return err
+			}
+			cc := CompressedChunk{H: r.address, FullCompressedChunk: payload}
+			l, _ := chunkRecordSize(cc)
+			buf := make([]byte, l)
+			writeChunkRecord(buf, cc)
+			_, err = pw.Write(buf)
+			return err
+		})
+		pw.CloseWithError(err)
+	}()
+
+	return journalWriterSnapshot{
+		pr,
+		func() error {
+			pr.Close()
+			return f.Close()
+		},
+	}, plainSz, nil
+}
+
+// reencrypt rewrites the journal so that every chunk record is encrypted with the current key of |wr.enc|. Offsets
+// in the journal are unchanged. It returns the number of chunk records which were re-encrypted.
+func (wr *journalWriter) reencrypt(ctx context.Context) (int, error) {
+	wr.lock.Lock()
+	defer wr.lock.Unlock()
+	if err := wr.flush(ctx); err != nil {
+		return 0, err
+	}
+
+	current := wr.enc.currentKey()
+	var stale int
+	_, err := processJournalRecords(ctx, io.NewSectionReader(wr.journal, 0, wr.off), 0, func(o int64, r journalRec) error {
+		if r.kind == chunkJournalRecKind && r.keyID != current.id {
+			stale++
+		}
+		return nil
+	})
+	if err != nil || stale == 0 {
+		return 0, err
+	}
+
+	// write the re-encrypted journal beside the journal and swap it in
+	temp, err := os.CreateTemp(filepath.Dir(wr.path), "nbs_journal_")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(temp.Name())
+	_, err = processJournalRecords(ctx, io.NewSectionReader(wr.journal, 0, wr.off), 0, func(o int64, r journalRec) error {
+		buf := make([]byte, r.length)
+		if _, err := wr.journal.ReadAt(buf, o); err != nil {
+			return err
+		}
+		if r.kind == chunkJournalRecKind && r.keyID != current.id {
+			payload, err := r.decryptPayload(wr.enc)
+			if err != nil {
+				return err
+			}
+			var nonce [encryptionNonceSize]byte
+			if _, err = rand.Read(nonce[:]); err != nil {
+				return err
+			}
+			writeEncryptedChunkRecord(buf, CompressedChunk{H: r.address, FullCompressedChunk: payload}, current, nonce)
+		}
+		_, err := temp.WriteAt(buf, o)
+		return err
+	})
+	if err == nil {
+		err = temp.Sync()
+	}
+	if cerr := temp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err = file.Rename(temp.Name(), wr.path); err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(wr.path, os.O_RDWR, 0666)
+	if err != nil {
+		return 0, err
+	}
+	_ = wr.journal.Close()
+	wr.journal = f
+	return stale, nil
+}
+
 func (wr *journalWriter) offset() int64 {
 	return wr.off + int64(len(wr.buf))
 }
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -236,6 +237,34 @@ func TestJournalWriterBootstrap(t *testing.T) {
 	}
 }
 
+func TestJournalWriterIterateRoots(t *testing.T) {
+	ctx := context.Background()
+	path := newTestFilePath(t)
+	j := newTestJournalWriter(t, path)
+	defer j.Close()
+
+	orig := journalRecordTimestampGenerator
+	defer func() { journalRecordTimestampGenerator = orig }()
+
+	var roots []hash.Hash
+	for _, cc := range randomCompressedChunks(8) {
+		require.NoError(t, j.writeCompressedChunk(ctx, cc))
+		ts := uint64(1000 + len(roots))
+		journalRecordTimestampGenerator = func() uint64 { return ts }
+		require.NoError(t, j.commitRootHash(ctx, cc.Hash()))
+		roots = append(roots, cc.Hash())
+	}
+
+	var actual []hash.Hash
+	err := j.iterateRoots(ctx, func(root hash.Hash, ts time.Time) error {
+		assert.Equal(t, int64(1000+len(actual)), ts.Unix())
+		actual = append(actual, root)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, roots, actual)
+}
+
 func validateAllLookups(t *testing.T, j *journalWriter, data map[hash.Hash]CompressedChunk) {
 	// move |data| to addr16-keyed map
 	prefixMap := make(map[addr16]CompressedChunk, len(data))
@@ -0,0 +1,359 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const lazyObjectsFileName = "lazyObjects.txt"
+
+// lazyCacheFlushSize is the size of the fetched chunks which a LazyBlockStore buffers before writing them to a table
+// file in its cache store.
+const lazyCacheFlushSize = 64 * 1024 * 1024
+
+// ErrNoLazySource is returned when a lazy clone needs a chunk from its remote, but no source for the remote was set.
+var ErrNoLazySource = errors.New("this database is a lazy clone, and the remote it was cloned from is not available")
+
+// LazySource opens the chunk store which a lazy clone fetches its missing chunks from.
+type LazySource func(ctx context.Context) (chunks.ChunkStore, error)
+
+// LazyBlockStore is the part of a GenerationalNBS which fetches the chunks that a lazy clone is missing from the
+// remote it was cloned from. Fetched chunks are cached in the old gen store, so each chunk is only fetched once. Reads
+// which walk history fetch a few chunks at a time, so fetched chunks are buffered, and only written to a table file
+// once the buffer reaches |flushSize|, or the store is closed.
+//
+// The commits which were not fetched when the clone was made are listed in a lazyObjects.txt file, and are reported
+// as present by Has and HasMany, the same as ghost commits are. Fetches and pulls into the clone stop at these
+// commits, rather than fetching all of history.
+type LazyBlockStore struct {
+	lazyObjectsFile string
+	cache           *NomsBlockStore
+
+	mu       sync.Mutex
+	lazyRefs hash.HashSet
+	open     LazySource
+	source   chunks.ChunkStore
+
+	// pending are the fetched chunks which have not been written to the cache store yet.
+	pending     map[hash.Hash]chunks.Chunk
+	pendingSize uint64
+	flushSize   uint64
+}
+
+// newLazyBlockStore loads the LazyBlockStore for the database in |nomsPath|, which caches chunks in |cache|. It
+// returns nil if the database is not a lazy clone.
+func newLazyBlockStore(nomsPath string, cache *NomsBlockStore) (*LazyBlockStore, error) {
+	lazyPath := filepath.Join(nomsPath, lazyObjectsFileName)
+	f, err := os.Open(lazyPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	refs := hash.NewHashSet()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		h, ok := hash.MaybeParse(scanner.Text())
+		if !ok {
+			return nil, fmt.Errorf("invalid hash %s in %s", scanner.Text(), lazyObjectsFileName)
+		}
+		refs.Insert(h)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &LazyBlockStore{
+		lazyObjectsFile: lazyPath,
+		cache:           cache,
+		lazyRefs:        refs,
+		pending:         make(map[hash.Hash]chunks.Chunk),
+		flushSize:       lazyCacheFlushSize,
+	}, nil
+}
+
+// persistLazyHashes makes the database in |nomsPath| a lazy clone, which is missing the commits in |hashes|.
+func persistLazyHashes(nomsPath string, cache *NomsBlockStore, hashes hash.HashSet) (*LazyBlockStore, error) {
+	lazyPath := filepath.Join(nomsPath, lazyObjectsFileName)
+	f, err := os.OpenFile(lazyPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	wr := bufio.NewWriter(f)
+	for h := range hashes {
+		if _, err := wr.WriteString(h.String() + "\n"); err != nil {
+			return nil, err
+		}
+	}
+	if err := wr.Flush(); err != nil {
+		return nil, err
+	}
+
+	return &LazyBlockStore{
+		lazyObjectsFile: lazyPath,
+		cache:           cache,
+		lazyRefs:        hashes.Copy(),
+		pending:         make(map[hash.Hash]chunks.Chunk),
+		flushSize:       lazyCacheFlushSize,
+	}, nil
+}
+
+// SetSource sets the function used to open the remote store on the first fetch.
+func (l *LazyBlockStore) SetSource(open LazySource) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.open = open
+}
+
+// LazyHashes returns the commits which were not fetched when the clone was made.
+func (l *LazyBlockStore) LazyHashes() hash.HashSet {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lazyRefs.Copy()
+}
+
+func (l *LazyBlockStore) getSource(ctx context.Context) (chunks.ChunkStore, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.source != nil {
+		return l.source, nil
+	}
+	if l.open == nil {
+		return nil, ErrNoLazySource
+	}
+	src, err := l.open(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoLazySource, err)
+	}
+	l.source = src
+	return src, nil
+}
+
+func (l *LazyBlockStore) has(h hash.Hash) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lazyRefs.Has(h)
+}
+
+func (l *LazyBlockStore) hasMany(hashes hash.HashSet) hash.HashSet {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	absent := hash.HashSet{}
+	for h := range hashes {
+		if !l.lazyRefs.Has(h) {
+			absent.Insert(h)
+		}
+	}
+	return absent
+}
+
+// refCheck marks the lazy commits in |recs| as present, and then checks the rest against the remote. New chunks may
+// reference chunks of history which have not been fetched yet, and are valid as long as the remote has them.
+func (l *LazyBlockStore) refCheck(ctx context.Context, recs []hasRecord) (hash.HashSet, error) {
+	l.mu.Lock()
+	absent := hash.HashSet{}
+	for i := range recs {
+		if !recs[i].has {
+			if l.lazyRefs.Has(*recs[i].a) {
+				recs[i].has = true
+			} else {
+				absent.Insert(*recs[i].a)
+			}
+		}
+	}
+	l.mu.Unlock()
+	if len(absent) == 0 {
+		return absent, nil
+	}
+
+	src, err := l.getSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	absent, err = src.HasMany(ctx, absent)
+	if err != nil {
+		return nil, err
+	}
+	for i := range recs {
+		if !recs[i].has && !absent.Has(*recs[i].a) {
+			recs[i].has = true
+		}
+	}
+	return absent, nil
+}
+
+// getMany fetches the chunks in |hashes| from the remote, caches them, and calls |found| with each of them. Chunks
+// which were fetched before, but not written to the cache store yet, are not fetched again. Chunks which the remote
+// does not have are ignored.
+func (l *LazyBlockStore) getMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	var buffered []chunks.Chunk
+	missing := hash.NewHashSet()
+	l.mu.Lock()
+	for h := range hashes {
+		if c, ok := l.pending[h]; ok {
+			buffered = append(buffered, c)
+		} else {
+			missing.Insert(h)
+		}
+	}
+	l.mu.Unlock()
+	for i := range buffered {
+		found(ctx, &buffered[i])
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	src, err := l.getSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var fetched []chunks.Chunk
+	err = src.GetMany(ctx, missing, func(ctx context.Context, c *chunks.Chunk) {
+		mu.Lock()
+		defer mu.Unlock()
+		fetched = append(fetched, *c)
+	})
+	if err != nil {
+		return err
+	}
+	if len(fetched) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	for _, c := range fetched {
+		if _, ok := l.pending[c.Hash()]; !ok {
+			l.pending[c.Hash()] = c
+			l.pendingSize += uint64(len(c.Data()))
+		}
+	}
+	full := l.pendingSize >= l.flushSize
+	l.mu.Unlock()
+	if full {
+		if err := l.flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	for i := range fetched {
+		found(ctx, &fetched[i])
+	}
+	return nil
+}
+
+// flush writes the buffered fetched chunks to a new table file in the cache store.
+func (l *LazyBlockStore) flush(ctx context.Context) error {
+	l.mu.Lock()
+	pending := l.pending
+	l.pending = make(map[hash.Hash]chunks.Chunk)
+	l.pendingSize = 0
+	l.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	fetched := make([]chunks.Chunk, 0, len(pending))
+	for _, c := range pending {
+		fetched = append(fetched, c)
+	}
+	return l.cacheChunks(ctx, fetched)
+}
+
+// cacheChunks writes |fetched| to a new table file in the cache store.
+func (l *LazyBlockStore) cacheChunks(ctx context.Context, fetched []chunks.Chunk) (err error) {
+	tw, err := NewCmpChunkTableWriter("")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tw.Cancel()
+		}
+	}()
+
+	for _, c := range fetched {
+		if _, err = tw.AddChunk(ChunkToCompressedChunk(c)); err != nil {
+			return err
+		}
+	}
+	_, id, err := tw.Finish()
+	if err != nil {
+		return err
+	}
+
+	err = l.cache.WriteTableFile(ctx, id, len(fetched), tw.GetMD5(), func() (io.ReadCloser, uint64, error) {
+		rd, err := tw.Reader()
+		return rd, tw.FullLength(), err
+	})
+	if err != nil {
+		return err
+	}
+	if err = tw.Remove(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// the chunks may reference chunks which have not been fetched, so the references are not checked
+	return l.cache.addTableFilesToManifest(ctx, map[string]int{id: len(fetched)}, nil, nil)
+}
+
+// complete fetches all of the missing chunks with |fetch|, and then deletes the lazyObjects.txt file, which makes the
+// database a complete clone. The lazy commits are not reported as present while |fetch| runs, so that they are
+// fetched along with everything they reference.
+func (l *LazyBlockStore) complete(ctx context.Context, fetch func(ctx context.Context, lazyRefs hash.HashSet) error) error {
+	if err := l.flush(ctx); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	lazyRefs := l.lazyRefs
+	l.lazyRefs = hash.HashSet{}
+	l.mu.Unlock()
+
+	if err := fetch(ctx, lazyRefs); err != nil {
+		l.mu.Lock()
+		l.lazyRefs = lazyRefs
+		l.mu.Unlock()
+		return err
+	}
+
+	err := os.Remove(l.lazyObjectsFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/constants"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestLazyGenerationalCS(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, nomsDir, _ := makeTestLocalStore(t, 64)
+	cs := NewGenerationalCS(oldGen, newGen, nil)
+	require.NoError(t, cs.LoadLazyGen())
+	assert.False(t, cs.IsLazy())
+
+	remote := (&chunks.MemoryStorage{}).NewViewWithFormat(constants.FormatDoltString)
+	chnks := genChunks(t, 4, 1024)
+	for _, c := range chnks {
+		require.NoError(t, remote.Put(ctx, c, noopGetAddrs))
+	}
+	lazyCommit := chnks[0].Hash()
+	absent := hash.Of([]byte("absent"))
+
+	require.NoError(t, cs.PersistLazyHashes(ctx, hash.NewHashSet(lazyCommit)))
+	assert.True(t, cs.IsLazy())
+
+	// lazy commits are present, the same as ghost commits
+	ok, err := cs.Has(ctx, lazyCommit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	absentSet, err := cs.HasMany(ctx, hash.NewHashSet(lazyCommit, chnks[1].Hash()))
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(chnks[1].Hash()), absentSet)
+
+	// missing chunks can't be read until there is a source
+	_, err = cs.Get(ctx, chnks[1].Hash())
+	assert.ErrorIs(t, err, ErrNoLazySource)
+
+	cs.SetLazySource(func(ctx context.Context) (chunks.ChunkStore, error) {
+		return remote, nil
+	})
+	c, err := cs.Get(ctx, chnks[1].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, chnks[1].Data(), c.Data())
+
+	found := hash.NewHashSet()
+	err = cs.GetMany(ctx, hash.NewHashSet(chnks[2].Hash(), absent), func(_ context.Context, c *chunks.Chunk) {
+		found.Insert(c.Hash())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(chnks[2].Hash()), found)
+
+	found = hash.NewHashSet()
+	err = cs.GetManyCompressed(ctx, hash.NewHashSet(chnks[3].Hash()), func(_ context.Context, c ToChunker) {
+		found.Insert(c.Hash())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(chnks[3].Hash()), found)
+
+	// fetched chunks are cached in the old gen once they are flushed
+	require.NoError(t, cs.lazyGen.flush(ctx))
+	for _, c := range chnks[1:] {
+		ok, err := oldGen.Has(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	// the lazy commits are loaded when the store is opened again
+	reopened := NewGenerationalCS(oldGen, newGen, nil)
+	require.NoError(t, reopened.LoadLazyGen())
+	assert.True(t, reopened.IsLazy())
+	ok, err = reopened.Has(ctx, lazyCommit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	var fetched hash.HashSet
+	err = cs.CompleteLazyClone(ctx, func(ctx context.Context, lazyRefs hash.HashSet) error {
+		fetched = lazyRefs
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(lazyCommit), fetched)
+	assert.False(t, cs.IsLazy())
+	_, err = os.Stat(filepath.Join(nomsDir, lazyObjectsFileName))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLazyGenerationalCSBuffersFetchedChunks(t *testing.T) {
+	ctx := context.Background()
+	// Allow many table files, so that the store does not conjoin them.
+	oldGen, _, _ := makeTestLocalStore(t, 1024)
+	newGen, _, _ := makeTestLocalStore(t, 1024)
+	cs := NewGenerationalCS(oldGen, newGen, nil)
+
+	remote := (&chunks.MemoryStorage{}).NewViewWithFormat(constants.FormatDoltString)
+	chnks := genChunks(t, 512, 1024)
+	for _, c := range chnks {
+		require.NoError(t, remote.Put(ctx, c, noopGetAddrs))
+	}
+	require.NoError(t, cs.PersistLazyHashes(ctx, hash.NewHashSet(hash.Of([]byte("lazy commit")))))
+	cs.SetLazySource(func(ctx context.Context) (chunks.ChunkStore, error) {
+		return remote, nil
+	})
+	cs.lazyGen.flushSize = 32 * 1024
+
+	_, before, _, err := oldGen.Sources(ctx)
+	require.NoError(t, err)
+
+	// Walking history fetches one chunk at a time, and reads some of them more than once.
+	for i := 0; i < 2; i++ {
+		for _, c := range chnks {
+			got, err := cs.Get(ctx, c.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, c.Data(), got.Data())
+		}
+	}
+	require.NoError(t, cs.lazyGen.flush(ctx))
+
+	// The chunks are at most 1024 bytes, so they fit in 512 * 1024 / flushSize + 1 table files.
+	_, after, _, err := oldGen.Sources(ctx)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(after)-len(before), 512*1024/(32*1024)+1)
+	for _, c := range chnks {
+		ok, err := oldGen.Has(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
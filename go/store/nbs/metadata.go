@@ -151,12 +151,17 @@ func GetStorageMetadata(ctx context.Context, path string, stats *Stats) (Storage
 		return StorageMetadata{}, err
 	}
 
+	enc, err := loadChunkCipher(ctx, newGen)
+	if err != nil {
+		return StorageMetadata{}, err
+	}
+
 	var artifacts []StorageArtifact
 
 	// for each table in the manifest, get the table spec
 	for i := 0; i < manifest.NumTableSpecs(); i++ {
 		tableSpecInfo := manifest.GetTableSpecInfo(i)
-		artifact, err := buildArtifact(ctx, tableSpecInfo, newGen, stats)
+		artifact, err := buildArtifact(ctx, tableSpecInfo, newGen, enc, stats)
 		if err != nil {
 			return StorageMetadata{}, err
 		}
@@ -180,10 +185,15 @@ func GetStorageMetadata(ctx context.Context, path string, stats *Stats) (Storage
 		return StorageMetadata{}, err
 	}
 
+	enc, err = loadChunkCipher(ctx, oldgen)
+	if err != nil {
+		return StorageMetadata{}, err
+	}
+
 	for i := 0; i < manifest.NumTableSpecs(); i++ {
 		tableSpecInfo := manifest.GetTableSpecInfo(i)
 
-		artifact, err := buildArtifact(ctx, tableSpecInfo, oldgen, stats)
+		artifact, err := buildArtifact(ctx, tableSpecInfo, oldgen, enc, stats)
 		if err != nil {
 			return StorageMetadata{}, err
 		}
@@ -193,7 +203,7 @@ func GetStorageMetadata(ctx context.Context, path string, stats *Stats) (Storage
 	return StorageMetadata{path, artifacts}, nil
 }
 
-func buildArtifact(ctx context.Context, info TableSpecInfo, genPath string, stats *Stats) (StorageArtifact, error) {
+func buildArtifact(ctx context.Context, info TableSpecInfo, genPath string, enc *chunkCipher, stats *Stats) (StorageArtifact, error) {
 	tfName := info.GetName()
 
 	archive := false
@@ -214,7 +224,7 @@ func buildArtifact(ctx context.Context, info TableSpecInfo, genPath string, stat
 	}
 
 	if !archive {
-		tblMeta, err := newTableFileMetadata(fullPath, info.GetChunkCount())
+		tblMeta, err := newTableFileMetadata(fullPath, info.GetChunkCount(), enc)
 		if err != nil {
 			return StorageArtifact{}, err
 		}
@@ -226,7 +236,7 @@ func buildArtifact(ctx context.Context, info TableSpecInfo, genPath string, stat
 			tblMetadata: tblMeta,
 		}, nil
 	} else {
-		fra, err := newFileReaderAt(fullPath)
+		fra, err := newFileReaderAt(fullPath, enc)
 		if err != nil {
 			return StorageArtifact{}, err
 		}
@@ -0,0 +1,320 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// tornJournalSuffix is appended to the name of the chunk journal to name the file which holds the bytes removed from
+// the end of the journal by TruncateTornJournalTail.
+const tornJournalSuffix = ".torn"
+
+// chunkScanner is implemented by chunk sources which can keep iterating over their chunks after one of them fails to
+// be read.
+type chunkScanner interface {
+	// scanAllChunks calls |cb| with each chunk which can be read, and |bad| with the address of each chunk which can't.
+	// The scan stops if |bad| returns an error.
+	scanAllChunks(ctx context.Context, cb func(chunks.Chunk), bad func(hash.Hash, error) error, stats *Stats) error
+}
+
+var _ chunkScanner = tableReader{}
+var _ chunkScanner = archiveChunkSource{}
+
+// ScanAllChunks is like IterateAllChunks, but it doesn't stop at chunks which can't be read. |cb| is called with each
+// chunk which can be read, and |bad| with the address of each chunk which can't. Both are given the name of the table
+// file the chunk is in.
+func (nbs *NomsBlockStore) ScanAllChunks(ctx context.Context, cb func(chk chunks.Chunk, file hash.Hash), bad func(h hash.Hash, file hash.Hash, err error)) error {
+	scan := func(css chunkSourceSet) error {
+		for name, cs := range css {
+			chkCb := func(chk chunks.Chunk) {
+				cb(chk, name)
+			}
+			var err error
+			if scanner, ok := cs.(chunkScanner); ok {
+				err = scanner.scanAllChunks(ctx, chkCb, func(h hash.Hash, err error) error {
+					bad(h, name, err)
+					return nil
+				}, nbs.stats)
+			} else {
+				err = cs.iterateAllChunks(ctx, chkCb, nbs.stats)
+			}
+			if err != nil {
+				return err
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
+
+	nbs.mu.RLock()
+	tables := nbs.tables
+	nbs.mu.RUnlock()
+	if err := scan(tables.novel); err != nil {
+		return err
+	}
+	return scan(tables.upstream)
+}
+
+// IsJournalFile returns true if |file| is the name of the chunk journal.
+func IsJournalFile(file hash.Hash) bool {
+	return isJournalAddr(file)
+}
+
+// RepairTableFiles replaces the table files named in |files| with a single new table file. The new table file holds
+// every chunk of the replaced files which can be read, except that the chunks in |repaired| are written from the
+// copies given there. Chunks in |repaired| which are in none of the replaced files are added to the new table file
+// too. Chunks which can't be read from the replaced files must have a copy in |repaired|.
+//
+// The replaced table files are left on disk until they are removed by garbage collection. The chunk journal can't be
+// replaced. Returns the name of the new table file.
+func (nbs *NomsBlockStore) RepairTableFiles(ctx context.Context, files hash.HashSet, repaired map[hash.Hash]chunks.Chunk) (newFile hash.Hash, err error) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+
+	tfp, ok := nbs.persister.(tableFilePersister)
+	if !ok {
+		return hash.Hash{}, errors.New("table files of this store can't be repaired")
+	}
+
+	upstream := nbs.upstream
+	inAppendix := toSpecSet(upstream.appendix)
+	for f := range files {
+		if isJournalAddr(f) {
+			return hash.Hash{}, errors.New("the chunk journal can't be replaced")
+		} else if _, ok := nbs.tables.upstream[f]; !ok {
+			return hash.Hash{}, fmt.Errorf("%w: %s", ErrTableFileNotFound, f.String())
+		} else if _, ok := inAppendix[f]; ok {
+			return hash.Hash{}, fmt.Errorf("table file %s is in the manifest appendix and can't be replaced", f.String())
+		}
+	}
+
+	copier, err := newGarbageCollectionCopier(chunks.NoArchive, tfp)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	defer copier.cancel(ctx)
+
+	written := hash.NewHashSet()
+	var copyErr error
+	addChunk := func(chk chunks.Chunk) {
+		if copyErr != nil || written.Has(chk.Hash()) {
+			return
+		}
+		written.Insert(chk.Hash())
+		copyErr = copier.addChunk(ctx, ChunkToCompressedChunk(chk))
+	}
+	for f := range files {
+		cs := nbs.tables.upstream[f]
+		cb := func(chk chunks.Chunk) {
+			if _, ok := repaired[chk.Hash()]; !ok {
+				addChunk(chk)
+			}
+		}
+		if scanner, ok := cs.(chunkScanner); ok {
+			err = scanner.scanAllChunks(ctx, cb, func(h hash.Hash, err error) error {
+				if _, ok := repaired[h]; ok {
+					return nil
+				}
+				return fmt.Errorf("chunk %s of table file %s can't be read and has no repaired copy: %w", h.String(), f.String(), err)
+			}, nbs.stats)
+		} else {
+			err = cs.iterateAllChunks(ctx, cb, nbs.stats)
+		}
+		if err != nil {
+			return hash.Hash{}, err
+		}
+	}
+	for _, chk := range repaired {
+		addChunk(chk)
+	}
+	if copyErr != nil {
+		return hash.Hash{}, copyErr
+	}
+	if written.Size() == 0 {
+		return hash.Hash{}, errors.New("no chunks to write")
+	}
+
+	specs, err := copier.copyTablesToDir(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	nbs.manifestMgr.LockForUpdate()
+	defer func() {
+		err = errors.Join(err, nbs.manifestMgr.UnlockForUpdate())
+	}()
+
+	newSpecs := make([]tableSpec, 0, len(upstream.specs)+len(specs))
+	for _, s := range upstream.specs {
+		if !files.Has(s.name) {
+			newSpecs = append(newSpecs, s)
+		}
+	}
+	newSpecs = append(newSpecs, specs...)
+	newContents := manifestContents{
+		nbfVers:  upstream.nbfVers,
+		root:     upstream.root,
+		lock:     generateLockHash(upstream.root, newSpecs, upstream.appendix, nil),
+		gcGen:    upstream.gcGen,
+		specs:    newSpecs,
+		appendix: upstream.appendix,
+	}
+	updated, err := nbs.manifestMgr.Update(ctx, upstream.lock, newContents, nbs.stats, nil)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	if updated.lock != newContents.lock {
+		return hash.Hash{}, errors.New("concurrent manifest edit during repair")
+	}
+
+	// chunks which were only in a replaced table file are gone
+	nbs.hasCache.Purge()
+
+	ts, err := nbs.tables.rebase(ctx, updated.specs, nil, nbs.stats)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	oldTables := nbs.tables
+	nbs.tables, nbs.upstream = ts, updated
+	if err = oldTables.close(); err != nil {
+		return hash.Hash{}, err
+	}
+	return specs[0].name, nil
+}
+
+// RepairJournalChunks appends the chunks in |repaired| to the store's chunk journal. Lookups of a chunk in the journal
+// find its latest record, so the appended chunks take the place of earlier records of the same chunks.
+func (nbs *NomsBlockStore) RepairJournalChunks(ctx context.Context, repaired map[hash.Hash]chunks.Chunk) error {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+
+	cj, ok := nbs.persister.(*ChunkJournal)
+	if !ok || cj.wr == nil {
+		return errors.New("this store has no chunk journal")
+	}
+	for _, chk := range repaired {
+		if err := cj.wr.writeCompressedChunk(ctx, ChunkToCompressedChunk(chk)); err != nil {
+			return err
+		}
+	}
+	// committing the current root again flushes and syncs the appended chunks
+	return cj.wr.commitRootHash(ctx, nbs.upstream.root)
+}
+
+// TornJournalTail returns the offset of the end of the last valid record in the store's chunk journal, and the number
+// of bytes after it which are not zero. These are left by a write to the journal which was interrupted, or by a
+// record which was corrupted. The records after a corrupted record are not read when the store is opened. It returns
+// zero if the store has no chunk journal.
+func (nbs *NomsBlockStore) TornJournalTail(ctx context.Context) (off int64, n int64, err error) {
+	cj, ok := nbs.persister.(*ChunkJournal)
+	if !ok || cj.wr == nil {
+		return 0, 0, nil
+	}
+	return cj.wr.tornTail(ctx)
+}
+
+// TruncateTornJournalTail truncates the store's chunk journal to the end of its last valid record. The bytes which
+// are removed are written to a file next to the journal first, whose path is returned. It returns an empty path if
+// the journal has no torn tail.
+func (nbs *NomsBlockStore) TruncateTornJournalTail(ctx context.Context) (string, error) {
+	cj, ok := nbs.persister.(*ChunkJournal)
+	if !ok || cj.wr == nil {
+		return "", nil
+	}
+	return cj.wr.truncateTornTail(ctx)
+}
+
+// tornTail returns the offset of the end of the last valid record of the journal, and the number of bytes after it
+// up to the last byte which is not zero.
+func (wr *journalWriter) tornTail(ctx context.Context) (int64, int64, error) {
+	wr.lock.Lock()
+	defer wr.lock.Unlock()
+	return wr.tornTailUnlocked(ctx)
+}
+
+func (wr *journalWriter) tornTailUnlocked(ctx context.Context) (int64, int64, error) {
+	if err := wr.flush(ctx); err != nil {
+		return 0, 0, err
+	}
+	info, err := wr.journal.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end := wr.off
+	buf := make([]byte, 64*1024)
+	for off := wr.off; off < info.Size(); {
+		n, err := wr.journal.ReadAt(buf, off)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, 0, err
+		}
+		if n == 0 {
+			break
+		}
+		for i := n - 1; i >= 0; i-- {
+			if buf[i] != 0 {
+				end = off + int64(i) + 1
+				break
+			}
+		}
+		off += int64(n)
+	}
+	return wr.off, end - wr.off, nil
+}
+
+// truncateTornTail copies the bytes after the last valid record of the journal to a file next to it, and truncates
+// the journal to the end of that record.
+func (wr *journalWriter) truncateTornTail(ctx context.Context) (string, error) {
+	wr.lock.Lock()
+	defer wr.lock.Unlock()
+
+	off, n, err := wr.tornTailUnlocked(ctx)
+	if err != nil || n == 0 {
+		return "", err
+	}
+
+	path := wr.path + tornJournalSuffix
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(f, io.NewSectionReader(wr.journal, off, n))
+	if err != nil {
+		f.Close()
+		return "", err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err = f.Close(); err != nil {
+		return "", err
+	}
+
+	if err = wr.journal.Truncate(off); err != nil {
+		return "", err
+	}
+	return path, wr.journal.Sync()
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/constants"
+)
+
+func TestS3BlobstoreSuite(t *testing.T) {
+	s3svc := makeFakeS3(t)
+	fn := func(ctx context.Context, dir string) (*NomsBlockStore, error) {
+		nbf := constants.FormatDefaultString
+		qp := NewUnlimitedMemQuotaProvider()
+		bs := blobstore.NewS3Blobstore(s3svc, "bucket", dir)
+		return NewBSStore(ctx, nbf, bs, testMemTableSize, qp)
+	}
+	suite.Run(t, &BlockStoreSuite{factory: fn})
+}
+
+func TestS3BlobstoreCheckAndPut(t *testing.T) {
+	ctx := context.Background()
+	bs := blobstore.NewS3Blobstore(makeFakeS3(t), "bucket", "db")
+
+	ver, err := checkAndPutBytes(ctx, bs, "", "manifest", []byte("first"))
+	require.NoError(t, err)
+
+	// the blob must not exist when no version is expected
+	_, err = checkAndPutBytes(ctx, bs, "", "manifest", []byte("second"))
+	assert.True(t, blobstore.IsCheckAndPutError(err))
+
+	newVer, err := checkAndPutBytes(ctx, bs, ver, "manifest", []byte("second"))
+	require.NoError(t, err)
+	assert.NotEqual(t, ver, newVer)
+
+	_, err = checkAndPutBytes(ctx, bs, ver, "manifest", []byte("third"))
+	assert.True(t, blobstore.IsCheckAndPutError(err))
+	_, err = checkAndPutBytes(ctx, bs, ver, "missing", []byte("third"))
+	assert.True(t, blobstore.IsCheckAndPutError(err))
+
+	data, getVer, err := blobstore.GetBytes(ctx, bs, "manifest", blobstore.AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+	assert.Equal(t, newVer, getVer)
+
+	_, _, err = blobstore.GetBytes(ctx, bs, "missing", blobstore.AllRange)
+	assert.True(t, blobstore.IsNotFoundError(err))
+	ok, err := bs.Exists(ctx, "manifest")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = bs.Exists(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestS3BlobstoreGetRange(t *testing.T) {
+	ctx := context.Background()
+	bs := blobstore.NewS3Blobstore(makeFakeS3(t), "bucket", "db")
+	_, err := blobstore.PutBytes(ctx, bs, "blob", []byte("0123456789"))
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		br       blobstore.BlobRange
+		expected string
+	}{
+		{blobstore.NewBlobRange(2, 3), "234"},
+		{blobstore.NewBlobRange(7, 0), "789"},
+		{blobstore.NewBlobRange(-4, 0), "6789"},
+		{blobstore.NewBlobRange(-4, 2), "67"},
+	} {
+		data, _, err := blobstore.GetBytes(ctx, bs, "blob", test.br)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, string(data))
+	}
+}
+
+func TestS3BlobstoreConcatenate(t *testing.T) {
+	ctx := context.Background()
+	s3svc := makeFakeS3(t)
+	bs := blobstore.NewS3Blobstore(s3svc, "bucket", "db")
+
+	// sources smaller than the minimum part size are combined into parts, and larger ones are copied
+	const minPartSize = 5 * 1024 * 1024
+	sizes := []int{100, minPartSize + 7, 3, minPartSize - 10, 2*minPartSize + 1, 20}
+	var keys []string
+	var expected []byte
+	for i, sz := range sizes {
+		data := make([]byte, sz)
+		rand.Read(data)
+		key := string(rune('a' + i))
+		_, err := blobstore.PutBytes(ctx, bs, key, data)
+		require.NoError(t, err)
+		keys = append(keys, key)
+		expected = append(expected, data...)
+	}
+
+	ver, err := bs.Concatenate(ctx, "composite", keys)
+	require.NoError(t, err)
+	data, getVer, err := blobstore.GetBytes(ctx, bs, "composite", blobstore.AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, ver, getVer)
+	assert.True(t, bytes.Equal(expected, data))
+	assert.Empty(t, s3svc.inProgress)
+
+	_, err = bs.Concatenate(ctx, "composite", []string{"a", "missing"})
+	assert.True(t, blobstore.IsNotFoundError(err))
+}
+
+func checkAndPutBytes(ctx context.Context, bs blobstore.Blobstore, expectedVersion, key string, data []byte) (string, error) {
+	return bs.CheckAndPut(ctx, expectedVersion, key, int64(len(data)), bytes.NewReader(data))
+}
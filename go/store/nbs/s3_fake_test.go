@@ -24,6 +24,7 @@ package nbs
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/url"
 	"strconv"
@@ -35,6 +36,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/dolthub/dolt/go/store/d"
@@ -48,10 +50,17 @@ func (m mockAWSError) Code() string    { return string(m) }
 func (m mockAWSError) Message() string { return string(m) }
 func (m mockAWSError) OrigErr() error  { return nil }
 
+// mockAWSError implements smithy.APIError, so that its code can be checked like those of the errors returned by the
+// S3 client.
+func (m mockAWSError) ErrorCode() string             { return string(m) }
+func (m mockAWSError) ErrorMessage() string          { return string(m) }
+func (m mockAWSError) ErrorFault() smithy.ErrorFault { return smithy.FaultClient }
+
 func makeFakeS3(t *testing.T) *fakeS3 {
 	return &fakeS3{
 		assert:     assert.New(t),
 		data:       map[string][]byte{},
+		etags:      map[string]string{},
 		inProgress: map[string]fakeS3Multipart{},
 		parts:      map[string][]byte{},
 	}
@@ -62,10 +71,12 @@ type fakeS3 struct {
 
 	mu                sync.Mutex
 	data              map[string][]byte
+	etags             map[string]string // Key -> ETag
 	inProgressCounter int
 	inProgress        map[string]fakeS3Multipart // Key -> {UploadId, Etags...}
 	parts             map[string][]byte          // ETag -> data
 	getCount          int
+	putCount          int
 }
 
 type fakeS3Multipart struct {
@@ -179,9 +190,8 @@ func (m *fakeS3) UploadPartCopy(ctx context.Context, input *s3.UploadPartCopyInp
 
 	unescaped, err := url.QueryUnescape(*input.CopySource)
 	m.assert.NoError(err)
-	slash := strings.LastIndex(unescaped, "/")
-	m.assert.NotEqual(-1, slash, "Malformed CopySource %s", unescaped)
-	src := unescaped[slash+1:]
+	_, src, ok := strings.Cut(unescaped, "/") // [bucket]/[key]
+	m.assert.True(ok, "Malformed CopySource %s", unescaped)
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -214,14 +224,16 @@ func (m *fakeS3) CompleteMultipartUpload(ctx context.Context, input *s3.Complete
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.assert.Equal(m.inProgress[*input.Key].uploadID, *input.UploadId)
+	var data []byte
 	for idx, part := range input.MultipartUpload.Parts {
 		m.assert.EqualValues(idx+1, *part.PartNumber) // Part numbers are 1-indexed
-		m.data[*input.Key] = append(m.data[*input.Key], m.parts[*part.ETag]...)
+		data = append(data, m.parts[*part.ETag]...)
 		delete(m.parts, *part.ETag)
 	}
 	delete(m.inProgress, *input.Key)
+	etag := m.put(*input.Key, data)
 
-	return &s3.CompleteMultipartUploadOutput{Bucket: input.Bucket, Key: input.Key}, nil
+	return &s3.CompleteMultipartUploadOutput{Bucket: input.Bucket, Key: input.Key, ETag: aws.String(etag)}, nil
 }
 
 func (m *fakeS3) GetObject(ctx context.Context, input *s3.GetObjectInput, opts ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
@@ -235,6 +247,9 @@ func (m *fakeS3) GetObject(ctx context.Context, input *s3.GetObjectInput, opts .
 	if !present {
 		return nil, mockAWSError("NoSuchKey")
 	}
+	if input.IfMatch != nil && *input.IfMatch != m.etags[*input.Key] {
+		return nil, mockAWSError("PreconditionFailed")
+	}
 	var outputRange *string
 	if input.Range != nil {
 		start, end := parseRange(*input.Range, len(obj))
@@ -246,6 +261,24 @@ func (m *fakeS3) GetObject(ctx context.Context, input *s3.GetObjectInput, opts .
 		Body:          io.NopCloser(bytes.NewReader(obj)),
 		ContentLength: aws.Int64(int64(len(obj))),
 		ContentRange:  outputRange,
+		ETag:          aws.String(m.etags[*input.Key]),
+	}, nil
+}
+
+func (m *fakeS3) HeadObject(ctx context.Context, input *s3.HeadObjectInput, opts ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	m.assert.NotNil(input.Bucket, "Bucket is a required field")
+	m.assert.NotNil(input.Key, "Key is a required field")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	obj, present := m.data[*input.Key]
+	if !present {
+		return nil, mockAWSError("NotFound")
+	}
+
+	return &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(int64(len(obj))),
+		ETag:          aws.String(m.etags[*input.Key]),
 	}, nil
 }
 
@@ -264,6 +297,10 @@ func parseRange(hdr string, total int) (start, end int) {
 	d.PanicIfFalse(len(ends) == 2)
 	start, err := strconv.Atoi(ends[0])
 	d.PanicIfError(err)
+	if ends[1] == "" {
+		// open-ended range
+		return start, total
+	}
 	end, err = strconv.Atoi(ends[1])
 	d.PanicIfError(err)
 	return start, end + 1 // insanely, the HTTP range header specifies ranges inclusively.
@@ -278,7 +315,23 @@ func (m *fakeS3) PutObject(ctx context.Context, input *s3.PutObjectInput, opts .
 	m.assert.NoError(err)
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.data[*input.Key] = buff.Bytes()
+	etag, present := m.etags[*input.Key]
+	if input.IfMatch != nil && (!present || *input.IfMatch != etag) {
+		return nil, mockAWSError("PreconditionFailed")
+	}
+	if input.IfNoneMatch != nil && present {
+		return nil, mockAWSError("PreconditionFailed")
+	}
+	etag = m.put(*input.Key, buff.Bytes())
+
+	return &s3.PutObjectOutput{ETag: aws.String(etag)}, nil
+}
 
-	return &s3.PutObjectOutput{}, nil
+// put stores |data| as the object |key| and returns its new ETag. Callers must hold |m.mu|.
+func (m *fakeS3) put(key string, data []byte) string {
+	m.putCount++
+	etag := fmt.Sprintf("\"%s-%d\"", hash.Of(data).String(), m.putCount)
+	m.data[key] = data
+	m.etags[key] = etag
+	return etag
 }
@@ -166,6 +166,11 @@ func (nbs *NomsBlockStore) ChunkJournal() *ChunkJournal {
 
 func (nbs *NomsBlockStore) GetChunkLocationsWithPaths(ctx context.Context, hashes hash.HashSet) (map[string]map[hash.Hash]Range, error) {
 	valctx.ValidateContext(ctx)
+	if nbs.encrypted() {
+		// the locations are byte ranges of the files on disk, which are not readable without the key. This is what
+		// keeps encrypted databases from being cloned or fetched through the remotesapi.
+		return nil, fmt.Errorf("cannot serve chunk locations to remotesapi clients: %w", errEncryptedStore)
+	}
 	sourcesToRanges, err := nbs.getChunkLocations(ctx, hashes)
 	if err != nil {
 		return nil, err
@@ -643,7 +648,11 @@ func newLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSi
 	if err != nil {
 		return nil, err
 	}
-	p := newFSTablePersister(dir, q)
+	enc, err := loadChunkCipher(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	p := newFSTablePersister(dir, q, enc)
 	c := conjoinStrategy(inlineConjoiner{maxTables})
 
 	return newNomsBlockStore(ctx, nbfVerStr, makeManifestManager(m), p, q, c, memTableSize)
@@ -659,7 +668,11 @@ func NewLocalJournalingStore(ctx context.Context, nbfVers, dir string, q MemoryQ
 	if err != nil {
 		return nil, err
 	}
-	p := newFSTablePersister(dir, q)
+	enc, err := loadChunkCipher(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	p := newFSTablePersister(dir, q, enc)
 
 	journal, err := newChunkJournal(ctx, nbfVers, dir, m, p.(*fsTablePersister))
 	if err != nil {
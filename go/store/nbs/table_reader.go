@@ -764,6 +764,12 @@ func (tr tableReader) clone() (tableReader, error) {
 }
 
 func (tr tableReader) iterateAllChunks(ctx context.Context, cb func(chunk chunks.Chunk), stats *Stats) error {
+	return tr.scanAllChunks(ctx, cb, func(_ hash.Hash, err error) error {
+		return err
+	}, stats)
+}
+
+func (tr tableReader) scanAllChunks(ctx context.Context, cb func(chunk chunks.Chunk), bad func(hash.Hash, error) error, stats *Stats) error {
 	count := tr.idx.chunkCount()
 	for i := uint32(0); i < count; i++ {
 		if ctx.Err() != nil {
@@ -787,11 +793,17 @@ func (tr tableReader) iterateAllChunks(ctx context.Context, cb func(chunk chunks
 
 		cchk, err := NewCompressedChunk(h, res)
 		if err != nil {
-			return err
+			if err = bad(h, err); err != nil {
+				return err
+			}
+			continue
 		}
 		chk, err := cchk.ToChunk()
 		if err != nil {
-			return err
+			if err = bad(h, err); err != nil {
+				return err
+			}
+			continue
 		}
 
 		cb(chk)
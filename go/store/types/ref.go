@@ -74,6 +74,12 @@ func ToRefOfValue(r Ref, nbf *NomsBinFormat) (Ref, error) {
 	return constructRef(nbf, r.TargetHash(), PrimitiveTypeMap[ValueKind], r.Height())
 }
 
+// NewGhostRef returns a Ref to the ghost value |g|. A ghost value has no data, so the Ref is built the same way as the
+// refs of a SerialMessage, which only need the address of their target.
+func NewGhostRef(g GhostValue, nbf *NomsBinFormat) (Ref, error) {
+	return constructRef(nbf, g.hash, PrimitiveTypeMap[ValueKind], SerialMessageRefHeight)
+}
+
 func constructRef(nbf *NomsBinFormat, targetHash hash.Hash, targetType *Type, height uint64) (Ref, error) {
 	w := newBinaryNomsWriter()
 
@@ -658,6 +658,26 @@ func (sm SerialMessage) WalkAddrs(nbf *NomsBinFormat, cb func(addr hash.Hash) er
 				return err
 			}
 		}
+		bisectState, err := msg.TryBisectState(nil)
+		if err != nil {
+			return err
+		}
+		if bisectState != nil {
+			for _, addrs := range [][]byte{
+				bisectState.BadCommitAddrBytes(),
+				bisectState.GoodCommitAddrsBytes(),
+				bisectState.SkipCommitAddrsBytes(),
+				bisectState.CurrentCommitAddrBytes(),
+			} {
+				for ; len(addrs) >= hash.ByteLen; addrs = addrs[hash.ByteLen:] {
+					if addr := hash.New(addrs[:hash.ByteLen]); !addr.IsEmpty() {
+						if err = cb(addr); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
 	case serial.RootValueFileID:
 		var msg serial.RootValue
 		err := serial.InitRootValueRoot(&msg, []byte(sm), serial.MessagePrefixSz)
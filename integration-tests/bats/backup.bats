@@ -317,3 +317,41 @@ teardown() {
     run dolt backup sync-url file://../bac1
     [ "$status" -ne 0 ]
 }
+
+@test "backup: restore --at restores the state at the given time" {
+    cd repo1
+    dolt sql -q "insert into t1 values (1)"
+    dolt commit -am "first row"
+    dolt backup sync-url file://../bac1
+    sleep 2
+    before=$(date -u +"%Y-%m-%d %H:%M:%S")
+    sleep 2
+    dolt sql -q "insert into t1 values (2)"
+    dolt backup sync-url file://../bac1
+
+    cd ..
+    dolt backup restore --at "$before" file://./bac1 repo2
+    cd repo2
+    run dolt sql -q "select * from t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ ! "$output" =~ "2" ]] || false
+
+    cd ..
+    dolt backup restore file://./bac1 repo3
+    cd repo3
+    run dolt sql -q "select * from t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "backup: restore --at before the first synced root fails" {
+    cd repo1
+    dolt backup sync-url file://../bac1
+
+    cd ..
+    run dolt backup restore --at 2001-01-01 file://./bac1 repo2
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "backup has no root recorded at or before the given time" ]] || false
+    [ ! -d repo2 ]
+}
@@ -0,0 +1,189 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE table t1 (pk int primary key);"
+    dolt commit -Am "commit 0"
+    for i in 1 2 3 4 5 6 7 8; do
+        dolt sql -q "INSERT INTO t1 VALUES ($i);"
+        dolt commit -am "commit $i"
+    done
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+# commit_hash prints the hash of the commit with message $1
+commit_hash() {
+    dolt sql -r csv -q "select commit_hash from dolt_log where message = '$1';" | tail -n 1
+}
+
+@test "bisect: no bisect in progress errors" {
+    run dolt bisect good
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+
+    run dolt bisect reset
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+}
+
+@test "bisect: unknown subcommand errors" {
+    dolt bisect start
+    run dolt bisect frobnicate
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown bisect subcommand frobnicate" ]] || false
+}
+
+@test "bisect: finds the first bad commit by marking commits good and bad" {
+    run dolt bisect start
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "waiting for both good and bad commits" ]] || false
+
+    run dolt bisect bad
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "waiting for both good and bad commits" ]] || false
+
+    run dolt bisect good HEAD~8
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Bisecting: 3 revisions left to test after this (roughly 2 steps)" ]] || false
+    [[ "$output" =~ "commit 4" ]] || false
+
+    # the first bad commit is "commit 6"
+    for i in 1 2 3 4; do
+        [[ "$output" =~ \]\ commit\ ([0-9]+) ]] || false
+        if [ "${BASH_REMATCH[1]}" -ge 6 ]; then
+            run dolt bisect bad
+        else
+            run dolt bisect good
+        fi
+        [ "$status" -eq 0 ]
+        if [[ "$output" =~ "is the first bad commit" ]]; then
+            break
+        fi
+    done
+
+    hash=$(commit_hash "commit 6")
+    [[ "$output" =~ "$hash is the first bad commit" ]] || false
+    [[ "$output" =~ "[$hash] commit 6" ]] || false
+
+    # the current branch is never changed
+    run dolt sql -q "select active_branch()"
+    [[ "$output" =~ "main" ]] || false
+    run dolt log -n 1
+    [[ "$output" =~ "commit 8" ]] || false
+
+    dolt bisect reset
+    run dolt bisect good
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+}
+
+@test "bisect: state is kept on the working set of the branch" {
+    dolt bisect start HEAD HEAD~8
+    run cat .dolt/repo_state.json
+    [[ ! "$output" =~ "\"bisect\"" ]] || false
+
+    dolt checkout -b other
+    run dolt bisect bad
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+
+    dolt checkout main
+    run dolt bisect bad
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Bisecting" ]] || false
+
+    dolt bisect reset
+    run dolt bisect bad
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+}
+
+@test "bisect: run marks commits with a query" {
+    dolt bisect start HEAD HEAD~8
+    run dolt bisect run "select * from t1 where pk = 3"
+    [ "$status" -eq 0 ]
+    hash=$(commit_hash "commit 3")
+    [[ "$output" =~ "$hash is the first bad commit" ]] || false
+    [[ "$output" =~ "[$hash] commit 3" ]] || false
+    [[ "$output" =~ "is good" ]] || false
+    [[ "$output" =~ "is bad" ]] || false
+}
+
+@test "bisect: run when the first bad commit is the bad commit" {
+    dolt bisect start HEAD HEAD~8
+    run dolt bisect run "select * from t1 where pk = 8"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$(commit_hash 'commit 8') is the first bad commit" ]] || false
+}
+
+@test "bisect: run requires good and bad commits" {
+    dolt bisect start HEAD
+    run dolt bisect run "select * from t1"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "run requires a bad commit and at least one good commit" ]] || false
+}
+
+@test "bisect: run skips commits where the query errors" {
+    dolt bisect start HEAD HEAD~8
+    run dolt bisect run "select * from not_a_table"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "skipped: table not found: not_a_table" ]] || false
+    [[ "$output" =~ "There are only 'skip'ped commits left to test" ]] || false
+}
+
+@test "bisect: run skips a commit where the query errors and keeps bisecting" {
+    dolt sql -q "CREATE TABLE t2 (pk int primary key);"
+    dolt commit -Am "commit 9"
+    dolt commit --allow-empty -m "commit 10"
+    dolt sql -q "DROP TABLE t2;"
+    dolt commit -Am "commit 11"
+    dolt sql -q "CREATE TABLE t2 (pk int primary key);"
+    dolt commit -Am "commit 12"
+    dolt sql -q "INSERT INTO t1 VALUES (100);"
+    dolt commit -Am "commit 13"
+    dolt sql -q "INSERT INTO t1 VALUES (101);"
+    dolt commit -Am "commit 14"
+    dolt bisect start HEAD HEAD~6
+    run dolt bisect run "select * from t1 where pk = 100 and (select count(*) from t2) = 0"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$(commit_hash 'commit 11') skipped: table not found: t2" ]] || false
+    [[ "$output" =~ "$(commit_hash 'commit 13') is the first bad commit" ]] || false
+}
+
+@test "bisect: skipped commits" {
+    dolt bisect start HEAD HEAD~2
+    run dolt bisect skip
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "There are only 'skip'ped commits left to test" ]] || false
+    [[ "$output" =~ "$(commit_hash 'commit 7')" ]] || false
+    [[ "$output" =~ "$(commit_hash 'commit 8')" ]] || false
+}
+
+@test "bisect: bad commit that is an ancestor of a good commit errors" {
+    run dolt bisect start HEAD~8 HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the bad commit is an ancestor of a good commit" ]] || false
+}
+
+@test "bisect: dolt_bisect procedure" {
+    run dolt sql -q "call dolt_bisect('start', 'HEAD', 'HEAD~8')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Bisecting: 3 revisions left to test after this" ]] || false
+
+    run dolt sql -q "call dolt_bisect('run', 'select * from t1 where pk = 5')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$(commit_hash 'commit 5') is the first bad commit" ]] || false
+
+    run dolt sql -q "call dolt_bisect('reset')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "bisect session ended" ]] || false
+
+    run dolt sql -q "call dolt_bisect('reset')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+}
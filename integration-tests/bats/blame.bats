@@ -140,7 +140,7 @@ SQL
 @test "blame: returns an error when the table is not found in the given revision" {
     run dolt blame HEAD~4 blame_test
     [ "$status" -eq 1 ]
-    [[ "$output" =~ "View 'dolt-repo-$$.dolt_blame_blame_test' references invalid table(s) or column(s) or function(s) or definer/invoker of view lack rights to use them" ]] || false
+    [[ "$output" =~ "table not found: blame_test" ]] || false
 }
 
 @test "blame: pk ordered output" {
@@ -183,3 +183,86 @@ SQL
     [[ "${lines[9]}" =~ "| sub  | 2   |" ]] || false
     [[ "${lines[10]}" =~ "| zzz  | 4   |" ]] || false
 }
+
+@test "blame: follows table renames" {
+    dolt sql -q "RENAME TABLE blame_test TO people"
+    dolt commit -Am "rename blame_test to people"
+    dolt sql -q "update people set name = 'Tommy' where pk = 1"
+    dolt commit -am "rename tom"
+
+    run dolt blame people
+    [ "$status" -eq 0 ]
+    [[ "${lines[3]}" =~ "| 1  |" ]] || false
+    [[ "${lines[3]}" =~ "rename tom" ]] || false
+    [[ "${lines[4]}" =~ "| 2  |" ]] || false
+    [[ "${lines[4]}" =~ "replace richard with harry" ]] || false
+    [[ "${lines[5]}" =~ "add more people to blame_test" ]] || false
+    [[ ! "$output" =~ "rename blame_test to people" ]] || false
+}
+
+@test "blame: follows primary key changes" {
+    dolt sql -q "ALTER TABLE blame_test MODIFY name varchar(20) NOT NULL"
+    dolt commit -am "make name a varchar"
+    dolt sql -q "ALTER TABLE blame_test DROP PRIMARY KEY, ADD PRIMARY KEY (pk, name)"
+    dolt commit -am "add name to primary key"
+
+    run dolt blame blame_test
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ "| pk | name " ]] || false
+    [[ "$output" =~ "make name a varchar" ]] || false
+    [[ ! "$output" =~ "add name to primary key" ]] || false
+}
+
+@test "blame: --since and --until" {
+    run dolt blame --since HEAD~1 blame_test
+    [ "$status" -eq 0 ]
+    [[ "${lines[3]}" =~ "| 1  |" ]] || false
+    [[ "${lines[3]}" =~ "replace richard with harry" ]] || false
+    [[ "${lines[4]}" =~ "replace richard with harry" ]] || false
+    [[ "${lines[5]}" =~ "add more people to blame_test" ]] || false
+
+    run dolt blame --until HEAD~2 blame_test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add richard to blame_test" ]] || false
+    [[ ! "$output" =~ "Harry Wombat" ]] || false
+
+    run dolt blame --since not_a_ref blame_test
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch not found: not_a_ref" ]] || false
+}
+
+@test "blame: --columns annotates each cell" {
+    dolt sql -q "ALTER TABLE blame_test ADD COLUMN age int"
+    dolt sql -q "update blame_test set age = 30 where pk = 2"
+    dolt commit -am "add ages"
+
+    run dolt blame --columns blame_test
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ "| pk | column_name |" ]] || false
+    [[ "$output" =~ "| 2  | name        |" ]] || false
+
+    run dolt sql -q "SELECT pk, column_name, message FROM dolt_blame('blame_test', '--columns') WHERE pk = 2 ORDER BY column_name" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "2,age,add ages" ]] || false
+    [[ "${lines[2]}" = "2,name,replace richard with harry" ]] || false
+}
+
+@test "blame: dolt_blame table function" {
+    run dolt sql -q "SELECT pk, committer FROM dolt_blame('blame_test') ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "1,\"Thomas Foolery,\"" ]] || false
+    [[ "${lines[2]}" = "2,\"Harry Wombat,\"" ]] || false
+
+    run dolt sql -q "SELECT pk, message FROM dolt_blame('HEAD~2', 'blame_test') ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[2]}" = "2,add richard to blame_test" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_blame('HEAD', 'blame_test', '--until', 'HEAD')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "a revision cannot be given together with --until" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_blame('not_a_table')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table not found" ]] || false
+}
@@ -0,0 +1,97 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    mkdir repo && cd repo
+    dolt init
+    dolt sql -q "create table t (pk int primary key, v int); insert into t values (1, 1);"
+    dolt commit -Am "add t"
+    dolt tag v1
+    REPO="$PWD"
+    cd ..
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "bundle: clone from a bundle" {
+    cd $REPO
+    dolt branch other
+    run dolt bundle create ../full.bundle main other v1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "full.bundle" ]] || false
+    cd ..
+
+    dolt clone full.bundle
+    cd full
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/other" ]] || false
+    run dolt tag
+    [[ "$output" =~ "v1" ]] || false
+    run dolt remote -v
+    [[ "$output" =~ "bundle://" ]] || false
+}
+
+@test "bundle: fetch and pull from an incremental bundle" {
+    cd $REPO
+    dolt bundle create ../full.bundle main
+    dolt clone ../full.bundle ../clone
+
+    dolt sql -q "insert into t values (2, 2)"
+    dolt commit -am "add row"
+    dolt checkout -b feature
+    dolt sql -q "insert into t values (3, 3)"
+    dolt commit -am "add another row"
+    dolt checkout main
+    dolt bundle create --since v1 ../inc.bundle main feature
+    cd ..
+
+    # an incremental bundle needs the commits it was created from
+    run dolt clone inc.bundle inc
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "which is not in this database" ]] || false
+    [ ! -d inc ]
+
+    cd clone
+    dolt remote add inc ../inc.bundle
+    dolt fetch inc
+    run dolt branch -a
+    [[ "$output" =~ "remotes/inc/feature" ]] || false
+
+    dolt pull inc main
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "2" ]] || false
+
+    # bundles are read only
+    dolt sql -q "insert into t values (4, 4)"
+    dolt commit -am "local row"
+    run dolt push inc main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "bundle remotes are read only" ]] || false
+}
+
+@test "bundle: invalid arguments" {
+    cd $REPO
+    run dolt bundle create ../out.bundle nope
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "nope is not a branch or tag" ]] || false
+
+    run dolt bundle create --since nope ../out.bundle main
+    [ "$status" -ne 0 ]
+    [ ! -f ../out.bundle ]
+
+    run dolt bundle create ../out.bundle
+    [ "$status" -ne 0 ]
+    cd ..
+
+    echo "not a bundle" > junk.bundle
+    run dolt clone junk.bundle junk
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not a dolt bundle" ]] || false
+}
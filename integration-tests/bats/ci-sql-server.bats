@@ -0,0 +1,209 @@
+#!/usr/bin/env bats
+#
+# Tests for sql-server running Dolt CI workflows when a branch head advances.
+
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    skiponwindows "tests are flaky on Windows"
+    if [ "$SQL_ENGINE" = "remote-engine" ]; then
+      skip "This test starts its own sql-server."
+    fi
+    setup_common
+
+    dolt sql -q "create table t1 (pk int primary key);"
+    dolt add .
+    dolt commit -m "add t1"
+    cat > workflow.yaml <<EOF
+name: main_checks
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: checks
+    steps:
+      - name: has table
+        assert_table: t1
+      - name: row count
+        row_count_table: t1
+        expected_row_count: "< 2"
+EOF
+    cat > feature.yaml <<EOF
+name: feature_checks
+on:
+  push:
+    branches:
+      - feature
+jobs:
+  - name: checks
+    steps:
+      - name: query
+        query: select 1
+        expected_results:
+          - [1]
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    dolt ci import ./feature.yaml
+
+    dolt sql -q "create user ci@localhost; grant select on *.* to ci@localhost;"
+    cat > ci.yaml <<EOF
+ci:
+  user: ci
+EOF
+}
+
+teardown() {
+    stop_sql_server 1 && sleep 0.5
+    teardown_common
+}
+
+# start_ci_sql_server starts a sql-server which runs workflows as the ci user
+start_ci_sql_server() {
+    start_sql_server_with_config "" ci.yaml
+}
+
+# wait_for_ci_runs waits until dolt_ci_runs has at least $1 rows
+wait_for_ci_runs() {
+    for i in {1..50}; do
+        run dolt sql -r csv -q "select count(*) as c from dolt_ci_runs;"
+        if [ "$status" -eq 0 ] && [ "${lines[1]}" -ge "$1" ]; then
+            return 0
+        fi
+        sleep 0.1
+    done
+    echo "timed out waiting for $1 dolt_ci_runs rows: $output"
+    return 1
+}
+
+@test "ci-sql-server: workflows run when a branch head advances" {
+    start_ci_sql_server
+
+    dolt sql -q "insert into t1 values (1); call dolt_commit('-am', 'one');"
+    wait_for_ci_runs 2
+    dolt sql -q "insert into t1 values (2); call dolt_commit('-am', 'two');"
+    wait_for_ci_runs 4
+
+    run dolt sql -r csv -q "select run_id, step_index, workflow_name, branch, job_name, step_name, status, message from dolt_ci_runs order by run_id, step_index;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,0,main_checks,main,checks,has table,passed," ]] || false
+    [[ "$output" =~ "1,1,main_checks,main,checks,row count,passed," ]] || false
+    [[ "$output" =~ "2,0,main_checks,main,checks,has table,passed," ]] || false
+    [[ "$output" =~ "2,1,main_checks,main,checks,row count,failed,\"expected rows < 2, got 2\"" ]] || false
+
+    run dolt sql -r csv -q "select count(*) as c from dolt_ci_runs where finished_at >= started_at and duration_ms >= 0;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c
+4" ]] || false
+
+    head=$(dolt sql -r csv -q "select hash from dolt_log limit 1;" | tail -n 1)
+    run dolt sql -r csv -q "select distinct commit_hash from dolt_ci_runs where run_id = 2;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$head" ]] || false
+}
+
+@test "ci-sql-server: workflows only run for the branches of their push trigger" {
+    start_ci_sql_server
+
+    dolt sql -q "call dolt_checkout('-b', 'feature'); insert into t1 values (1); call dolt_commit('-am', 'one');"
+    wait_for_ci_runs 2
+    sleep 0.5
+
+    run dolt sql -r csv -q "select workflow_name, branch, status from dolt_ci_runs;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature_checks,feature,passed" ]] || false
+    [[ ! "$output" =~ "main_checks" ]] || false
+}
+
+@test "ci-sql-server: workflows with a pull_request trigger run when a branch is merged into their branch" {
+    cat > pr.yaml <<EOF
+name: pr_checks
+on:
+  pull_request:
+    branches:
+      - main
+    activities:
+      - closed
+jobs:
+  - name: checks
+    steps:
+      - name: query
+        query: select 1
+        expected_results:
+          - [1]
+EOF
+    dolt ci import ./pr.yaml
+    start_ci_sql_server
+
+    dolt sql -q "insert into t1 values (1); call dolt_commit('-am', 'one');"
+    wait_for_ci_runs 2
+    dolt sql -q "call dolt_checkout('-b', 'feature'); insert into t1 values (2); call dolt_commit('-am', 'two');"
+    wait_for_ci_runs 4
+    sleep 0.5
+
+    run dolt sql -r csv -q "select count(*) as c from dolt_ci_runs where workflow_name = 'pr_checks';"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c
+0" ]] || false
+
+    dolt sql -q "call dolt_merge('--no-ff', 'feature');"
+    wait_for_ci_runs 7
+
+    run dolt sql -r csv -q "select workflow_name, branch, status from dolt_ci_runs where workflow_name = 'pr_checks';"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "pr_checks,main,passed" ]] || false
+}
+
+@test "ci-sql-server: workflows do not run in databases without dolt ci tables" {
+    dolt ci destroy
+    start_ci_sql_server
+
+    dolt sql -q "insert into t1 values (1); call dolt_commit('-am', 'one');"
+    sleep 1
+
+    run dolt sql -r csv -q "select count(*) as c from dolt_ci_runs;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c
+0" ]] || false
+}
+
+@test "ci-sql-server: workflows do not run without a ci section in the server config" {
+    start_sql_server
+
+    dolt sql -q "insert into t1 values (1); call dolt_commit('-am', 'one');"
+    sleep 1
+
+    run dolt sql -r csv -q "select count(*) as c from dolt_ci_runs;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c
+0" ]] || false
+}
+
+@test "ci-sql-server: workflows run with the privileges of the configured user" {
+    dolt sql -q "revoke select on *.* from ci@localhost;"
+    start_ci_sql_server
+
+    dolt sql -q "insert into t1 values (1); call dolt_commit('-am', 'one');"
+    sleep 1
+
+    # the ci user can not read the workflows, so none are run
+    run dolt sql -r csv -q "select count(*) as c from dolt_ci_runs;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c
+0" ]] || false
+}
+
+@test "ci-sql-server: workflow runs are kept after a restart" {
+    start_ci_sql_server
+
+    dolt sql -q "insert into t1 values (1); call dolt_commit('-am', 'one');"
+    wait_for_ci_runs 2
+    stop_sql_server 1
+
+    run dolt sql -r csv -q "select workflow_name, step_name, status from dolt_ci_runs order by step_name;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "main_checks,has table,passed" ]] || false
+    [[ "$output" =~ "main_checks,row count,passed" ]] || false
+}
@@ -38,6 +38,11 @@ get_commit_hash() {
     dolt sql -q "select * from dolt_ci_workflow_steps;"
     dolt sql -q "select * from dolt_ci_workflow_saved_query_steps;"
     dolt sql -q "select * from dolt_ci_workflow_saved_query_step_expected_row_column_results;"
+    dolt sql -q "select * from dolt_ci_workflow_sql_assertion_steps;"
+    dolt sql -q "select * from dolt_ci_workflow_schema_assertion_steps;"
+    dolt sql -q "select * from dolt_ci_workflow_row_count_steps;"
+    dolt sql -q "select * from dolt_ci_workflow_diff_size_steps;"
+    dolt sql -q "select * from dolt_ci_workflow_verify_constraints_steps;"
 }
 
 @test "ci: destroy should destroy dolt ci workflow tables" {
@@ -373,3 +378,447 @@ EOF
     [ "$status" -eq 0 ]
     [[ "$output" =~ "workflow_2" ]] || false
 }
+
+@test "ci: run passes when saved query assertions hold" {
+    skip_remote_engine
+    dolt sql -q "create table t1 (pk int primary key);"
+    dolt sql -q "insert into t1 values (1), (2);"
+    dolt sql -q "select * from t1" -s "all rows"
+    dolt add .
+    dolt commit -m "add t1"
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: validate t1
+    steps:
+      - name: t1 has two rows
+        saved_query_name: all rows
+        expected_rows: "== 2"
+        expected_columns: "== 1"
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    run dolt ci run "my_workflow"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "PASS  t1 has two rows" ]] || false
+    [[ "$output" =~ "Dolt CI Workflow 'my_workflow' passed." ]] || false
+}
+
+@test "ci: run fails when saved query assertions do not hold" {
+    skip_remote_engine
+    dolt sql -q "create table t1 (pk int primary key);"
+    dolt sql -q "insert into t1 values (1), (2), (3);"
+    dolt sql -q "select * from t1" -s "all rows"
+    dolt add .
+    dolt commit -m "add t1"
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: validate t1
+    steps:
+      - name: t1 has two rows
+        saved_query_name: all rows
+        expected_rows: "== 2"
+      - name: missing saved query
+        saved_query_name: does not exist
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    run dolt ci run "my_workflow"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "FAIL  t1 has two rows: expected rows == 2, got 3" ]] || false
+    [[ "$output" =~ "FAIL  missing saved query: saved query not found: does not exist" ]] || false
+    [[ "$output" =~ "Dolt CI Workflow 'my_workflow' failed." ]] || false
+}
+
+@test "ci: run runs against the given branch" {
+    skip_remote_engine
+    dolt sql -q "create table t1 (pk int primary key);"
+    dolt sql -q "insert into t1 values (1), (2);"
+    dolt sql -q "select * from t1" -s "all rows"
+    dolt add .
+    dolt commit -m "add t1"
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: validate t1
+    steps:
+      - name: t1 has two rows
+        saved_query_name: all rows
+        expected_rows: "== 2"
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    dolt branch other
+    dolt sql -q "insert into t1 values (3);"
+    dolt commit -am "add row"
+
+    run dolt ci run "my_workflow"
+    [ "$status" -eq 1 ]
+
+    run dolt ci run --branch other "my_workflow"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Dolt CI Workflow 'my_workflow' passed." ]] || false
+}
+
+@test "ci: run errors on unknown workflow" {
+    skip_remote_engine
+    dolt ci init
+    run dolt ci run "does_not_exist"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "workflow not found" ]] || false
+}
+
+@test "ci: import and export round trip assertion step types" {
+    skip_remote_engine
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: checks
+    steps:
+      - name: sql assertion
+        query: select 1, null
+        expected_results:
+          - [1, null]
+      - name: schema assertion
+        assert_table: t1
+        expected_table_columns: [pk, c1]
+      - name: row count
+        row_count_table: t1
+        expected_row_count: ">= 2"
+      - name: row count change
+        row_count_table: t1
+        row_count_base: main
+        expected_row_count: "> 0"
+      - name: diff size
+        diff_base: main
+        diff_table: t1
+        expected_diff_rows: "<= 10"
+      - name: verify constraints
+        verify_constraints: []
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    run dolt ci export "my_workflow"
+    [ "$status" -eq 0 ]
+    run cat my_workflow.yaml
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'query: "select 1, null"' ]] || false
+    [[ "$output" =~ '- ["1", null]' ]] || false
+    [[ "$output" =~ 'assert_table: "t1"' ]] || false
+    [[ "$output" =~ 'expected_table_columns: ["pk", "c1"]' ]] || false
+    [[ "$output" =~ 'row_count_table: "t1"' ]] || false
+    [[ "$output" =~ 'expected_row_count: ">= 2"' ]] || false
+    [[ "$output" =~ 'row_count_base: "main"' ]] || false
+    [[ "$output" =~ 'expected_row_count: "> 0"' ]] || false
+    [[ "$output" =~ 'diff_base: "main"' ]] || false
+    [[ "$output" =~ 'diff_table: "t1"' ]] || false
+    [[ "$output" =~ 'expected_diff_rows: "<= 10"' ]] || false
+    [[ "$output" =~ 'verify_constraints: []' ]] || false
+
+    run dolt ci import ./my_workflow.yaml
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Dolt CI Workflow 'my_workflow' up to date." ]] || false
+}
+
+@test "ci: import command will update existing assertion steps" {
+    skip_remote_engine
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: checks
+    steps:
+      - name: row count
+        row_count_table: t1
+        expected_row_count: ">= 2"
+      - name: check
+        assert_table: t1
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: checks
+    steps:
+      - name: check
+        verify_constraints: [t1]
+      - name: row count
+        row_count_table: t1
+        expected_row_count: "< 5"
+EOF
+    dolt ci import ./workflow.yaml
+    dolt ci export "my_workflow"
+    run cat my_workflow.yaml
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'verify_constraints: ["t1"]' ]] || false
+    [[ "$output" =~ 'expected_row_count: "< 5"' ]] || false
+    [[ ! "$output" =~ "assert_table" ]] || false
+
+    run dolt sql -r csv -q "select count(*) as c from dolt_ci_workflow_schema_assertion_steps;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c
+0" ]] || false
+}
+
+@test "ci: import command will error on a step with fields of more than one step type" {
+    skip_remote_engine
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: checks
+    steps:
+      - name: mixed
+        query: select 1
+        assert_table: t1
+EOF
+    dolt ci init
+    run dolt ci import ./workflow.yaml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid config: step mixed has fields of more than one step type" ]] || false
+}
+
+@test "ci: run passes when assertion steps hold" {
+    skip_remote_engine
+    dolt sql -q "create table t1 (pk int primary key, c1 varchar(10));"
+    dolt sql -q "insert into t1 values (1, 'a'), (2, null);"
+    dolt sql -q "create table t2 (pk int primary key, fk int, foreign key (fk) references t1 (pk));"
+    dolt add .
+    dolt commit -m "add tables"
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: checks
+    steps:
+      - name: sql assertion
+        query: select pk, c1 from t1 where pk < 3 order by pk
+        expected_results:
+          - [1, a]
+          - [2, null]
+      - name: schema assertion
+        assert_table: t1
+        expected_table_columns: [pk, c1]
+      - name: row count
+        row_count_table: t1
+        expected_row_count: "== 3"
+      - name: row count change
+        row_count_table: t1
+        row_count_base: base
+        expected_row_count: "== 1"
+      - name: new table row count change
+        row_count_table: t3
+        row_count_base: base
+        expected_row_count: "== 2"
+      - name: diff size
+        diff_base: base
+        expected_diff_rows: "== 3"
+      - name: verify constraints
+        verify_constraints: [t2]
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    dolt branch base
+    dolt sql -q "insert into t1 values (3, 'c');"
+    dolt sql -q "create table t3 (pk int primary key); insert into t3 values (1), (2);"
+    dolt add .
+    dolt commit -am "add rows"
+
+    run dolt ci run "my_workflow"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "PASS  sql assertion" ]] || false
+    [[ "$output" =~ "PASS  schema assertion" ]] || false
+    [[ "$output" =~ "PASS  row count" ]] || false
+    [[ "$output" =~ "PASS  row count change" ]] || false
+    [[ "$output" =~ "PASS  new table row count change" ]] || false
+    [[ "$output" =~ "PASS  diff size" ]] || false
+    [[ "$output" =~ "PASS  verify constraints" ]] || false
+    [[ "$output" =~ "Dolt CI Workflow 'my_workflow' passed." ]] || false
+}
+
+@test "ci: run fails when assertion steps do not hold" {
+    skip_remote_engine
+    dolt sql -q "create table t1 (pk int primary key, c1 varchar(10));"
+    dolt sql -q "insert into t1 values (1, 'a'), (2, null);"
+    dolt sql -q "create table t2 (pk int primary key, fk int, foreign key (fk) references t1 (pk));"
+    dolt add .
+    dolt commit -m "add tables"
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: checks
+    steps:
+      - name: sql assertion
+        query: select pk, c1 from t1 order by pk
+        expected_results:
+          - [1, a]
+          - [2, b]
+      - name: schema assertion
+        assert_table: t1
+        expected_table_columns: [pk, c2]
+      - name: missing table
+        assert_table: t3
+      - name: row count
+        row_count_table: t1
+        expected_row_count: "< 2"
+      - name: row count change
+        row_count_table: t1
+        row_count_base: base
+        expected_row_count: "> 0"
+      - name: diff size
+        diff_base: base
+        expected_diff_rows: "== 0"
+      - name: verify constraints
+        verify_constraints: []
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    dolt branch base
+    dolt sql -q "set foreign_key_checks = 0; insert into t2 values (1, 100);"
+    dolt commit -am "add orphaned row"
+
+    run dolt ci run "my_workflow"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "FAIL  sql assertion: row 2, column c1: expected 'b', got NULL" ]] || false
+    [[ "$output" =~ "FAIL  schema assertion: table t1 is missing columns: c2" ]] || false
+    [[ "$output" =~ "FAIL  missing table: table not found: t3" ]] || false
+    [[ "$output" =~ "FAIL  row count: expected rows < 2, got 2" ]] || false
+    [[ "$output" =~ "FAIL  row count change: expected change in rows since the merge base with base > 0, got 0" ]] || false
+    [[ "$output" =~ "FAIL  diff size: expected changed rows == 0, got 1" ]] || false
+    [[ "$output" =~ "FAIL  verify constraints: constraint violations found" ]] || false
+    [[ "$output" =~ "Dolt CI Workflow 'my_workflow' failed." ]] || false
+}
+
+@test "ci: dolt ci tables missing from an older initialization are treated as empty" {
+    skip_remote_engine
+    dolt ci init
+    dolt sql -q "drop table dolt_ci_workflow_verify_constraints_steps;"
+    dolt add .
+    dolt commit -m "remove table"
+
+    run dolt ci ls
+    [ "$status" -eq 0 ]
+
+    cat > workflow.yaml <<EOF
+name: my_workflow
+on:
+  push:
+    branches:
+      - master
+jobs:
+  - name: checks
+    steps:
+      - name: verify constraints
+        verify_constraints: []
+EOF
+    dolt ci import ./workflow.yaml
+    run dolt sql -q "select count(*) from dolt_ci_workflow_verify_constraints_steps;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
+
+@test "ci: init creates dolt ci tables missing from an older initialization" {
+    skip_remote_engine
+    dolt ci init
+    dolt sql -q "drop table dolt_ci_workflow_verify_constraints_steps;"
+    dolt add .
+    dolt commit -m "remove table"
+
+    dolt ci init
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully upgraded Dolt CI" ]] || false
+
+    run dolt ci ls
+    [ "$status" -eq 0 ]
+}
+
+@test "ci: workflows required by a protected branch are run when merging into it" {
+    skip_remote_engine
+    dolt sql -q "create table t1 (pk int primary key);"
+    dolt add .
+    dolt commit -m "add t1"
+    cat > workflow.yaml <<EOF
+name: main_checks
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: checks
+    steps:
+      - name: row count
+        row_count_table: t1
+        expected_row_count: "< 2"
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    dolt sql -q "insert into dolt_branch_protection (branch, required_workflows) values ('main', 'main_checks');"
+
+    dolt checkout -b feature
+    dolt sql -q "insert into t1 values (1), (2);"
+    dolt commit -am "two rows"
+    too_many=$(get_commit_hash 1)
+    dolt checkout main
+
+    run dolt merge feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "workflow 'main_checks' has not passed on $too_many" ]] || false
+
+    run dolt sql -r csv -q "select commit_hash, status, message from dolt_ci_runs"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$too_many,failed,\"expected rows < 2, got 2\"" ]] || false
+
+    dolt checkout feature
+    dolt sql -q "delete from t1 where pk = 2;"
+    dolt commit -am "one row"
+    just_right=$(get_commit_hash 1)
+    dolt checkout main
+
+    run dolt merge feature
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "select commit_hash, status from dolt_ci_runs where commit_hash = '$just_right'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$just_right,passed" ]] || false
+    [ "${#lines[@]}" -eq 2 ]
+}
@@ -0,0 +1,139 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    KEY1=$(head -c 32 /dev/urandom | od -An -tx1 | tr -d ' \n')
+    KEY2=$(head -c 32 /dev/urandom | od -An -tx1 | tr -d ' \n')
+}
+
+teardown() {
+    teardown_common
+}
+
+init_encrypted() {
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt init --encrypt
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt sql -q "create table t (pk int primary key, v varchar(64)); insert into t values (1, 'plaintext-secret-value');"
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt commit -Am "add t"
+}
+
+@test "encryption: init --encrypt requires a key" {
+    run dolt init --encrypt
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no encryption key found" ]] || false
+    [ ! -d .dolt ]
+
+    DOLT_ENCRYPTION_KEY=not-a-key run dolt init --encrypt
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid encryption key" ]] || false
+}
+
+@test "encryption: chunk data is not stored in plaintext" {
+    init_encrypted
+    [ -f .dolt/noms/encryption.json ]
+
+    run grep -rl "plaintext-secret-value" .dolt/noms
+    [ "$status" -eq 1 ]
+
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt gc
+    run grep -rl "plaintext-secret-value" .dolt/noms
+    [ "$status" -eq 1 ]
+    [ -f .dolt/noms/oldgen/encryption.json ]
+
+    DOLT_ENCRYPTION_KEY=$KEY1 run dolt sql -q "select v from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-secret-value" ]] || false
+}
+
+@test "encryption: repository cannot be read without its key" {
+    init_encrypted
+
+    run dolt status
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no encryption key found" ]] || false
+
+    DOLT_ENCRYPTION_KEY=$KEY2 run dolt log
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "is not available" ]] || false
+}
+
+@test "encryption: modified chunk data is detected" {
+    init_encrypted
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt gc
+
+    # overwrite a byte in the middle of the largest table file
+    f=$(ls -S .dolt/noms/oldgen | grep -v -e manifest -e LOCK -e encryption.json | head -n 1)
+    sz=$(wc -c < ".dolt/noms/oldgen/$f")
+    printf '\x00' | dd of=".dolt/noms/oldgen/$f" bs=1 seek=$((sz / 2)) count=1 conv=notrunc
+
+    DOLT_ENCRYPTION_KEY=$KEY1 run dolt status
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "encrypted data failed authentication" ]] || false
+}
+
+@test "encryption: keys can be read from a file" {
+    printf "# dolt keys\n%s\n" "$KEY1" > "$BATS_TMPDIR/keys-$$"
+    DOLT_ENCRYPTION_KEY_FILE="$BATS_TMPDIR/keys-$$" dolt init --encrypt
+    DOLT_ENCRYPTION_KEY_FILE="$BATS_TMPDIR/keys-$$" dolt sql -q "create table t (pk int primary key)"
+    DOLT_ENCRYPTION_KEY=$KEY1 run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t" ]] || false
+    rm "$BATS_TMPDIR/keys-$$"
+}
+
+@test "encryption: rotate-key re-encrypts the repository" {
+    init_encrypted
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt sql -q "insert into t values (2, 'uncommitted')"
+    old_id=$(grep key_id .dolt/noms/encryption.json)
+
+    DOLT_ENCRYPTION_KEY=$KEY2 run dolt rotate-key
+    [ "$status" -ne 0 ]
+
+    DOLT_ENCRYPTION_KEY=$KEY2,$KEY1 run dolt rotate-key
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rotated encryption key" ]] || false
+    new_id=$(grep key_id .dolt/noms/encryption.json)
+    [ "$old_id" != "$new_id" ]
+
+    # the old key is no longer needed
+    DOLT_ENCRYPTION_KEY=$KEY2 run dolt sql -q "select * from t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-secret-value" ]] || false
+    [[ "$output" =~ "uncommitted" ]] || false
+
+    DOLT_ENCRYPTION_KEY=$KEY2 run dolt fsck
+    [ "$status" -eq 0 ]
+
+    DOLT_ENCRYPTION_KEY=$KEY1 run dolt status
+    [ "$status" -ne 0 ]
+
+    run dolt rotate-key
+    [ "$status" -ne 0 ]
+}
+
+@test "encryption: rotate-key on an unencrypted repository" {
+    dolt init
+    DOLT_ENCRYPTION_KEY=$KEY1 run dolt rotate-key
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not encrypted" ]] || false
+}
+
+@test "encryption: push and clone from an encrypted repository" {
+    mkdir repo && cd repo
+    init_encrypted
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt remote add origin file://../remote
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt push origin main
+    cd ..
+
+    dolt clone file://./remote clone
+    cd clone
+    run dolt sql -q "select v from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "plaintext-secret-value" ]] || false
+
+    # the commit hashes of the encrypted repository match its clone
+    head=$(dolt log -n 1 --oneline | cut -d ' ' -f 1)
+    cd ../repo
+    DOLT_ENCRYPTION_KEY=$KEY1 run dolt log -n 1 --oneline
+    [[ "$output" =~ "$head" ]] || false
+}
@@ -0,0 +1,192 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    repo=$PWD
+    dolt sql -q "CREATE TABLE t (pk int primary key, c1 varchar(20), c2 json, key (c1));"
+    dolt sql -q "INSERT INTO t VALUES (1, 'a', '{\"x\": 1}'), (2, 'b', null);"
+    dolt commit -Am "create t"
+
+    # an unrelated repository with the same table
+    mkdir "$BATS_TMPDIR/other-$$"
+    cd "$BATS_TMPDIR/other-$$"
+    dolt init
+    dolt sql -q "CREATE TABLE t (pk int primary key, c1 varchar(20), c2 json, key (c1));"
+    dolt sql -q "INSERT INTO t VALUES (1, 'a', '{\"x\": 1}'), (2, 'b', null);"
+    dolt commit -Am "create t in other"
+    other=$PWD
+    cd "$repo"
+}
+
+teardown() {
+    assert_feature_version
+    rm -rf "$BATS_TMPDIR/other-$$"
+    teardown_common
+}
+
+@test "format-patch: apply row changes to an unrelated repository" {
+    dolt sql -q "UPDATE t SET c1 = 'z', c2 = '{\"x\": [1, 2]}' WHERE pk = 1; DELETE FROM t WHERE pk = 2; INSERT INTO t VALUES (3, 'c', '[]');"
+    dolt commit --author "Patch Author <patch@example.com>" -am "change rows"
+    dolt sql -q "INSERT INTO t VALUES (4, 'd', null);"
+    dolt commit -am "add a row"
+
+    run dolt format-patch -o patch.json HEAD~2..HEAD
+    [ "$status" -eq 0 ]
+
+    cd "$other"
+    run dolt am "$repo/patch.json"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applied 2 commits" ]] || false
+
+    run dolt sql -q "SELECT pk, c1, c2 FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '1,z,"{""x"":[1,2]}"' ]] || false
+    [[ "$output" =~ '3,c,[]' ]] || false
+    [[ "$output" =~ '4,d,' ]] || false
+    [[ ! "$output" =~ '2,b' ]] || false
+
+    run dolt sql -q "SELECT pk FROM t WHERE c1 = 'z'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt log -n 2
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add a row" ]] || false
+    [[ "$output" =~ "change rows" ]] || false
+    [[ "$output" =~ "Patch Author <patch@example.com>" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "format-patch: a single revision formats the commits after it" {
+    dolt sql -q "INSERT INTO t VALUES (3, 'c', null);"
+    dolt commit -am "add a row"
+
+    run dolt format-patch HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"format": "dolt-patch"' ]] || false
+    [[ "$output" =~ '"message": "add a row"' ]] || false
+    [[ ! "$output" =~ '"message": "create t"' ]] || false
+
+    run dolt format-patch HEAD
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"commits": []' ]] || false
+
+    run dolt sql -q "CALL dolt_format_patch('HEAD~1..HEAD')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add a row" ]] || false
+}
+
+@test "format-patch: apply schema changes, new tables, renames and drops" {
+    dolt sql -q "ALTER TABLE t ADD COLUMN c3 int DEFAULT 7; UPDATE t SET c3 = 8 WHERE pk = 2;"
+    dolt sql -q "CREATE TABLE u (id int primary key, name varchar(10)); INSERT INTO u VALUES (1, 'one');"
+    dolt commit -Am "add c3 and u"
+    dolt sql -q "RENAME TABLE u TO v; UPDATE v SET name = 'uno';"
+    dolt commit -Am "rename u"
+    dolt sql -q "DROP TABLE v;"
+    dolt commit -Am "drop v"
+
+    dolt format-patch -o patch.json HEAD~3
+
+    cd "$other"
+    run dolt am "$repo/patch.json"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applied 3 commits" ]] || false
+
+    run dolt sql -q "SELECT pk, c1, c3 FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,a,7" ]] || false
+    [[ "$output" =~ "2,b,8" ]] || false
+
+    run dolt sql -q "SELECT * FROM v AS OF 'HEAD~1'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,uno" ]] || false
+
+    run dolt sql -q "SHOW TABLES" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "t" ]] || false
+
+    run dolt schema show t
+    [[ "$output" =~ '`c3` int DEFAULT' ]] || false
+    [[ "$output" =~ 'KEY `c1` (`c1`)' ]] || false
+}
+
+@test "format-patch: conflicting changes stop dolt am" {
+    dolt sql -q "UPDATE t SET c1 = 'theirs' WHERE pk = 1;"
+    dolt commit -am "change pk 1"
+    dolt sql -q "UPDATE t SET c1 = 'second' WHERE pk = 2;"
+    dolt commit -am "change pk 2"
+    dolt format-patch -o patch.json HEAD~2
+
+    cd "$other"
+    dolt sql -q "UPDATE t SET c1 = 'ours' WHERE pk = 1;"
+    dolt commit -am "local change"
+
+    run dolt am "$repo/patch.json"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Applied 0 commits" ]] || false
+    [[ "$output" =~ "patch 1 of 2" ]] || false
+    [[ "$output" =~ "--skip 1" ]] || false
+
+    run dolt sql -q "SELECT our_c1, their_c1 FROM dolt_conflicts_t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "ours,theirs" ]] || false
+
+    # dolt am does not apply over uncommitted changes
+    run dolt am "$repo/patch.json"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "uncommitted changes" ]] || false
+
+    dolt conflicts resolve --theirs t
+    dolt add t
+    dolt commit -m "change pk 1"
+
+    run dolt am --skip 1 "$repo/patch.json"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applied 1 commits" ]] || false
+
+    run dolt sql -q "SELECT pk, c1 FROM t ORDER BY pk" -r csv
+    [[ "$output" =~ "1,theirs" ]] || false
+    [[ "$output" =~ "2,second" ]] || false
+}
+
+@test "format-patch: patches only apply to tables with the same columns" {
+    dolt sql -q "UPDATE t SET c1 = 'z' WHERE pk = 1;"
+    dolt commit -am "change rows"
+    dolt format-patch -o patch.json HEAD~1
+
+    cd "$other"
+    dolt sql -q "ALTER TABLE t DROP COLUMN c2;"
+    dolt commit -am "drop c2"
+
+    run dolt am "$repo/patch.json"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the columns of table t do not match the patch" ]] || false
+
+    dolt sql -q "DROP TABLE t;"
+    dolt commit -am "drop t"
+    run dolt am "$repo/patch.json"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table t does not exist" ]] || false
+}
+
+@test "format-patch: keyless tables and invalid patches are rejected" {
+    dolt sql -q "CREATE TABLE keyless (c int); INSERT INTO keyless VALUES (1);"
+    dolt commit -Am "add keyless"
+
+    run dolt format-patch HEAD~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "keyless tables cannot be written to a patch" ]] || false
+
+    echo '{"format": "something else"}' > not-a-patch.json
+    run dolt am not-a-patch.json
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not a dolt patch" ]] || false
+
+    run dolt am missing.json
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "failed to read patch missing.json" ]] || false
+}
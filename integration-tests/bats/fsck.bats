@@ -87,3 +87,112 @@ UPDATE tbl SET guid = UUID() WHERE i >= @random_id LIMIT 1;"
     [ "$status" -eq 1 ]
     [[ "$output" =~ "Chunk: 7i48kt4h41hcjniri7scv5m8a69cdn13 content hash mismatch: hitg0bb0hsakip96qvu2hts0hkrrla9o" ]] || false
 }
+
+# Creates a database with its chunks in a single table file in oldgen, and pushes it to the remote origin.
+setup_repair_repo() {
+    dolt init
+    dolt sql -q "create table tbl (i int auto_increment primary key, guid char(36))"
+    dolt commit -Am "Create table tbl"
+    dolt sql -q "$(insert_statement)"
+
+    dolt remote add origin file://../remote
+    dolt push origin main
+    dolt gc
+}
+
+# Overwrites some bytes of the chunk data in the oldgen table file.
+corrupt_oldgen_table_file() {
+    table_file=$(ls .dolt/noms/oldgen | grep -E '^[0-9a-v]{32}$' | head -n 1)
+    printf 'XXXXXXXX' | dd of=".dolt/noms/oldgen/$table_file" bs=1 seek=60 conv=notrunc
+}
+
+@test "fsck: repair corrupt table file from a remote" {
+    mkdir repo && cd repo
+    setup_repair_repo
+    corrupt_oldgen_table_file
+
+    run dolt fsck
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Corrupt Chunks: 1" ]] || false
+
+    run dolt fsck --repair origin
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Before repair:" ]] || false
+    [[ "$output" =~ "Repaired corrupt chunk" ]] || false
+    [[ "$output" =~ "Replaced table file $table_file" ]] || false
+    [[ "$output" =~ "After repair:" ]] || false
+    [[ "$output" =~ "No problems found." ]] || false
+
+    dolt fsck
+    run dolt sql -q "select count(*) from tbl" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "25" ]] || false
+
+    dolt gc
+    [ ! -f ".dolt/noms/oldgen/$table_file" ]
+    dolt fsck
+}
+
+@test "fsck: repair dry run makes no changes" {
+    mkdir repo && cd repo
+    setup_repair_repo
+    corrupt_oldgen_table_file
+
+    run dolt fsck --repair origin --dry-run
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Would repair corrupt chunk" ]] || false
+    [[ "$output" =~ "Would replace table file $table_file" ]] || false
+    [[ ! "$output" =~ "After repair:" ]] || false
+
+    run dolt fsck
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Corrupt Chunks: 1" ]] || false
+}
+
+@test "fsck: repair torn journal tail" {
+    mkdir repo && cd repo
+    setup_repair_repo
+    dolt sql -q "$(insert_statement)"
+    printf 'torn record' >> .dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv
+
+    run dolt fsck
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "bytes after the last valid record" ]] || false
+
+    run dolt fsck --repair origin
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Truncated" ]] || false
+    [[ "$output" =~ "No problems found." ]] || false
+    [ -f .dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv.torn ]
+
+    run dolt sql -q "select count(*) from tbl" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "50" ]] || false
+}
+
+@test "fsck: repair from a backup" {
+    mkdir repo && cd repo
+    setup_repair_repo
+    dolt backup add bak file://../bak
+    dolt backup sync bak
+    dolt remote remove origin
+    corrupt_oldgen_table_file
+
+    run dolt fsck --repair bak
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Repaired corrupt chunk" ]] || false
+    [[ "$output" =~ "No problems found." ]] || false
+}
+
+@test "fsck: repair invalid arguments" {
+    mkdir repo && cd repo
+    setup_repair_repo
+
+    run dolt fsck --dry-run
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--dry-run" ]] || false
+
+    run dolt fsck --repair nosuchremote
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "nosuchremote" ]] || false
+}
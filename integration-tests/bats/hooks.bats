@@ -0,0 +1,243 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE products (id int primary key, price decimal(10,2));"
+    dolt commit -Am "create products"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "hooks: sql pre-commit hook aborts the commit when it returns rows" {
+    dolt config --local --add hooks.pre-commit.sql "SELECT id FROM products WHERE price IS NULL"
+
+    dolt sql -q "INSERT INTO products VALUES (1, 10.00), (2, NULL);"
+    run dolt commit -am "null price"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-commit hook failed: hook query returned rows" ]] || false
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "create products" ]] || false
+
+    # the working set is untouched
+    run dolt sql -q "SELECT count(*) FROM products" -r csv
+    [[ "$output" =~ "2" ]] || false
+
+    dolt sql -q "UPDATE products SET price = 5.00 WHERE id = 2;"
+    run dolt commit -am "all prices"
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [[ "$output" =~ "all prices" ]] || false
+}
+
+@test "hooks: sql pre-commit hook sees the staged changes" {
+    dolt config --local --add hooks.pre-commit.sql "SELECT id FROM products WHERE price IS NULL"
+
+    dolt sql -q "INSERT INTO products VALUES (1, 10.00);"
+    dolt add products
+    dolt sql -q "INSERT INTO products VALUES (2, NULL);"
+
+    # only the staged row is committed, so the hook passes
+    run dolt commit -m "staged row"
+    [ "$status" -eq 0 ]
+
+    run dolt status
+    [[ "$output" =~ "modified:" ]] || false
+}
+
+@test "hooks: --no-verify skips the pre-commit hook" {
+    dolt config --local --add hooks.pre-commit.sql "SELECT id FROM products WHERE price IS NULL"
+
+    dolt sql -q "INSERT INTO products VALUES (1, NULL);"
+    run dolt commit --no-verify -am "skip hooks"
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [[ "$output" =~ "skip hooks" ]] || false
+}
+
+@test "hooks: executable pre-commit hook" {
+    skiponwindows "uses a shell script"
+    cat > ../check.sh <<'EOF'
+#!/bin/sh
+echo "hook $DOLT_HOOK on $DOLT_DATABASE/$DOLT_BRANCH"
+if [ -f "$PWD/.block" ]; then
+    echo "commits are blocked"
+    exit 1
+fi
+EOF
+    chmod +x ../check.sh
+    dolt config --local --add hooks.pre-commit.exec "../check.sh"
+
+    touch .block
+    dolt sql -q "INSERT INTO products VALUES (1, 1.00);"
+    run dolt commit -am "blocked"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-commit hook failed: exit status 1" ]] || false
+    [[ "$output" =~ "hook pre-commit on" ]] || false
+    [[ "$output" =~ "/main" ]] || false
+    [[ "$output" =~ "commits are blocked" ]] || false
+
+    rm .block
+    run dolt commit -am "unblocked"
+    [ "$status" -eq 0 ]
+}
+
+@test "hooks: hooks run from the dolt_commit procedure" {
+    dolt config --local --add hooks.pre-commit.sql "SELECT id FROM products WHERE price IS NULL"
+
+    run dolt sql -q "INSERT INTO products VALUES (1, NULL); CALL dolt_commit('-am', 'null price');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-commit hook failed" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_log" -r csv
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "CALL dolt_commit('-am', 'null price', '--no-verify');"
+    [ "$status" -eq 0 ]
+}
+
+@test "hooks: post-commit hook failures are reported but keep the commit" {
+    skiponwindows "uses a shell script"
+    dolt config --local --add hooks.post-commit.exec 'echo "committed $DOLT_COMMIT" > ../post.txt; exit 3'
+
+    dolt sql -q "INSERT INTO products VALUES (1, 1.00);"
+    run dolt commit -am "post commit"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "post-commit hook failed: exit status 3" ]] || false
+
+    head=$(dolt sql -q "SELECT dolt_hashof('HEAD')" -r csv | tail -n 1)
+    run cat ../post.txt
+    [[ "$output" =~ "committed $head" ]] || false
+
+    run dolt sql -q "INSERT INTO products VALUES (2, 1.00); CALL dolt_commit('-am', 'sql commit'); SHOW WARNINGS;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "post-commit hook failed" ]] || false
+}
+
+@test "hooks: pre-merge hook aborts fast-forward merges" {
+    dolt config --local --add hooks.pre-merge.sql "SELECT id FROM products WHERE price IS NULL AND active_branch() = 'main'"
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO products VALUES (1, NULL);"
+    dolt commit -am "null price on feature"
+    dolt checkout main
+
+    run dolt merge feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-merge hook failed: hook query returned rows" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "create products" ]] || false
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt merge --no-verify feature
+    [ "$status" -eq 0 ]
+    run dolt log -n 1
+    [[ "$output" =~ "null price on feature" ]] || false
+}
+
+@test "hooks: pre-merge hook aborts three way merges" {
+    dolt config --local --add hooks.pre-merge.sql "SELECT id FROM products WHERE price IS NULL"
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO products VALUES (1, NULL);"
+    dolt commit -am "null price on feature"
+    dolt checkout main
+    dolt sql -q "INSERT INTO products VALUES (2, 2.00);"
+    dolt commit -am "main change"
+
+    run dolt merge feature -m "merge feature"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-merge hook failed" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "main change" ]] || false
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    [[ ! "$output" =~ "merging" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM products" -r csv
+    [[ "$output" =~ "1" ]] || false
+
+    dolt sql -q "UPDATE products SET price = 3.00 WHERE id = 2;"
+    dolt commit -am "another main change"
+    dolt checkout feature
+    dolt sql -q "UPDATE products SET price = 1.00 WHERE id = 1;"
+    dolt commit -am "fix price"
+    dolt checkout main
+    run dolt merge feature -m "merge feature"
+    [ "$status" -eq 0 ]
+}
+
+@test "hooks: pre-merge hook runs from the dolt_merge procedure" {
+    dolt config --local --add hooks.pre-merge.sql "SELECT id FROM products WHERE price IS NULL"
+
+    dolt branch feature
+    dolt sql -q "CALL dolt_checkout('feature'); INSERT INTO products VALUES (1, NULL); CALL dolt_commit('-am', 'null price');"
+
+    run dolt sql -q "CALL dolt_merge('feature');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-merge hook failed" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM products" -r csv
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "hooks: hook config keys are accepted by dolt config" {
+    run dolt config --local --add hooks.pre-commit.sql "SELECT 1 FROM dual WHERE false"
+    [ "$status" -eq 0 ]
+    run dolt config --local --add hooks.pre-merge.exec "true"
+    [ "$status" -eq 0 ]
+    run dolt config --local --add hooks.post-commit.sql "SELECT 1 FROM dual WHERE false"
+    [ "$status" -eq 0 ]
+
+    dolt sql -q "INSERT INTO products VALUES (1, 1.00);"
+    run dolt commit -am "hooks pass"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "hook failed" ]] || false
+}
+
+@test "hooks: hooks run in sql-server" {
+    dolt config --local --add hooks.pre-commit.sql "SELECT id FROM products WHERE price IS NULL"
+    start_sql_server
+
+    run dolt sql -q "INSERT INTO products VALUES (1, NULL); CALL dolt_commit('-am', 'null price');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-commit hook failed" ]] || false
+
+    run dolt sql -q "UPDATE products SET price = 1.00; CALL dolt_commit('-am', 'price');"
+    [ "$status" -eq 0 ]
+
+    stop_sql_server
+}
+
+@test "hooks: hook queries and --no-verify are subject to the privileges of the user" {
+    dolt config --local --add hooks.pre-commit.sql "SELECT id FROM secretdb.secrets WHERE id < 0"
+    start_sql_server
+    dbname=$(basename "$PWD")
+
+    dolt sql -q "CREATE DATABASE secretdb; CREATE TABLE secretdb.secrets (id int primary key);"
+    dolt sql -q "CREATE USER alice@'%' IDENTIFIED BY 'pass'; GRANT ALL ON \`$dbname\`.* TO alice@'%';"
+
+    run dolt -u alice -p pass sql -q "INSERT INTO products VALUES (1, 1.00); CALL dolt_commit('-am', 'alice');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pre-commit hook failed" ]] || false
+    [[ "$output" =~ "Access denied for user 'alice'" ]] || false
+
+    run dolt -u alice -p pass sql -q "CALL dolt_commit('-am', 'alice', '--no-verify');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--no-verify requires the SUPER privilege" ]] || false
+
+    run dolt sql -q "CALL dolt_commit('-am', 'root');"
+    [ "$status" -eq 0 ]
+
+    stop_sql_server
+}
@@ -0,0 +1,154 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    mkdir repo && cd repo
+    dolt init
+    dolt sql -q "create table t (pk int primary key, v int); insert into t values (1, 1);"
+    dolt commit -Am "add t"
+    for i in 2 3 4; do
+        dolt sql -q "insert into t values ($i, $i)"
+        dolt commit -am "add row $i"
+    done
+    dolt branch other HEAD~2
+    dolt tag v1 HEAD~1
+
+    REMOTE="file://$PWD/../remote"
+    dolt remote add origin "$REMOTE"
+    dolt push origin main
+    dolt push origin other
+    dolt push origin v1
+    cd ..
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "lazy-clone: history is fetched when it is read" {
+    dolt clone --lazy "$REMOTE" lazy
+    cd lazy
+    [ -f .dolt/noms/lazyObjects.txt ]
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add row 2" ]] || false
+    [[ "$output" =~ "add t" ]] || false
+
+    run dolt sql -q "select count(*) from t as of 'HEAD~3'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_history_t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "10" ]] || false
+
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/other" ]] || false
+    run dolt tag
+    [[ "$output" =~ "v1" ]] || false
+
+    dolt checkout other
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "lazy-clone: fetch, pull and push" {
+    dolt clone --lazy "$REMOTE" lazy
+
+    cd repo
+    dolt sql -q "insert into t values (5, 5)"
+    dolt commit -am "add row 5"
+    dolt push origin main
+
+    cd ../lazy
+    dolt pull origin main
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "5" ]] || false
+
+    dolt sql -q "insert into t values (6, 6)"
+    dolt commit -am "add row 6"
+    dolt push origin main
+
+    cd ../repo
+    dolt pull origin main
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "6" ]] || false
+}
+
+@test "lazy-clone: backfill makes a complete clone" {
+    dolt clone --lazy "$REMOTE" lazy
+    cd lazy
+
+    run dolt gc
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot garbage collect a lazy clone" ]] || false
+
+    run dolt backfill
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "complete clone" ]] || false
+    [ ! -f .dolt/noms/lazyObjects.txt ]
+
+    # the remote is no longer needed to read history
+    mv ../remote ../remote.bak
+    run dolt sql -q "select count(*) from dolt_history_t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "10" ]] || false
+    mv ../remote.bak ../remote
+
+    dolt gc
+
+    run dolt backfill
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "not a lazy clone" ]] || false
+}
+
+@test "lazy-clone: fetched history is cached in a single table file" {
+    dolt clone --lazy --single-branch "$REMOTE" lazy
+    cd lazy
+    before=$(ls .dolt/noms/oldgen | wc -l)
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add t" ]] || false
+    after=$(ls .dolt/noms/oldgen | wc -l)
+    [ "$after" -le $((before + 1)) ]
+
+    mv ../remote ../remote.bak
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add t" ]] || false
+    mv ../remote.bak ../remote
+}
+
+@test "lazy-clone: reading history fails without the remote" {
+    # sql sessions read the head of every branch, so only the checked out branch is cloned
+    dolt clone --lazy --single-branch "$REMOTE" lazy
+    cd lazy
+    mv ../remote ../remote.bak
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt log
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "lazy clone" ]] || false
+}
+
+@test "lazy-clone: invalid arguments" {
+    run dolt clone --lazy --depth 1 "$REMOTE" lazy
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot be used together" ]] || false
+    [ ! -d lazy ]
+
+    cd repo
+    run dolt sql -q "call dolt_clone('--lazy', '$REMOTE', 'lazy')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "only supported by the dolt clone command" ]] || false
+}
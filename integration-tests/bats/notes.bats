@@ -0,0 +1,197 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t1 (pk int primary key);"
+    dolt commit -Am "commit 1"
+    dolt sql -q "INSERT INTO t1 VALUES (1);"
+    dolt commit -am "commit 2"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "notes: add and show a note" {
+    run dolt notes add -m "reviewed by alice"
+    [ "$status" -eq 0 ]
+
+    run dolt notes show
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "reviewed by alice" ]] || false
+
+    run dolt notes show HEAD~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no note found for commit 'HEAD~1'" ]] || false
+
+    run dolt notes add -m "first commit" HEAD~1
+    [ "$status" -eq 0 ]
+
+    run dolt notes show HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "first commit" ]] || false
+
+    head=$(dolt sql -q "SELECT dolt_hashof('HEAD')" -r csv | tail -n 1)
+    run dolt notes
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "$head reviewed by alice" ]] || false
+
+    run dolt notes list
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "notes: a commit has only one note unless forced" {
+    dolt notes add -m "first note"
+
+    run dolt notes add -m "second note"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "a note already exists for commit" ]] || false
+
+    run dolt notes show
+    [[ "$output" =~ "first note" ]] || false
+
+    run dolt notes add -f -m "second note"
+    [ "$status" -eq 0 ]
+
+    run dolt notes show
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "second note" ]] || false
+    [[ ! "$output" =~ "first note" ]] || false
+}
+
+@test "notes: remove a note" {
+    dolt notes add -m "a note"
+
+    run dolt notes remove
+    [ "$status" -eq 0 ]
+
+    run dolt notes show
+    [ "$status" -eq 1 ]
+
+    run dolt notes remove
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no note found for commit" ]] || false
+}
+
+@test "notes: adding a note does not change the commit or branch" {
+    head=$(dolt sql -q "SELECT dolt_hashof('HEAD')" -r csv | tail -n 1)
+    dolt notes add -m "a note"
+
+    run dolt sql -q "SELECT dolt_hashof('HEAD')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$head" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "notes: dolt_notes system table joins with dolt_log" {
+    dolt notes add -m "note on head" --author "Alice <alice@example.com>"
+    dolt notes add -m "note on parent" HEAD~1
+
+    run dolt sql -q "SELECT l.message, n.note, n.author, n.email FROM dolt_log l JOIN dolt_notes n ON l.commit_hash = n.commit_hash ORDER BY l.date DESC" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[1]}" =~ "commit 2,note on head,Alice,alice@example.com" ]] || false
+    [[ "${lines[2]}" =~ "commit 1,note on parent" ]] || false
+}
+
+@test "notes: dolt_notes procedure" {
+    dolt sql -q "CALL dolt_notes('add', '-m', 'from sql')"
+
+    run dolt sql -q "SELECT note FROM dolt_notes WHERE commit_hash = dolt_hashof('HEAD')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "from sql" ]] || false
+
+    dolt sql -q "CALL dolt_notes('remove', 'HEAD')"
+    run dolt sql -q "SELECT count(*) FROM dolt_notes" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt sql -q "CALL dolt_notes()"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "use 'dolt_notes' system table to list notes" ]] || false
+
+    run dolt sql -q "CALL dolt_notes('add')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "a note message must be given with -m" ]] || false
+
+    run dolt sql -q "CALL dolt_notes('frobnicate')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown subcommand 'frobnicate'" ]] || false
+}
+
+@test "notes: push and fetch notes" {
+    repo=$PWD
+    mkdir ../remote
+    dolt remote add origin file://../remote
+    dolt push origin main
+    dolt notes add -m "pushed note"
+
+    run dolt notes push
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Pushed 1 note" ]] || false
+
+    run dolt notes push origin
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Everything up-to-date" ]] || false
+
+    cd ..
+    dolt clone file://./remote clone
+    cd clone
+    run dolt notes show main
+    [ "$status" -eq 1 ]
+
+    run dolt notes fetch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Fetched 1 note" ]] || false
+
+    run dolt notes show main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "pushed note" ]] || false
+
+    # changed notes replace the note on the other side
+    dolt notes add -f -m "changed note" main
+    dolt notes push
+    cd $repo
+    dolt notes fetch
+    run dolt notes show
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "changed note" ]] || false
+}
+
+@test "notes: notes on commits missing from the remote are not pushed" {
+    mkdir ../remote
+    dolt remote add origin file://../remote
+    dolt push origin main
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO t1 VALUES (2);"
+    dolt commit -am "unpushed commit"
+    dolt notes add -m "unpushed note"
+
+    run dolt notes push
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Everything up-to-date" ]] || false
+
+    dolt push origin other
+    run dolt notes push
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Pushed 1 note" ]] || false
+}
+
+@test "notes: push and fetch errors" {
+    run dolt notes push
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no remote" ]] || false
+
+    mkdir ../remote
+    dolt remote add origin file://../remote
+    run dolt notes fetch not_a_remote
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown remote" ]] || false
+}
@@ -76,6 +76,28 @@ teardown() {
     fi
 }
 
+@test "remote-cmd: s3 params" {
+    if [ "$SQL_ENGINE" = "remote-engine" ]; then
+        skip "remotes with parameters can not be added while a server is running"
+    fi
+
+    dolt remote add --aws-region us-west --s3-endpoint http://localhost:9000 --s3-path-style origin s3://bucket/org/db
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "origin s3://bucket/org/db" ]] || false
+    [[ "$output" =~ '"s3-endpoint": "http://localhost:9000"' ]] || false
+    [[ "$output" =~ '"s3-path-style": "true"' ]] || false
+    [[ "$output" =~ '"aws-region": "us-west"' ]] || false
+
+    run dolt remote add --s3-endpoint http://localhost:9000 other aws://customhost/org/db
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only valid for s3 remotes" ]] || false
+
+    run dolt remote add --s3-path-style other http://customhost/org/db
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only valid for s3 remotes" ]] || false
+}
+
 @test "remote-cmd: remove origin and verify tracking is gone" {
     mkdir remote_repo
     mkdir initter
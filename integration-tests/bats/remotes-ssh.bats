@@ -0,0 +1,126 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    skiponwindows "tests use a shell script in place of ssh"
+    setup_no_dolt_init
+
+    # runs the remote command locally, ignoring the ssh options and host
+    cat > fake-ssh <<'EOF'
+#!/bin/sh
+while [ $# -gt 1 ]; do shift; done
+exec sh -c "$1"
+EOF
+    chmod +x fake-ssh
+    export DOLT_SSH_COMMAND="$PWD/fake-ssh"
+
+    mkdir repo && cd repo
+    dolt init
+    dolt sql -q "create table t (pk int primary key, v int); insert into t values (1, 1), (2, 2);"
+    dolt commit -Am "add t"
+    REPO="$PWD"
+    cd ..
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "remotes-ssh: clone, push, pull and fetch" {
+    dolt clone "ssh://localhost$REPO" clone
+    CLONE="$PWD/clone"
+    cd clone
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    dolt sql -q "insert into t values (3, 3)"
+    dolt commit -am "add row"
+    dolt push origin main
+    dolt checkout -b feature
+    dolt push origin feature
+
+    cd $REPO
+    run dolt branch
+    [[ "$output" =~ "feature" ]] || false
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "3" ]] || false
+
+    dolt sql -q "insert into t values (4, 4)"
+    dolt commit -am "add another row"
+
+    cd $CLONE
+    dolt checkout main
+    dolt pull origin main
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "4" ]] || false
+
+    cd $REPO
+    dolt branch other
+    cd $CLONE
+    dolt fetch
+    run dolt branch -r
+    [[ "$output" =~ "origin/other" ]] || false
+}
+
+@test "remotes-ssh: add an ssh remote" {
+    mkdir other && cd other
+    dolt init
+    dolt remote add origin ssh://user@example.com:2222/path/to/db
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "ssh://user@example.com:2222/path/to/db" ]] || false
+
+    dolt remote add local "ssh://localhost$REPO"
+    dolt fetch local
+    run dolt branch -r
+    [[ "$output" =~ "local/main" ]] || false
+}
+
+@test "remotes-ssh: clone a path which is not a dolt database" {
+    mkdir empty
+    run dolt clone "ssh://localhost$PWD/empty" clone
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "failed to load dolt database" ]] || false
+    [ ! -d clone ]
+
+    run dolt clone ssh://localhost/ clone
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "must include the path of a dolt database" ]] || false
+}
+
+@test "remotes-ssh: read only transfer-server rejects pushes" {
+    cat > dolt-read-only <<'EOF'
+#!/bin/sh
+exec dolt "$1" --read-only "$2"
+EOF
+    chmod +x dolt-read-only
+
+    dolt clone "ssh://localhost$REPO" clone
+    cd clone
+    dolt sql -q "insert into t values (3, 3)"
+    dolt commit -am "add row"
+
+    DOLT_SSH_REMOTE_DOLT="$PWD/../dolt-read-only" run dolt push origin main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "read-only" ]] || false
+
+    dolt push origin main
+}
+
+@test "remotes-ssh: transfer-server requires a path" {
+    run dolt transfer-server
+    [ "$status" -ne 0 ]
+}
+
+@test "remotes-ssh: hosts and users which look like ssh options are rejected" {
+    run dolt clone "ssh://-oProxyCommand=touch%20pwned@localhost$REPO" clone
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid user" ]] || false
+    [ ! -e pwned ]
+
+    run dolt clone "ssh://-oProxyCommand=x$REPO" clone
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid host" ]] || false
+}
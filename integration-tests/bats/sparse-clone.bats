@@ -0,0 +1,135 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    mkdir repo && cd repo
+    dolt init
+    dolt sql -q "create table t1 (pk int primary key, v int); insert into t1 values (1, 1), (2, 2);"
+    dolt sql -q "create table t2 (pk int primary key, v varchar(10)); insert into t2 values (1, 'a'), (2, 'b');"
+    dolt sql -q "create view v1 as select * from t1;"
+    dolt commit -Am "add tables"
+    dolt sql -q "insert into t2 values (3, 'c');"
+    dolt commit -am "add row to t2"
+
+    REMOTE="file://$PWD/../remote"
+    dolt remote add origin "$REMOTE"
+    dolt push origin main
+    cd ..
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "sparse-clone: only the selected tables are readable" {
+    dolt clone --tables t1 "$REMOTE" sparse
+    cd sparse
+
+    run dolt sql -q "select count(*) from t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "select count(*) from v1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "show tables"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t1" ]] || false
+    [[ ! "$output" =~ "t2" ]] || false
+
+    run dolt sql -q "select * from t2"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table is not part of this sparse clone: t2" ]] || false
+
+    run dolt sql -q "insert into t2 values (4, 'd')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table is not part of this sparse clone: t2" ]] || false
+
+    run dolt sql -q "drop table t2"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table is not part of this sparse clone: t2" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add row to t2" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
+
+@test "sparse-clone: commits push cleanly" {
+    dolt clone --tables t1 "$REMOTE" sparse
+    cd sparse
+    dolt sql -q "insert into t1 values (3, 3)"
+    dolt commit -am "add row to t1"
+    dolt push origin main
+
+    cd ../repo
+    dolt pull origin main
+    run dolt sql -q "select count(*) from t1" -r csv
+    [[ "$output" =~ "3" ]] || false
+    run dolt sql -q "select count(*) from t2" -r csv
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt fsck
+    [ "$status" -eq 0 ]
+}
+
+@test "sparse-clone: fetch and pull only fetch the selected tables" {
+    dolt clone --tables t1 "$REMOTE" sparse
+
+    cd repo
+    dolt sql -q "insert into t1 values (3, 3); insert into t2 values (4, 'd');"
+    dolt sql -q "create table t3 (pk int primary key); insert into t3 values (1);"
+    dolt commit -Am "upstream changes"
+    dolt push origin main
+
+    cd ../sparse
+    dolt sql -q "insert into t1 values (4, 4)"
+    dolt commit -am "add row to t1"
+    dolt pull origin main
+
+    run dolt sql -q "select count(*) from t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt sql -q "select * from t3"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table is not part of this sparse clone: t3" ]] || false
+
+    dolt push origin main
+
+    cd ../repo
+    dolt pull origin main
+    run dolt sql -q "select count(*) from t1" -r csv
+    [[ "$output" =~ "4" ]] || false
+    run dolt sql -q "select count(*) from t2" -r csv
+    [[ "$output" =~ "4" ]] || false
+    run dolt sql -q "select count(*) from t3" -r csv
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "sparse-clone: invalid arguments" {
+    run dolt clone --tables t1 --depth 1 "$REMOTE" sparse
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot be used together" ]] || false
+    [ ! -d sparse ]
+
+    run dolt clone --tables t1 --lazy "$REMOTE" sparse
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot be used together" ]] || false
+    [ ! -d sparse ]
+
+    run dolt clone --tables nosuchtable "$REMOTE" sparse
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "nosuchtable" ]] || false
+    [ ! -d sparse ]
+
+    cd repo
+    run dolt sql -q "call dolt_clone('--tables', 't1', '$REMOTE', 'sparse')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "only supported by the dolt clone command" ]] || false
+}
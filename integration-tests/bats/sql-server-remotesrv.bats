@@ -317,6 +317,39 @@ call dolt_commit('-am', 'add one val');"
     [[ "$output" =~ "11" ]] || false
 }
 
+@test "sql-server-remotesrv: clone is denied to users who cannot read every branch" {
+    mkdir -p db/remote
+    cd db/remote
+    dolt init
+    dolt sql -q 'create table vals (i int);'
+    dolt sql -q 'insert into vals (i) values (1), (2), (3);'
+    dolt add vals
+    dolt commit -m 'initial vals.'
+    dolt branch customer_a
+
+    dolt sql-server --port 3307 --remotesapi-port 50051 &
+    srv_pid=$!
+    sleep 2
+    dolt sql -q "
+CREATE USER clone_admin_user@'localhost' IDENTIFIED BY 'pass1';
+GRANT CLONE_ADMIN ON *.* TO clone_admin_user@'localhost';
+INSERT INTO dolt_branch_control VALUES ('remote', 'customer_%', 'clone_admin_user', '%', 'deny');"
+
+    export DOLT_REMOTE_PASSWORD="pass1"
+    cd ../../
+    run dolt clone http://localhost:50051/remote repo1 -u clone_admin_user
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot read the branch" ]] || false
+
+    cd db/remote
+    dolt sql -q "DELETE FROM dolt_branch_control WHERE user = 'clone_admin_user';"
+    cd ../../
+    dolt clone http://localhost:50051/remote repo2 -u clone_admin_user
+    cd repo2
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/customer_a" ]] || false
+}
+
 @test "sql-server-remotesrv: dolt clone without authentication returns error" {
     mkdir -p db/remote
     cd db/remote
@@ -637,6 +670,54 @@ GRANT CLONE_ADMIN ON *.* TO clone_admin_user@'localhost';
     [[ "$output" =~ "main" ]] || false
 }
 
+@test "sql-server-remotesrv: push to remotesapi port is checked against branch protection rules" {
+    mkdir remote
+    cd remote
+    dolt init
+    dolt sql -q 'create table names (name varchar(10) primary key);'
+    dolt sql -q 'insert into names (name) values ("abe"), ("betsy"), ("calvin");'
+    dolt add names
+    dolt commit -m 'initial names.'
+    dolt branch new_branch HEAD
+    dolt sql -q "insert into dolt_branch_protection (branch, require_merge) values ('main', true);"
+    dolt sql -q "insert into dolt_branch_protection (branch, block_deletion) values ('new_branch', true);"
+
+    APIPORT=$( definePORT )
+    dolt sql -q "CREATE USER root@'%' identified by 'rootpass'; GRANT ALL ON *.* to root@'%';"
+    export DOLT_REMOTE_PASSWORD="rootpass"
+    export SQL_USER="root"
+    start_sql_server_with_args --remotesapi-port $APIPORT
+
+    cd ../
+    dolt clone http://localhost:$APIPORT/remote cloned_db -u $SQL_USER
+    cd cloned_db
+    dolt sql -q 'insert into names values ("dave");'
+    dolt commit -am 'add dave'
+
+    run dolt push origin --user $SQL_USER main:main
+    [[ "$status" -ne 0 ]] || false
+    [[ "$output" =~ "'main' only accepts merges" ]] || false
+
+    run dolt push origin --user $SQL_USER :new_branch
+    [[ "$status" -ne 0 ]] || false
+    [[ "$output" =~ "cannot delete 'new_branch'" ]] || false
+
+    dolt checkout -b feature HEAD~1
+    dolt sql -q 'insert into names values ("erin");'
+    dolt commit -am 'add erin'
+    dolt checkout main
+    dolt merge --no-ff feature -m 'merge feature'
+    run dolt push origin --user $SQL_USER main:main
+    [[ "$status" -eq 0 ]] || false
+
+    cd ../remote
+    run dolt sql -q 'select * from names;'
+    [[ "$output" =~ "dave" ]] || false
+    [[ "$output" =~ "erin" ]] || false
+    run dolt branch
+    [[ "$output" =~ "new_branch" ]] || false
+}
+
 @test "sql-server-remotesrv: push to non-existent database fails" {
     mkdir remote
     cd remote
@@ -696,3 +777,22 @@ GRANT CLONE_ADMIN ON *.* TO clone_admin_user@'localhost';
     [[ "$output" =~ "main" ]] || false
 }
 
+
+@test "sql-server-remotesrv: encrypted databases cannot be cloned from remotesapi port" {
+    mkdir remote
+    cd remote
+    export DOLT_ENCRYPTION_KEY=$(head -c 32 /dev/urandom | od -An -tx1 | tr -d ' \n')
+    dolt init --encrypt
+    dolt sql -q 'create table names (name varchar(10) primary key);'
+    dolt commit -Am 'initial names.'
+
+    APIPORT=$( definePORT )
+    start_sql_server_with_args --remotesapi-port $APIPORT
+
+    cd ../
+    unset DOLT_ENCRYPTION_KEY
+    run dolt clone http://localhost:$APIPORT/remote cloned_db
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "operation is not supported for encrypted databases" ]] || false
+    [ ! -d cloned_db ]
+}
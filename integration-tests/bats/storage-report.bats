@@ -0,0 +1,73 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t1 (pk int primary key, c1 varchar(20), j json, INDEX idx_c1 (c1));"
+    dolt sql -q "INSERT INTO t1 VALUES (1, 'a', '{\"k\": 1}'), (2, 'b', '{\"k\": 2}');"
+    dolt commit -Am "commit 1"
+    dolt sql -q "UPDATE t1 SET c1 = 'c' WHERE pk = 1;"
+    dolt commit -am "commit 2"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "storage-report: report storage usage by category" {
+    run dolt admin storage report
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "rows" ]] || false
+    [[ "$output" =~ "idx_c1" ]] || false
+    [[ "$output" =~ "blobs" ]] || false
+    [[ "$output" =~ "history" ]] || false
+    [[ "$output" =~ "metadata" ]] || false
+    [[ "$output" =~ "garbage" ]] || false
+    [[ "$output" =~ "Total:" ]] || false
+
+    run dolt admin storage report -r json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"category": "index"' ]] || false
+    [[ "$output" =~ '"index": "idx_c1"' ]] || false
+
+    run dolt admin storage report -r csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid result format: csv" ]] || false
+}
+
+@test "storage-report: workspace, stash and garbage" {
+    run dolt sql -r csv -q "SELECT chunks FROM dolt_storage_usage WHERE category = 'workspace'"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+
+    dolt sql -q "INSERT INTO t1 VALUES (3, 'd', '{}');"
+    run dolt sql -r csv -q "SELECT chunks > 0 FROM dolt_storage_usage WHERE category = 'workspace'"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "true" ]
+
+    dolt stash
+    run dolt sql -r csv -q "SELECT chunks > 0 FROM dolt_storage_usage WHERE category = 'stash'"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "true" ]
+
+    dolt stash drop
+    run dolt sql -r csv -q "SELECT chunks > 0 FROM dolt_storage_usage WHERE category = 'garbage'"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "true" ]
+
+    dolt gc
+    run dolt sql -r csv -q "SELECT chunks FROM dolt_storage_usage WHERE category = 'garbage'"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "storage-report: admin storage still lists storage files" {
+    run dolt admin storage
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Storage Artifact" ]] || false
+
+    run dolt admin storage list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Storage Artifact" ]] || false
+}
@@ -0,0 +1,278 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    mkdir main && cd main
+    dolt init
+    dolt sql -q "CREATE table t1 (pk int primary key);"
+    dolt commit -Am "main commit 1"
+    dolt branch b1
+    dolt branch b2
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "worktree: list with no linked worktrees" {
+    run dolt worktree
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "$PWD  [main]" ]] || false
+
+    run dolt worktree list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$PWD  [main]" ]] || false
+}
+
+@test "worktree: add a worktree for a branch" {
+    run dolt worktree add ../wt1 b1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Added worktree at '../wt1' with branch 'b1' checked out" ]] || false
+
+    main=$PWD
+    wt1=$(cd ../wt1 && pwd)
+    run dolt worktree
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$main  [main]" ]] || false
+    [[ "$output" =~ "$wt1  [b1]" ]] || false
+
+    cd ../wt1
+    run dolt branch --show-current
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "b1" ]] || false
+
+    # worktrees list the same worktrees
+    run dolt worktree
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$main  [main]" ]] || false
+    [[ "$output" =~ "$wt1  [b1]" ]] || false
+}
+
+@test "worktree: worktrees have their own head and working set" {
+    dolt worktree add ../wt1 b1
+    dolt sql -q "INSERT INTO t1 VALUES (1);"
+
+    cd ../wt1
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "On branch b1" ]] || false
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    dolt sql -q "INSERT INTO t1 VALUES (2);"
+    dolt commit -am "b1 commit"
+
+    run dolt sql -q "SELECT * FROM t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    [[ ! "$output" =~ "1" ]] || false
+
+    cd -
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "On branch main" ]] || false
+    [[ "$output" =~ "modified:" ]] || false
+
+    # commits made in a worktree are visible from the others
+    run dolt log b1 -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "b1 commit" ]] || false
+}
+
+@test "worktree: a branch can only be checked out in one worktree" {
+    dolt worktree add ../wt1 b1
+
+    run dolt worktree add ../wt2 b1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'b1' is already checked out in worktree" ]] || false
+    [ ! -d ../wt2/.dolt ]
+
+    run dolt worktree add ../wt2 main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'main' is already checked out in worktree" ]] || false
+
+    run dolt checkout b1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'b1' is already checked out in worktree" ]] || false
+
+    cd ../wt1
+    run dolt checkout main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'main' is already checked out in worktree" ]] || false
+
+    run dolt checkout b2
+    [ "$status" -eq 0 ]
+
+    cd -
+    run dolt checkout b1
+    [ "$status" -eq 0 ]
+}
+
+@test "worktree: branches checked out in another worktree cannot be deleted" {
+    dolt worktree add ../wt1 b1
+
+    run dolt branch -d b1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Cannot delete branch 'b1'" ]] || false
+
+    run dolt branch -D b1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Cannot delete branch 'b1'" ]] || false
+
+    run dolt branch -d b2
+    [ "$status" -eq 0 ]
+}
+
+@test "worktree: a branch checked out in another worktree cannot be switched to or changed from sql" {
+    dolt worktree add ../wt1 b1
+
+    run dolt sql -q "CALL dolt_checkout('b1')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'b1' is already checked out in worktree" ]] || false
+
+    run dolt sql -q "CALL dolt_checkout('-B', 'b1')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'b1' is already checked out in worktree" ]] || false
+
+    run dolt sql -q "CALL dolt_branch('-m', 'b1', 'b3')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'b1' is already checked out in worktree" ]] || false
+
+    run dolt branch -m b1 b3
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'b1' is already checked out in worktree" ]] || false
+
+    run dolt sql -q "CALL dolt_branch('-f', 'b1', 'HEAD')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'b1' is already checked out in worktree" ]] || false
+
+    run dolt sql -q "CALL dolt_branch('-d', 'b1')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Cannot delete branch 'b1'" ]] || false
+
+    run dolt sql -q "CALL dolt_checkout('b2')"
+    [ "$status" -eq 0 ]
+    run dolt branch -m b2 b3
+    [ "$status" -eq 0 ]
+}
+
+@test "worktree: a branch checked out in another worktree cannot be written to from sql" {
+    dolt worktree add ../wt1 b1
+
+    run dolt sql -q 'insert into `main/b1`.t1 values (1)'
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot write to branch 'b1': branch 'b1' is already checked out in worktree" ]] || false
+
+    run dolt sql -q 'use `main/b1`; insert into t1 values (1)'
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot write to branch 'b1'" ]] || false
+
+    run dolt sql -q 'insert into `main/b2`.t1 values (1)'
+    [ "$status" -eq 0 ]
+
+    cd ../wt1
+    run dolt sql -q "insert into t1 values (2)"
+    [ "$status" -eq 0 ]
+    run dolt sql -q 'insert into `wt1/main`.t1 values (3)'
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot write to branch 'main'" ]] || false
+
+    run dolt sql -r csv -q "select * from t1"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    [[ ! "$output" =~ "1" ]] || false
+}
+
+@test "worktree: linked worktrees point to the data directory of the main worktree" {
+    dolt worktree add ../wt1 b1
+    main=$PWD
+
+    [ -f ../wt1/.dolt/noms ]
+    [ ! -L ../wt1/.dolt/noms ]
+    run cat ../wt1/.dolt/noms
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "datadir: $main/.dolt/noms" ]] || false
+
+    # a worktree whose main worktree's data directory is gone can't be loaded
+    mv .dolt/noms ../noms
+    cd ../wt1
+    run dolt status
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not a valid dolt repository" ]] || false
+    mv ../noms ../main/.dolt/noms
+    run dolt status
+    [ "$status" -eq 0 ]
+}
+
+@test "worktree: add errors" {
+    run dolt worktree add ../wt1 does_not_exist
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch not found" ]] || false
+
+    mkdir -p ../other
+    cd ../other
+    dolt init
+    cd -
+    run dolt worktree add ../other b1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ ".dolt directory already exists" ]] || false
+
+    run dolt worktree add ../wt1
+    [ "$status" -eq 1 ]
+}
+
+@test "worktree: remove a worktree" {
+    dolt worktree add ../wt1 b1
+    cd ../wt1
+    dolt sql -q "INSERT INTO t1 VALUES (1);"
+    cd -
+
+    run dolt worktree remove ../wt1
+    [ "$status" -eq 0 ]
+    [ ! -d ../wt1 ]
+
+    run dolt worktree
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+
+    # the chunk store is untouched, and uncommitted changes stay in the branch's working set
+    dolt checkout b1
+    run dolt sql -q "SELECT * FROM t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "worktree: remove errors" {
+    dolt worktree add ../wt1 b1
+
+    run dolt worktree rm .
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the main worktree cannot be removed" ]] || false
+
+    run dolt worktree rm ../not_a_worktree
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not a linked worktree of this repository" ]] || false
+
+    cd ../wt1
+    run dolt worktree rm .
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the current worktree cannot be removed" ]] || false
+}
+
+@test "worktree: worktrees can be added from a linked worktree" {
+    dolt worktree add ../wt1 b1
+    cd ../wt1
+    run dolt worktree add ../wt2 b2
+    [ "$status" -eq 0 ]
+
+    cd ../wt2
+    run dolt branch --show-current
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "b2" ]] || false
+
+    run dolt worktree
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+}